@@ -41,6 +41,24 @@ const (
 	// ClientServiceGetCallbackURLProcedure is the fully-qualified name of the ClientService's
 	// GetCallbackURL RPC.
 	ClientServiceGetCallbackURLProcedure = "/processing.client.v1.ClientService/GetCallbackURL"
+	// ClientServiceUpdateSignSettingsProcedure is the fully-qualified name of the ClientService's
+	// UpdateSignSettings RPC.
+	ClientServiceUpdateSignSettingsProcedure = "/processing.client.v1.ClientService/UpdateSignSettings"
+	// ClientServiceRotateSecretProcedure is the fully-qualified name of the ClientService's
+	// RotateSecret RPC.
+	ClientServiceRotateSecretProcedure = "/processing.client.v1.ClientService/RotateSecret"
+	// ClientServiceRevokeSecretProcedure is the fully-qualified name of the ClientService's
+	// RevokeSecret RPC.
+	ClientServiceRevokeSecretProcedure = "/processing.client.v1.ClientService/RevokeSecret"
+	// ClientServiceCreateAPIKeyProcedure is the fully-qualified name of the ClientService's
+	// CreateAPIKey RPC.
+	ClientServiceCreateAPIKeyProcedure = "/processing.client.v1.ClientService/CreateAPIKey"
+	// ClientServiceGetSecretsProcedure is the fully-qualified name of the ClientService's GetSecrets
+	// RPC.
+	ClientServiceGetSecretsProcedure = "/processing.client.v1.ClientService/GetSecrets"
+	// ClientServiceUpdateAllowedIPsProcedure is the fully-qualified name of the ClientService's
+	// UpdateAllowedIPs RPC.
+	ClientServiceUpdateAllowedIPsProcedure = "/processing.client.v1.ClientService/UpdateAllowedIPs"
 )
 
 // ClientServiceClient is a client for the processing.client.v1.ClientService service.
@@ -51,6 +69,20 @@ type ClientServiceClient interface {
 	UpdateCallbackURL(context.Context, *connect.Request[v1.UpdateCallbackURLRequest]) (*connect.Response[v1.UpdateCallbackURLResponse], error)
 	// Get merchant callback url
 	GetCallbackURL(context.Context, *connect.Request[v1.GetCallbackURLRequest]) (*connect.Response[v1.GetCallbackURLResponse], error)
+	// Require the v2 request signature for the client
+	UpdateSignSettings(context.Context, *connect.Request[v1.UpdateSignSettingsRequest]) (*connect.Response[v1.UpdateSignSettingsResponse], error)
+	// Create a new secret of the scope, the active secrets of the scope with all
+	// permissions expire after the grace period
+	RotateSecret(context.Context, *connect.Request[v1.RotateSecretRequest]) (*connect.Response[v1.RotateSecretResponse], error)
+	// Revoke the client secret immediately
+	RevokeSecret(context.Context, *connect.Request[v1.RevokeSecretRequest]) (*connect.Response[v1.RevokeSecretResponse], error)
+	// Create an api key limited to the permissions
+	CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error)
+	// Get the client secrets without the keys
+	GetSecrets(context.Context, *connect.Request[v1.GetSecretsRequest]) (*connect.Response[v1.GetSecretsResponse], error)
+	// Set the ip addresses and the cidr ranges the client requests are accepted
+	// from, the empty list allows any address
+	UpdateAllowedIPs(context.Context, *connect.Request[v1.UpdateAllowedIPsRequest]) (*connect.Response[v1.UpdateAllowedIPsResponse], error)
 }
 
 // NewClientServiceClient constructs a client for the processing.client.v1.ClientService service. By
@@ -82,14 +114,56 @@ func NewClientServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(clientServiceMethods.ByName("GetCallbackURL")),
 			connect.WithClientOptions(opts...),
 		),
+		updateSignSettings: connect.NewClient[v1.UpdateSignSettingsRequest, v1.UpdateSignSettingsResponse](
+			httpClient,
+			baseURL+ClientServiceUpdateSignSettingsProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("UpdateSignSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateSecret: connect.NewClient[v1.RotateSecretRequest, v1.RotateSecretResponse](
+			httpClient,
+			baseURL+ClientServiceRotateSecretProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("RotateSecret")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSecret: connect.NewClient[v1.RevokeSecretRequest, v1.RevokeSecretResponse](
+			httpClient,
+			baseURL+ClientServiceRevokeSecretProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("RevokeSecret")),
+			connect.WithClientOptions(opts...),
+		),
+		createAPIKey: connect.NewClient[v1.CreateAPIKeyRequest, v1.CreateAPIKeyResponse](
+			httpClient,
+			baseURL+ClientServiceCreateAPIKeyProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("CreateAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+		getSecrets: connect.NewClient[v1.GetSecretsRequest, v1.GetSecretsResponse](
+			httpClient,
+			baseURL+ClientServiceGetSecretsProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("GetSecrets")),
+			connect.WithClientOptions(opts...),
+		),
+		updateAllowedIPs: connect.NewClient[v1.UpdateAllowedIPsRequest, v1.UpdateAllowedIPsResponse](
+			httpClient,
+			baseURL+ClientServiceUpdateAllowedIPsProcedure,
+			connect.WithSchema(clientServiceMethods.ByName("UpdateAllowedIPs")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // clientServiceClient implements ClientServiceClient.
 type clientServiceClient struct {
-	create            *connect.Client[v1.CreateRequest, v1.CreateResponse]
-	updateCallbackURL *connect.Client[v1.UpdateCallbackURLRequest, v1.UpdateCallbackURLResponse]
-	getCallbackURL    *connect.Client[v1.GetCallbackURLRequest, v1.GetCallbackURLResponse]
+	create             *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	updateCallbackURL  *connect.Client[v1.UpdateCallbackURLRequest, v1.UpdateCallbackURLResponse]
+	getCallbackURL     *connect.Client[v1.GetCallbackURLRequest, v1.GetCallbackURLResponse]
+	updateSignSettings *connect.Client[v1.UpdateSignSettingsRequest, v1.UpdateSignSettingsResponse]
+	rotateSecret       *connect.Client[v1.RotateSecretRequest, v1.RotateSecretResponse]
+	revokeSecret       *connect.Client[v1.RevokeSecretRequest, v1.RevokeSecretResponse]
+	createAPIKey       *connect.Client[v1.CreateAPIKeyRequest, v1.CreateAPIKeyResponse]
+	getSecrets         *connect.Client[v1.GetSecretsRequest, v1.GetSecretsResponse]
+	updateAllowedIPs   *connect.Client[v1.UpdateAllowedIPsRequest, v1.UpdateAllowedIPsResponse]
 }
 
 // Create calls processing.client.v1.ClientService.Create.
@@ -107,6 +181,36 @@ func (c *clientServiceClient) GetCallbackURL(ctx context.Context, req *connect.R
 	return c.getCallbackURL.CallUnary(ctx, req)
 }
 
+// UpdateSignSettings calls processing.client.v1.ClientService.UpdateSignSettings.
+func (c *clientServiceClient) UpdateSignSettings(ctx context.Context, req *connect.Request[v1.UpdateSignSettingsRequest]) (*connect.Response[v1.UpdateSignSettingsResponse], error) {
+	return c.updateSignSettings.CallUnary(ctx, req)
+}
+
+// RotateSecret calls processing.client.v1.ClientService.RotateSecret.
+func (c *clientServiceClient) RotateSecret(ctx context.Context, req *connect.Request[v1.RotateSecretRequest]) (*connect.Response[v1.RotateSecretResponse], error) {
+	return c.rotateSecret.CallUnary(ctx, req)
+}
+
+// RevokeSecret calls processing.client.v1.ClientService.RevokeSecret.
+func (c *clientServiceClient) RevokeSecret(ctx context.Context, req *connect.Request[v1.RevokeSecretRequest]) (*connect.Response[v1.RevokeSecretResponse], error) {
+	return c.revokeSecret.CallUnary(ctx, req)
+}
+
+// CreateAPIKey calls processing.client.v1.ClientService.CreateAPIKey.
+func (c *clientServiceClient) CreateAPIKey(ctx context.Context, req *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error) {
+	return c.createAPIKey.CallUnary(ctx, req)
+}
+
+// GetSecrets calls processing.client.v1.ClientService.GetSecrets.
+func (c *clientServiceClient) GetSecrets(ctx context.Context, req *connect.Request[v1.GetSecretsRequest]) (*connect.Response[v1.GetSecretsResponse], error) {
+	return c.getSecrets.CallUnary(ctx, req)
+}
+
+// UpdateAllowedIPs calls processing.client.v1.ClientService.UpdateAllowedIPs.
+func (c *clientServiceClient) UpdateAllowedIPs(ctx context.Context, req *connect.Request[v1.UpdateAllowedIPsRequest]) (*connect.Response[v1.UpdateAllowedIPsResponse], error) {
+	return c.updateAllowedIPs.CallUnary(ctx, req)
+}
+
 // ClientServiceHandler is an implementation of the processing.client.v1.ClientService service.
 type ClientServiceHandler interface {
 	// Create client
@@ -115,6 +219,20 @@ type ClientServiceHandler interface {
 	UpdateCallbackURL(context.Context, *connect.Request[v1.UpdateCallbackURLRequest]) (*connect.Response[v1.UpdateCallbackURLResponse], error)
 	// Get merchant callback url
 	GetCallbackURL(context.Context, *connect.Request[v1.GetCallbackURLRequest]) (*connect.Response[v1.GetCallbackURLResponse], error)
+	// Require the v2 request signature for the client
+	UpdateSignSettings(context.Context, *connect.Request[v1.UpdateSignSettingsRequest]) (*connect.Response[v1.UpdateSignSettingsResponse], error)
+	// Create a new secret of the scope, the active secrets of the scope with all
+	// permissions expire after the grace period
+	RotateSecret(context.Context, *connect.Request[v1.RotateSecretRequest]) (*connect.Response[v1.RotateSecretResponse], error)
+	// Revoke the client secret immediately
+	RevokeSecret(context.Context, *connect.Request[v1.RevokeSecretRequest]) (*connect.Response[v1.RevokeSecretResponse], error)
+	// Create an api key limited to the permissions
+	CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error)
+	// Get the client secrets without the keys
+	GetSecrets(context.Context, *connect.Request[v1.GetSecretsRequest]) (*connect.Response[v1.GetSecretsResponse], error)
+	// Set the ip addresses and the cidr ranges the client requests are accepted
+	// from, the empty list allows any address
+	UpdateAllowedIPs(context.Context, *connect.Request[v1.UpdateAllowedIPsRequest]) (*connect.Response[v1.UpdateAllowedIPsResponse], error)
 }
 
 // NewClientServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -142,6 +260,42 @@ func NewClientServiceHandler(svc ClientServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(clientServiceMethods.ByName("GetCallbackURL")),
 		connect.WithHandlerOptions(opts...),
 	)
+	clientServiceUpdateSignSettingsHandler := connect.NewUnaryHandler(
+		ClientServiceUpdateSignSettingsProcedure,
+		svc.UpdateSignSettings,
+		connect.WithSchema(clientServiceMethods.ByName("UpdateSignSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clientServiceRotateSecretHandler := connect.NewUnaryHandler(
+		ClientServiceRotateSecretProcedure,
+		svc.RotateSecret,
+		connect.WithSchema(clientServiceMethods.ByName("RotateSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clientServiceRevokeSecretHandler := connect.NewUnaryHandler(
+		ClientServiceRevokeSecretProcedure,
+		svc.RevokeSecret,
+		connect.WithSchema(clientServiceMethods.ByName("RevokeSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clientServiceCreateAPIKeyHandler := connect.NewUnaryHandler(
+		ClientServiceCreateAPIKeyProcedure,
+		svc.CreateAPIKey,
+		connect.WithSchema(clientServiceMethods.ByName("CreateAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clientServiceGetSecretsHandler := connect.NewUnaryHandler(
+		ClientServiceGetSecretsProcedure,
+		svc.GetSecrets,
+		connect.WithSchema(clientServiceMethods.ByName("GetSecrets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clientServiceUpdateAllowedIPsHandler := connect.NewUnaryHandler(
+		ClientServiceUpdateAllowedIPsProcedure,
+		svc.UpdateAllowedIPs,
+		connect.WithSchema(clientServiceMethods.ByName("UpdateAllowedIPs")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/processing.client.v1.ClientService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ClientServiceCreateProcedure:
@@ -150,6 +304,18 @@ func NewClientServiceHandler(svc ClientServiceHandler, opts ...connect.HandlerOp
 			clientServiceUpdateCallbackURLHandler.ServeHTTP(w, r)
 		case ClientServiceGetCallbackURLProcedure:
 			clientServiceGetCallbackURLHandler.ServeHTTP(w, r)
+		case ClientServiceUpdateSignSettingsProcedure:
+			clientServiceUpdateSignSettingsHandler.ServeHTTP(w, r)
+		case ClientServiceRotateSecretProcedure:
+			clientServiceRotateSecretHandler.ServeHTTP(w, r)
+		case ClientServiceRevokeSecretProcedure:
+			clientServiceRevokeSecretHandler.ServeHTTP(w, r)
+		case ClientServiceCreateAPIKeyProcedure:
+			clientServiceCreateAPIKeyHandler.ServeHTTP(w, r)
+		case ClientServiceGetSecretsProcedure:
+			clientServiceGetSecretsHandler.ServeHTTP(w, r)
+		case ClientServiceUpdateAllowedIPsProcedure:
+			clientServiceUpdateAllowedIPsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -170,3 +336,27 @@ func (UnimplementedClientServiceHandler) UpdateCallbackURL(context.Context, *con
 func (UnimplementedClientServiceHandler) GetCallbackURL(context.Context, *connect.Request[v1.GetCallbackURLRequest]) (*connect.Response[v1.GetCallbackURLResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.GetCallbackURL is not implemented"))
 }
+
+func (UnimplementedClientServiceHandler) UpdateSignSettings(context.Context, *connect.Request[v1.UpdateSignSettingsRequest]) (*connect.Response[v1.UpdateSignSettingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.UpdateSignSettings is not implemented"))
+}
+
+func (UnimplementedClientServiceHandler) RotateSecret(context.Context, *connect.Request[v1.RotateSecretRequest]) (*connect.Response[v1.RotateSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.RotateSecret is not implemented"))
+}
+
+func (UnimplementedClientServiceHandler) RevokeSecret(context.Context, *connect.Request[v1.RevokeSecretRequest]) (*connect.Response[v1.RevokeSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.RevokeSecret is not implemented"))
+}
+
+func (UnimplementedClientServiceHandler) CreateAPIKey(context.Context, *connect.Request[v1.CreateAPIKeyRequest]) (*connect.Response[v1.CreateAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.CreateAPIKey is not implemented"))
+}
+
+func (UnimplementedClientServiceHandler) GetSecrets(context.Context, *connect.Request[v1.GetSecretsRequest]) (*connect.Response[v1.GetSecretsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.GetSecrets is not implemented"))
+}
+
+func (UnimplementedClientServiceHandler) UpdateAllowedIPs(context.Context, *connect.Request[v1.UpdateAllowedIPsRequest]) (*connect.Response[v1.UpdateAllowedIPsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.client.v1.ClientService.UpdateAllowedIPs is not implemented"))
+}
@@ -55,6 +55,15 @@ const (
 	// OwnerServiceValidateTwoFactorTokenProcedure is the fully-qualified name of the OwnerService's
 	// ValidateTwoFactorToken RPC.
 	OwnerServiceValidateTwoFactorTokenProcedure = "/processing.owner.v1.OwnerService/ValidateTwoFactorToken"
+	// OwnerServiceRegenerateRecoveryCodesProcedure is the fully-qualified name of the OwnerService's
+	// RegenerateRecoveryCodes RPC.
+	OwnerServiceRegenerateRecoveryCodesProcedure = "/processing.owner.v1.OwnerService/RegenerateRecoveryCodes"
+	// OwnerServiceStartMnemonicRotationProcedure is the fully-qualified name of the OwnerService's
+	// StartMnemonicRotation RPC.
+	OwnerServiceStartMnemonicRotationProcedure = "/processing.owner.v1.OwnerService/StartMnemonicRotation"
+	// OwnerServiceGetMnemonicRotationProcedure is the fully-qualified name of the OwnerService's
+	// GetMnemonicRotation RPC.
+	OwnerServiceGetMnemonicRotationProcedure = "/processing.owner.v1.OwnerService/GetMnemonicRotation"
 )
 
 // OwnerServiceClient is a client for the processing.owner.v1.OwnerService service.
@@ -75,6 +84,13 @@ type OwnerServiceClient interface {
 	GetTwoFactorAuthData(context.Context, *connect.Request[v1.GetTwoFactorAuthDataRequest]) (*connect.Response[v1.GetTwoFactorAuthDataResponse], error)
 	// Validate 2fa token
 	ValidateTwoFactorToken(context.Context, *connect.Request[v1.ValidateTwoFactorTokenRequest]) (*connect.Response[v1.ValidateTwoFactorTokenResponse], error)
+	// Replace owner 2fa recovery codes, requires the totp
+	RegenerateRecoveryCodes(context.Context, *connect.Request[v1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1.RegenerateRecoveryCodesResponse], error)
+	// Start rotation of the owner mnemonic with migration of all funds to the
+	// new wallets
+	StartMnemonicRotation(context.Context, *connect.Request[v1.StartMnemonicRotationRequest]) (*connect.Response[v1.StartMnemonicRotationResponse], error)
+	// Get progress of the last owner mnemonic rotation
+	GetMnemonicRotation(context.Context, *connect.Request[v1.GetMnemonicRotationRequest]) (*connect.Response[v1.GetMnemonicRotationResponse], error)
 }
 
 // NewOwnerServiceClient constructs a client for the processing.owner.v1.OwnerService service. By
@@ -136,19 +152,40 @@ func NewOwnerServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(ownerServiceMethods.ByName("ValidateTwoFactorToken")),
 			connect.WithClientOptions(opts...),
 		),
+		regenerateRecoveryCodes: connect.NewClient[v1.RegenerateRecoveryCodesRequest, v1.RegenerateRecoveryCodesResponse](
+			httpClient,
+			baseURL+OwnerServiceRegenerateRecoveryCodesProcedure,
+			connect.WithSchema(ownerServiceMethods.ByName("RegenerateRecoveryCodes")),
+			connect.WithClientOptions(opts...),
+		),
+		startMnemonicRotation: connect.NewClient[v1.StartMnemonicRotationRequest, v1.StartMnemonicRotationResponse](
+			httpClient,
+			baseURL+OwnerServiceStartMnemonicRotationProcedure,
+			connect.WithSchema(ownerServiceMethods.ByName("StartMnemonicRotation")),
+			connect.WithClientOptions(opts...),
+		),
+		getMnemonicRotation: connect.NewClient[v1.GetMnemonicRotationRequest, v1.GetMnemonicRotationResponse](
+			httpClient,
+			baseURL+OwnerServiceGetMnemonicRotationProcedure,
+			connect.WithSchema(ownerServiceMethods.ByName("GetMnemonicRotation")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // ownerServiceClient implements OwnerServiceClient.
 type ownerServiceClient struct {
-	create                 *connect.Client[v1.CreateRequest, v1.CreateResponse]
-	getSeeds               *connect.Client[v1.GetSeedsRequest, v1.GetSeedsResponse]
-	getPrivateKeys         *connect.Client[v1.GetPrivateKeysRequest, v1.GetPrivateKeysResponse]
-	getHotWalletKeys       *connect.Client[v1.GetHotWalletKeysRequest, v1.GetHotWalletKeysResponse]
-	confirmTwoFactorAuth   *connect.Client[v1.ConfirmTwoFactorAuthRequest, v1.ConfirmTwoFactorAuthResponse]
-	disableTwoFactorAuth   *connect.Client[v1.DisableTwoFactorAuthRequest, v1.DisableTwoFactorAuthResponse]
-	getTwoFactorAuthData   *connect.Client[v1.GetTwoFactorAuthDataRequest, v1.GetTwoFactorAuthDataResponse]
-	validateTwoFactorToken *connect.Client[v1.ValidateTwoFactorTokenRequest, v1.ValidateTwoFactorTokenResponse]
+	create                  *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	getSeeds                *connect.Client[v1.GetSeedsRequest, v1.GetSeedsResponse]
+	getPrivateKeys          *connect.Client[v1.GetPrivateKeysRequest, v1.GetPrivateKeysResponse]
+	getHotWalletKeys        *connect.Client[v1.GetHotWalletKeysRequest, v1.GetHotWalletKeysResponse]
+	confirmTwoFactorAuth    *connect.Client[v1.ConfirmTwoFactorAuthRequest, v1.ConfirmTwoFactorAuthResponse]
+	disableTwoFactorAuth    *connect.Client[v1.DisableTwoFactorAuthRequest, v1.DisableTwoFactorAuthResponse]
+	getTwoFactorAuthData    *connect.Client[v1.GetTwoFactorAuthDataRequest, v1.GetTwoFactorAuthDataResponse]
+	validateTwoFactorToken  *connect.Client[v1.ValidateTwoFactorTokenRequest, v1.ValidateTwoFactorTokenResponse]
+	regenerateRecoveryCodes *connect.Client[v1.RegenerateRecoveryCodesRequest, v1.RegenerateRecoveryCodesResponse]
+	startMnemonicRotation   *connect.Client[v1.StartMnemonicRotationRequest, v1.StartMnemonicRotationResponse]
+	getMnemonicRotation     *connect.Client[v1.GetMnemonicRotationRequest, v1.GetMnemonicRotationResponse]
 }
 
 // Create calls processing.owner.v1.OwnerService.Create.
@@ -191,6 +228,21 @@ func (c *ownerServiceClient) ValidateTwoFactorToken(ctx context.Context, req *co
 	return c.validateTwoFactorToken.CallUnary(ctx, req)
 }
 
+// RegenerateRecoveryCodes calls processing.owner.v1.OwnerService.RegenerateRecoveryCodes.
+func (c *ownerServiceClient) RegenerateRecoveryCodes(ctx context.Context, req *connect.Request[v1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1.RegenerateRecoveryCodesResponse], error) {
+	return c.regenerateRecoveryCodes.CallUnary(ctx, req)
+}
+
+// StartMnemonicRotation calls processing.owner.v1.OwnerService.StartMnemonicRotation.
+func (c *ownerServiceClient) StartMnemonicRotation(ctx context.Context, req *connect.Request[v1.StartMnemonicRotationRequest]) (*connect.Response[v1.StartMnemonicRotationResponse], error) {
+	return c.startMnemonicRotation.CallUnary(ctx, req)
+}
+
+// GetMnemonicRotation calls processing.owner.v1.OwnerService.GetMnemonicRotation.
+func (c *ownerServiceClient) GetMnemonicRotation(ctx context.Context, req *connect.Request[v1.GetMnemonicRotationRequest]) (*connect.Response[v1.GetMnemonicRotationResponse], error) {
+	return c.getMnemonicRotation.CallUnary(ctx, req)
+}
+
 // OwnerServiceHandler is an implementation of the processing.owner.v1.OwnerService service.
 type OwnerServiceHandler interface {
 	// Create owner of client (creates processing wallet as side effect)
@@ -209,6 +261,13 @@ type OwnerServiceHandler interface {
 	GetTwoFactorAuthData(context.Context, *connect.Request[v1.GetTwoFactorAuthDataRequest]) (*connect.Response[v1.GetTwoFactorAuthDataResponse], error)
 	// Validate 2fa token
 	ValidateTwoFactorToken(context.Context, *connect.Request[v1.ValidateTwoFactorTokenRequest]) (*connect.Response[v1.ValidateTwoFactorTokenResponse], error)
+	// Replace owner 2fa recovery codes, requires the totp
+	RegenerateRecoveryCodes(context.Context, *connect.Request[v1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1.RegenerateRecoveryCodesResponse], error)
+	// Start rotation of the owner mnemonic with migration of all funds to the
+	// new wallets
+	StartMnemonicRotation(context.Context, *connect.Request[v1.StartMnemonicRotationRequest]) (*connect.Response[v1.StartMnemonicRotationResponse], error)
+	// Get progress of the last owner mnemonic rotation
+	GetMnemonicRotation(context.Context, *connect.Request[v1.GetMnemonicRotationRequest]) (*connect.Response[v1.GetMnemonicRotationResponse], error)
 }
 
 // NewOwnerServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -266,6 +325,24 @@ func NewOwnerServiceHandler(svc OwnerServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(ownerServiceMethods.ByName("ValidateTwoFactorToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ownerServiceRegenerateRecoveryCodesHandler := connect.NewUnaryHandler(
+		OwnerServiceRegenerateRecoveryCodesProcedure,
+		svc.RegenerateRecoveryCodes,
+		connect.WithSchema(ownerServiceMethods.ByName("RegenerateRecoveryCodes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ownerServiceStartMnemonicRotationHandler := connect.NewUnaryHandler(
+		OwnerServiceStartMnemonicRotationProcedure,
+		svc.StartMnemonicRotation,
+		connect.WithSchema(ownerServiceMethods.ByName("StartMnemonicRotation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ownerServiceGetMnemonicRotationHandler := connect.NewUnaryHandler(
+		OwnerServiceGetMnemonicRotationProcedure,
+		svc.GetMnemonicRotation,
+		connect.WithSchema(ownerServiceMethods.ByName("GetMnemonicRotation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/processing.owner.v1.OwnerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case OwnerServiceCreateProcedure:
@@ -284,6 +361,12 @@ func NewOwnerServiceHandler(svc OwnerServiceHandler, opts ...connect.HandlerOpti
 			ownerServiceGetTwoFactorAuthDataHandler.ServeHTTP(w, r)
 		case OwnerServiceValidateTwoFactorTokenProcedure:
 			ownerServiceValidateTwoFactorTokenHandler.ServeHTTP(w, r)
+		case OwnerServiceRegenerateRecoveryCodesProcedure:
+			ownerServiceRegenerateRecoveryCodesHandler.ServeHTTP(w, r)
+		case OwnerServiceStartMnemonicRotationProcedure:
+			ownerServiceStartMnemonicRotationHandler.ServeHTTP(w, r)
+		case OwnerServiceGetMnemonicRotationProcedure:
+			ownerServiceGetMnemonicRotationHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -324,3 +407,15 @@ func (UnimplementedOwnerServiceHandler) GetTwoFactorAuthData(context.Context, *c
 func (UnimplementedOwnerServiceHandler) ValidateTwoFactorToken(context.Context, *connect.Request[v1.ValidateTwoFactorTokenRequest]) (*connect.Response[v1.ValidateTwoFactorTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.owner.v1.OwnerService.ValidateTwoFactorToken is not implemented"))
 }
+
+func (UnimplementedOwnerServiceHandler) RegenerateRecoveryCodes(context.Context, *connect.Request[v1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1.RegenerateRecoveryCodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.owner.v1.OwnerService.RegenerateRecoveryCodes is not implemented"))
+}
+
+func (UnimplementedOwnerServiceHandler) StartMnemonicRotation(context.Context, *connect.Request[v1.StartMnemonicRotationRequest]) (*connect.Response[v1.StartMnemonicRotationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.owner.v1.OwnerService.StartMnemonicRotation is not implemented"))
+}
+
+func (UnimplementedOwnerServiceHandler) GetMnemonicRotation(context.Context, *connect.Request[v1.GetMnemonicRotationRequest]) (*connect.Response[v1.GetMnemonicRotationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.owner.v1.OwnerService.GetMnemonicRotation is not implemented"))
+}
@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: processing/signer/v1/signer.proto
+
+package signerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/dv-net/dv-processing/api/processing/signer/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SignerServiceName is the fully-qualified name of the SignerService service.
+	SignerServiceName = "processing.signer.v1.SignerService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SignerServiceSignUTXOTransactionProcedure is the fully-qualified name of the SignerService's
+	// SignUTXOTransaction RPC.
+	SignerServiceSignUTXOTransactionProcedure = "/processing.signer.v1.SignerService/SignUTXOTransaction"
+	// SignerServiceSignEVMTransactionProcedure is the fully-qualified name of the SignerService's
+	// SignEVMTransaction RPC.
+	SignerServiceSignEVMTransactionProcedure = "/processing.signer.v1.SignerService/SignEVMTransaction"
+	// SignerServiceSignTronTransactionProcedure is the fully-qualified name of the SignerService's
+	// SignTronTransaction RPC.
+	SignerServiceSignTronTransactionProcedure = "/processing.signer.v1.SignerService/SignTronTransaction"
+	// SignerServiceSignMessageProcedure is the fully-qualified name of the SignerService's SignMessage
+	// RPC.
+	SignerServiceSignMessageProcedure = "/processing.signer.v1.SignerService/SignMessage"
+	// SignerServiceDeriveAddressesProcedure is the fully-qualified name of the SignerService's
+	// DeriveAddresses RPC.
+	SignerServiceDeriveAddressesProcedure = "/processing.signer.v1.SignerService/DeriveAddresses"
+	// SignerServiceSealSecretProcedure is the fully-qualified name of the SignerService's SealSecret
+	// RPC.
+	SignerServiceSealSecretProcedure = "/processing.signer.v1.SignerService/SealSecret"
+	// SignerServiceUnsealOTPDataProcedure is the fully-qualified name of the SignerService's
+	// UnsealOTPData RPC.
+	SignerServiceUnsealOTPDataProcedure = "/processing.signer.v1.SignerService/UnsealOTPData"
+)
+
+// SignerServiceClient is a client for the processing.signer.v1.SignerService service.
+type SignerServiceClient interface {
+	// Sign the inputs of a bitcoin like transaction
+	SignUTXOTransaction(context.Context, *connect.Request[v1.SignUTXOTransactionRequest]) (*connect.Response[v1.SignUTXOTransactionResponse], error)
+	// Sign an EVM dynamic fee transaction
+	SignEVMTransaction(context.Context, *connect.Request[v1.SignEVMTransactionRequest]) (*connect.Response[v1.SignEVMTransactionResponse], error)
+	// Sign a Tron transaction
+	SignTronTransaction(context.Context, *connect.Request[v1.SignTronTransactionRequest]) (*connect.Response[v1.SignTronTransactionResponse], error)
+	// Sign a message with the key of the wallet address
+	SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error)
+	// Derive the wallet addresses from the encrypted owner seed
+	DeriveAddresses(context.Context, *connect.Request[v1.DeriveAddressesRequest]) (*connect.Response[v1.DeriveAddressesResponse], error)
+	// Encrypt an owner secret with the data key wrapped by the master key
+	SealSecret(context.Context, *connect.Request[v1.SealSecretRequest]) (*connect.Response[v1.SealSecretResponse], error)
+	// Decrypt the OTP data of the owner, other secrets are never decrypted
+	UnsealOTPData(context.Context, *connect.Request[v1.UnsealOTPDataRequest]) (*connect.Response[v1.UnsealOTPDataResponse], error)
+}
+
+// NewSignerServiceClient constructs a client for the processing.signer.v1.SignerService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSignerServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SignerServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	signerServiceMethods := v1.File_processing_signer_v1_signer_proto.Services().ByName("SignerService").Methods()
+	return &signerServiceClient{
+		signUTXOTransaction: connect.NewClient[v1.SignUTXOTransactionRequest, v1.SignUTXOTransactionResponse](
+			httpClient,
+			baseURL+SignerServiceSignUTXOTransactionProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignUTXOTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		signEVMTransaction: connect.NewClient[v1.SignEVMTransactionRequest, v1.SignEVMTransactionResponse](
+			httpClient,
+			baseURL+SignerServiceSignEVMTransactionProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignEVMTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		signTronTransaction: connect.NewClient[v1.SignTronTransactionRequest, v1.SignTronTransactionResponse](
+			httpClient,
+			baseURL+SignerServiceSignTronTransactionProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignTronTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		signMessage: connect.NewClient[v1.SignMessageRequest, v1.SignMessageResponse](
+			httpClient,
+			baseURL+SignerServiceSignMessageProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		deriveAddresses: connect.NewClient[v1.DeriveAddressesRequest, v1.DeriveAddressesResponse](
+			httpClient,
+			baseURL+SignerServiceDeriveAddressesProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("DeriveAddresses")),
+			connect.WithClientOptions(opts...),
+		),
+		sealSecret: connect.NewClient[v1.SealSecretRequest, v1.SealSecretResponse](
+			httpClient,
+			baseURL+SignerServiceSealSecretProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SealSecret")),
+			connect.WithClientOptions(opts...),
+		),
+		unsealOTPData: connect.NewClient[v1.UnsealOTPDataRequest, v1.UnsealOTPDataResponse](
+			httpClient,
+			baseURL+SignerServiceUnsealOTPDataProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("UnsealOTPData")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// signerServiceClient implements SignerServiceClient.
+type signerServiceClient struct {
+	signUTXOTransaction *connect.Client[v1.SignUTXOTransactionRequest, v1.SignUTXOTransactionResponse]
+	signEVMTransaction  *connect.Client[v1.SignEVMTransactionRequest, v1.SignEVMTransactionResponse]
+	signTronTransaction *connect.Client[v1.SignTronTransactionRequest, v1.SignTronTransactionResponse]
+	signMessage         *connect.Client[v1.SignMessageRequest, v1.SignMessageResponse]
+	deriveAddresses     *connect.Client[v1.DeriveAddressesRequest, v1.DeriveAddressesResponse]
+	sealSecret          *connect.Client[v1.SealSecretRequest, v1.SealSecretResponse]
+	unsealOTPData       *connect.Client[v1.UnsealOTPDataRequest, v1.UnsealOTPDataResponse]
+}
+
+// SignUTXOTransaction calls processing.signer.v1.SignerService.SignUTXOTransaction.
+func (c *signerServiceClient) SignUTXOTransaction(ctx context.Context, req *connect.Request[v1.SignUTXOTransactionRequest]) (*connect.Response[v1.SignUTXOTransactionResponse], error) {
+	return c.signUTXOTransaction.CallUnary(ctx, req)
+}
+
+// SignEVMTransaction calls processing.signer.v1.SignerService.SignEVMTransaction.
+func (c *signerServiceClient) SignEVMTransaction(ctx context.Context, req *connect.Request[v1.SignEVMTransactionRequest]) (*connect.Response[v1.SignEVMTransactionResponse], error) {
+	return c.signEVMTransaction.CallUnary(ctx, req)
+}
+
+// SignTronTransaction calls processing.signer.v1.SignerService.SignTronTransaction.
+func (c *signerServiceClient) SignTronTransaction(ctx context.Context, req *connect.Request[v1.SignTronTransactionRequest]) (*connect.Response[v1.SignTronTransactionResponse], error) {
+	return c.signTronTransaction.CallUnary(ctx, req)
+}
+
+// SignMessage calls processing.signer.v1.SignerService.SignMessage.
+func (c *signerServiceClient) SignMessage(ctx context.Context, req *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error) {
+	return c.signMessage.CallUnary(ctx, req)
+}
+
+// DeriveAddresses calls processing.signer.v1.SignerService.DeriveAddresses.
+func (c *signerServiceClient) DeriveAddresses(ctx context.Context, req *connect.Request[v1.DeriveAddressesRequest]) (*connect.Response[v1.DeriveAddressesResponse], error) {
+	return c.deriveAddresses.CallUnary(ctx, req)
+}
+
+// SealSecret calls processing.signer.v1.SignerService.SealSecret.
+func (c *signerServiceClient) SealSecret(ctx context.Context, req *connect.Request[v1.SealSecretRequest]) (*connect.Response[v1.SealSecretResponse], error) {
+	return c.sealSecret.CallUnary(ctx, req)
+}
+
+// UnsealOTPData calls processing.signer.v1.SignerService.UnsealOTPData.
+func (c *signerServiceClient) UnsealOTPData(ctx context.Context, req *connect.Request[v1.UnsealOTPDataRequest]) (*connect.Response[v1.UnsealOTPDataResponse], error) {
+	return c.unsealOTPData.CallUnary(ctx, req)
+}
+
+// SignerServiceHandler is an implementation of the processing.signer.v1.SignerService service.
+type SignerServiceHandler interface {
+	// Sign the inputs of a bitcoin like transaction
+	SignUTXOTransaction(context.Context, *connect.Request[v1.SignUTXOTransactionRequest]) (*connect.Response[v1.SignUTXOTransactionResponse], error)
+	// Sign an EVM dynamic fee transaction
+	SignEVMTransaction(context.Context, *connect.Request[v1.SignEVMTransactionRequest]) (*connect.Response[v1.SignEVMTransactionResponse], error)
+	// Sign a Tron transaction
+	SignTronTransaction(context.Context, *connect.Request[v1.SignTronTransactionRequest]) (*connect.Response[v1.SignTronTransactionResponse], error)
+	// Sign a message with the key of the wallet address
+	SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error)
+	// Derive the wallet addresses from the encrypted owner seed
+	DeriveAddresses(context.Context, *connect.Request[v1.DeriveAddressesRequest]) (*connect.Response[v1.DeriveAddressesResponse], error)
+	// Encrypt an owner secret with the data key wrapped by the master key
+	SealSecret(context.Context, *connect.Request[v1.SealSecretRequest]) (*connect.Response[v1.SealSecretResponse], error)
+	// Decrypt the OTP data of the owner, other secrets are never decrypted
+	UnsealOTPData(context.Context, *connect.Request[v1.UnsealOTPDataRequest]) (*connect.Response[v1.UnsealOTPDataResponse], error)
+}
+
+// NewSignerServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSignerServiceHandler(svc SignerServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	signerServiceMethods := v1.File_processing_signer_v1_signer_proto.Services().ByName("SignerService").Methods()
+	signerServiceSignUTXOTransactionHandler := connect.NewUnaryHandler(
+		SignerServiceSignUTXOTransactionProcedure,
+		svc.SignUTXOTransaction,
+		connect.WithSchema(signerServiceMethods.ByName("SignUTXOTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignEVMTransactionHandler := connect.NewUnaryHandler(
+		SignerServiceSignEVMTransactionProcedure,
+		svc.SignEVMTransaction,
+		connect.WithSchema(signerServiceMethods.ByName("SignEVMTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignTronTransactionHandler := connect.NewUnaryHandler(
+		SignerServiceSignTronTransactionProcedure,
+		svc.SignTronTransaction,
+		connect.WithSchema(signerServiceMethods.ByName("SignTronTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignMessageHandler := connect.NewUnaryHandler(
+		SignerServiceSignMessageProcedure,
+		svc.SignMessage,
+		connect.WithSchema(signerServiceMethods.ByName("SignMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceDeriveAddressesHandler := connect.NewUnaryHandler(
+		SignerServiceDeriveAddressesProcedure,
+		svc.DeriveAddresses,
+		connect.WithSchema(signerServiceMethods.ByName("DeriveAddresses")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSealSecretHandler := connect.NewUnaryHandler(
+		SignerServiceSealSecretProcedure,
+		svc.SealSecret,
+		connect.WithSchema(signerServiceMethods.ByName("SealSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceUnsealOTPDataHandler := connect.NewUnaryHandler(
+		SignerServiceUnsealOTPDataProcedure,
+		svc.UnsealOTPData,
+		connect.WithSchema(signerServiceMethods.ByName("UnsealOTPData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/processing.signer.v1.SignerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SignerServiceSignUTXOTransactionProcedure:
+			signerServiceSignUTXOTransactionHandler.ServeHTTP(w, r)
+		case SignerServiceSignEVMTransactionProcedure:
+			signerServiceSignEVMTransactionHandler.ServeHTTP(w, r)
+		case SignerServiceSignTronTransactionProcedure:
+			signerServiceSignTronTransactionHandler.ServeHTTP(w, r)
+		case SignerServiceSignMessageProcedure:
+			signerServiceSignMessageHandler.ServeHTTP(w, r)
+		case SignerServiceDeriveAddressesProcedure:
+			signerServiceDeriveAddressesHandler.ServeHTTP(w, r)
+		case SignerServiceSealSecretProcedure:
+			signerServiceSealSecretHandler.ServeHTTP(w, r)
+		case SignerServiceUnsealOTPDataProcedure:
+			signerServiceUnsealOTPDataHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSignerServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSignerServiceHandler struct{}
+
+func (UnimplementedSignerServiceHandler) SignUTXOTransaction(context.Context, *connect.Request[v1.SignUTXOTransactionRequest]) (*connect.Response[v1.SignUTXOTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.SignUTXOTransaction is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignEVMTransaction(context.Context, *connect.Request[v1.SignEVMTransactionRequest]) (*connect.Response[v1.SignEVMTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.SignEVMTransaction is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignTronTransaction(context.Context, *connect.Request[v1.SignTronTransactionRequest]) (*connect.Response[v1.SignTronTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.SignTronTransaction is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.SignMessage is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) DeriveAddresses(context.Context, *connect.Request[v1.DeriveAddressesRequest]) (*connect.Response[v1.DeriveAddressesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.DeriveAddresses is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SealSecret(context.Context, *connect.Request[v1.SealSecretRequest]) (*connect.Response[v1.SealSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.SealSecret is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) UnsealOTPData(context.Context, *connect.Request[v1.UnsealOTPDataRequest]) (*connect.Response[v1.UnsealOTPDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.signer.v1.SignerService.UnsealOTPData is not implemented"))
+}
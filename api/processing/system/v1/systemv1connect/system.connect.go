@@ -44,6 +44,9 @@ const (
 	// SystemServiceGetLastLogsProcedure is the fully-qualified name of the SystemService's GetLastLogs
 	// RPC.
 	SystemServiceGetLastLogsProcedure = "/processing.system.v1.SystemService/GetLastLogs"
+	// SystemServiceGetAuditEventsProcedure is the fully-qualified name of the SystemService's
+	// GetAuditEvents RPC.
+	SystemServiceGetAuditEventsProcedure = "/processing.system.v1.SystemService/GetAuditEvents"
 )
 
 // SystemServiceClient is a client for the processing.system.v1.SystemService service.
@@ -56,6 +59,8 @@ type SystemServiceClient interface {
 	UpdateToNewVersion(context.Context, *connect.Request[v1.UpdateToNewVersionRequest]) (*connect.Response[v1.UpdateToNewVersionResponse], error)
 	// Get last memory logs
 	GetLastLogs(context.Context, *connect.Request[v1.GetLastLogsRequest]) (*connect.Response[v1.GetLastLogsResponse], error)
+	// Get audit events of the sensitive operations, the newest first
+	GetAuditEvents(context.Context, *connect.Request[v1.GetAuditEventsRequest]) (*connect.Response[v1.GetAuditEventsResponse], error)
 }
 
 // NewSystemServiceClient constructs a client for the processing.system.v1.SystemService service. By
@@ -93,6 +98,12 @@ func NewSystemServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(systemServiceMethods.ByName("GetLastLogs")),
 			connect.WithClientOptions(opts...),
 		),
+		getAuditEvents: connect.NewClient[v1.GetAuditEventsRequest, v1.GetAuditEventsResponse](
+			httpClient,
+			baseURL+SystemServiceGetAuditEventsProcedure,
+			connect.WithSchema(systemServiceMethods.ByName("GetAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -102,6 +113,7 @@ type systemServiceClient struct {
 	checkNewVersion    *connect.Client[v1.CheckNewVersionRequest, v1.CheckNewVersionResponse]
 	updateToNewVersion *connect.Client[v1.UpdateToNewVersionRequest, v1.UpdateToNewVersionResponse]
 	getLastLogs        *connect.Client[v1.GetLastLogsRequest, v1.GetLastLogsResponse]
+	getAuditEvents     *connect.Client[v1.GetAuditEventsRequest, v1.GetAuditEventsResponse]
 }
 
 // Info calls processing.system.v1.SystemService.Info.
@@ -124,6 +136,11 @@ func (c *systemServiceClient) GetLastLogs(ctx context.Context, req *connect.Requ
 	return c.getLastLogs.CallUnary(ctx, req)
 }
 
+// GetAuditEvents calls processing.system.v1.SystemService.GetAuditEvents.
+func (c *systemServiceClient) GetAuditEvents(ctx context.Context, req *connect.Request[v1.GetAuditEventsRequest]) (*connect.Response[v1.GetAuditEventsResponse], error) {
+	return c.getAuditEvents.CallUnary(ctx, req)
+}
+
 // SystemServiceHandler is an implementation of the processing.system.v1.SystemService service.
 type SystemServiceHandler interface {
 	// System info (version etc)
@@ -134,6 +151,8 @@ type SystemServiceHandler interface {
 	UpdateToNewVersion(context.Context, *connect.Request[v1.UpdateToNewVersionRequest]) (*connect.Response[v1.UpdateToNewVersionResponse], error)
 	// Get last memory logs
 	GetLastLogs(context.Context, *connect.Request[v1.GetLastLogsRequest]) (*connect.Response[v1.GetLastLogsResponse], error)
+	// Get audit events of the sensitive operations, the newest first
+	GetAuditEvents(context.Context, *connect.Request[v1.GetAuditEventsRequest]) (*connect.Response[v1.GetAuditEventsResponse], error)
 }
 
 // NewSystemServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -167,6 +186,12 @@ func NewSystemServiceHandler(svc SystemServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(systemServiceMethods.ByName("GetLastLogs")),
 		connect.WithHandlerOptions(opts...),
 	)
+	systemServiceGetAuditEventsHandler := connect.NewUnaryHandler(
+		SystemServiceGetAuditEventsProcedure,
+		svc.GetAuditEvents,
+		connect.WithSchema(systemServiceMethods.ByName("GetAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/processing.system.v1.SystemService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case SystemServiceInfoProcedure:
@@ -177,6 +202,8 @@ func NewSystemServiceHandler(svc SystemServiceHandler, opts ...connect.HandlerOp
 			systemServiceUpdateToNewVersionHandler.ServeHTTP(w, r)
 		case SystemServiceGetLastLogsProcedure:
 			systemServiceGetLastLogsHandler.ServeHTTP(w, r)
+		case SystemServiceGetAuditEventsProcedure:
+			systemServiceGetAuditEventsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -201,3 +228,7 @@ func (UnimplementedSystemServiceHandler) UpdateToNewVersion(context.Context, *co
 func (UnimplementedSystemServiceHandler) GetLastLogs(context.Context, *connect.Request[v1.GetLastLogsRequest]) (*connect.Response[v1.GetLastLogsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.system.v1.SystemService.GetLastLogs is not implemented"))
 }
+
+func (UnimplementedSystemServiceHandler) GetAuditEvents(context.Context, *connect.Request[v1.GetAuditEventsRequest]) (*connect.Response[v1.GetAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.system.v1.SystemService.GetAuditEvents is not implemented"))
+}
@@ -38,6 +38,15 @@ const (
 	// TransferServiceGetByRequestIDProcedure is the fully-qualified name of the TransferService's
 	// GetByRequestID RPC.
 	TransferServiceGetByRequestIDProcedure = "/processing.transfer.v1.TransferService/GetByRequestID"
+	// TransferServiceSetSweepRuleProcedure is the fully-qualified name of the TransferService's
+	// SetSweepRule RPC.
+	TransferServiceSetSweepRuleProcedure = "/processing.transfer.v1.TransferService/SetSweepRule"
+	// TransferServiceGetSweepRulesProcedure is the fully-qualified name of the TransferService's
+	// GetSweepRules RPC.
+	TransferServiceGetSweepRulesProcedure = "/processing.transfer.v1.TransferService/GetSweepRules"
+	// TransferServiceDeleteSweepRuleProcedure is the fully-qualified name of the TransferService's
+	// DeleteSweepRule RPC.
+	TransferServiceDeleteSweepRuleProcedure = "/processing.transfer.v1.TransferService/DeleteSweepRule"
 )
 
 // TransferServiceClient is a client for the processing.transfer.v1.TransferService service.
@@ -46,6 +55,12 @@ type TransferServiceClient interface {
 	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
 	// Get transfer by request ID
 	GetByRequestID(context.Context, *connect.Request[v1.GetByRequestIDRequest]) (*connect.Response[v1.GetByRequestIDResponse], error)
+	// Create or update a rule for automatic sweeping of hot wallets to a cold wallet
+	SetSweepRule(context.Context, *connect.Request[v1.SetSweepRuleRequest]) (*connect.Response[v1.SetSweepRuleResponse], error)
+	// Get owner sweep rules
+	GetSweepRules(context.Context, *connect.Request[v1.GetSweepRulesRequest]) (*connect.Response[v1.GetSweepRulesResponse], error)
+	// Delete owner sweep rule
+	DeleteSweepRule(context.Context, *connect.Request[v1.DeleteSweepRuleRequest]) (*connect.Response[v1.DeleteSweepRuleResponse], error)
 }
 
 // NewTransferServiceClient constructs a client for the processing.transfer.v1.TransferService
@@ -71,13 +86,34 @@ func NewTransferServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(transferServiceMethods.ByName("GetByRequestID")),
 			connect.WithClientOptions(opts...),
 		),
+		setSweepRule: connect.NewClient[v1.SetSweepRuleRequest, v1.SetSweepRuleResponse](
+			httpClient,
+			baseURL+TransferServiceSetSweepRuleProcedure,
+			connect.WithSchema(transferServiceMethods.ByName("SetSweepRule")),
+			connect.WithClientOptions(opts...),
+		),
+		getSweepRules: connect.NewClient[v1.GetSweepRulesRequest, v1.GetSweepRulesResponse](
+			httpClient,
+			baseURL+TransferServiceGetSweepRulesProcedure,
+			connect.WithSchema(transferServiceMethods.ByName("GetSweepRules")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSweepRule: connect.NewClient[v1.DeleteSweepRuleRequest, v1.DeleteSweepRuleResponse](
+			httpClient,
+			baseURL+TransferServiceDeleteSweepRuleProcedure,
+			connect.WithSchema(transferServiceMethods.ByName("DeleteSweepRule")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // transferServiceClient implements TransferServiceClient.
 type transferServiceClient struct {
-	create         *connect.Client[v1.CreateRequest, v1.CreateResponse]
-	getByRequestID *connect.Client[v1.GetByRequestIDRequest, v1.GetByRequestIDResponse]
+	create          *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	getByRequestID  *connect.Client[v1.GetByRequestIDRequest, v1.GetByRequestIDResponse]
+	setSweepRule    *connect.Client[v1.SetSweepRuleRequest, v1.SetSweepRuleResponse]
+	getSweepRules   *connect.Client[v1.GetSweepRulesRequest, v1.GetSweepRulesResponse]
+	deleteSweepRule *connect.Client[v1.DeleteSweepRuleRequest, v1.DeleteSweepRuleResponse]
 }
 
 // Create calls processing.transfer.v1.TransferService.Create.
@@ -90,6 +126,21 @@ func (c *transferServiceClient) GetByRequestID(ctx context.Context, req *connect
 	return c.getByRequestID.CallUnary(ctx, req)
 }
 
+// SetSweepRule calls processing.transfer.v1.TransferService.SetSweepRule.
+func (c *transferServiceClient) SetSweepRule(ctx context.Context, req *connect.Request[v1.SetSweepRuleRequest]) (*connect.Response[v1.SetSweepRuleResponse], error) {
+	return c.setSweepRule.CallUnary(ctx, req)
+}
+
+// GetSweepRules calls processing.transfer.v1.TransferService.GetSweepRules.
+func (c *transferServiceClient) GetSweepRules(ctx context.Context, req *connect.Request[v1.GetSweepRulesRequest]) (*connect.Response[v1.GetSweepRulesResponse], error) {
+	return c.getSweepRules.CallUnary(ctx, req)
+}
+
+// DeleteSweepRule calls processing.transfer.v1.TransferService.DeleteSweepRule.
+func (c *transferServiceClient) DeleteSweepRule(ctx context.Context, req *connect.Request[v1.DeleteSweepRuleRequest]) (*connect.Response[v1.DeleteSweepRuleResponse], error) {
+	return c.deleteSweepRule.CallUnary(ctx, req)
+}
+
 // TransferServiceHandler is an implementation of the processing.transfer.v1.TransferService
 // service.
 type TransferServiceHandler interface {
@@ -97,6 +148,12 @@ type TransferServiceHandler interface {
 	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
 	// Get transfer by request ID
 	GetByRequestID(context.Context, *connect.Request[v1.GetByRequestIDRequest]) (*connect.Response[v1.GetByRequestIDResponse], error)
+	// Create or update a rule for automatic sweeping of hot wallets to a cold wallet
+	SetSweepRule(context.Context, *connect.Request[v1.SetSweepRuleRequest]) (*connect.Response[v1.SetSweepRuleResponse], error)
+	// Get owner sweep rules
+	GetSweepRules(context.Context, *connect.Request[v1.GetSweepRulesRequest]) (*connect.Response[v1.GetSweepRulesResponse], error)
+	// Delete owner sweep rule
+	DeleteSweepRule(context.Context, *connect.Request[v1.DeleteSweepRuleRequest]) (*connect.Response[v1.DeleteSweepRuleResponse], error)
 }
 
 // NewTransferServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -118,12 +175,36 @@ func NewTransferServiceHandler(svc TransferServiceHandler, opts ...connect.Handl
 		connect.WithSchema(transferServiceMethods.ByName("GetByRequestID")),
 		connect.WithHandlerOptions(opts...),
 	)
+	transferServiceSetSweepRuleHandler := connect.NewUnaryHandler(
+		TransferServiceSetSweepRuleProcedure,
+		svc.SetSweepRule,
+		connect.WithSchema(transferServiceMethods.ByName("SetSweepRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transferServiceGetSweepRulesHandler := connect.NewUnaryHandler(
+		TransferServiceGetSweepRulesProcedure,
+		svc.GetSweepRules,
+		connect.WithSchema(transferServiceMethods.ByName("GetSweepRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transferServiceDeleteSweepRuleHandler := connect.NewUnaryHandler(
+		TransferServiceDeleteSweepRuleProcedure,
+		svc.DeleteSweepRule,
+		connect.WithSchema(transferServiceMethods.ByName("DeleteSweepRule")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/processing.transfer.v1.TransferService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TransferServiceCreateProcedure:
 			transferServiceCreateHandler.ServeHTTP(w, r)
 		case TransferServiceGetByRequestIDProcedure:
 			transferServiceGetByRequestIDHandler.ServeHTTP(w, r)
+		case TransferServiceSetSweepRuleProcedure:
+			transferServiceSetSweepRuleHandler.ServeHTTP(w, r)
+		case TransferServiceGetSweepRulesProcedure:
+			transferServiceGetSweepRulesHandler.ServeHTTP(w, r)
+		case TransferServiceDeleteSweepRuleProcedure:
+			transferServiceDeleteSweepRuleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -140,3 +221,15 @@ func (UnimplementedTransferServiceHandler) Create(context.Context, *connect.Requ
 func (UnimplementedTransferServiceHandler) GetByRequestID(context.Context, *connect.Request[v1.GetByRequestIDRequest]) (*connect.Response[v1.GetByRequestIDResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.transfer.v1.TransferService.GetByRequestID is not implemented"))
 }
+
+func (UnimplementedTransferServiceHandler) SetSweepRule(context.Context, *connect.Request[v1.SetSweepRuleRequest]) (*connect.Response[v1.SetSweepRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.transfer.v1.TransferService.SetSweepRule is not implemented"))
+}
+
+func (UnimplementedTransferServiceHandler) GetSweepRules(context.Context, *connect.Request[v1.GetSweepRulesRequest]) (*connect.Response[v1.GetSweepRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.transfer.v1.TransferService.GetSweepRules is not implemented"))
+}
+
+func (UnimplementedTransferServiceHandler) DeleteSweepRule(context.Context, *connect.Request[v1.DeleteSweepRuleRequest]) (*connect.Response[v1.DeleteSweepRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.transfer.v1.TransferService.DeleteSweepRule is not implemented"))
+}
@@ -42,15 +42,45 @@ const (
 	// WalletServiceGetOwnerProcessingWalletsProcedure is the fully-qualified name of the
 	// WalletService's GetOwnerProcessingWallets RPC.
 	WalletServiceGetOwnerProcessingWalletsProcedure = "/processing.wallet.v1.WalletService/GetOwnerProcessingWallets"
+	// WalletServiceCreateOwnerProcessingWalletsProcedure is the fully-qualified name of the
+	// WalletService's CreateOwnerProcessingWallets RPC.
+	WalletServiceCreateOwnerProcessingWalletsProcedure = "/processing.wallet.v1.WalletService/CreateOwnerProcessingWallets"
+	// WalletServiceSetProcessingWalletsStrategyProcedure is the fully-qualified name of the
+	// WalletService's SetProcessingWalletsStrategy RPC.
+	WalletServiceSetProcessingWalletsStrategyProcedure = "/processing.wallet.v1.WalletService/SetProcessingWalletsStrategy"
+	// WalletServiceGetProcessingWalletsStrategyProcedure is the fully-qualified name of the
+	// WalletService's GetProcessingWalletsStrategy RPC.
+	WalletServiceGetProcessingWalletsStrategyProcedure = "/processing.wallet.v1.WalletService/GetProcessingWalletsStrategy"
 	// WalletServiceAttachOwnerColdWalletsProcedure is the fully-qualified name of the WalletService's
 	// AttachOwnerColdWallets RPC.
 	WalletServiceAttachOwnerColdWalletsProcedure = "/processing.wallet.v1.WalletService/AttachOwnerColdWallets"
+	// WalletServiceDetachOwnerColdWalletProcedure is the fully-qualified name of the WalletService's
+	// DetachOwnerColdWallet RPC.
+	WalletServiceDetachOwnerColdWalletProcedure = "/processing.wallet.v1.WalletService/DetachOwnerColdWallet"
+	// WalletServiceSetOwnerColdWalletLabelProcedure is the fully-qualified name of the WalletService's
+	// SetOwnerColdWalletLabel RPC.
+	WalletServiceSetOwnerColdWalletLabelProcedure = "/processing.wallet.v1.WalletService/SetOwnerColdWalletLabel"
+	// WalletServiceSetColdWalletsDistributionProcedure is the fully-qualified name of the
+	// WalletService's SetColdWalletsDistribution RPC.
+	WalletServiceSetColdWalletsDistributionProcedure = "/processing.wallet.v1.WalletService/SetColdWalletsDistribution"
+	// WalletServiceGetColdWalletsDistributionProcedure is the fully-qualified name of the
+	// WalletService's GetColdWalletsDistribution RPC.
+	WalletServiceGetColdWalletsDistributionProcedure = "/processing.wallet.v1.WalletService/GetColdWalletsDistribution"
 	// WalletServiceMarkDirtyHotWalletProcedure is the fully-qualified name of the WalletService's
 	// MarkDirtyHotWallet RPC.
 	WalletServiceMarkDirtyHotWalletProcedure = "/processing.wallet.v1.WalletService/MarkDirtyHotWallet"
 	// WalletServiceCreateOwnerHotWalletProcedure is the fully-qualified name of the WalletService's
 	// CreateOwnerHotWallet RPC.
 	WalletServiceCreateOwnerHotWalletProcedure = "/processing.wallet.v1.WalletService/CreateOwnerHotWallet"
+	// WalletServiceCreateOwnerHotWalletsProcedure is the fully-qualified name of the WalletService's
+	// CreateOwnerHotWallets RPC.
+	WalletServiceCreateOwnerHotWalletsProcedure = "/processing.wallet.v1.WalletService/CreateOwnerHotWallets"
+	// WalletServiceSignMessageProcedure is the fully-qualified name of the WalletService's SignMessage
+	// RPC.
+	WalletServiceSignMessageProcedure = "/processing.wallet.v1.WalletService/SignMessage"
+	// WalletServiceVerifyMessageProcedure is the fully-qualified name of the WalletService's
+	// VerifyMessage RPC.
+	WalletServiceVerifyMessageProcedure = "/processing.wallet.v1.WalletService/VerifyMessage"
 )
 
 // WalletServiceClient is a client for the processing.wallet.v1.WalletService service.
@@ -61,12 +91,32 @@ type WalletServiceClient interface {
 	GetOwnerColdWallets(context.Context, *connect.Request[v1.GetOwnerColdWalletsRequest]) (*connect.Response[v1.GetOwnerColdWalletsResponse], error)
 	// Get owner processing wallets
 	GetOwnerProcessingWallets(context.Context, *connect.Request[v1.GetOwnerProcessingWalletsRequest]) (*connect.Response[v1.GetOwnerProcessingWalletsResponse], error)
+	// Create additional owner processing wallets for the blockchain
+	CreateOwnerProcessingWallets(context.Context, *connect.Request[v1.CreateOwnerProcessingWalletsRequest]) (*connect.Response[v1.CreateOwnerProcessingWalletsResponse], error)
+	// Set the strategy which chooses the owner processing wallet for outgoing transfers
+	SetProcessingWalletsStrategy(context.Context, *connect.Request[v1.SetProcessingWalletsStrategyRequest]) (*connect.Response[v1.SetProcessingWalletsStrategyResponse], error)
+	// Get the owner processing wallets strategy
+	GetProcessingWalletsStrategy(context.Context, *connect.Request[v1.GetProcessingWalletsStrategyRequest]) (*connect.Response[v1.GetProcessingWalletsStrategyResponse], error)
 	// Attach owner cold wallets
 	AttachOwnerColdWallets(context.Context, *connect.Request[v1.AttachOwnerColdWalletsRequest]) (*connect.Response[v1.AttachOwnerColdWalletsResponse], error)
+	// Detach an owner cold wallet, the wallet is kept for audit
+	DetachOwnerColdWallet(context.Context, *connect.Request[v1.DetachOwnerColdWalletRequest]) (*connect.Response[v1.DetachOwnerColdWalletResponse], error)
+	// Set a human-readable label and note of an owner cold wallet
+	SetOwnerColdWalletLabel(context.Context, *connect.Request[v1.SetOwnerColdWalletLabelRequest]) (*connect.Response[v1.SetOwnerColdWalletLabelResponse], error)
+	// Set the strategy which chooses the owner cold wallet for the "auto" transfers
+	SetColdWalletsDistribution(context.Context, *connect.Request[v1.SetColdWalletsDistributionRequest]) (*connect.Response[v1.SetColdWalletsDistributionResponse], error)
+	// Get the owner cold wallets distribution strategy
+	GetColdWalletsDistribution(context.Context, *connect.Request[v1.GetColdWalletsDistributionRequest]) (*connect.Response[v1.GetColdWalletsDistributionResponse], error)
 	// Mark a dirty hot wallet
 	MarkDirtyHotWallet(context.Context, *connect.Request[v1.MarkDirtyHotWalletRequest]) (*connect.Response[v1.MarkDirtyHotWalletResponse], error)
 	// Create owner hot wallet
 	CreateOwnerHotWallet(context.Context, *connect.Request[v1.CreateOwnerHotWalletRequest]) (*connect.Response[v1.CreateOwnerHotWalletResponse], error)
+	// Create owner hot wallets for several external wallet ids at once
+	CreateOwnerHotWallets(context.Context, *connect.Request[v1.CreateOwnerHotWalletsRequest]) (*connect.Response[v1.CreateOwnerHotWalletsResponse], error)
+	// Sign a message with the key of the owner hot or processing wallet
+	SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error)
+	// Verify a message signature made by the key of the address
+	VerifyMessage(context.Context, *connect.Request[v1.VerifyMessageRequest]) (*connect.Response[v1.VerifyMessageResponse], error)
 }
 
 // NewWalletServiceClient constructs a client for the processing.wallet.v1.WalletService service. By
@@ -98,12 +148,54 @@ func NewWalletServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(walletServiceMethods.ByName("GetOwnerProcessingWallets")),
 			connect.WithClientOptions(opts...),
 		),
+		createOwnerProcessingWallets: connect.NewClient[v1.CreateOwnerProcessingWalletsRequest, v1.CreateOwnerProcessingWalletsResponse](
+			httpClient,
+			baseURL+WalletServiceCreateOwnerProcessingWalletsProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("CreateOwnerProcessingWallets")),
+			connect.WithClientOptions(opts...),
+		),
+		setProcessingWalletsStrategy: connect.NewClient[v1.SetProcessingWalletsStrategyRequest, v1.SetProcessingWalletsStrategyResponse](
+			httpClient,
+			baseURL+WalletServiceSetProcessingWalletsStrategyProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("SetProcessingWalletsStrategy")),
+			connect.WithClientOptions(opts...),
+		),
+		getProcessingWalletsStrategy: connect.NewClient[v1.GetProcessingWalletsStrategyRequest, v1.GetProcessingWalletsStrategyResponse](
+			httpClient,
+			baseURL+WalletServiceGetProcessingWalletsStrategyProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("GetProcessingWalletsStrategy")),
+			connect.WithClientOptions(opts...),
+		),
 		attachOwnerColdWallets: connect.NewClient[v1.AttachOwnerColdWalletsRequest, v1.AttachOwnerColdWalletsResponse](
 			httpClient,
 			baseURL+WalletServiceAttachOwnerColdWalletsProcedure,
 			connect.WithSchema(walletServiceMethods.ByName("AttachOwnerColdWallets")),
 			connect.WithClientOptions(opts...),
 		),
+		detachOwnerColdWallet: connect.NewClient[v1.DetachOwnerColdWalletRequest, v1.DetachOwnerColdWalletResponse](
+			httpClient,
+			baseURL+WalletServiceDetachOwnerColdWalletProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("DetachOwnerColdWallet")),
+			connect.WithClientOptions(opts...),
+		),
+		setOwnerColdWalletLabel: connect.NewClient[v1.SetOwnerColdWalletLabelRequest, v1.SetOwnerColdWalletLabelResponse](
+			httpClient,
+			baseURL+WalletServiceSetOwnerColdWalletLabelProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("SetOwnerColdWalletLabel")),
+			connect.WithClientOptions(opts...),
+		),
+		setColdWalletsDistribution: connect.NewClient[v1.SetColdWalletsDistributionRequest, v1.SetColdWalletsDistributionResponse](
+			httpClient,
+			baseURL+WalletServiceSetColdWalletsDistributionProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("SetColdWalletsDistribution")),
+			connect.WithClientOptions(opts...),
+		),
+		getColdWalletsDistribution: connect.NewClient[v1.GetColdWalletsDistributionRequest, v1.GetColdWalletsDistributionResponse](
+			httpClient,
+			baseURL+WalletServiceGetColdWalletsDistributionProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("GetColdWalletsDistribution")),
+			connect.WithClientOptions(opts...),
+		),
 		markDirtyHotWallet: connect.NewClient[v1.MarkDirtyHotWalletRequest, v1.MarkDirtyHotWalletResponse](
 			httpClient,
 			baseURL+WalletServiceMarkDirtyHotWalletProcedure,
@@ -116,17 +208,45 @@ func NewWalletServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(walletServiceMethods.ByName("CreateOwnerHotWallet")),
 			connect.WithClientOptions(opts...),
 		),
+		createOwnerHotWallets: connect.NewClient[v1.CreateOwnerHotWalletsRequest, v1.CreateOwnerHotWalletsResponse](
+			httpClient,
+			baseURL+WalletServiceCreateOwnerHotWalletsProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("CreateOwnerHotWallets")),
+			connect.WithClientOptions(opts...),
+		),
+		signMessage: connect.NewClient[v1.SignMessageRequest, v1.SignMessageResponse](
+			httpClient,
+			baseURL+WalletServiceSignMessageProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("SignMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyMessage: connect.NewClient[v1.VerifyMessageRequest, v1.VerifyMessageResponse](
+			httpClient,
+			baseURL+WalletServiceVerifyMessageProcedure,
+			connect.WithSchema(walletServiceMethods.ByName("VerifyMessage")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // walletServiceClient implements WalletServiceClient.
 type walletServiceClient struct {
-	getOwnerHotWallets        *connect.Client[v1.GetOwnerHotWalletsRequest, v1.GetOwnerHotWalletsResponse]
-	getOwnerColdWallets       *connect.Client[v1.GetOwnerColdWalletsRequest, v1.GetOwnerColdWalletsResponse]
-	getOwnerProcessingWallets *connect.Client[v1.GetOwnerProcessingWalletsRequest, v1.GetOwnerProcessingWalletsResponse]
-	attachOwnerColdWallets    *connect.Client[v1.AttachOwnerColdWalletsRequest, v1.AttachOwnerColdWalletsResponse]
-	markDirtyHotWallet        *connect.Client[v1.MarkDirtyHotWalletRequest, v1.MarkDirtyHotWalletResponse]
-	createOwnerHotWallet      *connect.Client[v1.CreateOwnerHotWalletRequest, v1.CreateOwnerHotWalletResponse]
+	getOwnerHotWallets           *connect.Client[v1.GetOwnerHotWalletsRequest, v1.GetOwnerHotWalletsResponse]
+	getOwnerColdWallets          *connect.Client[v1.GetOwnerColdWalletsRequest, v1.GetOwnerColdWalletsResponse]
+	getOwnerProcessingWallets    *connect.Client[v1.GetOwnerProcessingWalletsRequest, v1.GetOwnerProcessingWalletsResponse]
+	createOwnerProcessingWallets *connect.Client[v1.CreateOwnerProcessingWalletsRequest, v1.CreateOwnerProcessingWalletsResponse]
+	setProcessingWalletsStrategy *connect.Client[v1.SetProcessingWalletsStrategyRequest, v1.SetProcessingWalletsStrategyResponse]
+	getProcessingWalletsStrategy *connect.Client[v1.GetProcessingWalletsStrategyRequest, v1.GetProcessingWalletsStrategyResponse]
+	attachOwnerColdWallets       *connect.Client[v1.AttachOwnerColdWalletsRequest, v1.AttachOwnerColdWalletsResponse]
+	detachOwnerColdWallet        *connect.Client[v1.DetachOwnerColdWalletRequest, v1.DetachOwnerColdWalletResponse]
+	setOwnerColdWalletLabel      *connect.Client[v1.SetOwnerColdWalletLabelRequest, v1.SetOwnerColdWalletLabelResponse]
+	setColdWalletsDistribution   *connect.Client[v1.SetColdWalletsDistributionRequest, v1.SetColdWalletsDistributionResponse]
+	getColdWalletsDistribution   *connect.Client[v1.GetColdWalletsDistributionRequest, v1.GetColdWalletsDistributionResponse]
+	markDirtyHotWallet           *connect.Client[v1.MarkDirtyHotWalletRequest, v1.MarkDirtyHotWalletResponse]
+	createOwnerHotWallet         *connect.Client[v1.CreateOwnerHotWalletRequest, v1.CreateOwnerHotWalletResponse]
+	createOwnerHotWallets        *connect.Client[v1.CreateOwnerHotWalletsRequest, v1.CreateOwnerHotWalletsResponse]
+	signMessage                  *connect.Client[v1.SignMessageRequest, v1.SignMessageResponse]
+	verifyMessage                *connect.Client[v1.VerifyMessageRequest, v1.VerifyMessageResponse]
 }
 
 // GetOwnerHotWallets calls processing.wallet.v1.WalletService.GetOwnerHotWallets.
@@ -144,11 +264,49 @@ func (c *walletServiceClient) GetOwnerProcessingWallets(ctx context.Context, req
 	return c.getOwnerProcessingWallets.CallUnary(ctx, req)
 }
 
+// CreateOwnerProcessingWallets calls
+// processing.wallet.v1.WalletService.CreateOwnerProcessingWallets.
+func (c *walletServiceClient) CreateOwnerProcessingWallets(ctx context.Context, req *connect.Request[v1.CreateOwnerProcessingWalletsRequest]) (*connect.Response[v1.CreateOwnerProcessingWalletsResponse], error) {
+	return c.createOwnerProcessingWallets.CallUnary(ctx, req)
+}
+
+// SetProcessingWalletsStrategy calls
+// processing.wallet.v1.WalletService.SetProcessingWalletsStrategy.
+func (c *walletServiceClient) SetProcessingWalletsStrategy(ctx context.Context, req *connect.Request[v1.SetProcessingWalletsStrategyRequest]) (*connect.Response[v1.SetProcessingWalletsStrategyResponse], error) {
+	return c.setProcessingWalletsStrategy.CallUnary(ctx, req)
+}
+
+// GetProcessingWalletsStrategy calls
+// processing.wallet.v1.WalletService.GetProcessingWalletsStrategy.
+func (c *walletServiceClient) GetProcessingWalletsStrategy(ctx context.Context, req *connect.Request[v1.GetProcessingWalletsStrategyRequest]) (*connect.Response[v1.GetProcessingWalletsStrategyResponse], error) {
+	return c.getProcessingWalletsStrategy.CallUnary(ctx, req)
+}
+
 // AttachOwnerColdWallets calls processing.wallet.v1.WalletService.AttachOwnerColdWallets.
 func (c *walletServiceClient) AttachOwnerColdWallets(ctx context.Context, req *connect.Request[v1.AttachOwnerColdWalletsRequest]) (*connect.Response[v1.AttachOwnerColdWalletsResponse], error) {
 	return c.attachOwnerColdWallets.CallUnary(ctx, req)
 }
 
+// DetachOwnerColdWallet calls processing.wallet.v1.WalletService.DetachOwnerColdWallet.
+func (c *walletServiceClient) DetachOwnerColdWallet(ctx context.Context, req *connect.Request[v1.DetachOwnerColdWalletRequest]) (*connect.Response[v1.DetachOwnerColdWalletResponse], error) {
+	return c.detachOwnerColdWallet.CallUnary(ctx, req)
+}
+
+// SetOwnerColdWalletLabel calls processing.wallet.v1.WalletService.SetOwnerColdWalletLabel.
+func (c *walletServiceClient) SetOwnerColdWalletLabel(ctx context.Context, req *connect.Request[v1.SetOwnerColdWalletLabelRequest]) (*connect.Response[v1.SetOwnerColdWalletLabelResponse], error) {
+	return c.setOwnerColdWalletLabel.CallUnary(ctx, req)
+}
+
+// SetColdWalletsDistribution calls processing.wallet.v1.WalletService.SetColdWalletsDistribution.
+func (c *walletServiceClient) SetColdWalletsDistribution(ctx context.Context, req *connect.Request[v1.SetColdWalletsDistributionRequest]) (*connect.Response[v1.SetColdWalletsDistributionResponse], error) {
+	return c.setColdWalletsDistribution.CallUnary(ctx, req)
+}
+
+// GetColdWalletsDistribution calls processing.wallet.v1.WalletService.GetColdWalletsDistribution.
+func (c *walletServiceClient) GetColdWalletsDistribution(ctx context.Context, req *connect.Request[v1.GetColdWalletsDistributionRequest]) (*connect.Response[v1.GetColdWalletsDistributionResponse], error) {
+	return c.getColdWalletsDistribution.CallUnary(ctx, req)
+}
+
 // MarkDirtyHotWallet calls processing.wallet.v1.WalletService.MarkDirtyHotWallet.
 func (c *walletServiceClient) MarkDirtyHotWallet(ctx context.Context, req *connect.Request[v1.MarkDirtyHotWalletRequest]) (*connect.Response[v1.MarkDirtyHotWalletResponse], error) {
 	return c.markDirtyHotWallet.CallUnary(ctx, req)
@@ -159,6 +317,21 @@ func (c *walletServiceClient) CreateOwnerHotWallet(ctx context.Context, req *con
 	return c.createOwnerHotWallet.CallUnary(ctx, req)
 }
 
+// CreateOwnerHotWallets calls processing.wallet.v1.WalletService.CreateOwnerHotWallets.
+func (c *walletServiceClient) CreateOwnerHotWallets(ctx context.Context, req *connect.Request[v1.CreateOwnerHotWalletsRequest]) (*connect.Response[v1.CreateOwnerHotWalletsResponse], error) {
+	return c.createOwnerHotWallets.CallUnary(ctx, req)
+}
+
+// SignMessage calls processing.wallet.v1.WalletService.SignMessage.
+func (c *walletServiceClient) SignMessage(ctx context.Context, req *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error) {
+	return c.signMessage.CallUnary(ctx, req)
+}
+
+// VerifyMessage calls processing.wallet.v1.WalletService.VerifyMessage.
+func (c *walletServiceClient) VerifyMessage(ctx context.Context, req *connect.Request[v1.VerifyMessageRequest]) (*connect.Response[v1.VerifyMessageResponse], error) {
+	return c.verifyMessage.CallUnary(ctx, req)
+}
+
 // WalletServiceHandler is an implementation of the processing.wallet.v1.WalletService service.
 type WalletServiceHandler interface {
 	// Get owner hot wallets
@@ -167,12 +340,32 @@ type WalletServiceHandler interface {
 	GetOwnerColdWallets(context.Context, *connect.Request[v1.GetOwnerColdWalletsRequest]) (*connect.Response[v1.GetOwnerColdWalletsResponse], error)
 	// Get owner processing wallets
 	GetOwnerProcessingWallets(context.Context, *connect.Request[v1.GetOwnerProcessingWalletsRequest]) (*connect.Response[v1.GetOwnerProcessingWalletsResponse], error)
+	// Create additional owner processing wallets for the blockchain
+	CreateOwnerProcessingWallets(context.Context, *connect.Request[v1.CreateOwnerProcessingWalletsRequest]) (*connect.Response[v1.CreateOwnerProcessingWalletsResponse], error)
+	// Set the strategy which chooses the owner processing wallet for outgoing transfers
+	SetProcessingWalletsStrategy(context.Context, *connect.Request[v1.SetProcessingWalletsStrategyRequest]) (*connect.Response[v1.SetProcessingWalletsStrategyResponse], error)
+	// Get the owner processing wallets strategy
+	GetProcessingWalletsStrategy(context.Context, *connect.Request[v1.GetProcessingWalletsStrategyRequest]) (*connect.Response[v1.GetProcessingWalletsStrategyResponse], error)
 	// Attach owner cold wallets
 	AttachOwnerColdWallets(context.Context, *connect.Request[v1.AttachOwnerColdWalletsRequest]) (*connect.Response[v1.AttachOwnerColdWalletsResponse], error)
+	// Detach an owner cold wallet, the wallet is kept for audit
+	DetachOwnerColdWallet(context.Context, *connect.Request[v1.DetachOwnerColdWalletRequest]) (*connect.Response[v1.DetachOwnerColdWalletResponse], error)
+	// Set a human-readable label and note of an owner cold wallet
+	SetOwnerColdWalletLabel(context.Context, *connect.Request[v1.SetOwnerColdWalletLabelRequest]) (*connect.Response[v1.SetOwnerColdWalletLabelResponse], error)
+	// Set the strategy which chooses the owner cold wallet for the "auto" transfers
+	SetColdWalletsDistribution(context.Context, *connect.Request[v1.SetColdWalletsDistributionRequest]) (*connect.Response[v1.SetColdWalletsDistributionResponse], error)
+	// Get the owner cold wallets distribution strategy
+	GetColdWalletsDistribution(context.Context, *connect.Request[v1.GetColdWalletsDistributionRequest]) (*connect.Response[v1.GetColdWalletsDistributionResponse], error)
 	// Mark a dirty hot wallet
 	MarkDirtyHotWallet(context.Context, *connect.Request[v1.MarkDirtyHotWalletRequest]) (*connect.Response[v1.MarkDirtyHotWalletResponse], error)
 	// Create owner hot wallet
 	CreateOwnerHotWallet(context.Context, *connect.Request[v1.CreateOwnerHotWalletRequest]) (*connect.Response[v1.CreateOwnerHotWalletResponse], error)
+	// Create owner hot wallets for several external wallet ids at once
+	CreateOwnerHotWallets(context.Context, *connect.Request[v1.CreateOwnerHotWalletsRequest]) (*connect.Response[v1.CreateOwnerHotWalletsResponse], error)
+	// Sign a message with the key of the owner hot or processing wallet
+	SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error)
+	// Verify a message signature made by the key of the address
+	VerifyMessage(context.Context, *connect.Request[v1.VerifyMessageRequest]) (*connect.Response[v1.VerifyMessageResponse], error)
 }
 
 // NewWalletServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -200,12 +393,54 @@ func NewWalletServiceHandler(svc WalletServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(walletServiceMethods.ByName("GetOwnerProcessingWallets")),
 		connect.WithHandlerOptions(opts...),
 	)
+	walletServiceCreateOwnerProcessingWalletsHandler := connect.NewUnaryHandler(
+		WalletServiceCreateOwnerProcessingWalletsProcedure,
+		svc.CreateOwnerProcessingWallets,
+		connect.WithSchema(walletServiceMethods.ByName("CreateOwnerProcessingWallets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceSetProcessingWalletsStrategyHandler := connect.NewUnaryHandler(
+		WalletServiceSetProcessingWalletsStrategyProcedure,
+		svc.SetProcessingWalletsStrategy,
+		connect.WithSchema(walletServiceMethods.ByName("SetProcessingWalletsStrategy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceGetProcessingWalletsStrategyHandler := connect.NewUnaryHandler(
+		WalletServiceGetProcessingWalletsStrategyProcedure,
+		svc.GetProcessingWalletsStrategy,
+		connect.WithSchema(walletServiceMethods.ByName("GetProcessingWalletsStrategy")),
+		connect.WithHandlerOptions(opts...),
+	)
 	walletServiceAttachOwnerColdWalletsHandler := connect.NewUnaryHandler(
 		WalletServiceAttachOwnerColdWalletsProcedure,
 		svc.AttachOwnerColdWallets,
 		connect.WithSchema(walletServiceMethods.ByName("AttachOwnerColdWallets")),
 		connect.WithHandlerOptions(opts...),
 	)
+	walletServiceDetachOwnerColdWalletHandler := connect.NewUnaryHandler(
+		WalletServiceDetachOwnerColdWalletProcedure,
+		svc.DetachOwnerColdWallet,
+		connect.WithSchema(walletServiceMethods.ByName("DetachOwnerColdWallet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceSetOwnerColdWalletLabelHandler := connect.NewUnaryHandler(
+		WalletServiceSetOwnerColdWalletLabelProcedure,
+		svc.SetOwnerColdWalletLabel,
+		connect.WithSchema(walletServiceMethods.ByName("SetOwnerColdWalletLabel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceSetColdWalletsDistributionHandler := connect.NewUnaryHandler(
+		WalletServiceSetColdWalletsDistributionProcedure,
+		svc.SetColdWalletsDistribution,
+		connect.WithSchema(walletServiceMethods.ByName("SetColdWalletsDistribution")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceGetColdWalletsDistributionHandler := connect.NewUnaryHandler(
+		WalletServiceGetColdWalletsDistributionProcedure,
+		svc.GetColdWalletsDistribution,
+		connect.WithSchema(walletServiceMethods.ByName("GetColdWalletsDistribution")),
+		connect.WithHandlerOptions(opts...),
+	)
 	walletServiceMarkDirtyHotWalletHandler := connect.NewUnaryHandler(
 		WalletServiceMarkDirtyHotWalletProcedure,
 		svc.MarkDirtyHotWallet,
@@ -218,6 +453,24 @@ func NewWalletServiceHandler(svc WalletServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(walletServiceMethods.ByName("CreateOwnerHotWallet")),
 		connect.WithHandlerOptions(opts...),
 	)
+	walletServiceCreateOwnerHotWalletsHandler := connect.NewUnaryHandler(
+		WalletServiceCreateOwnerHotWalletsProcedure,
+		svc.CreateOwnerHotWallets,
+		connect.WithSchema(walletServiceMethods.ByName("CreateOwnerHotWallets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceSignMessageHandler := connect.NewUnaryHandler(
+		WalletServiceSignMessageProcedure,
+		svc.SignMessage,
+		connect.WithSchema(walletServiceMethods.ByName("SignMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	walletServiceVerifyMessageHandler := connect.NewUnaryHandler(
+		WalletServiceVerifyMessageProcedure,
+		svc.VerifyMessage,
+		connect.WithSchema(walletServiceMethods.ByName("VerifyMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/processing.wallet.v1.WalletService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case WalletServiceGetOwnerHotWalletsProcedure:
@@ -226,12 +479,32 @@ func NewWalletServiceHandler(svc WalletServiceHandler, opts ...connect.HandlerOp
 			walletServiceGetOwnerColdWalletsHandler.ServeHTTP(w, r)
 		case WalletServiceGetOwnerProcessingWalletsProcedure:
 			walletServiceGetOwnerProcessingWalletsHandler.ServeHTTP(w, r)
+		case WalletServiceCreateOwnerProcessingWalletsProcedure:
+			walletServiceCreateOwnerProcessingWalletsHandler.ServeHTTP(w, r)
+		case WalletServiceSetProcessingWalletsStrategyProcedure:
+			walletServiceSetProcessingWalletsStrategyHandler.ServeHTTP(w, r)
+		case WalletServiceGetProcessingWalletsStrategyProcedure:
+			walletServiceGetProcessingWalletsStrategyHandler.ServeHTTP(w, r)
 		case WalletServiceAttachOwnerColdWalletsProcedure:
 			walletServiceAttachOwnerColdWalletsHandler.ServeHTTP(w, r)
+		case WalletServiceDetachOwnerColdWalletProcedure:
+			walletServiceDetachOwnerColdWalletHandler.ServeHTTP(w, r)
+		case WalletServiceSetOwnerColdWalletLabelProcedure:
+			walletServiceSetOwnerColdWalletLabelHandler.ServeHTTP(w, r)
+		case WalletServiceSetColdWalletsDistributionProcedure:
+			walletServiceSetColdWalletsDistributionHandler.ServeHTTP(w, r)
+		case WalletServiceGetColdWalletsDistributionProcedure:
+			walletServiceGetColdWalletsDistributionHandler.ServeHTTP(w, r)
 		case WalletServiceMarkDirtyHotWalletProcedure:
 			walletServiceMarkDirtyHotWalletHandler.ServeHTTP(w, r)
 		case WalletServiceCreateOwnerHotWalletProcedure:
 			walletServiceCreateOwnerHotWalletHandler.ServeHTTP(w, r)
+		case WalletServiceCreateOwnerHotWalletsProcedure:
+			walletServiceCreateOwnerHotWalletsHandler.ServeHTTP(w, r)
+		case WalletServiceSignMessageProcedure:
+			walletServiceSignMessageHandler.ServeHTTP(w, r)
+		case WalletServiceVerifyMessageProcedure:
+			walletServiceVerifyMessageHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -253,10 +526,38 @@ func (UnimplementedWalletServiceHandler) GetOwnerProcessingWallets(context.Conte
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.GetOwnerProcessingWallets is not implemented"))
 }
 
+func (UnimplementedWalletServiceHandler) CreateOwnerProcessingWallets(context.Context, *connect.Request[v1.CreateOwnerProcessingWalletsRequest]) (*connect.Response[v1.CreateOwnerProcessingWalletsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.CreateOwnerProcessingWallets is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) SetProcessingWalletsStrategy(context.Context, *connect.Request[v1.SetProcessingWalletsStrategyRequest]) (*connect.Response[v1.SetProcessingWalletsStrategyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.SetProcessingWalletsStrategy is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) GetProcessingWalletsStrategy(context.Context, *connect.Request[v1.GetProcessingWalletsStrategyRequest]) (*connect.Response[v1.GetProcessingWalletsStrategyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.GetProcessingWalletsStrategy is not implemented"))
+}
+
 func (UnimplementedWalletServiceHandler) AttachOwnerColdWallets(context.Context, *connect.Request[v1.AttachOwnerColdWalletsRequest]) (*connect.Response[v1.AttachOwnerColdWalletsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.AttachOwnerColdWallets is not implemented"))
 }
 
+func (UnimplementedWalletServiceHandler) DetachOwnerColdWallet(context.Context, *connect.Request[v1.DetachOwnerColdWalletRequest]) (*connect.Response[v1.DetachOwnerColdWalletResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.DetachOwnerColdWallet is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) SetOwnerColdWalletLabel(context.Context, *connect.Request[v1.SetOwnerColdWalletLabelRequest]) (*connect.Response[v1.SetOwnerColdWalletLabelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.SetOwnerColdWalletLabel is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) SetColdWalletsDistribution(context.Context, *connect.Request[v1.SetColdWalletsDistributionRequest]) (*connect.Response[v1.SetColdWalletsDistributionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.SetColdWalletsDistribution is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) GetColdWalletsDistribution(context.Context, *connect.Request[v1.GetColdWalletsDistributionRequest]) (*connect.Response[v1.GetColdWalletsDistributionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.GetColdWalletsDistribution is not implemented"))
+}
+
 func (UnimplementedWalletServiceHandler) MarkDirtyHotWallet(context.Context, *connect.Request[v1.MarkDirtyHotWalletRequest]) (*connect.Response[v1.MarkDirtyHotWalletResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.MarkDirtyHotWallet is not implemented"))
 }
@@ -264,3 +565,15 @@ func (UnimplementedWalletServiceHandler) MarkDirtyHotWallet(context.Context, *co
 func (UnimplementedWalletServiceHandler) CreateOwnerHotWallet(context.Context, *connect.Request[v1.CreateOwnerHotWalletRequest]) (*connect.Response[v1.CreateOwnerHotWalletResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.CreateOwnerHotWallet is not implemented"))
 }
+
+func (UnimplementedWalletServiceHandler) CreateOwnerHotWallets(context.Context, *connect.Request[v1.CreateOwnerHotWalletsRequest]) (*connect.Response[v1.CreateOwnerHotWalletsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.CreateOwnerHotWallets is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) SignMessage(context.Context, *connect.Request[v1.SignMessageRequest]) (*connect.Response[v1.SignMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.SignMessage is not implemented"))
+}
+
+func (UnimplementedWalletServiceHandler) VerifyMessage(context.Context, *connect.Request[v1.VerifyMessageRequest]) (*connect.Response[v1.VerifyMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.wallet.v1.WalletService.VerifyMessage is not implemented"))
+}
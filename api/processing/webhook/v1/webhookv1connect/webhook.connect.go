@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: processing/webhook/v1/webhook.proto
+
+package webhookv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/dv-net/dv-processing/api/processing/webhook/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WebhookServiceName is the fully-qualified name of the WebhookService service.
+	WebhookServiceName = "processing.webhook.v1.WebhookService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WebhookServiceListProcedure is the fully-qualified name of the WebhookService's List RPC.
+	WebhookServiceListProcedure = "/processing.webhook.v1.WebhookService/List"
+	// WebhookServiceGetProcedure is the fully-qualified name of the WebhookService's Get RPC.
+	WebhookServiceGetProcedure = "/processing.webhook.v1.WebhookService/Get"
+	// WebhookServiceRedeliverProcedure is the fully-qualified name of the WebhookService's Redeliver
+	// RPC.
+	WebhookServiceRedeliverProcedure = "/processing.webhook.v1.WebhookService/Redeliver"
+	// WebhookServiceListEndpointsProcedure is the fully-qualified name of the WebhookService's
+	// ListEndpoints RPC.
+	WebhookServiceListEndpointsProcedure = "/processing.webhook.v1.WebhookService/ListEndpoints"
+	// WebhookServiceCreateEndpointProcedure is the fully-qualified name of the WebhookService's
+	// CreateEndpoint RPC.
+	WebhookServiceCreateEndpointProcedure = "/processing.webhook.v1.WebhookService/CreateEndpoint"
+	// WebhookServiceUpdateEndpointProcedure is the fully-qualified name of the WebhookService's
+	// UpdateEndpoint RPC.
+	WebhookServiceUpdateEndpointProcedure = "/processing.webhook.v1.WebhookService/UpdateEndpoint"
+	// WebhookServiceDeleteEndpointProcedure is the fully-qualified name of the WebhookService's
+	// DeleteEndpoint RPC.
+	WebhookServiceDeleteEndpointProcedure = "/processing.webhook.v1.WebhookService/DeleteEndpoint"
+)
+
+// WebhookServiceClient is a client for the processing.webhook.v1.WebhookService service.
+type WebhookServiceClient interface {
+	// Get the webhooks of the client, newest first
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error)
+	// Get the webhook with the payload and the last response
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	// Reset the webhook or the filtered webhooks to be sent again
+	Redeliver(context.Context, *connect.Request[v1.RedeliverRequest]) (*connect.Response[v1.RedeliverResponse], error)
+	// Get the endpoints the webhooks are delivered to, the default one first
+	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
+	// Create the endpoint with a new secret, the secret is returned once
+	CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error)
+	// Replace the settings of the endpoint
+	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
+	// Delete the endpoint with its deliveries, the default endpoint can not be
+	// deleted
+	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error)
+}
+
+// NewWebhookServiceClient constructs a client for the processing.webhook.v1.WebhookService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhookServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WebhookServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	webhookServiceMethods := v1.File_processing_webhook_v1_webhook_proto.Services().ByName("WebhookService").Methods()
+	return &webhookServiceClient{
+		list: connect.NewClient[v1.ListRequest, v1.ListResponse](
+			httpClient,
+			baseURL+WebhookServiceListProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[v1.GetRequest, v1.GetResponse](
+			httpClient,
+			baseURL+WebhookServiceGetProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		redeliver: connect.NewClient[v1.RedeliverRequest, v1.RedeliverResponse](
+			httpClient,
+			baseURL+WebhookServiceRedeliverProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("Redeliver")),
+			connect.WithClientOptions(opts...),
+		),
+		listEndpoints: connect.NewClient[v1.ListEndpointsRequest, v1.ListEndpointsResponse](
+			httpClient,
+			baseURL+WebhookServiceListEndpointsProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("ListEndpoints")),
+			connect.WithClientOptions(opts...),
+		),
+		createEndpoint: connect.NewClient[v1.CreateEndpointRequest, v1.CreateEndpointResponse](
+			httpClient,
+			baseURL+WebhookServiceCreateEndpointProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("CreateEndpoint")),
+			connect.WithClientOptions(opts...),
+		),
+		updateEndpoint: connect.NewClient[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse](
+			httpClient,
+			baseURL+WebhookServiceUpdateEndpointProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("UpdateEndpoint")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteEndpoint: connect.NewClient[v1.DeleteEndpointRequest, v1.DeleteEndpointResponse](
+			httpClient,
+			baseURL+WebhookServiceDeleteEndpointProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("DeleteEndpoint")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// webhookServiceClient implements WebhookServiceClient.
+type webhookServiceClient struct {
+	list           *connect.Client[v1.ListRequest, v1.ListResponse]
+	get            *connect.Client[v1.GetRequest, v1.GetResponse]
+	redeliver      *connect.Client[v1.RedeliverRequest, v1.RedeliverResponse]
+	listEndpoints  *connect.Client[v1.ListEndpointsRequest, v1.ListEndpointsResponse]
+	createEndpoint *connect.Client[v1.CreateEndpointRequest, v1.CreateEndpointResponse]
+	updateEndpoint *connect.Client[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse]
+	deleteEndpoint *connect.Client[v1.DeleteEndpointRequest, v1.DeleteEndpointResponse]
+}
+
+// List calls processing.webhook.v1.WebhookService.List.
+func (c *webhookServiceClient) List(ctx context.Context, req *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Get calls processing.webhook.v1.WebhookService.Get.
+func (c *webhookServiceClient) Get(ctx context.Context, req *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Redeliver calls processing.webhook.v1.WebhookService.Redeliver.
+func (c *webhookServiceClient) Redeliver(ctx context.Context, req *connect.Request[v1.RedeliverRequest]) (*connect.Response[v1.RedeliverResponse], error) {
+	return c.redeliver.CallUnary(ctx, req)
+}
+
+// ListEndpoints calls processing.webhook.v1.WebhookService.ListEndpoints.
+func (c *webhookServiceClient) ListEndpoints(ctx context.Context, req *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error) {
+	return c.listEndpoints.CallUnary(ctx, req)
+}
+
+// CreateEndpoint calls processing.webhook.v1.WebhookService.CreateEndpoint.
+func (c *webhookServiceClient) CreateEndpoint(ctx context.Context, req *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error) {
+	return c.createEndpoint.CallUnary(ctx, req)
+}
+
+// UpdateEndpoint calls processing.webhook.v1.WebhookService.UpdateEndpoint.
+func (c *webhookServiceClient) UpdateEndpoint(ctx context.Context, req *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error) {
+	return c.updateEndpoint.CallUnary(ctx, req)
+}
+
+// DeleteEndpoint calls processing.webhook.v1.WebhookService.DeleteEndpoint.
+func (c *webhookServiceClient) DeleteEndpoint(ctx context.Context, req *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error) {
+	return c.deleteEndpoint.CallUnary(ctx, req)
+}
+
+// WebhookServiceHandler is an implementation of the processing.webhook.v1.WebhookService service.
+type WebhookServiceHandler interface {
+	// Get the webhooks of the client, newest first
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error)
+	// Get the webhook with the payload and the last response
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	// Reset the webhook or the filtered webhooks to be sent again
+	Redeliver(context.Context, *connect.Request[v1.RedeliverRequest]) (*connect.Response[v1.RedeliverResponse], error)
+	// Get the endpoints the webhooks are delivered to, the default one first
+	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
+	// Create the endpoint with a new secret, the secret is returned once
+	CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error)
+	// Replace the settings of the endpoint
+	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
+	// Delete the endpoint with its deliveries, the default endpoint can not be
+	// deleted
+	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error)
+}
+
+// NewWebhookServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhookServiceHandler(svc WebhookServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	webhookServiceMethods := v1.File_processing_webhook_v1_webhook_proto.Services().ByName("WebhookService").Methods()
+	webhookServiceListHandler := connect.NewUnaryHandler(
+		WebhookServiceListProcedure,
+		svc.List,
+		connect.WithSchema(webhookServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceGetHandler := connect.NewUnaryHandler(
+		WebhookServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(webhookServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceRedeliverHandler := connect.NewUnaryHandler(
+		WebhookServiceRedeliverProcedure,
+		svc.Redeliver,
+		connect.WithSchema(webhookServiceMethods.ByName("Redeliver")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceListEndpointsHandler := connect.NewUnaryHandler(
+		WebhookServiceListEndpointsProcedure,
+		svc.ListEndpoints,
+		connect.WithSchema(webhookServiceMethods.ByName("ListEndpoints")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceCreateEndpointHandler := connect.NewUnaryHandler(
+		WebhookServiceCreateEndpointProcedure,
+		svc.CreateEndpoint,
+		connect.WithSchema(webhookServiceMethods.ByName("CreateEndpoint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceUpdateEndpointHandler := connect.NewUnaryHandler(
+		WebhookServiceUpdateEndpointProcedure,
+		svc.UpdateEndpoint,
+		connect.WithSchema(webhookServiceMethods.ByName("UpdateEndpoint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceDeleteEndpointHandler := connect.NewUnaryHandler(
+		WebhookServiceDeleteEndpointProcedure,
+		svc.DeleteEndpoint,
+		connect.WithSchema(webhookServiceMethods.ByName("DeleteEndpoint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/processing.webhook.v1.WebhookService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebhookServiceListProcedure:
+			webhookServiceListHandler.ServeHTTP(w, r)
+		case WebhookServiceGetProcedure:
+			webhookServiceGetHandler.ServeHTTP(w, r)
+		case WebhookServiceRedeliverProcedure:
+			webhookServiceRedeliverHandler.ServeHTTP(w, r)
+		case WebhookServiceListEndpointsProcedure:
+			webhookServiceListEndpointsHandler.ServeHTTP(w, r)
+		case WebhookServiceCreateEndpointProcedure:
+			webhookServiceCreateEndpointHandler.ServeHTTP(w, r)
+		case WebhookServiceUpdateEndpointProcedure:
+			webhookServiceUpdateEndpointHandler.ServeHTTP(w, r)
+		case WebhookServiceDeleteEndpointProcedure:
+			webhookServiceDeleteEndpointHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebhookServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhookServiceHandler struct{}
+
+func (UnimplementedWebhookServiceHandler) List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.List is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.Get is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) Redeliver(context.Context, *connect.Request[v1.RedeliverRequest]) (*connect.Response[v1.RedeliverResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.Redeliver is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.ListEndpoints is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.CreateEndpoint is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.UpdateEndpoint is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("processing.webhook.v1.WebhookService.DeleteEndpoint is not implemented"))
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/dv-processing/internal/services/system"
 	"github.com/dv-net/dv-processing/internal/store"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/postgres"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/tron"
@@ -164,19 +163,25 @@ func tronReclaimResourceCMD() *cli.Command {
 				return fmt.Errorf("invalid resource type %s", cl.String("type"))
 			}
 
-			mnemonic := owner.Mnemonic
+			mnemonic, passPhrase := owner.Mnemonic, owner.PassPhrase.String
 			if conf.IsEnabledSeedEncryption() {
-				mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
+				mnemonic, err = baseSvc.Secrets().Decrypt(ctx, owner.ID, mnemonic)
 				if err != nil {
 					return fmt.Errorf("decrypt mnemonic: %w", err)
 				}
+
+				passPhrase, err = baseSvc.Secrets().DecryptOptional(ctx, owner.ID, passPhrase)
+				if err != nil {
+					return fmt.Errorf("decrypt pass phrase: %w", err)
+				}
 			}
 
 			reclaimResourceParams := tron.ReclaimResourceParams{
 				FromAddress:  processingWallet.Address,
 				FromSequence: uint32(processingWallet.Sequence), //nolint:gosec
 				Mnemonic:     mnemonic,
-				PassPhrase:   owner.PassPhrase.String,
+				PassPhrase:   passPhrase,
+				Derivation:   owner.Derivation.Options(wconstants.BlockchainTypeTron),
 				ToAddress:    cl.String("destination-address"),
 				ResourceType: resourceType,
 			}
@@ -64,6 +64,7 @@ func main() {
 			migrateCMD(),
 			blockchainCMD(),
 			utilsCMD(),
+			signerCMD(),
 			versionCMD(),
 		},
 	}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/eproxy"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/services/system"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/pkg/postgres"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/mx/launcher"
+	"github.com/dv-net/mx/logger"
+	"github.com/dv-net/mx/service"
+	"github.com/urfave/cli/v3"
+)
+
+func signerCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "signer",
+		Usage: "transaction signer process",
+		Commands: []*cli.Command{
+			{
+				Name:  "start",
+				Usage: "start the signer server which holds the owner seeds and signs the transactions of the processing",
+				Flags: []cli.Flag{cfgPathsFlag()},
+				Action: func(ctx context.Context, cl *cli.Command) error {
+					conf, err := config.Load[config.Config](cl.StringSlice("configs"), envPrefix)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+
+					l := logger.NewExtended(append(defaultLoggerOpts(), logger.WithConfig(conf.Log))...)
+					defer func() { _ = l.Sync() }()
+
+					// init postgres connection
+					psql, err := postgres.New(ctx, conf.Postgres, l)
+					if err != nil {
+						return fmt.Errorf("failed to init postgres: %w", err)
+					}
+
+					// init store
+					st := store.New(psql)
+
+					secretsService, err := secrets.New(conf, st)
+					if err != nil {
+						return fmt.Errorf("init secrets service: %w", err)
+					}
+
+					// init wallet sdk
+					sdk := walletsdk.New(walletsdk.Config{
+						Bitcoin: walletsdk.BitcoinConfig{
+							Network: conf.Blockchain.Bitcoin.Network,
+						},
+						Litecoin: walletsdk.LitecoinConfig{
+							Network: conf.Blockchain.Litecoin.Network,
+						},
+						BitcoinCash: walletsdk.BitcoinCashConfig{
+							Network: conf.Blockchain.BitcoinCash.Network,
+						},
+					})
+
+					// the input amounts of the bitcoin like transactions are checked with the explorer proxy
+					systemSvc := system.New(l, st, version, commitHash)
+					pID, err := systemSvc.ProcessingID(ctx)
+					if err != nil {
+						return fmt.Errorf("processing ID: %w", err)
+					}
+
+					appCtx := context.WithValue(ctx, constants.ProcessingIDParamName, pID)
+					appCtx = context.WithValue(appCtx, constants.ProcessingVersionParamName, systemSvc.SystemVersion(ctx))
+
+					explorerProxySvc, err := eproxy.New(appCtx, conf.ExplorerProxy)
+					if err != nil {
+						return fmt.Errorf("failed to init eproxy service: %w", err)
+					}
+
+					backend, err := signer.NewBackend(conf, st, secretsService, sdk, explorerProxySvc)
+					if err != nil {
+						return fmt.Errorf("init signer: %w", err)
+					}
+
+					signerServer := signer.NewServer(l, conf.Signer.Server, backend, secretsService)
+
+					ln := launcher.New(
+						launcher.WithContext(ctx),
+						launcher.WithVersion(getVersion()),
+						launcher.WithName(appName+"-signer"),
+						launcher.WithLogger(l),
+						launcher.WithRunnerServicesSequence(launcher.RunnerServicesSequenceFifo),
+						launcher.WithAppStartStopLog(true),
+					)
+
+					ln.ServicesRunner().Register(
+						service.New(service.WithService(signerServer)),
+						service.New(service.WithService(psql)),
+					)
+
+					return ln.Run()
+				},
+			},
+			importKeysCMD(),
+		},
+	}
+}
+
+func importKeysCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "import-keys",
+		Usage: "import the keys of the evm and tron processing wallets of all owners to the HSM of the pkcs11 signer",
+		Flags: []cli.Flag{cfgPathsFlag()},
+		Action: func(ctx context.Context, cl *cli.Command) error {
+			conf, err := config.Load[config.Config](cl.StringSlice("configs"), envPrefix)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if conf.Signer.Mode != config.SignerModePKCS11 {
+				return fmt.Errorf("signer mode must be %s", config.SignerModePKCS11)
+			}
+
+			l := logger.NewExtended(append(defaultLoggerOpts(), logger.WithConfig(conf.Log))...)
+			defer func() { _ = l.Sync() }()
+
+			// init postgres connection
+			psql, err := postgres.New(ctx, conf.Postgres, l)
+			if err != nil {
+				return fmt.Errorf("failed to init postgres: %w", err)
+			}
+
+			// init store
+			st := store.New(psql)
+
+			secretsService, err := secrets.New(conf, st)
+			if err != nil {
+				return fmt.Errorf("init secrets service: %w", err)
+			}
+
+			pkcs11Signer, err := signer.NewPKCS11(conf, st, secretsService, walletsdk.New(walletsdk.Config{}), nil)
+			if err != nil {
+				return fmt.Errorf("init signer: %w", err)
+			}
+			defer func() { _ = pkcs11Signer.Close() }()
+
+			res, err := pkcs11Signer.ImportKeys(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to import keys: %w", err)
+			}
+
+			l.Infow("wallet keys imported", "imported", res.Imported, "skipped", res.Skipped)
+
+			return nil
+		},
+	}
+}
@@ -22,11 +22,13 @@ import (
 	"github.com/dv-net/dv-processing/api/processing/system/v1/systemv1connect"
 	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
 	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/api/processing/webhook/v1/webhookv1connect"
 	"github.com/dv-net/dv-processing/internal/dispatcher"
 	"github.com/dv-net/dv-processing/internal/eproxy"
 	"github.com/dv-net/dv-processing/internal/escanner"
 	"github.com/dv-net/dv-processing/internal/handler"
 	"github.com/dv-net/dv-processing/internal/interceptors"
+	"github.com/dv-net/dv-processing/internal/mtls"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/internal/taskmanager"
@@ -149,6 +151,18 @@ func startCMD() *cli.Command { //nolint:funlen
 				return fmt.Errorf("encrypt mnemonics for all owners: %w", err)
 			}
 
+			// re-encrypt owner secrets with data keys when the master key provider is configured
+			if baseSvc.Secrets().IsEnvelope() {
+				upgraded, err := baseSvc.Secrets().UpgradeAllOwners(appCtx)
+				if err != nil {
+					return fmt.Errorf("upgrade owner secrets to envelope encryption: %w", err)
+				}
+
+				if upgraded > 0 {
+					l.Infow("owner secrets upgraded to envelope encryption", "owners", upgraded)
+				}
+			}
+
 			// init task manager
 			tm, err := taskmanager.New(l, conf, st, baseSvc)
 			if err != nil {
@@ -172,23 +186,33 @@ func startCMD() *cli.Command { //nolint:funlen
 				}
 			}
 
+			serverHandlerWrapper := func(h http.Handler) http.Handler {
+				return h2c.NewHandler(
+					handler.WithCORS(h),
+					&http2.Server{})
+			}
+
+			rateLimitInterceptor := interceptors.NewRateLimitInterceptor(l, conf.Interceptors.RateLimit)
+
+			serveMux := http.NewServeMux()
 			connectrpcService := connectrpc_transport.NewServer(
 				connectrpc_transport.WithLogger(l),
 				connectrpc_transport.WithConfig(conf.Grpc),
+				connectrpc_transport.WithServeMux(serveMux),
 				connectrpc_transport.WithServices(hdlr.AllServers()...),
-				connectrpc_transport.WithServerHandlerWrapper(
-					func(h http.Handler) http.Handler {
-						return h2c.NewHandler(
-							handler.WithCORS(h),
-							&http2.Server{})
-					},
-				),
+				connectrpc_transport.WithServerHandlerWrapper(serverHandlerWrapper),
 				connectrpc_transport.WithConnectRPCOptions(
 					connect.WithInterceptors(
+						interceptors.NewAuditInterceptor(l, baseSvc.Audit()),
 						interceptors.NewSignInterceptor(
 							baseSvc.Clients(),
 							conf.Interceptors.DisableCheckingSign,
+							conf.Interceptors.SignV2.ClockSkew,
+							conf.MTLS.Enabled && conf.Interceptors.TrustCertificateIdentity,
 						),
+						interceptors.NewAllowlistInterceptor(baseSvc.Clients()),
+						rateLimitInterceptor,
+						interceptors.NewPermissionInterceptor(),
 					),
 					connect.WithHandlerOptions(
 						connectproto.WithJSON(
@@ -203,14 +227,26 @@ func startCMD() *cli.Command { //nolint:funlen
 					transferv1connect.TransferServiceName,
 					walletv1connect.WalletServiceName,
 					systemv1connect.SystemServiceName,
+					webhookv1connect.WebhookServiceName,
 				),
 			)
 
+			// serve the same handlers over tls with the client certificate authentication
+			var grpcService service.IService = connectrpcService
+			if conf.Grpc.Enabled && conf.MTLS.Enabled {
+				grpcService, err = mtls.NewServer(l, conf.MTLS, conf.Grpc.Addr, serverHandlerWrapper(serveMux))
+				if err != nil {
+					return fmt.Errorf("init mtls server: %w", err)
+				}
+			}
+
 			// register services
 			ln.ServicesRunner().Register(
 				service.New(service.WithService(pingpong.New(l))),
-				service.New(service.WithService(connectrpcService)),
+				service.New(service.WithService(grpcService)),
 				service.New(service.WithService(st.Cache())),
+				service.New(service.WithService(rateLimitInterceptor)),
+				service.New(service.WithService(baseSvc.Audit())),
 				service.New(service.WithService(baseSvc.Webhooks().WebhookServer())),
 				service.New(service.WithService(baseSvc.Wallets())),
 				service.New(service.WithService(tm)),
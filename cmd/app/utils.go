@@ -10,7 +10,9 @@ import (
 
 	processing "github.com/dv-net/dv-processing"
 	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/services/owners"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/pkg/postgres"
 	"github.com/dv-net/mx/logger"
@@ -26,6 +28,47 @@ func utilsCMD() *cli.Command {
 			genReadmeCMD(),
 			compressSeedsCMD(),
 			compressOTPDataCMD(),
+			verifyAuditCMD(),
+		},
+	}
+}
+
+func verifyAuditCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "verify-audit",
+		Usage: "verify the hash chain of the audit events",
+		Flags: []cli.Flag{
+			cfgPathsFlag(),
+		},
+		Action: func(ctx context.Context, cl *cli.Command) error {
+			conf, err := config.Load[config.Config](cl.StringSlice("configs"), envPrefix)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			loggerOpts := append(defaultLoggerOpts(), logger.WithConfig(conf.Log))
+
+			l := logger.NewExtended(loggerOpts...)
+			defer func() { _ = l.Sync() }()
+
+			// init postgres connection
+			psql, err := postgres.New(ctx, conf.Postgres, l)
+			if err != nil {
+				return fmt.Errorf("failed to init postgres: %w", err)
+			}
+
+			res, err := audit.New(store.New(psql)).Verify(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to verify audit events: %w", err)
+			}
+
+			if res.BrokenAtID != nil {
+				return fmt.Errorf("audit chain is broken at the event %d, %d events before it are valid", *res.BrokenAtID, res.Checked)
+			}
+
+			l.Infow("audit chain is valid", "events", res.Checked)
+
+			return nil
 		},
 	}
 }
@@ -83,6 +126,9 @@ func compressSeedsCMD() *cli.Command { //nolint:dupl
 	return &cli.Command{
 		Name:  "seeds",
 		Usage: "encrypt and decrypt seeds for all owners",
+		Commands: []*cli.Command{
+			rotateMasterKeyCMD(),
+		},
 		Flags: []cli.Flag{
 			cfgPathsFlag(),
 			&cli.BoolFlag{
@@ -93,14 +139,25 @@ func compressSeedsCMD() *cli.Command { //nolint:dupl
 				Name:  "decrypt",
 				Usage: "decrypt seeds",
 			},
+			&cli.BoolFlag{
+				Name:  "upgrade",
+				Usage: "re-encrypt owner secrets with per-owner data keys wrapped by the master key",
+			},
 		},
 		Action: func(ctx context.Context, cl *cli.Command) error {
-			if cl.Bool("encrypt") && cl.Bool("decrypt") {
-				return fmt.Errorf("only one of encrypt or decrypt can be set")
+			var set int
+			for _, name := range []string{"encrypt", "decrypt", "upgrade"} {
+				if cl.Bool(name) {
+					set++
+				}
 			}
 
-			if !cl.Bool("encrypt") && !cl.Bool("decrypt") {
-				return fmt.Errorf("one of encrypt or decrypt must be set")
+			if set > 1 {
+				return fmt.Errorf("only one of encrypt, decrypt or upgrade can be set")
+			}
+
+			if set == 0 {
+				return fmt.Errorf("one of encrypt, decrypt or upgrade must be set")
 			}
 
 			conf, err := config.Load[config.Config](cl.StringSlice("configs"), envPrefix)
@@ -122,7 +179,12 @@ func compressSeedsCMD() *cli.Command { //nolint:dupl
 			// init store
 			st := store.New(psql)
 
-			ownersService := owners.New(conf, st, nil)
+			secretsService, err := secrets.New(conf, st)
+			if err != nil {
+				return fmt.Errorf("failed to init secrets service: %w", err)
+			}
+
+			ownersService := owners.New(conf, st, secretsService, nil)
 
 			if cl.Bool("encrypt") {
 				if err := ownersService.EncryptSeedsForAllOwners(ctx); err != nil {
@@ -136,6 +198,97 @@ func compressSeedsCMD() *cli.Command { //nolint:dupl
 				}
 			}
 
+			if cl.Bool("upgrade") {
+				upgraded, err := secretsService.UpgradeAllOwners(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to upgrade owner secrets: %w", err)
+				}
+
+				l.Infow("owner secrets upgraded", "owners", upgraded)
+			}
+
+			return nil
+		},
+	}
+}
+
+func rotateMasterKeyCMD() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate-key",
+		Usage: "re-wrap data keys of all owners with the current master key, the previous one must be set in the config",
+		Flags: []cli.Flag{
+			cfgPathsFlag(),
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "process and verify all owners without saving the changes",
+			},
+			&cli.IntFlag{
+				Name:  "batch-size",
+				Usage: "number of owners processed in one transaction",
+				Value: 100,
+			},
+		},
+		Action: func(ctx context.Context, cl *cli.Command) error {
+			conf, err := config.Load[config.Config](cl.StringSlice("configs"), envPrefix)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			loggerOpts := append(defaultLoggerOpts(), logger.WithConfig(conf.Log))
+
+			l := logger.NewExtended(loggerOpts...)
+			defer func() { _ = l.Sync() }()
+
+			// init postgres connection
+			psql, err := postgres.New(ctx, conf.Postgres, l)
+			if err != nil {
+				return fmt.Errorf("failed to init postgres: %w", err)
+			}
+
+			// init store
+			st := store.New(psql)
+
+			secretsService, err := secrets.New(conf, st)
+			if err != nil {
+				return fmt.Errorf("failed to init secrets service: %w", err)
+			}
+
+			res, err := secretsService.RotateMasterKey(ctx, secrets.RotateKeyParams{
+				BatchSize: int32(cl.Int("batch-size")), //nolint:gosec
+				DryRun:    cl.Bool("dry-run"),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to rotate master key: %w", err)
+			}
+
+			l.Infow("master key rotation processed",
+				"owners", res.Owners,
+				"batches", res.Batches,
+				"rewrapped", res.Rewrapped,
+				"upgraded", res.Upgraded,
+				"dry_run", cl.Bool("dry-run"),
+			)
+
+			verifyErrors := res.Errors
+			if !cl.Bool("dry-run") {
+				verifyRes, err := secretsService.VerifyAllOwners(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to verify owners: %w", err)
+				}
+
+				verifyErrors = verifyRes.Errors
+			}
+
+			for _, err := range verifyErrors {
+				l.Errorw("owner verification failed", "error", err)
+			}
+
+			if len(verifyErrors) > 0 {
+				return fmt.Errorf("verification failed for %d owners", len(verifyErrors))
+			}
+
+			l.Infow("all owners verified", "owners", res.Owners)
+
 			return nil
 		},
 	}
@@ -184,7 +337,12 @@ func compressOTPDataCMD() *cli.Command { //nolint:dupl
 			// init store
 			st := store.New(psql)
 
-			ownersService := owners.New(conf, st, nil)
+			secretsService, err := secrets.New(conf, st)
+			if err != nil {
+				return fmt.Errorf("failed to init secrets service: %w", err)
+			}
+
+			ownersService := owners.New(conf, st, secretsService, nil)
 
 			if cl.Bool("encrypt") {
 				if err := ownersService.EncryptOTPDataForAllOwners(ctx); err != nil {
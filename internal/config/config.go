@@ -17,14 +17,18 @@ type Config struct {
 	Ops             ops.Config
 	Postgres        postgres.Config
 	Grpc            connectrpc_transport.Config
+	MTLS            MTLS                     `yaml:"mtls"`
 	ExplorerProxy   connectrpc_client.Config `yaml:"explorer_proxy"`
 	Blockchain      Blockchain
 	ResourceManager ResourceManager `yaml:"resource_manager"`
 	Watcher         Watcher         `yaml:"watcher"`
 	Webhooks        struct {
 		Sender struct {
-			Enabled  bool  `json:"enabled" yaml:"enabled" usage:"allows to enable and disable webhook sender" default:"true" example:"true / false"`
-			Quantity int32 `json:"quantity" yaml:"quantity" usage:"allows you to specify the number of webhooks to send at once" default:"500" example:"500" validate:"gte=1"`
+			Enabled     bool          `json:"enabled" yaml:"enabled" usage:"allows to enable and disable webhook sender" default:"true" example:"true / false"`
+			Quantity    int32         `json:"quantity" yaml:"quantity" usage:"allows you to specify the number of webhooks to send at once" default:"500" example:"500" validate:"gte=1"`
+			MaxAttempts int32         `json:"max_attempts" yaml:"max_attempts" usage:"allows to set the number of attempts after which the webhook is marked as failed" default:"30" example:"30" validate:"gte=1"`
+			MinBackoff  time.Duration `json:"min_backoff" yaml:"min_backoff" usage:"allows to set the delay before the first retry, it doubles with every next attempt" default:"10s" example:"10s" validate:"gte=1s"`
+			MaxBackoff  time.Duration `json:"max_backoff" yaml:"max_backoff" usage:"allows to set the max delay between the retries" default:"6h" example:"6h" validate:"gtefield=MinBackoff"`
 		}
 		Cleanup struct {
 			Enabled bool          `json:"enabled" yaml:"enabled" usage:"allows to enable and disable webhook cleanup worker" default:"true" example:"true / false"`
@@ -35,17 +39,43 @@ type Config struct {
 	}
 	Interceptors struct {
 		DisableCheckingSign bool `yaml:"disable_checking_sign" json:"disable_checking_sign" usage:"allows to disable checking sign of request" default:"false" example:"true / false"`
+		SignV2              struct {
+			ClockSkew        time.Duration `yaml:"clock_skew" json:"clock_skew" usage:"allows to set the max difference between the request timestamp and the processing time" default:"5m" example:"5m" validate:"gte=1s"`
+			NonceCleanupCron string        `yaml:"nonce_cleanup_cron" json:"nonce_cleanup_cron" usage:"allows to set custom cron rule for deleting expired request nonces" default:"*/10 * * * *" example:"*/10 * * * *"`
+		} `yaml:"sign_v2"`
+		TrustCertificateIdentity bool      `yaml:"trust_certificate_identity" json:"trust_certificate_identity" usage:"allows to accept the requests of the clients authenticated by the mtls certificate without the sign" default:"false" example:"true / false"`
+		RateLimit                RateLimit `yaml:"rate_limit"`
 	}
 	Transfers struct {
 		Enabled bool `yaml:"enabled" json:"enabled" usage:"allows to enable transfers service" default:"true" example:"true / false"`
 	}
-	UseCacheForWallets bool          `yaml:"use_cache_for_wallets" json:"use_cache_for_wallets" usage:"allows to use cache for wallets. this option is experimental" default:"true" example:"true / false"`
-	MerchantAdmin      MerchantAdmin `yaml:"merchant_admin"`
-	Updater            Updater       `yaml:"updater"`
+	HotWalletsPool     HotWalletsPool    `yaml:"hot_wallets_pool"`
+	Sweeps             Sweeps            `yaml:"sweeps"`
+	MnemonicRotations  MnemonicRotations `yaml:"mnemonic_rotations"`
+	SeedEncryption     SeedEncryption    `yaml:"seed_encryption"`
+	Signer             Signer            `yaml:"signer"`
+	UseCacheForWallets bool              `yaml:"use_cache_for_wallets" json:"use_cache_for_wallets" usage:"allows to use cache for wallets. this option is experimental" default:"true" example:"true / false"`
+	MerchantAdmin      MerchantAdmin     `yaml:"merchant_admin"`
+	Updater            Updater           `yaml:"updater"`
 }
 
 func (c Config) IsEnabledSeedEncryption() bool { return true }
 
+type HotWalletsPool struct {
+	Enabled  bool          `yaml:"enabled" json:"enabled" usage:"allows to pre-generate hot wallet addresses for owners in background" default:"false" example:"true / false"`
+	Size     int32         `yaml:"size" json:"size" usage:"number of unassigned addresses kept per owner and blockchain" default:"20" example:"20" validate:"gte=1"`
+	Interval time.Duration `yaml:"interval" json:"interval" usage:"interval between pool refills" default:"1m" example:"1m"`
+}
+
+type Sweeps struct {
+	Enabled bool   `yaml:"enabled" json:"enabled" usage:"allows to automatically sweep hot wallets to cold wallets by owner rules" default:"false" example:"true / false"`
+	Cron    string `yaml:"cron" json:"cron" usage:"allows to set custom cron rule for evaluating sweep rules" default:"*/15 * * * *" example:"*/15 * * * *"`
+}
+
+type MnemonicRotations struct {
+	Cron string `yaml:"cron" json:"cron" usage:"allows to set custom cron rule for moving funds of the owner mnemonic rotations" default:"*/5 * * * *" example:"*/5 * * * *"`
+}
+
 type Watcher struct {
 	ClientSecret          string                   `yaml:"client_secret"`
 	GrpcReconnectionDelay time.Duration            `yaml:"grpc_reconnection_delay" default:"1s" example:"1s"`
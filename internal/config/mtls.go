@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/google/uuid"
+)
+
+type MTLS struct {
+	Enabled        bool           `yaml:"enabled" json:"enabled" usage:"allows to serve the grpc api over tls with the client certificate authentication" default:"false" example:"true / false"`
+	CertFile       string         `yaml:"cert_file" json:"cert_file" usage:"path to the server certificate" example:"/etc/dv-processing/server.pem"`
+	KeyFile        string         `yaml:"key_file" json:"key_file" usage:"path to the server certificate key" example:"/etc/dv-processing/server-key.pem"`
+	ClientCAFile   string         `yaml:"client_ca_file" json:"client_ca_file" usage:"path to the CA bundle of the client certificates" example:"/etc/dv-processing/clients-ca.pem"`
+	ReloadInterval time.Duration  `yaml:"reload_interval" json:"reload_interval" usage:"allows to set the interval of checking the certificate files for changes" default:"1m" example:"1m" validate:"gte=1s"`
+	Identities     []MTLSIdentity `yaml:"identities" json:"identities" usage:"allows to map the client certificates to the clients"`
+}
+
+// MTLSIdentity maps the client certificate to the client by the subject or by the public key.
+type MTLSIdentity struct {
+	ClientID    string   `yaml:"client_id" json:"client_id" usage:"id of the client"`
+	Subject     string   `yaml:"subject" json:"subject" usage:"distinguished name of the certificate subject" example:"CN=storefront,O=Merchant"`
+	SPKI        string   `yaml:"spki" json:"spki" usage:"base64 sha256 hash of the certificate public key info" example:"d6qzRu9zOECb90Uez27xWltNsj0e1Md7GkYYkVoZWmM="`
+	Permissions []string `yaml:"permissions" json:"permissions" usage:"allows to limit the permissions of the requests trusted by the certificate, all permissions by default" example:"wallets:read"`
+}
+
+func (o *MTLS) Validate() error {
+	if !o.Enabled {
+		return nil
+	}
+
+	if o.CertFile == "" || o.KeyFile == "" || o.ClientCAFile == "" {
+		return errors.New("mtls certificate, key and client ca files are required")
+	}
+
+	for _, identity := range o.Identities {
+		if _, err := uuid.Parse(identity.ClientID); err != nil {
+			return fmt.Errorf("mtls identity client id %q: %w", identity.ClientID, err)
+		}
+
+		if identity.Subject == "" && identity.SPKI == "" {
+			return fmt.Errorf("mtls identity of the client %s requires the subject or the spki", identity.ClientID)
+		}
+
+		for _, permission := range identity.Permissions {
+			if !constants.Permission(permission).Valid() {
+				return fmt.Errorf("mtls identity of the client %s has invalid permission %s", identity.ClientID, permission)
+			}
+		}
+	}
+
+	return nil
+}
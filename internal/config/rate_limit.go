@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RateLimit struct {
+	Enabled    bool                 `yaml:"enabled" json:"enabled" usage:"allows to limit the rate of the requests per client and procedure" default:"true" example:"true / false"`
+	Rate       float64              `yaml:"rate" json:"rate" usage:"allows to set the requests per second of the client to the procedure" default:"20" example:"20" validate:"gt=0"`
+	Burst      int                  `yaml:"burst" json:"burst" usage:"allows to set the requests of the client to the procedure allowed at once" default:"40" example:"40" validate:"gte=1"`
+	Procedures []RateLimitProcedure `yaml:"procedures" json:"procedures" usage:"allows to override the limits of the procedures, the two factor auth procedures are limited to 0.1 requests per second with the burst of 5 by default"`
+}
+
+// RateLimitProcedure overrides the rate limit of the procedure.
+type RateLimitProcedure struct {
+	Procedure string  `yaml:"procedure" json:"procedure" usage:"full name of the procedure" example:"/processing.wallet.v1.WalletService/CreateOwnerHotWallet"`
+	Rate      float64 `yaml:"rate" json:"rate" usage:"requests per second of the client to the procedure" example:"5"`
+	Burst     int     `yaml:"burst" json:"burst" usage:"requests of the client to the procedure allowed at once" example:"10"`
+}
+
+func (o *RateLimit) Validate() error {
+	for _, item := range o.Procedures {
+		if !strings.HasPrefix(item.Procedure, "/") {
+			return fmt.Errorf("rate limit procedure %q must start with /", item.Procedure)
+		}
+
+		if item.Rate <= 0 || item.Burst < 1 {
+			return fmt.Errorf("rate limit of the procedure %s requires positive rate and burst", item.Procedure)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	KeyProviderNone   = "none"
+	KeyProviderFile   = "file"
+	KeyProviderEnv    = "env"
+	KeyProviderVault  = "vault"
+	KeyProviderPKCS11 = "pkcs11"
+)
+
+type SeedEncryption struct {
+	KeyProvider     string       `yaml:"key_provider" json:"key_provider" usage:"allows to wrap owner data keys with a master key. with none the secrets are encrypted with the owner id" default:"none" example:"none / file / env / vault / pkcs11" validate:"oneof=none file env vault pkcs11"`
+	KeyFile         string       `yaml:"key_file" json:"key_file" usage:"path to the file with the hex or base64 encoded 256-bit master key" example:"/etc/dv-processing/master.key"`
+	KeyEnv          string       `yaml:"key_env" json:"key_env" usage:"name of the environment variable with the hex or base64 encoded 256-bit master key" default:"PROCESSING_MASTER_KEY" example:"PROCESSING_MASTER_KEY"`
+	PreviousKeyFile string       `yaml:"previous_key_file" json:"previous_key_file" usage:"path to the file with the previous master key during the master key rotation" example:"/etc/dv-processing/master.key.old"`
+	PreviousKeyEnv  string       `yaml:"previous_key_env" json:"previous_key_env" usage:"name of the environment variable with the previous master key during the master key rotation" example:"PROCESSING_PREVIOUS_MASTER_KEY"`
+	Vault           VaultTransit `yaml:"vault"`
+	PKCS11          SeedPKCS11   `yaml:"pkcs11"`
+}
+
+type VaultTransit struct {
+	Address string        `yaml:"address" json:"address" usage:"allows to set the vault compatible transit api endpoint" example:"https://vault.example.com:8200"`
+	Token   string        `yaml:"token" json:"token" usage:"allows to set the vault token" secret:"true"`
+	Mount   string        `yaml:"mount" json:"mount" usage:"allows to set the mount path of the transit secrets engine" default:"transit" example:"transit"`
+	KeyName string        `yaml:"key_name" json:"key_name" usage:"allows to set the name of the transit key" example:"dv-processing"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout" usage:"allows to set the vault request timeout" default:"10s" example:"10s"`
+}
+
+type SeedPKCS11 struct {
+	ModulePath string `yaml:"module_path" json:"module_path" usage:"path to the PKCS#11 library of the HSM" example:"/usr/lib/softhsm/libsofthsm2.so"`
+	TokenLabel string `yaml:"token_label" json:"token_label" usage:"allows to set the label of the HSM token" example:"dv-processing"`
+	Pin        string `yaml:"pin" json:"pin" usage:"allows to set the user pin of the HSM token" secret:"true"`
+	KeyLabel   string `yaml:"key_label" json:"key_label" usage:"allows to set the label of the AES key which wraps the owner data keys" example:"dv-processing-master"`
+}
+
+func (o *SeedEncryption) Validate() error {
+	switch o.KeyProvider {
+	case KeyProviderFile:
+		if o.KeyFile == "" {
+			return errors.New("seed encryption key file is required")
+		}
+	case KeyProviderEnv:
+		if o.KeyEnv == "" {
+			return errors.New("seed encryption key environment variable is required")
+		}
+	case KeyProviderVault:
+		if o.Vault.Address == "" || o.Vault.KeyName == "" {
+			return errors.New("seed encryption vault address and key name are required")
+		}
+	case KeyProviderPKCS11:
+		if o.PKCS11.ModulePath == "" || o.PKCS11.TokenLabel == "" || o.PKCS11.KeyLabel == "" {
+			return errors.New("seed encryption pkcs11 module path, token label and key label are required")
+		}
+	}
+
+	return nil
+}
+
+// IsEnvelope returns true if the owner data keys are wrapped by a master key.
+func (o SeedEncryption) IsEnvelope() bool {
+	return o.KeyProvider != "" && o.KeyProvider != KeyProviderNone
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	SignerModeLocal  = "local"
+	SignerModeRemote = "remote"
+	SignerModePKCS11 = "pkcs11"
+
+	unixSocketPrefix = "unix://"
+)
+
+type Signer struct {
+	Mode   string       `yaml:"mode" json:"mode" usage:"allows to sign transactions in the processing process, in the separate signer process which holds the seeds or with the wallet keys stored in the HSM" default:"local" example:"local / remote / pkcs11" validate:"oneof=local remote pkcs11"`
+	Remote SignerRemote `yaml:"remote"`
+	PKCS11 SignerPKCS11 `yaml:"pkcs11"`
+	Server SignerServer `yaml:"server"`
+	Policy SignerPolicy `yaml:"policy"`
+}
+
+type SignerRemote struct {
+	Address    string        `yaml:"address" json:"address" usage:"allows to set the signer address, a unix socket or a https address with mutual tls" example:"unix:///run/dv-processing/signer.sock / https://signer:9100"`
+	CAFile     string        `yaml:"ca_file" json:"ca_file" usage:"path to the CA certificate of the signer server" example:"/etc/dv-processing/signer-ca.pem"`
+	CertFile   string        `yaml:"cert_file" json:"cert_file" usage:"path to the client certificate" example:"/etc/dv-processing/processing.pem"`
+	KeyFile    string        `yaml:"key_file" json:"key_file" usage:"path to the client certificate key" example:"/etc/dv-processing/processing-key.pem"`
+	ServerName string        `yaml:"server_name" json:"server_name" usage:"allows to override the server name of the signer certificate" example:"signer"`
+	Timeout    time.Duration `yaml:"timeout" json:"timeout" usage:"allows to set the signing request timeout" default:"30s" example:"30s"`
+}
+
+type SignerServer struct {
+	Listen       string `yaml:"listen" json:"listen" usage:"allows to set the signer server address, a unix socket or a tcp address with mutual tls" default:"unix:///run/dv-processing/signer.sock" example:"unix:///run/dv-processing/signer.sock / :9100"`
+	CertFile     string `yaml:"cert_file" json:"cert_file" usage:"path to the server certificate" example:"/etc/dv-processing/signer.pem"`
+	KeyFile      string `yaml:"key_file" json:"key_file" usage:"path to the server certificate key" example:"/etc/dv-processing/signer-key.pem"`
+	ClientCAFile string `yaml:"client_ca_file" json:"client_ca_file" usage:"path to the CA certificate of the processing clients" example:"/etc/dv-processing/processing-ca.pem"`
+}
+
+type SignerPKCS11 struct {
+	ModulePath string `yaml:"module_path" json:"module_path" usage:"path to the PKCS#11 library of the HSM" example:"/usr/lib/softhsm/libsofthsm2.so"`
+	TokenLabel string `yaml:"token_label" json:"token_label" usage:"allows to set the label of the HSM token with the wallet keys" example:"dv-processing"`
+	Pin        string `yaml:"pin" json:"pin" usage:"allows to set the user pin of the HSM token" secret:"true"`
+}
+
+type SignerPolicy struct {
+	MaxEVMGasFeeCapGwei   uint64            `yaml:"max_evm_gas_fee_cap_gwei" json:"max_evm_gas_fee_cap_gwei" usage:"allows to reject evm transactions with the higher max fee per gas. 0 disables the check" default:"0" example:"500"`
+	MaxUTXOFee            int64             `yaml:"max_utxo_fee" json:"max_utxo_fee" usage:"allows to reject bitcoin like transactions with the higher fee in satoshis. 0 disables the check" default:"0" example:"1000000"`
+	MaxUTXOAmount         int64             `yaml:"max_utxo_amount" json:"max_utxo_amount" usage:"allows to reject bitcoin like transactions which send more satoshis to the addresses other than the input ones. 0 disables the check" default:"0" example:"100000000"`
+	MaxEVMValueGwei       uint64            `yaml:"max_evm_value_gwei" json:"max_evm_value_gwei" usage:"allows to reject evm transactions with the higher native amount in gwei. 0 disables the check" default:"0" example:"10000000000"`
+	MaxTronAmount         int64             `yaml:"max_tron_amount" json:"max_tron_amount" usage:"allows to reject tron transactions with the higher trx amount in sun. 0 disables the check" default:"0" example:"100000000000"`
+	MaxTokenAmounts       map[string]string `yaml:"max_token_amounts" json:"max_token_amounts" usage:"allows to reject token transfers with the higher amount in the smallest token units by the token contract address"`
+	OwnerDestinationsOnly bool              `yaml:"owner_destinations_only" json:"owner_destinations_only" usage:"allows to sign only the transactions which send funds to the wallets of the same owner or to the allowed destinations. The owner wallets are read from the database of the processing, only the allowed destinations do not trust it" default:"false"`
+	AllowedDestinations   []string          `yaml:"allowed_destinations" json:"allowed_destinations" usage:"allows to set the addresses which may receive funds from any owner wallet when only the owner destinations are allowed"`
+}
+
+func (o *SignerPolicy) Validate() error {
+	for contract, amount := range o.MaxTokenAmounts {
+		value, ok := new(big.Int).SetString(amount, 10)
+		if !ok || value.Sign() < 0 {
+			return fmt.Errorf("signer max token amount of %s must be a non-negative integer", contract)
+		}
+	}
+
+	return nil
+}
+
+func (o *Signer) Validate() error {
+	if err := o.Policy.Validate(); err != nil {
+		return err
+	}
+
+	if o.Mode == SignerModePKCS11 {
+		if o.PKCS11.ModulePath == "" || o.PKCS11.TokenLabel == "" {
+			return errors.New("signer pkcs11 module path and token label are required in the pkcs11 mode")
+		}
+
+		return nil
+	}
+
+	if o.Mode != SignerModeRemote {
+		return nil
+	}
+
+	if o.Remote.Address == "" {
+		return errors.New("signer address is required in the remote mode")
+	}
+
+	if IsUnixSocket(o.Remote.Address) {
+		return nil
+	}
+
+	if !strings.HasPrefix(o.Remote.Address, "https://") {
+		return errors.New("signer address must be a unix socket or a https address")
+	}
+
+	if o.Remote.CAFile == "" || o.Remote.CertFile == "" || o.Remote.KeyFile == "" {
+		return errors.New("signer ca, certificate and key files are required for the mutual tls")
+	}
+
+	return nil
+}
+
+// IsUnixSocket returns true if the address is a unix socket, e.g. unix:///run/signer.sock.
+func IsUnixSocket(address string) bool {
+	return strings.HasPrefix(address, unixSocketPrefix)
+}
+
+// UnixSocketPath returns the path of the unix socket address.
+func UnixSocketPath(address string) string {
+	return strings.TrimPrefix(address, unixSocketPrefix)
+}
@@ -2,6 +2,7 @@ package constants
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -29,3 +30,37 @@ func GetClientIDFromContext(ctx context.Context) string {
 
 	return clientID
 }
+
+type ClientSecretScope string
+
+const (
+	// ClientSecretScopeAPI is the scope of the secrets which sign the client requests
+	ClientSecretScopeAPI ClientSecretScope = "api"
+	// ClientSecretScopeWebhook is the scope of the secrets which sign the webhooks sent to the client
+	ClientSecretScopeWebhook ClientSecretScope = "webhook"
+)
+
+// String returns the client secret scope as a string
+func (s ClientSecretScope) String() string { return string(s) }
+
+// Valid checks if the client secret scope is valid
+func (s ClientSecretScope) Valid() bool {
+	switch s {
+	case ClientSecretScopeAPI, ClientSecretScopeWebhook:
+		return true
+	}
+	return false
+}
+
+// Scan implements the sql.Scanner interface
+func (s *ClientSecretScope) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*s = ClientSecretScope(v)
+	case string:
+		*s = ClientSecretScope(v)
+	default:
+		return fmt.Errorf("unsupported scan type for ClientSecretScope: %T", src)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package constants
+
+import "fmt"
+
+type MnemonicRotationStatus string
+
+const (
+	// MnemonicRotationStatusMigrating means funds are being moved to the wallets of the new mnemonic
+	MnemonicRotationStatusMigrating MnemonicRotationStatus = "migrating"
+	// MnemonicRotationStatusCompleted means the owner uses the new mnemonic and the old wallets are archived
+	MnemonicRotationStatusCompleted MnemonicRotationStatus = "completed"
+)
+
+// String returns the mnemonic rotation status as a string
+func (s MnemonicRotationStatus) String() string { return string(s) }
+
+// Valid checks if the mnemonic rotation status is valid
+func (s MnemonicRotationStatus) Valid() bool {
+	switch s {
+	case MnemonicRotationStatusMigrating, MnemonicRotationStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// Scan implements the sql.Scanner interface
+func (s *MnemonicRotationStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*s = MnemonicRotationStatus(v)
+	case string:
+		*s = MnemonicRotationStatus(v)
+	default:
+		return fmt.Errorf("unsupported scan type for MnemonicRotationStatus: %T", src)
+	}
+	return nil
+}
+
+type MnemonicRotationWalletStatus string
+
+const (
+	// MnemonicRotationWalletStatusPending means the old wallet balances are not checked yet
+	MnemonicRotationWalletStatusPending MnemonicRotationWalletStatus = "pending"
+	// MnemonicRotationWalletStatusMigrating means transfers to the new wallet are created
+	MnemonicRotationWalletStatusMigrating MnemonicRotationWalletStatus = "migrating"
+	// MnemonicRotationWalletStatusEmpty means the old wallet has no funds left
+	MnemonicRotationWalletStatusEmpty MnemonicRotationWalletStatus = "empty"
+	// MnemonicRotationWalletStatusInsufficientFee means the old wallet balance can not pay the transfer fee
+	MnemonicRotationWalletStatusInsufficientFee MnemonicRotationWalletStatus = "insufficient_fee"
+	// MnemonicRotationWalletStatusSkipped means the blockchain is disabled and the old wallet balances are not checked
+	MnemonicRotationWalletStatusSkipped MnemonicRotationWalletStatus = "skipped"
+	// MnemonicRotationWalletStatusArchived means the old wallet is archived and the new one is active
+	MnemonicRotationWalletStatusArchived MnemonicRotationWalletStatus = "archived"
+)
+
+// String returns the mnemonic rotation wallet status as a string
+func (s MnemonicRotationWalletStatus) String() string { return string(s) }
+
+// Valid checks if the mnemonic rotation wallet status is valid
+func (s MnemonicRotationWalletStatus) Valid() bool {
+	switch s {
+	case MnemonicRotationWalletStatusPending,
+		MnemonicRotationWalletStatusMigrating,
+		MnemonicRotationWalletStatusEmpty,
+		MnemonicRotationWalletStatusInsufficientFee,
+		MnemonicRotationWalletStatusSkipped,
+		MnemonicRotationWalletStatusArchived:
+		return true
+	}
+	return false
+}
+
+// Scan implements the sql.Scanner interface
+func (s *MnemonicRotationWalletStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*s = MnemonicRotationWalletStatus(v)
+	case string:
+		*s = MnemonicRotationWalletStatus(v)
+	default:
+		return fmt.Errorf("unsupported scan type for MnemonicRotationWalletStatus: %T", src)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package constants
+
+// Permission allows an API key to call a group of procedures.
+type Permission string
+
+const (
+	// PermissionAll allows to call all procedures
+	PermissionAll Permission = "*"
+
+	PermissionWalletsRead     Permission = "wallets:read"
+	PermissionWalletsCreate   Permission = "wallets:create"
+	PermissionWalletsManage   Permission = "wallets:manage"
+	PermissionTransfersRead   Permission = "transfers:read"
+	PermissionTransfersCreate Permission = "transfers:create"
+	PermissionOwnersCreate    Permission = "owners:create"
+	PermissionOwnersManage    Permission = "owners:manage"
+	PermissionKeysExport      Permission = "keys:export"
+	PermissionWebhooksRead    Permission = "webhooks:read"
+	PermissionWebhooksManage  Permission = "webhooks:manage"
+	PermissionSystemAdmin     Permission = "system:admin"
+)
+
+// String returns the permission as a string
+func (p Permission) String() string { return string(p) }
+
+// Valid checks if the permission is valid
+func (p Permission) Valid() bool {
+	switch p {
+	case PermissionAll,
+		PermissionWalletsRead,
+		PermissionWalletsCreate,
+		PermissionWalletsManage,
+		PermissionTransfersRead,
+		PermissionTransfersCreate,
+		PermissionOwnersCreate,
+		PermissionOwnersManage,
+		PermissionKeysExport,
+		PermissionWebhooksRead,
+		PermissionWebhooksManage,
+		PermissionSystemAdmin:
+		return true
+	}
+	return false
+}
@@ -79,3 +79,6 @@ func AllTransferStatuses() []TransferStatus {
 		TransferStatusFrozen,
 	}
 }
+
+// ProcessingWalletAddressStateKey is the transfer state data key of the processing wallet chosen to pay the transfer fees
+const ProcessingWalletAddressStateKey = "processing_wallet_address"
@@ -34,3 +34,75 @@ func (w *WalletType) Scan(src interface{}) error {
 	}
 	return nil
 }
+
+type ColdWalletsStrategy string
+
+const (
+	// ColdWalletsStrategyRoundRobin uses cold wallets one by one
+	ColdWalletsStrategyRoundRobin ColdWalletsStrategy = "round_robin"
+	// ColdWalletsStrategyWeighted distributes transfers between cold wallets in proportion to their weights
+	ColdWalletsStrategyWeighted ColdWalletsStrategy = "weighted"
+	// ColdWalletsStrategyCap fills cold wallets in order until their cap is reached
+	ColdWalletsStrategyCap ColdWalletsStrategy = "cap"
+)
+
+// String returns the cold wallets strategy as a string
+func (s ColdWalletsStrategy) String() string { return string(s) }
+
+// Valid checks if the cold wallets strategy is valid
+func (s ColdWalletsStrategy) Valid() bool {
+	switch s {
+	case ColdWalletsStrategyRoundRobin, ColdWalletsStrategyWeighted, ColdWalletsStrategyCap:
+		return true
+	}
+	return false
+}
+
+// Scan implements the sql.Scanner interface
+func (s *ColdWalletsStrategy) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*s = ColdWalletsStrategy(v)
+	case string:
+		*s = ColdWalletsStrategy(v)
+	default:
+		return fmt.Errorf("unsupported scan type for ColdWalletsStrategy: %T", src)
+	}
+	return nil
+}
+
+type ProcessingWalletsStrategy string
+
+const (
+	// ProcessingWalletsStrategyLeastBusy uses the processing wallet with the lowest number of active transfers
+	ProcessingWalletsStrategyLeastBusy ProcessingWalletsStrategy = "least_busy"
+	// ProcessingWalletsStrategyRoundRobin uses processing wallets one by one
+	ProcessingWalletsStrategyRoundRobin ProcessingWalletsStrategy = "round_robin"
+	// ProcessingWalletsStrategyHighestBalance uses the processing wallet with the highest native asset balance
+	ProcessingWalletsStrategyHighestBalance ProcessingWalletsStrategy = "highest_balance"
+)
+
+// String returns the processing wallets strategy as a string
+func (s ProcessingWalletsStrategy) String() string { return string(s) }
+
+// Valid checks if the processing wallets strategy is valid
+func (s ProcessingWalletsStrategy) Valid() bool {
+	switch s {
+	case ProcessingWalletsStrategyLeastBusy, ProcessingWalletsStrategyRoundRobin, ProcessingWalletsStrategyHighestBalance:
+		return true
+	}
+	return false
+}
+
+// Scan implements the sql.Scanner interface
+func (s *ProcessingWalletsStrategy) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*s = ProcessingWalletsStrategy(v)
+	case string:
+		*s = ProcessingWalletsStrategy(v)
+	default:
+		return fmt.Errorf("unsupported scan type for ProcessingWalletsStrategy: %T", src)
+	}
+	return nil
+}
@@ -1,17 +1,20 @@
 package fsmdoge
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/workflow"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/doge"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/ltcsuite/ltcd/wire"
 	"github.com/shopspring/decimal"
 )
 
@@ -62,15 +65,9 @@ func (s *FSM) getAddressUTXO(ctx context.Context, fromAddress string) ([]UTXO, e
 // getAddressesUTXO
 func (s *FSM) processAddressesUTXOs(ctx context.Context, owner *models.Owner, newTx *doge.TxBuilder, addresses []string) (decimal.Decimal, error) {
 	var totalUTXOAmount decimal.Decimal
-	var err error
 
-	mnemonic := owner.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
-		if err != nil {
-			return totalUTXOAmount, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
-	}
+	// inputs are signed by the signer with the keys of the addresses
+	signerInputs := make(map[string]signer.UTXOInput)
 
 	// get UTXOs for all addresses
 	for _, address := range addresses {
@@ -86,31 +83,77 @@ func (s *FSM) processAddressesUTXOs(ctx context.Context, owner *models.Owner, ne
 			return totalUTXOAmount, fmt.Errorf("get sequence by wallet type: %w", err)
 		}
 
-		addrData, err := s.doge.WalletSDK.GenerateAddress(mnemonic, owner.PassPhrase.String, uint32(sequence)) //nolint:gosec
-		if err != nil {
-			return totalUTXOAmount, fmt.Errorf("get private key for address %s: %w", address, err)
-		}
-
 		for _, input := range utxos {
 			txInput := doge.TxInput{
-				PrivateKey: addrData.PrivateKey,
-				PkScript:   input.PkScript,
-				Hash:       input.TxHash,
-				Sequence:   uint32(input.Sequence), //nolint:gosec
-				Amount:     input.Amount.IntPart(),
+				PkScript: input.PkScript,
+				Hash:     input.TxHash,
+				Sequence: uint32(input.Sequence), //nolint:gosec
+				Amount:   input.Amount.IntPart(),
 			}
 
 			if err := newTx.AddInput(txInput); err != nil {
 				return totalUTXOAmount, fmt.Errorf("add transaction input: hash %s, sequence %d: %w", input.TxHash, sequence, err)
 			}
 
+			signerInputs[utxoKey(txInput.Hash, txInput.Sequence)] = signer.UTXOInput{
+				Address:         address,
+				AddressSequence: uint32(sequence), //nolint:gosec
+				PkScript:        txInput.PkScript,
+				Hash:            txInput.Hash,
+				Vout:            txInput.Sequence,
+				Amount:          txInput.Amount,
+			}
+
 			totalUTXOAmount = totalUTXOAmount.Add(input.Amount)
 		}
 	}
 
+	newTx.SetSignFunc(s.signFunc(ctx, owner.ID, signerInputs))
+
 	return totalUTXOAmount, nil
 }
 
+func utxoKey(hash string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", hash, vout)
+}
+
+// signFunc sends the transaction to the signer with the addresses of the spent outputs
+func (s *FSM) signFunc(ctx context.Context, ownerID uuid.UUID, signerInputs map[string]signer.UTXOInput) doge.SignFunc {
+	return func(tx *wire.MsgTx, inputs []doge.TxInput) (*wire.MsgTx, error) {
+		reqInputs := make([]signer.UTXOInput, 0, len(inputs))
+		for _, input := range inputs {
+			signerInput, ok := signerInputs[utxoKey(input.Hash, input.Sequence)]
+			if !ok {
+				return nil, fmt.Errorf("unknown transaction input %s:%d", input.Hash, input.Sequence)
+			}
+
+			reqInputs = append(reqInputs, signerInput)
+		}
+
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			return nil, fmt.Errorf("serialize transaction: %w", err)
+		}
+
+		signed, err := s.bs.Signer().SignUTXOTx(ctx, signer.SignUTXOTxRequest{
+			OwnerID:    ownerID,
+			Blockchain: wconstants.BlockchainTypeDogecoin,
+			UnsignedTx: buf.Bytes(),
+			Inputs:     reqInputs,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		signedTx := new(wire.MsgTx)
+		if err := signedTx.Deserialize(bytes.NewReader(signed)); err != nil {
+			return nil, fmt.Errorf("deserialize signed transaction: %w", err)
+		}
+
+		return signedTx, nil
+	}
+}
+
 // sendFailureEvent
 func (s *FSM) sendFailureEvent(ctx context.Context, w *workflow.Workflow, err error, repoOpts ...repos.Option) error {
 	params, err := s.bs.Webhooks().EventTransferStatusCreateParams(ctx, webhooks.EventTransferStatusCreateParamsData{
@@ -19,12 +19,11 @@ import (
 )
 
 type FSM struct {
-	logger                logger.Logger
-	config                config.IEVMConfig
-	enabledSeedEncryption bool
-	wf                    *workflow.Workflow
-	transfer              *models.Transfer
-	st                    store.IStore
+	logger   logger.Logger
+	config   config.IEVMConfig
+	wf       *workflow.Workflow
+	transfer *models.Transfer
+	st       store.IStore
 
 	evm *evm.EVM
 
@@ -35,20 +34,18 @@ type FSM struct {
 func NewFSM(
 	l logger.Logger,
 	conf config.IEVMConfig,
-	enabledSeedCompression bool,
 	st store.IStore,
 	bs baseservices.IBaseServices,
 	evmInstance *evm.EVM,
 	transfer *models.Transfer,
 ) (*FSM, error) {
 	fsm := &FSM{
-		logger:                l,
-		config:                conf,
-		enabledSeedEncryption: enabledSeedCompression,
-		st:                    st,
-		bs:                    bs,
-		evm:                   evmInstance,
-		transfer:              transfer,
+		logger:   l,
+		config:   conf,
+		st:       st,
+		bs:       bs,
+		evm:      evmInstance,
+		transfer: transfer,
 	}
 
 	// create a workflow
@@ -2,7 +2,6 @@ package fsmevm
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"strings"
@@ -14,9 +13,9 @@ import (
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/workflow"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/utils"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/evm/erc20"
@@ -245,38 +244,30 @@ func (s *FSM) getAssetDecimals(ctx context.Context, assetIdentifier string) (int
 	return s.bs.EProxy().AssetDecimals(ctx, s.evm.Blockchain(), assetIdentifier)
 }
 
+// walletCreds identifies the wallet which key signs the transactions
 type walletCreds struct {
-	Address    string
-	PrivateKey *ecdsa.PrivateKey
-	PublicKey  *ecdsa.PublicKey
+	OwnerID  uuid.UUID
+	Address  string
+	Sequence uint32
 }
 
-// getWalletCreds
-func (s *FSM) getWalletCreds(ctx context.Context, ownerID uuid.UUID, sequence uint32) (*walletCreds, error) {
-	// get owner
-	owner, err := s.bs.Owners().GetByID(ctx, ownerID)
-	if err != nil {
-		return nil, fmt.Errorf("get owner: %w", err)
-	}
-
-	mnemonic := owner.Mnemonic
-	if s.enabledSeedEncryption {
-		mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
-	}
-
-	address, priv, public, err := evm.WalletPubKeyHash(mnemonic, owner.PassPhrase.String, sequence)
-	if err != nil {
-		return nil, err
+func newWalletCreds(ownerID uuid.UUID, address string, sequence uint32) *walletCreds {
+	return &walletCreds{
+		OwnerID:  ownerID,
+		Address:  address,
+		Sequence: sequence,
 	}
+}
 
-	return &walletCreds{
-		Address:    address,
-		PrivateKey: priv,
-		PublicKey:  public,
-	}, nil
+// signTx signs the transaction with the signer
+func (s *FSM) signTx(ctx context.Context, wCreds *walletCreds, tx *types.Transaction) (*types.Transaction, error) {
+	return s.bs.Signer().SignEVMTx(ctx, signer.SignEVMTxRequest{
+		OwnerID:         wCreds.OwnerID,
+		Blockchain:      s.evm.Blockchain(),
+		Address:         wCreds.Address,
+		AddressSequence: wCreds.Sequence,
+		Tx:              tx,
+	})
 }
 
 // sendBaseAsset allows to send base asset berween wallets.
@@ -333,7 +324,7 @@ func (s *FSM) sendBaseAsset(ctx context.Context, wCreds *walletCreds, toAddress
 		Data:      nil,
 	})
 
-	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), wCreds.PrivateKey)
+	signedTx, err := s.signTx(ctx, wCreds, tx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("sign transaction: %w", err)
 	}
@@ -391,12 +382,29 @@ func (s *FSM) sendERC20(ctx context.Context, wCreds *walletCreds, contractAddres
 	}
 
 	// create auth
-	auth, err := bind.NewKeyedTransactorWithChainID(wCreds.PrivateKey, chainID)
-	if err != nil {
-		return nil, nil, err
+	from := common.HexToAddress(wCreds.Address)
+	auth := &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			// the bound contract does not set the chain id of the dynamic fee transaction
+			return s.signTx(ctx, wCreds, types.NewTx(&types.DynamicFeeTx{
+				ChainID:    chainID,
+				Nonce:      tx.Nonce(),
+				GasTipCap:  tx.GasTipCap(),
+				GasFeeCap:  tx.GasFeeCap(),
+				Gas:        tx.Gas(),
+				To:         tx.To(),
+				Value:      tx.Value(),
+				Data:       tx.Data(),
+				AccessList: tx.AccessList(),
+			}))
+		},
 	}
 
-	auth.From = common.HexToAddress(wCreds.Address)
 	auth.Context = ctx
 	auth.Value = big.NewInt(0)
 	auth.Nonce = big.NewInt(int64(nonce)) //nolint:gosec
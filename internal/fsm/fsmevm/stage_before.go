@@ -116,8 +116,8 @@ func (s *FSM) sendBaseAssetForBurn(ctx context.Context, wf *workflow.Workflow, _
 		return nil
 	}
 
-	// get processing wallet by owner id
-	processingWallet, err := s.bs.Wallets().Processing().GetByBlockchain(ctx, s.transfer.OwnerID, s.evm.Blockchain())
+	// get processing wallet of the transfer
+	processingWallet, err := s.bs.Wallets().Processing().GetByTransfer(ctx, s.transfer)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -195,11 +195,7 @@ func (s *FSM) sendBaseAssetForBurn(ctx context.Context, wf *workflow.Workflow, _
 		)
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 	_, sendStateData, err := s.sendBaseAsset(ctx, wcreds, s.transfer.GetFromAddress(), needFeeBaseAssetAmount, estimateResult)
 	if err != nil {
@@ -101,11 +101,7 @@ func (s *FSM) sending(ctx context.Context, _ *workflow.Workflow, _ *workflow.Sta
 		return fmt.Errorf("get sequence by wallet type: %w", err)
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, fromAddress, uint32(sequence)) //nolint:gosec
 
 	var stateData map[string]any
 	var newTx *types.Transaction
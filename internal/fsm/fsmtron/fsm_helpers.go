@@ -2,7 +2,6 @@ package fsmtron
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"strings"
 	"time"
@@ -23,10 +22,10 @@ import (
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_transfer_transactions"
 	"github.com/dv-net/dv-processing/internal/workflow"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/errutils"
 	"github.com/dv-net/dv-processing/pkg/retry"
 	"github.com/dv-net/dv-processing/pkg/utils"
@@ -153,38 +152,40 @@ func (s *FSM) getAssetDecimals(ctx context.Context, assetIdentifier string) (int
 	return s.bs.EProxy().AssetDecimals(ctx, wconstants.BlockchainTypeTron, assetIdentifier)
 }
 
+// walletCreds identifies the wallet which key signs the transactions
 type walletCreds struct {
-	Address    string
-	PrivateKey *ecdsa.PrivateKey
-	PublicKey  *ecdsa.PublicKey
+	OwnerID  uuid.UUID
+	Address  string
+	Sequence uint32
 }
 
-// getWalletCreds
-func (s *FSM) getWalletCreds(ctx context.Context, ownerID uuid.UUID, sequence uint32) (*walletCreds, error) {
-	// get owner
-	owner, err := s.bs.Owners().GetByID(ctx, ownerID)
-	if err != nil {
-		return nil, fmt.Errorf("get owner: %w", err)
+func newWalletCreds(ownerID uuid.UUID, address string, sequence uint32) *walletCreds {
+	return &walletCreds{
+		OwnerID:  ownerID,
+		Address:  address,
+		Sequence: sequence,
 	}
+}
 
-	mnemonic := owner.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
+// signTransaction signs the transaction with the signer and appends the signature to the transaction
+func (s *FSM) signTransaction(ctx context.Context, wCreds *walletCreds, tx *core.Transaction) error {
+	if tx == nil {
+		return fmt.Errorf("empty tron tx")
 	}
 
-	address, priv, public, err := tron.WalletPubKeyHash(mnemonic, owner.PassPhrase.String, sequence)
+	signature, err := s.bs.Signer().SignTronTx(ctx, signer.SignTronTxRequest{
+		OwnerID:         wCreds.OwnerID,
+		Address:         wCreds.Address,
+		AddressSequence: wCreds.Sequence,
+		Tx:              tx,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &walletCreds{
-		Address:    address,
-		PrivateKey: priv,
-		PublicKey:  public,
-	}, nil
+	tx.Signature = append(tx.Signature, signature)
+
+	return nil
 }
 
 func (s *FSM) systemActivation(ctx context.Context, wCreds *walletCreds, toAddress string) (*api.TransactionExtention, error) {
@@ -201,7 +202,7 @@ func (s *FSM) systemActivation(ctx context.Context, wCreds *walletCreds, toAddre
 		return nil, fmt.Errorf("create system activation tx error: %s", string(tx.Result.Message))
 	}
 
-	if err := s.tron.SignTransaction(tx.GetTransaction(), wCreds.PrivateKey); err != nil {
+	if err := s.signTransaction(ctx, wCreds, tx.GetTransaction()); err != nil {
 		return nil, fmt.Errorf("sign transaction: %w", err)
 	}
 
@@ -252,7 +253,7 @@ func (s *FSM) sendTRX(ctx context.Context, wCreds *walletCreds, toAddress string
 		return nil, fmt.Errorf("create send trx tx error: %s", string(tx.Result.Message))
 	}
 
-	if err := s.tron.SignTransaction(tx.GetTransaction(), wCreds.PrivateKey); err != nil {
+	if err := s.signTransaction(ctx, wCreds, tx.GetTransaction()); err != nil {
 		return nil, fmt.Errorf("sign transaction: %w", err)
 	}
 
@@ -319,7 +320,7 @@ func (s *FSM) sendTrc20(ctx context.Context, wCreds *walletCreds, contractAddres
 		return nil, fmt.Errorf("create send trc20 tx error: %s", string(tx.Result.Message))
 	}
 
-	if err := s.tron.SignTransaction(tx.GetTransaction(), wCreds.PrivateKey); err != nil {
+	if err := s.signTransaction(ctx, wCreds, tx.GetTransaction()); err != nil {
 		return nil, fmt.Errorf("sign transaction: %w", err)
 	}
 
@@ -407,7 +408,7 @@ func (s *FSM) delegateResource(
 		return nil, nil, fmt.Errorf("create delegate tx error: %s", string(tx.Result.Message))
 	}
 
-	if err := s.tron.SignTransaction(tx.GetTransaction(), wCreds.PrivateKey); err != nil {
+	if err := s.signTransaction(ctx, wCreds, tx.GetTransaction()); err != nil {
 		return nil, nil, fmt.Errorf("sign transaction: %w", err)
 	}
 
@@ -464,7 +465,7 @@ func (s *FSM) reclaimResource(ctx context.Context, wCreds *walletCreds, toAddres
 		return nil, nil, fmt.Errorf("create reclaim tx error: %s", string(tx.Result.Message))
 	}
 
-	if err := s.tron.SignTransaction(tx.GetTransaction(), wCreds.PrivateKey); err != nil {
+	if err := s.signTransaction(ctx, wCreds, tx.GetTransaction()); err != nil {
 		return nil, nil, fmt.Errorf("sign transaction: %w", err)
 	}
 
@@ -183,11 +183,7 @@ func (s *FSM) reclaimResources(ctx context.Context, wf *workflow.Workflow, _ *wo
 				return fmt.Errorf("get processing wallet: %w", err)
 			}
 
-			// get wallet creds
-			wcreds, err := s.getWalletCreds(egCtx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-			if err != nil {
-				return fmt.Errorf("get wallet creds: %w", err)
-			}
+			wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 			for _, frozenResource := range frozenResources {
 				if frozenResource.DelegateTo != delegatedData.ToAddress {
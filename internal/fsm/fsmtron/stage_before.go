@@ -153,7 +153,7 @@ func (s *FSM) beforeActivationCheckTransferKind(_ context.Context, wf *workflow.
 }
 
 func (s *FSM) activateWalletResources(ctx context.Context, _ *workflow.Workflow, _ *workflow.Stage, step *workflow.Step) error {
-	processingWallet, err := s.bs.Wallets().Processing().GetByBlockchain(ctx, s.transfer.OwnerID, wconstants.BlockchainTypeTron)
+	processingWallet, err := s.bs.Wallets().Processing().GetByTransfer(ctx, s.transfer)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -202,14 +202,10 @@ func (s *FSM) activateWalletResources(ctx context.Context, _ *workflow.Workflow,
 		return fmt.Errorf("create unsigned activation transaction: %w", err)
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 	// sign transaction
-	if err = s.tron.SignTransaction(tx.Transaction, wcreds.PrivateKey); err != nil {
+	if err = s.signTransaction(ctx, wcreds, tx.Transaction); err != nil {
 		return fmt.Errorf("sign activation transaction: %w", err)
 	}
 
@@ -272,8 +268,8 @@ func (s *FSM) waitingExternalActivateWalletConfirmations(ctx context.Context, w
 }
 
 func (s *FSM) activateWalletBurnTRX(ctx context.Context, _ *workflow.Workflow, _ *workflow.Stage, step *workflow.Step) error {
-	// get processing wallet by owner id
-	processingWallet, err := s.bs.Wallets().Processing().GetByBlockchain(ctx, s.transfer.OwnerID, wconstants.BlockchainTypeTron)
+	// get processing wallet of the transfer
+	processingWallet, err := s.bs.Wallets().Processing().GetByTransfer(ctx, s.transfer)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -298,11 +294,7 @@ func (s *FSM) activateWalletBurnTRX(ctx context.Context, _ *workflow.Workflow, _
 		return fmt.Errorf("processing wallet balance is less than %s trx", accountActivationFee.Trx.String())
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 	_, err = s.systemActivation(ctx, wcreds, s.transfer.GetFromAddress())
 	if err != nil {
@@ -369,8 +361,8 @@ func (s *FSM) sendTRXForBurn(ctx context.Context, wf *workflow.Workflow, _ *work
 		return nil
 	}
 
-	// get processing wallet by owner id
-	processingWallet, err := s.bs.Wallets().Processing().GetByBlockchain(ctx, s.transfer.OwnerID, wconstants.BlockchainTypeTron)
+	// get processing wallet of the transfer
+	processingWallet, err := s.bs.Wallets().Processing().GetByTransfer(ctx, s.transfer)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -422,11 +414,7 @@ func (s *FSM) sendTRXForBurn(ctx context.Context, wf *workflow.Workflow, _ *work
 		return fmt.Errorf("not enough trx on processing wallet: %s", sentTrxAmount.String())
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 	_, err = s.sendTRX(ctx, wcreds, s.transfer.GetFromAddress(), sentTrxAmount)
 	if err != nil {
@@ -458,8 +446,8 @@ func (s *FSM) waitingSendBurnTrxConfirmations(ctx context.Context, wf *workflow.
 
 // delegateResources or send trx for burning
 func (s *FSM) delegateResources(ctx context.Context, wf *workflow.Workflow, _ *workflow.Stage, step *workflow.Step) (err error) {
-	// get processing wallet by owner id
-	processingWallet, err := s.bs.Wallets().Processing().GetByBlockchain(ctx, s.transfer.OwnerID, wconstants.BlockchainTypeTron)
+	// get processing wallet of the transfer
+	processingWallet, err := s.bs.Wallets().Processing().GetByTransfer(ctx, s.transfer)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -534,11 +522,7 @@ func (s *FSM) delegateResources(ctx context.Context, wf *workflow.Workflow, _ *w
 		}
 	}
 
-	// get wallet creds
-	wcreds, err := s.getWalletCreds(ctx, s.transfer.OwnerID, uint32(processingWallet.Sequence)) //nolint:gosec
-	if err != nil {
-		return fmt.Errorf("get wallet creds: %w", err)
-	}
+	wcreds := newWalletCreds(s.transfer.OwnerID, processingWallet.Address, uint32(processingWallet.Sequence)) //nolint:gosec
 
 	stateData := xsync.NewMap[string, any]()
 	stateData.Store("proecssing_wallet_available_resources", processingResources)
@@ -2,15 +2,22 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"connectrpc.com/connect"
 	clientv1 "github.com/dv-net/dv-processing/api/processing/client/v1"
 	"github.com/dv-net/dv-processing/api/processing/client/v1/clientv1connect"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/interceptors"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/dv-processing/internal/services/clients"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type clientsServer struct {
@@ -49,6 +56,8 @@ func (s *clientsServer) Create(ctx context.Context, request *connect.Request[cli
 		ClientId:       res.Client.ID.String(),
 		ClientKey:      res.Client.SecretKey,
 		AdminSecretKey: res.AdminSecret,
+		ClientKeyId:    res.APISecret.ID.String(),
+		WebhookKeyId:   res.WebhookSecret.ID.String(),
 	}), nil
 }
 
@@ -79,3 +88,228 @@ func (s *clientsServer) GetCallbackURL(ctx context.Context, request *connect.Req
 
 	return connect.NewResponse(&clientv1.GetCallbackURLResponse{CallbackUrl: client.CallbackUrl}), nil
 }
+
+// UpdateSignSettings - update client request signature settings
+func (s *clientsServer) UpdateSignSettings(ctx context.Context, request *connect.Request[clientv1.UpdateSignSettingsRequest]) (*connect.Response[clientv1.UpdateSignSettingsResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	if err = s.bs.Clients().SetRequireSignV2(ctx, cid, request.Msg.GetRequireSignV2()); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("update client sign settings: %w", err))
+	}
+
+	return connect.NewResponse(new(clientv1.UpdateSignSettingsResponse)), nil
+}
+
+// checkRequestClient checks that the request is signed by the client it manages
+func checkRequestClient(header http.Header, clientID uuid.UUID) error {
+	if requestClientID := header.Get(interceptors.ClientIDHeaderName); requestClientID != "" && requestClientID != clientID.String() {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("client id does not match the request client"))
+	}
+
+	return nil
+}
+
+func convertSecretScope(scope clientv1.SecretScope) (constants.ClientSecretScope, error) {
+	switch scope {
+	case clientv1.SecretScope_SECRET_SCOPE_API:
+		return constants.ClientSecretScopeAPI, nil
+	case clientv1.SecretScope_SECRET_SCOPE_WEBHOOK:
+		return constants.ClientSecretScopeWebhook, nil
+	default:
+		return "", fmt.Errorf("undefined secret scope: %s", scope)
+	}
+}
+
+// RotateSecret - create a new client secret of the scope
+func (s *clientsServer) RotateSecret(ctx context.Context, request *connect.Request[clientv1.RotateSecretRequest]) (*connect.Response[clientv1.RotateSecretResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	scope, err := convertSecretScope(request.Msg.GetScope())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	gracePeriod := clients.DefaultSecretGracePeriod
+	if request.Msg.GracePeriodSeconds != nil {
+		gracePeriod = time.Duration(request.Msg.GetGracePeriodSeconds()) * time.Second
+	}
+
+	res, err := s.bs.Clients().RotateSecret(ctx, clients.RotateSecretDTO{
+		ClientID:    cid,
+		Scope:       scope,
+		GracePeriod: gracePeriod,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("rotate client secret: %w", err))
+	}
+
+	return connect.NewResponse(&clientv1.RotateSecretResponse{
+		SecretId:          res.Secret.ID.String(),
+		SecretKey:         res.Secret.SecretKey,
+		CreatedAt:         timestamppb.New(res.Secret.CreatedAt.Time),
+		PreviousExpiresAt: timestamppb.New(res.PreviousExpiresAt),
+	}), nil
+}
+
+// RevokeSecret - revoke the client secret
+func (s *clientsServer) RevokeSecret(ctx context.Context, request *connect.Request[clientv1.RevokeSecretRequest]) (*connect.Response[clientv1.RevokeSecretResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	secretID, err := uuid.Parse(request.Msg.GetSecretId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("secret id undefined: %w", err))
+	}
+
+	if err := s.bs.Clients().RevokeSecret(ctx, cid, secretID); err != nil {
+		if errors.Is(err, storecmn.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("active secret not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("revoke client secret: %w", err))
+	}
+
+	return connect.NewResponse(new(clientv1.RevokeSecretResponse)), nil
+}
+
+// CreateAPIKey - create the api key limited to the permissions
+func (s *clientsServer) CreateAPIKey(ctx context.Context, request *connect.Request[clientv1.CreateAPIKeyRequest]) (*connect.Response[clientv1.CreateAPIKeyResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	permissions := make([]constants.Permission, 0, len(request.Msg.GetPermissions()))
+	for _, permission := range request.Msg.GetPermissions() {
+		if !constants.Permission(permission).Valid() {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("undefined permission: %s", permission))
+		}
+		permissions = append(permissions, constants.Permission(permission))
+	}
+
+	// the key can not get the permissions which the calling key does not have
+	if callerPermissions, ok := interceptors.PermissionsFromContext(ctx); ok && !slices.Contains(callerPermissions, constants.PermissionAll.String()) {
+		for _, permission := range permissions {
+			if !slices.Contains(callerPermissions, permission.String()) {
+				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("api key has no %s permission", permission))
+			}
+		}
+	}
+
+	secret, err := s.bs.Clients().CreateAPIKey(ctx, clients.CreateAPIKeyDTO{
+		ClientID:    cid,
+		Permissions: permissions,
+		ExpiresIn:   time.Duration(request.Msg.GetExpiresInSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("create api key: %w", err))
+	}
+
+	response := &clientv1.CreateAPIKeyResponse{
+		SecretId:  secret.ID.String(),
+		SecretKey: secret.SecretKey,
+		CreatedAt: timestamppb.New(secret.CreatedAt.Time),
+	}
+
+	if secret.ExpiresAt.Valid {
+		response.ExpiresAt = timestamppb.New(secret.ExpiresAt.Time)
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// GetSecrets - get the client secrets without the keys
+func (s *clientsServer) GetSecrets(ctx context.Context, request *connect.Request[clientv1.GetSecretsRequest]) (*connect.Response[clientv1.GetSecretsResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	secrets, err := s.bs.Clients().GetSecrets(ctx, cid)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get client secrets: %w", err))
+	}
+
+	response := &clientv1.GetSecretsResponse{
+		Secrets: make([]*clientv1.ClientSecret, 0, len(secrets)),
+	}
+
+	for _, secret := range secrets {
+		item := &clientv1.ClientSecret{
+			Id:          secret.ID.String(),
+			Permissions: secret.Permissions,
+			CreatedAt:   timestamppb.New(secret.CreatedAt.Time),
+		}
+
+		switch secret.Scope {
+		case constants.ClientSecretScopeAPI:
+			item.Scope = clientv1.SecretScope_SECRET_SCOPE_API
+		case constants.ClientSecretScopeWebhook:
+			item.Scope = clientv1.SecretScope_SECRET_SCOPE_WEBHOOK
+		}
+
+		if secret.ExpiresAt.Valid {
+			item.ExpiresAt = timestamppb.New(secret.ExpiresAt.Time)
+		}
+
+		if secret.RevokedAt.Valid {
+			item.RevokedAt = timestamppb.New(secret.RevokedAt.Time)
+		}
+
+		response.Secrets = append(response.Secrets, item)
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// UpdateAllowedIPs - set the ip addresses and the cidr ranges the client requests are accepted from
+func (s *clientsServer) UpdateAllowedIPs(ctx context.Context, request *connect.Request[clientv1.UpdateAllowedIPsRequest]) (*connect.Response[clientv1.UpdateAllowedIPsResponse], error) {
+	cid, err := uuid.Parse(request.Msg.GetClientId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("client id undefined: %w", err))
+	}
+
+	if err := checkRequestClient(request.Header(), cid); err != nil {
+		return nil, err
+	}
+
+	allowedIPs, err := s.bs.Clients().SetAllowedIPs(ctx, cid, request.Msg.GetAllowedIps())
+	if err != nil {
+		if errors.Is(err, clients.ErrInvalidAllowedIP) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if errors.Is(err, storecmn.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("client not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("update client allowed ips: %w", err))
+	}
+
+	return connect.NewResponse(&clientv1.UpdateAllowedIPsResponse{AllowedIps: allowedIPs}), nil
+}
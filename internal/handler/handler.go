@@ -6,6 +6,7 @@ import (
 	"github.com/dv-net/dv-processing/api/processing/system/v1/systemv1connect"
 	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
 	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/api/processing/webhook/v1/webhookv1connect"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/mx/logger"
 	"github.com/dv-net/mx/transport/connectrpc_transport"
@@ -32,6 +33,10 @@ type Handler struct {
 		connectrpc_transport.ConnectRPCService
 		systemv1connect.SystemServiceHandler
 	}
+	WebhooksServer interface {
+		connectrpc_transport.ConnectRPCService
+		webhookv1connect.WebhookServiceHandler
+	}
 }
 
 func New(
@@ -44,6 +49,7 @@ func New(
 		WalletsServer:   newWalletsServer(bs),
 		TransfersServer: newTransfersServer(l, bs),
 		SystemServer:    newSystemServer(bs),
+		WebhooksServer:  newWebhooksServer(bs),
 	}
 }
 
@@ -54,5 +60,6 @@ func (h *Handler) AllServers() []connectrpc_transport.ConnectRPCService {
 		h.WalletsServer,
 		h.TransfersServer,
 		h.SystemServer,
+		h.WebhooksServer,
 	}
 }
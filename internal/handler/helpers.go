@@ -2,12 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"connectrpc.com/connect"
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/interceptors"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/baseservices"
+	"github.com/dv-net/dv-processing/internal/services/owners"
 	"github.com/google/uuid"
 
 	connectcors "connectrpc.com/cors"
@@ -33,3 +38,43 @@ func WithClientContext[T any](ctx context.Context, request connect.Request[T]) c
 
 	return constants.WithClientContext(ctx, clientID)
 }
+
+// checkOwnerOTP returns the owner if the two-factor token is valid.
+func checkOwnerOTP(ctx context.Context, bs baseservices.IBaseServices, ownerID uuid.UUID, otp string) (*models.Owner, error) {
+	if otp == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("otp undefined"))
+	}
+
+	owner, err := bs.Owners().GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if owner.OtpSecret.String == "" {
+		return nil, fmt.Errorf("owner has no secret")
+	}
+
+	if !owner.OtpConfirmed {
+		return nil, fmt.Errorf("two-factor authenticator is disabled")
+	}
+
+	if err := bs.Owners().ValidateTwoFactorToken(ctx, owner.ID, otp); err != nil {
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInvalidArgument), fmt.Errorf("validate otp: %w", err))
+	}
+
+	return owner, nil
+}
+
+// twoFactorErrorCode returns the code of the two-factor validation or the seeds export error and the fallback code for other errors
+func twoFactorErrorCode(err error, fallback connect.Code) connect.Code {
+	switch {
+	case errors.Is(err, owners.ErrTwoFactorLocked):
+		return connect.CodeResourceExhausted
+	case errors.Is(err, owners.ErrInvalidOTP), errors.Is(err, owners.ErrOTPAlreadyUsed):
+		return connect.CodeInvalidArgument
+	case errors.Is(err, owners.ErrSeedsHeldBySigner):
+		return connect.CodeFailedPrecondition
+	default:
+		return fallback
+	}
+}
@@ -9,13 +9,18 @@ import (
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ownerv1 "github.com/dv-net/dv-processing/api/processing/owner/v1"
 	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
 	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/dv-processing/internal/services/owners"
+	"github.com/dv-net/dv-processing/internal/services/rotations"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 )
 
 type ownersServer struct {
@@ -45,13 +50,22 @@ func (s *ownersServer) Create(ctx context.Context, request *connect.Request[owne
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	derivationConfig, err := convertDerivation(request.Msg.GetDerivation())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("derivation: %w", err))
+	}
+
 	owner, err := s.bs.Owners().Create(ctx, owners.CreateParams{
 		ClientID:   cid,
 		ExternalID: request.Msg.GetExternalId(),
 		Mnemonic:   request.Msg.GetMnemonic(),
+		PassPhrase: request.Msg.GetPassPhrase(),
+		Derivation: derivationConfig,
 	})
 	if err != nil {
-		if errors.Is(err, owners.ErrClientNotFound) || errors.Is(err, owners.ErrExternalIDExists) {
+		if errors.Is(err, owners.ErrClientNotFound) ||
+			errors.Is(err, owners.ErrExternalIDExists) ||
+			errors.Is(err, owners.ErrInvalidDerivation) {
 			return nil, connect.NewError(connect.CodeInvalidArgument, err)
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("owner insert: %w", err))
@@ -74,12 +88,13 @@ func (s *ownersServer) GetSeeds(ctx context.Context, request *connect.Request[ow
 
 	data, err := s.bs.Owners().GetSeeds(ctx, oid, otp)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), err)
 	}
 
 	return connect.NewResponse(&ownerv1.GetSeedsResponse{
 		Mnemonic:   data.Mnemonic,
 		PassPhrase: data.PassPhrase,
+		Derivation: convertDerivationToPb(data.Derivation),
 	}), nil
 }
 
@@ -103,7 +118,7 @@ func (s *ownersServer) GetPrivateKeys(
 		OTP:     otp,
 	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), err)
 	}
 
 	res := &ownerv1.GetPrivateKeysResponse{
@@ -146,7 +161,7 @@ func (s *ownersServer) GetHotWalletKeys(ctx context.Context, request *connect.Re
 		ExcludedAddresses: request.Msg.GetExcludedWalletAddresses(),
 	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), err)
 	}
 
 	res := &ownerv1.GetHotWalletKeysResponse{
@@ -184,11 +199,14 @@ func (s *ownersServer) ConfirmTwoFactorAuth(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("otp undefined"))
 	}
 
-	if err := s.bs.Owners().ConfirmTwoFactorAuth(ctx, oid, otp); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("confirm two factor auth: %w", err))
+	recoveryCodes, err := s.bs.Owners().ConfirmTwoFactorAuth(ctx, oid, otp)
+	if err != nil {
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), fmt.Errorf("confirm two factor auth: %w", err))
 	}
 
-	return connect.NewResponse(new(ownerv1.ConfirmTwoFactorAuthResponse)), nil
+	return connect.NewResponse(&ownerv1.ConfirmTwoFactorAuthResponse{
+		RecoveryCodes: recoveryCodes,
+	}), nil
 }
 
 // DisableTwoFactorAuth disables owner two-factor auth, removing secret.
@@ -207,7 +225,7 @@ func (s *ownersServer) DisableTwoFactorAuth(
 	}
 
 	if err := s.bs.Owners().DisableTwoFactorAuth(ctx, oid, otp); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("disable two factor auth: %w", err))
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), fmt.Errorf("disable two factor auth: %w", err))
 	}
 
 	return connect.NewResponse(new(ownerv1.DisableTwoFactorAuthResponse)), nil
@@ -227,8 +245,9 @@ func (s *ownersServer) GetTwoFactorAuthData(ctx context.Context, request *connec
 	}
 
 	return connect.NewResponse(&ownerv1.GetTwoFactorAuthDataResponse{
-		Secret:      data.Secret,
-		IsConfirmed: data.IsConfirmed,
+		Secret:            data.Secret,
+		IsConfirmed:       data.IsConfirmed,
+		RecoveryCodesLeft: uint32(data.RecoveryCodesLeft),
 	}), nil
 }
 
@@ -248,8 +267,152 @@ func (s *ownersServer) ValidateTwoFactorToken(
 
 	// TODO: Decide on returning either error/true or false/true
 	if err := s.bs.Owners().ValidateTwoFactorToken(ctx, oid, otp); err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validate two factor auth: %w", err))
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInvalidArgument), fmt.Errorf("validate two factor auth: %w", err))
 	}
 
 	return connect.NewResponse(new(ownerv1.ValidateTwoFactorTokenResponse)), nil
 }
+
+// RegenerateRecoveryCodes replaces owner two-factor recovery codes.
+func (s *ownersServer) RegenerateRecoveryCodes(
+	ctx context.Context,
+	request *connect.Request[ownerv1.RegenerateRecoveryCodesRequest],
+) (*connect.Response[ownerv1.RegenerateRecoveryCodesResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	otp := request.Msg.GetTotp()
+	if otp == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("otp undefined"))
+	}
+
+	recoveryCodes, err := s.bs.Owners().RegenerateRecoveryCodes(ctx, oid, otp)
+	if err != nil {
+		return nil, connect.NewError(twoFactorErrorCode(err, connect.CodeInternal), fmt.Errorf("regenerate recovery codes: %w", err))
+	}
+
+	return connect.NewResponse(&ownerv1.RegenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}), nil
+}
+
+func (s *ownersServer) StartMnemonicRotation(
+	ctx context.Context,
+	request *connect.Request[ownerv1.StartMnemonicRotationRequest],
+) (*connect.Response[ownerv1.StartMnemonicRotationResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id: %w", err))
+	}
+
+	if _, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp()); err != nil {
+		return nil, err
+	}
+
+	res, err := s.bs.Rotations().Start(ctx, rotations.StartParams{
+		OwnerID:    oid,
+		Mnemonic:   request.Msg.GetMnemonic(),
+		PassPhrase: request.Msg.GetPassPhrase(),
+	})
+	if err != nil {
+		if errors.Is(err, rotations.ErrInvalidMnemonic) ||
+			errors.Is(err, rotations.ErrSameMnemonic) ||
+			errors.Is(err, rotations.ErrPassPhraseIsTooLong) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		if errors.Is(err, rotations.ErrRotationInProgress) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("start mnemonic rotation: %w", err))
+	}
+
+	return connect.NewResponse(&ownerv1.StartMnemonicRotationResponse{
+		Id:       res.Rotation.ID.String(),
+		Status:   res.Rotation.Status.String(),
+		Mnemonic: res.Mnemonic,
+	}), nil
+}
+
+func (s *ownersServer) GetMnemonicRotation(
+	ctx context.Context,
+	request *connect.Request[ownerv1.GetMnemonicRotationRequest],
+) (*connect.Response[ownerv1.GetMnemonicRotationResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id: %w", err))
+	}
+
+	res, err := s.bs.Rotations().GetLast(ctx, oid)
+	if err != nil {
+		if errors.Is(err, rotations.ErrRotationNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get mnemonic rotation: %w", err))
+	}
+
+	response := &ownerv1.GetMnemonicRotationResponse{
+		Id:        res.Rotation.ID.String(),
+		Status:    res.Rotation.Status.String(),
+		CreatedAt: timestamppb.New(res.Rotation.CreatedAt.Time),
+		Wallets:   make([]*ownerv1.MnemonicRotationWallet, 0, len(res.Wallets)),
+	}
+
+	if res.Rotation.LastError.Valid {
+		response.LastError = &res.Rotation.LastError.String
+	}
+
+	if res.Rotation.CompletedAt.Valid {
+		response.CompletedAt = timestamppb.New(res.Rotation.CompletedAt.Time)
+	}
+
+	for _, item := range res.Wallets {
+		response.Wallets = append(response.Wallets, &ownerv1.MnemonicRotationWallet{
+			WalletType: item.WalletType.String(),
+			Blockchain: models.ConvertBlockchainTypeToPb(item.Blockchain),
+			OldAddress: item.OldAddress,
+			NewAddress: item.NewAddress,
+			Status:     item.Status.String(),
+		})
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+func convertDerivation(item *ownerv1.Derivation) (derivation.Config, error) {
+	res := derivation.Config{
+		Account: item.GetAccount(),
+	}
+
+	for _, tmpl := range item.GetTemplates() {
+		blockchain, err := models.ConvertBlockchainType(tmpl.GetBlockchain())
+		if err != nil {
+			return res, err
+		}
+
+		if res.Templates == nil {
+			res.Templates = make(map[wconstants.BlockchainType]string)
+		}
+		res.Templates[blockchain] = tmpl.GetTemplate()
+	}
+
+	return res, nil
+}
+
+func convertDerivationToPb(item derivation.Config) *ownerv1.Derivation {
+	res := &ownerv1.Derivation{
+		Account: item.Account,
+	}
+
+	for blockchain, tmpl := range item.Templates {
+		res.Templates = append(res.Templates, &ownerv1.DerivationTemplate{
+			Blockchain: models.ConvertBlockchainTypeToPb(blockchain),
+			Template:   tmpl,
+		})
+	}
+
+	return res
+}
@@ -2,14 +2,18 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	systemv1 "github.com/dv-net/dv-processing/api/processing/system/v1"
 	"github.com/dv-net/dv-processing/api/processing/system/v1/systemv1connect"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
+	"github.com/dv-net/dv-processing/pkg/utils"
 )
 
 type systemService struct {
@@ -89,3 +93,83 @@ func (s *systemService) GetLastLogs(ctx context.Context, _ *connect.Request[syst
 
 	return connect.NewResponse(resp), nil
 }
+
+const (
+	defaultAuditEventsLimit = 100
+	maxAuditEventsLimit     = 1000
+)
+
+// GetAuditEvents - get audit events of the sensitive operations
+func (s *systemService) GetAuditEvents(ctx context.Context, request *connect.Request[systemv1.GetAuditEventsRequest]) (*connect.Response[systemv1.GetAuditEventsResponse], error) {
+	params := audit.FindParams{
+		Procedure: request.Msg.Procedure,
+		Result:    request.Msg.Result,
+		BeforeID:  request.Msg.BeforeId,
+		Limit:     defaultAuditEventsLimit,
+	}
+
+	if request.Msg.ClientId != nil {
+		clientID, err := uuid.Parse(request.Msg.GetClientId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid client id: %w", err))
+		}
+		params.ClientID = &clientID
+	}
+
+	if request.Msg.OwnerId != nil {
+		ownerID, err := uuid.Parse(request.Msg.GetOwnerId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+		}
+		params.OwnerID = &ownerID
+	}
+
+	if request.Msg.From != nil {
+		params.From = utils.Pointer(request.Msg.GetFrom().AsTime())
+	}
+
+	if request.Msg.To != nil {
+		params.To = utils.Pointer(request.Msg.GetTo().AsTime())
+	}
+
+	if request.Msg.Limit != nil {
+		params.Limit = min(int(request.Msg.GetLimit()), maxAuditEventsLimit)
+		if params.Limit == 0 {
+			params.Limit = defaultAuditEventsLimit
+		}
+	}
+
+	events, err := s.bs.Audit().Find(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	response := &systemv1.GetAuditEventsResponse{
+		Events: make([]*systemv1.AuditEvent, 0, len(events)),
+	}
+
+	for _, event := range events {
+		item := &systemv1.AuditEvent{
+			Id:          event.ID,
+			Procedure:   event.Procedure,
+			SourceIp:    event.SourceIp,
+			Result:      event.Result,
+			Fingerprint: event.Fingerprint,
+			PrevHash:    event.PrevHash,
+			Hash:        event.Hash,
+			CreatedAt:   timestamppb.New(event.CreatedAt.Time),
+		}
+
+		if event.ClientID.Valid {
+			item.ClientId = utils.Pointer(event.ClientID.UUID.String())
+		}
+
+		if event.OwnerID.Valid {
+			item.OwnerId = utils.Pointer(event.OwnerID.UUID.String())
+		}
+
+		response.Events = append(response.Events, item)
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
+	"github.com/dv-net/dv-processing/internal/services/sweeps"
 	"github.com/dv-net/dv-processing/internal/services/transfers"
 	"github.com/dv-net/dv-processing/rpccode"
 	"github.com/dv-net/mx/logger"
@@ -163,3 +165,113 @@ func (s *transfersServer) GetByRequestID(ctx context.Context, req *connect.Reque
 
 	return response, nil
 }
+
+// SetSweepRule - creates or updates the sweep rule of the owner asset
+func (s *transfersServer) SetSweepRule(ctx context.Context, req *connect.Request[transferv1.SetSweepRuleRequest]) (*connect.Response[transferv1.SetSweepRuleResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id"))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(req.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var threshold decimal.NullDecimal
+	if req.Msg.Threshold != nil && *req.Msg.Threshold != "" {
+		t, err := decimal.NewFromString(*req.Msg.Threshold)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid threshold"))
+		}
+
+		threshold = decimal.NullDecimal{
+			Decimal: t,
+			Valid:   true,
+		}
+	}
+
+	var feeMax decimal.NullDecimal
+	if req.Msg.FeeMax != nil && *req.Msg.FeeMax != "" {
+		f, err := decimal.NewFromString(*req.Msg.FeeMax)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid max fee"))
+		}
+
+		feeMax = decimal.NullDecimal{
+			Decimal: f,
+			Valid:   true,
+		}
+	}
+
+	if _, err := checkOwnerOTP(ctx, s.bs, ownerID, req.Msg.GetTotp()); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.bs.Sweeps().SetRule(ctx, sweeps.SetRuleParams{
+		OwnerID:         ownerID,
+		Blockchain:      blockchain,
+		AssetIdentifier: req.Msg.GetAssetIdentifier(),
+		ToAddress:       req.Msg.GetToAddress(),
+		Threshold:       threshold,
+		IntervalHours:   req.Msg.IntervalHours,
+		FeeMax:          feeMax,
+		Kind:            req.Msg.Kind,
+		IsEnabled:       req.Msg.GetIsEnabled(),
+	})
+	if err != nil {
+		if errors.Is(err, sweeps.ErrInvalidRule) || errors.Is(err, sweeps.ErrColdWalletNotFound) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&transferv1.SetSweepRuleResponse{
+		Item: rule.ToPb(),
+	}), nil
+}
+
+// GetSweepRules - gets all sweep rules of the owner
+func (s *transfersServer) GetSweepRules(ctx context.Context, req *connect.Request[transferv1.GetSweepRulesRequest]) (*connect.Response[transferv1.GetSweepRulesResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id"))
+	}
+
+	rules, err := s.bs.Sweeps().GetRules(ctx, ownerID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	items := make([]*transferv1.SweepRule, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, rule.ToPb())
+	}
+
+	return connect.NewResponse(&transferv1.GetSweepRulesResponse{
+		Items: items,
+	}), nil
+}
+
+// DeleteSweepRule - deletes the sweep rule of the owner
+func (s *transfersServer) DeleteSweepRule(ctx context.Context, req *connect.Request[transferv1.DeleteSweepRuleRequest]) (*connect.Response[transferv1.DeleteSweepRuleResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id"))
+	}
+
+	ruleID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid sweep rule id"))
+	}
+
+	if _, err := checkOwnerOTP(ctx, s.bs, ownerID, req.Msg.GetTotp()); err != nil {
+		return nil, err
+	}
+
+	if err := s.bs.Sweeps().DeleteRule(ctx, ownerID, ruleID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(new(transferv1.DeleteSweepRuleResponse)), nil
+}
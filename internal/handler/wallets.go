@@ -11,13 +11,18 @@ import (
 	commonv1 "github.com/dv-net/dv-processing/api/processing/common/v1"
 	walletv1 "github.com/dv-net/dv-processing/api/processing/wallet/v1"
 	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/services/baseservices"
 	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/ltc"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type walletsServer struct {
@@ -89,6 +94,10 @@ func (s *walletsServer) GetOwnerColdWallets(
 		OwnerID: &oid,
 	}
 
+	if !request.Msg.GetIncludeDetached() {
+		params.IsDetached = lo.ToPtr(false)
+	}
+
 	if request.Msg.Blockchain != nil {
 		blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
 		if err != nil {
@@ -105,10 +114,7 @@ func (s *walletsServer) GetOwnerColdWallets(
 
 	items := make([]*walletv1.WalletPreview, 0, len(data.Items))
 	for _, wallet := range data.Items {
-		items = append(items, &walletv1.WalletPreview{
-			Address:    wallet.Address,
-			Blockchain: models.ConvertBlockchainTypeToPb(wallet.Blockchain),
-		})
+		items = append(items, convertColdWalletToPb(wallet))
 	}
 
 	return connect.NewResponse(&walletv1.GetOwnerColdWalletsResponse{Items: items}), nil
@@ -185,6 +191,103 @@ func (s *walletsServer) GetOwnerProcessingWallets(
 	return connect.NewResponse(&walletv1.GetOwnerProcessingWalletsResponse{Items: items}), nil
 }
 
+func (s *walletsServer) CreateOwnerProcessingWallets(ctx context.Context, request *connect.Request[walletv1.CreateOwnerProcessingWalletsRequest]) (*connect.Response[walletv1.CreateOwnerProcessingWalletsResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	count := int(request.Msg.GetCount())
+	if count <= 0 || count > wallets.MaxProcessingWalletsPerRequest {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("count must be between 1 and %d", wallets.MaxProcessingWalletsPerRequest))
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.bs.Wallets().Processing().CreateMany(ctx, wallets.CreateProcessingWalletParams{
+		OwnerID:    owner.ID,
+		Blockchain: blockchain,
+		Mnemonic:   owner.Mnemonic,
+		Passphrase: owner.PassPhrase.String,
+		Derivation: owner.Derivation,
+	}, count)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("create processing wallets: %w", err))
+	}
+
+	addresses := make([]string, 0, len(items))
+	for _, item := range items {
+		addresses = append(addresses, item.Address)
+	}
+
+	return connect.NewResponse(&walletv1.CreateOwnerProcessingWalletsResponse{Addresses: addresses}), nil
+}
+
+func (s *walletsServer) SetProcessingWalletsStrategy(ctx context.Context, request *connect.Request[walletv1.SetProcessingWalletsStrategyRequest]) (*connect.Response[walletv1.SetProcessingWalletsStrategyResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	strategy, err := convertProcessingWalletsStrategy(request.Msg.GetStrategy())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.bs.Wallets().Processing().SetStrategy(ctx, owner.ID, blockchain, strategy); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("set processing wallets strategy: %w", err))
+	}
+
+	return connect.NewResponse(new(walletv1.SetProcessingWalletsStrategyResponse)), nil
+}
+
+func (s *walletsServer) GetProcessingWalletsStrategy(ctx context.Context, request *connect.Request[walletv1.GetProcessingWalletsStrategyRequest]) (*connect.Response[walletv1.GetProcessingWalletsStrategyResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	strategy, err := s.bs.Wallets().Processing().GetStrategy(ctx, oid, blockchain)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get processing wallets strategy: %w", err))
+	}
+
+	res := &walletv1.GetProcessingWalletsStrategyResponse{}
+	switch strategy {
+	case constants.ProcessingWalletsStrategyLeastBusy:
+		res.Strategy = walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_LEAST_BUSY
+	case constants.ProcessingWalletsStrategyRoundRobin:
+		res.Strategy = walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_ROUND_ROBIN
+	case constants.ProcessingWalletsStrategyHighestBalance:
+		res.Strategy = walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_HIGHEST_BALANCE
+	}
+
+	return connect.NewResponse(res), nil
+}
+
 func (s *walletsServer) CreateOwnerHotWallet(ctx context.Context, request *connect.Request[walletv1.CreateOwnerHotWalletRequest]) (*connect.Response[walletv1.CreateOwnerHotWalletResponse], error) {
 	oid, err := uuid.Parse(request.Msg.GetOwnerId())
 	if err != nil {
@@ -238,6 +341,7 @@ func (s *walletsServer) CreateOwnerHotWallet(ctx context.Context, request *conne
 		ExternalWalletID: request.Msg.GetExternalWalletId(),
 		Mnemonic:         owner.Mnemonic,
 		Passphrase:       owner.PassPhrase.String,
+		Derivation:       owner.Derivation,
 		AddressType:      addressType,
 	})
 	if err != nil {
@@ -249,6 +353,96 @@ func (s *walletsServer) CreateOwnerHotWallet(ctx context.Context, request *conne
 	}), nil
 }
 
+// maxHotWalletsBatchSize limits the amount of hot wallets created by one request.
+const maxHotWalletsBatchSize = 1000
+
+func (s *walletsServer) CreateOwnerHotWallets(ctx context.Context, request *connect.Request[walletv1.CreateOwnerHotWalletsRequest]) (*connect.Response[walletv1.CreateOwnerHotWalletsResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	externalWalletIDs := lo.Uniq(request.Msg.GetExternalWalletIds())
+	if len(externalWalletIDs) == 0 || lo.Contains(externalWalletIDs, "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("external wallet ids undefined"))
+	}
+
+	if len(externalWalletIDs) > maxHotWalletsBatchSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("too many external wallet ids, max %d", maxHotWalletsBatchSize))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	owner, err := s.bs.Owners().GetByID(ctx, oid)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	addresses := make(map[string]string, len(externalWalletIDs))
+	notExists := make([]string, 0, len(externalWalletIDs))
+	for _, externalWalletID := range externalWalletIDs {
+		// find exists hot wallet
+		findExistsWallet, err := s.bs.Wallets().Hot().Find(ctx, wallets.FindHotWalletsParams{
+			OwnerID:          &owner.ID,
+			Blockchain:       &blockchain,
+			ExternalWalletID: &externalWalletID,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("find hot wallets: %w", err))
+		}
+
+		existsWallet, ok := lo.Find(findExistsWallet.Items, func(wallet *models.HotWallet) bool {
+			return !wallet.IsDirty && wallet.IsActive
+		})
+		if ok {
+			addresses[externalWalletID] = existsWallet.Address
+			continue
+		}
+
+		notExists = append(notExists, externalWalletID)
+	}
+
+	if len(notExists) > 0 {
+		var addressType string
+		switch blockchain {
+		case wconstants.BlockchainTypeBitcoin:
+			addressType = convertBitcoinWalletType(request.Msg.GetBitcoinAddressType())
+		case wconstants.BlockchainTypeLitecoin:
+			addressType = convertLitecoinWalletType(request.Msg.GetLitecoinAddressType())
+		}
+
+		newWallets, err := s.bs.Wallets().Hot().CreateMany(ctx, wallets.CreateHotWalletsParams{
+			Blockchain:        blockchain,
+			OwnerID:           owner.ID,
+			ExternalWalletIDs: notExists,
+			Mnemonic:          owner.Mnemonic,
+			Passphrase:        owner.PassPhrase.String,
+			Derivation:        owner.Derivation,
+			AddressType:       addressType,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("create hot wallets: %w", err))
+		}
+
+		for _, wallet := range newWallets {
+			addresses[wallet.ExternalWalletID] = wallet.Address
+		}
+	}
+
+	items := make([]*walletv1.CreateOwnerHotWalletsResponse_Item, 0, len(externalWalletIDs))
+	for _, externalWalletID := range externalWalletIDs {
+		items = append(items, &walletv1.CreateOwnerHotWalletsResponse_Item{
+			ExternalWalletId: externalWalletID,
+			Address:          addresses[externalWalletID],
+		})
+	}
+
+	return connect.NewResponse(&walletv1.CreateOwnerHotWalletsResponse{Items: items}), nil
+}
+
 func (s *walletsServer) MarkDirtyHotWallet(ctx context.Context, request *connect.Request[walletv1.MarkDirtyHotWalletRequest]) (*connect.Response[walletv1.MarkDirtyHotWalletResponse], error) {
 	oid, err := uuid.Parse(request.Msg.GetOwnerId())
 	if err != nil {
@@ -306,7 +500,7 @@ func (s *walletsServer) AttachOwnerColdWallets(ctx context.Context, request *con
 	}
 
 	if ok := s.bs.Owners().ValidateTwoFactorToken(ctx, owner.ID, otp); ok != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("validate otp: %w", ok))
+		return nil, connect.NewError(twoFactorErrorCode(ok, connect.CodeInvalidArgument), fmt.Errorf("validate otp: %w", ok))
 	}
 
 	batchParams := make([]wallets.CreateColdWalletParams, 0, len(request.Msg.GetAddresses()))
@@ -327,6 +521,208 @@ func (s *walletsServer) AttachOwnerColdWallets(ctx context.Context, request *con
 	return connect.NewResponse(new(walletv1.AttachOwnerColdWalletsResponse)), nil
 }
 
+func (s *walletsServer) DetachOwnerColdWallet(ctx context.Context, request *connect.Request[walletv1.DetachOwnerColdWalletRequest]) (*connect.Response[walletv1.DetachOwnerColdWalletResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.bs.Wallets().Cold().Detach(ctx, owner.ID, blockchain, request.Msg.GetAddress()); err != nil {
+		if errors.Is(err, storecmn.ErrNotFound) || errors.Is(err, storecmn.ErrEmptyAddress) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("detach cold wallet: %w", err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("detach cold wallet: %w", err))
+	}
+
+	return connect.NewResponse(new(walletv1.DetachOwnerColdWalletResponse)), nil
+}
+
+func (s *walletsServer) SetOwnerColdWalletLabel(ctx context.Context, request *connect.Request[walletv1.SetOwnerColdWalletLabelRequest]) (*connect.Response[walletv1.SetOwnerColdWalletLabelResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.bs.Wallets().Cold().SetLabel(ctx, wallets.SetColdWalletLabelParams{
+		OwnerID:    owner.ID,
+		Blockchain: blockchain,
+		Address:    request.Msg.GetAddress(),
+		Label:      request.Msg.Label,
+		Note:       request.Msg.Note,
+	})
+	if err != nil {
+		if errors.Is(err, storecmn.ErrNotFound) || errors.Is(err, storecmn.ErrEmptyAddress) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("set cold wallet label: %w", err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("set cold wallet label: %w", err))
+	}
+
+	return connect.NewResponse(&walletv1.SetOwnerColdWalletLabelResponse{
+		Item: convertColdWalletToPb(wallet),
+	}), nil
+}
+
+func (s *walletsServer) SetColdWalletsDistribution(ctx context.Context, request *connect.Request[walletv1.SetColdWalletsDistributionRequest]) (*connect.Response[walletv1.SetColdWalletsDistributionResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	strategy, err := convertColdWalletsStrategy(request.Msg.GetStrategy())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	items := make([]wallets.ColdWalletsDistributionItem, 0, len(request.Msg.GetItems()))
+	for _, item := range request.Msg.GetItems() {
+		distributionItem := wallets.ColdWalletsDistributionItem{
+			Address: item.GetAddress(),
+			Weight:  1,
+		}
+
+		if item.Weight != nil {
+			distributionItem.Weight = item.GetWeight()
+		}
+
+		if item.Cap != nil && item.GetCap() != "" {
+			c, err := decimal.NewFromString(item.GetCap())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid cap of %s", item.GetAddress()))
+			}
+
+			distributionItem.Cap = decimal.NullDecimal{
+				Decimal: c,
+				Valid:   true,
+			}
+		}
+
+		items = append(items, distributionItem)
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	distribution, err := s.bs.Wallets().Cold().SetDistribution(ctx, wallets.ColdWalletsDistribution{
+		OwnerID:    owner.ID,
+		Blockchain: blockchain,
+		Strategy:   strategy,
+		Items:      items,
+	})
+	if err != nil {
+		if errors.Is(err, wallets.ErrInvalidColdWalletsItem) || errors.Is(err, wallets.ErrColdWalletNotAttached) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("set cold wallets distribution: %w", err))
+	}
+
+	return connect.NewResponse(&walletv1.SetColdWalletsDistributionResponse{
+		Item: convertColdWalletsDistributionToPb(distribution),
+	}), nil
+}
+
+func (s *walletsServer) GetColdWalletsDistribution(ctx context.Context, request *connect.Request[walletv1.GetColdWalletsDistributionRequest]) (*connect.Response[walletv1.GetColdWalletsDistributionResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	distribution, err := s.bs.Wallets().Cold().GetDistribution(ctx, oid, blockchain)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get cold wallets distribution: %w", err))
+	}
+
+	return connect.NewResponse(&walletv1.GetColdWalletsDistributionResponse{
+		Item: convertColdWalletsDistributionToPb(distribution),
+	}), nil
+}
+
+func (s *walletsServer) SignMessage(ctx context.Context, request *connect.Request[walletv1.SignMessageRequest]) (*connect.Response[walletv1.SignMessageResponse], error) {
+	oid, err := uuid.Parse(request.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner id undefined: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	owner, err := checkOwnerOTP(ctx, s.bs, oid, request.Msg.GetTotp())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.bs.Wallets().SignMessage(ctx, wallets.SignMessageParams{
+		OwnerID:    owner.ID,
+		Blockchain: blockchain,
+		Address:    request.Msg.GetAddress(),
+		Message:    request.Msg.GetMessage(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, wallets.ErrAddressNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, wallets.ErrWalletCannotSign),
+			errors.Is(err, wallets.ErrInvalidMessageLength),
+			errors.Is(err, storecmn.ErrEmptyAddress):
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("sign message: %w", err))
+	}
+
+	return connect.NewResponse(&walletv1.SignMessageResponse{
+		Signature: signature,
+	}), nil
+}
+
+func (s *walletsServer) VerifyMessage(_ context.Context, request *connect.Request[walletv1.VerifyMessageRequest]) (*connect.Response[walletv1.VerifyMessageResponse], error) {
+	blockchain, err := models.ConvertBlockchainType(request.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	valid, err := s.bs.Wallets().VerifyMessage(blockchain, request.Msg.GetAddress(), request.Msg.GetMessage(), request.Msg.GetSignature())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("verify message: %w", err))
+	}
+
+	return connect.NewResponse(&walletv1.VerifyMessageResponse{
+		Valid: valid,
+	}), nil
+}
+
 func (s *walletsServer) walletBlockchainAdditionalData(ctx context.Context, address string, blockchain wconstants.BlockchainType) (*walletv1.BlockchainAdditionalData, error) {
 	addData := &walletv1.BlockchainAdditionalData{}
 
@@ -407,3 +803,81 @@ func convertLitecoinWalletType(wt commonv1.LitecoinAddressType) string {
 		return string(ltc.AddressTypeP2TR)
 	}
 }
+
+func convertColdWalletsStrategy(strategy walletv1.ColdWalletsStrategy) (constants.ColdWalletsStrategy, error) {
+	switch strategy {
+	case walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_ROUND_ROBIN:
+		return constants.ColdWalletsStrategyRoundRobin, nil
+	case walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_WEIGHTED:
+		return constants.ColdWalletsStrategyWeighted, nil
+	case walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_CAP:
+		return constants.ColdWalletsStrategyCap, nil
+	default:
+		return "", fmt.Errorf("undefined cold wallets strategy: %s", strategy)
+	}
+}
+
+func convertProcessingWalletsStrategy(strategy walletv1.ProcessingWalletsStrategy) (constants.ProcessingWalletsStrategy, error) {
+	switch strategy {
+	case walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_LEAST_BUSY:
+		return constants.ProcessingWalletsStrategyLeastBusy, nil
+	case walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_ROUND_ROBIN:
+		return constants.ProcessingWalletsStrategyRoundRobin, nil
+	case walletv1.ProcessingWalletsStrategy_PROCESSING_WALLETS_STRATEGY_HIGHEST_BALANCE:
+		return constants.ProcessingWalletsStrategyHighestBalance, nil
+	default:
+		return "", fmt.Errorf("undefined processing wallets strategy: %s", strategy)
+	}
+}
+
+func convertColdWalletsDistributionToPb(distribution *wallets.ColdWalletsDistribution) *walletv1.ColdWalletsDistribution {
+	res := &walletv1.ColdWalletsDistribution{
+		Blockchain: models.ConvertBlockchainTypeToPb(distribution.Blockchain),
+		Items:      make([]*walletv1.ColdWalletsDistributionItem, 0, len(distribution.Items)),
+	}
+
+	switch distribution.Strategy {
+	case constants.ColdWalletsStrategyRoundRobin:
+		res.Strategy = walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_ROUND_ROBIN
+	case constants.ColdWalletsStrategyWeighted:
+		res.Strategy = walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_WEIGHTED
+	case constants.ColdWalletsStrategyCap:
+		res.Strategy = walletv1.ColdWalletsStrategy_COLD_WALLETS_STRATEGY_CAP
+	}
+
+	for _, item := range distribution.Items {
+		pbItem := &walletv1.ColdWalletsDistributionItem{
+			Address: item.Address,
+			Weight:  &item.Weight,
+		}
+
+		if item.Cap.Valid {
+			pbItem.Cap = lo.ToPtr(item.Cap.Decimal.String())
+		}
+
+		res.Items = append(res.Items, pbItem)
+	}
+
+	return res
+}
+
+func convertColdWalletToPb(wallet *models.ColdWallet) *walletv1.WalletPreview {
+	res := &walletv1.WalletPreview{
+		Address:    wallet.Address,
+		Blockchain: models.ConvertBlockchainTypeToPb(wallet.Blockchain),
+	}
+
+	if wallet.Label.Valid {
+		res.Label = &wallet.Label.String
+	}
+
+	if wallet.Note.Valid {
+		res.Note = &wallet.Note.String
+	}
+
+	if wallet.DetachedAt.Valid {
+		res.DetachedAt = timestamppb.New(wallet.DetachedAt.Time)
+	}
+
+	return res
+}
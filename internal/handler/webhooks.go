@@ -0,0 +1,464 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	commonv1 "github.com/dv-net/dv-processing/api/processing/common/v1"
+	webhookv1 "github.com/dv-net/dv-processing/api/processing/webhook/v1"
+	"github.com/dv-net/dv-processing/api/processing/webhook/v1/webhookv1connect"
+	"github.com/dv-net/dv-processing/internal/interceptors"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/baseservices"
+	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhooks"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxWebhooksPageSize is the max page size of the webhooks list
+const maxWebhooksPageSize = 1000
+
+type webhooksServer struct {
+	bs baseservices.IBaseServices
+
+	webhookv1connect.UnimplementedWebhookServiceHandler
+}
+
+func newWebhooksServer(
+	bs baseservices.IBaseServices,
+) *webhooksServer {
+	return &webhooksServer{
+		bs: bs,
+	}
+}
+
+func (s webhooksServer) Name() string { return "webhooks-server" }
+
+func (s *webhooksServer) RegisterHandler(opts ...connect.HandlerOption) (string, http.Handler) {
+	return webhookv1connect.NewWebhookServiceHandler(s, opts...)
+}
+
+// List - get the webhooks of the request client
+func (s *webhooksServer) List(ctx context.Context, request *connect.Request[webhookv1.ListRequest]) (*connect.Response[webhookv1.ListResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := convertWebhookFilter(request.Msg.GetFilter())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if request.Msg.Page != nil {
+		params.Page = lo.ToPtr(uint64(request.Msg.GetPage()))
+	}
+
+	if request.Msg.PageSize != nil {
+		if request.Msg.GetPageSize() == 0 || request.Msg.GetPageSize() > maxWebhooksPageSize {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("page size must be between 1 and %d", maxWebhooksPageSize))
+		}
+		params.PageSize = lo.ToPtr(uint64(request.Msg.GetPageSize()))
+	}
+
+	res, err := s.bs.Webhooks().List(ctx, cid, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list webhooks: %w", err))
+	}
+
+	response := &webhookv1.ListResponse{
+		Items:          make([]*webhookv1.Webhook, 0, len(res.Items)),
+		NextPageExists: res.IsNextPageExists,
+	}
+
+	for _, item := range res.Items {
+		response.Items = append(response.Items, convertWebhook(item))
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// Get - get the webhook of the request client with the payload and the last response
+func (s *webhooksServer) Get(ctx context.Context, request *connect.Request[webhookv1.GetRequest]) (*connect.Response[webhookv1.GetResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(request.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("webhook id undefined: %w", err))
+	}
+
+	webhook, err := s.bs.Webhooks().Get(ctx, cid, id)
+	if err != nil {
+		if errors.Is(err, storecmn.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get webhook: %w", err))
+	}
+
+	deliveries, err := s.bs.Webhooks().GetDeliveries(ctx, webhook.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get webhook deliveries: %w", err))
+	}
+
+	response := &webhookv1.GetResponse{
+		Webhook:    convertWebhook(webhook),
+		Payload:    string(webhook.Payload),
+		Deliveries: make([]*webhookv1.Delivery, 0, len(deliveries)),
+	}
+
+	if webhook.Response.Valid {
+		response.Response = &webhook.Response.String
+	}
+
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDelivery(delivery))
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// Redeliver - reset the webhook or the filtered webhooks of the request client to be sent again
+func (s *webhooksServer) Redeliver(ctx context.Context, request *connect.Request[webhookv1.RedeliverRequest]) (*connect.Response[webhookv1.RedeliverResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	var params repo_webhooks.RedeliverParams
+	if request.Msg.Id != nil {
+		id, err := uuid.Parse(request.Msg.GetId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("webhook id undefined: %w", err))
+		}
+		params.ID = &id
+	} else {
+		params.FindParams, err = convertWebhookFilter(request.Msg.GetFilter())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	if request.Msg.EndpointId != nil {
+		endpointID, err := uuid.Parse(request.Msg.GetEndpointId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("endpoint id undefined: %w", err))
+		}
+		params.EndpointID = &endpointID
+	}
+
+	count, err := s.bs.Webhooks().Redeliver(ctx, cid, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhooks.ErrEmptyRedeliverFilter):
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		case errors.Is(err, storecmn.ErrNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("redeliver webhooks: %w", err))
+	}
+
+	return connect.NewResponse(&webhookv1.RedeliverResponse{
+		Count: uint64(count), //nolint:gosec
+	}), nil
+}
+
+// ListEndpoints - get the webhook endpoints of the request client
+func (s *webhooksServer) ListEndpoints(ctx context.Context, request *connect.Request[webhookv1.ListEndpointsRequest]) (*connect.Response[webhookv1.ListEndpointsResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.bs.Webhooks().GetEndpoints(ctx, cid)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get webhook endpoints: %w", err))
+	}
+
+	response := &webhookv1.ListEndpointsResponse{
+		Items: make([]*webhookv1.Endpoint, 0, len(endpoints)),
+	}
+
+	for _, endpoint := range endpoints {
+		response.Items = append(response.Items, convertWebhookEndpoint(endpoint))
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// CreateEndpoint - create the webhook endpoint of the request client
+func (s *webhooksServer) CreateEndpoint(ctx context.Context, request *connect.Request[webhookv1.CreateEndpointRequest]) (*connect.Response[webhookv1.CreateEndpointResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	dto, err := convertWebhookEndpointDTO(request.Msg.GetUrl(), true, request.Msg.GetKinds(), request.Msg.GetBlockchains(), request.Msg.GetOwnerIds())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	endpoint, err := s.bs.Webhooks().CreateEndpoint(ctx, cid, dto)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrInvalidEndpoint) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("create webhook endpoint: %w", err))
+	}
+
+	return connect.NewResponse(&webhookv1.CreateEndpointResponse{
+		Endpoint:  convertWebhookEndpoint(endpoint),
+		SecretKey: endpoint.SecretKey.String,
+	}), nil
+}
+
+// UpdateEndpoint - replace the settings of the webhook endpoint of the request client
+func (s *webhooksServer) UpdateEndpoint(ctx context.Context, request *connect.Request[webhookv1.UpdateEndpointRequest]) (*connect.Response[webhookv1.UpdateEndpointResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(request.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("endpoint id undefined: %w", err))
+	}
+
+	dto, err := convertWebhookEndpointDTO(request.Msg.GetUrl(), request.Msg.GetEnabled(), request.Msg.GetKinds(), request.Msg.GetBlockchains(), request.Msg.GetOwnerIds())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	endpoint, err := s.bs.Webhooks().UpdateEndpoint(ctx, cid, id, dto)
+	if err != nil {
+		return nil, connect.NewError(webhookEndpointErrorCode(err), fmt.Errorf("update webhook endpoint: %w", err))
+	}
+
+	return connect.NewResponse(&webhookv1.UpdateEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+	}), nil
+}
+
+// DeleteEndpoint - delete the webhook endpoint of the request client
+func (s *webhooksServer) DeleteEndpoint(ctx context.Context, request *connect.Request[webhookv1.DeleteEndpointRequest]) (*connect.Response[webhookv1.DeleteEndpointResponse], error) {
+	cid, err := webhookClientID(request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(request.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("endpoint id undefined: %w", err))
+	}
+
+	if err := s.bs.Webhooks().DeleteEndpoint(ctx, cid, id); err != nil {
+		return nil, connect.NewError(webhookEndpointErrorCode(err), fmt.Errorf("delete webhook endpoint: %w", err))
+	}
+
+	return connect.NewResponse(new(webhookv1.DeleteEndpointResponse)), nil
+}
+
+// webhookEndpointErrorCode returns the code of the webhook endpoint error
+func webhookEndpointErrorCode(err error) connect.Code {
+	switch {
+	case errors.Is(err, storecmn.ErrNotFound):
+		return connect.CodeNotFound
+	case errors.Is(err, webhooks.ErrInvalidEndpoint):
+		return connect.CodeInvalidArgument
+	case errors.Is(err, webhooks.ErrDefaultEndpoint):
+		return connect.CodeFailedPrecondition
+	default:
+		return connect.CodeInternal
+	}
+}
+
+// webhookClientID returns the client the request is signed by
+func webhookClientID(header http.Header) (uuid.UUID, error) {
+	cid, err := uuid.Parse(header.Get(interceptors.ClientIDHeaderName))
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("request client undefined: %w", err))
+	}
+
+	return cid, nil
+}
+
+func convertWebhookFilter(filter *webhookv1.Filter) (repo_webhooks.FindParams, error) {
+	var params repo_webhooks.FindParams
+	if filter == nil {
+		return params, nil
+	}
+
+	if filter.Kind != nil {
+		kind := models.WebhookKind(filter.GetKind())
+		if !kind.Valid() {
+			return params, fmt.Errorf("undefined webhook kind: %s", filter.GetKind())
+		}
+		params.Kind = &kind
+	}
+
+	if filter.GetStatus() != webhookv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED {
+		status, err := convertWebhookStatusFromPb(filter.GetStatus())
+		if err != nil {
+			return params, err
+		}
+		params.Status = &status
+	}
+
+	if filter.From != nil {
+		params.From = lo.ToPtr(filter.GetFrom().AsTime())
+	}
+
+	if filter.To != nil {
+		params.To = lo.ToPtr(filter.GetTo().AsTime())
+	}
+
+	if filter.TxHash != nil {
+		params.TxHash = filter.TxHash
+	}
+
+	if filter.RequestId != nil {
+		params.RequestID = filter.RequestId
+	}
+
+	return params, nil
+}
+
+func convertWebhookStatusFromPb(status webhookv1.WebhookStatus) (models.WebhookStatus, error) {
+	switch status {
+	case webhookv1.WebhookStatus_WEBHOOK_STATUS_NEW:
+		return models.WebhookStatusNew, nil
+	case webhookv1.WebhookStatus_WEBHOOK_STATUS_SENT:
+		return models.WebhookStatusSent, nil
+	case webhookv1.WebhookStatus_WEBHOOK_STATUS_FAILED:
+		return models.WebhookStatusFailed, nil
+	default:
+		return "", fmt.Errorf("undefined webhook status: %s", status)
+	}
+}
+
+func convertWebhookStatusToPb(status models.WebhookStatus) webhookv1.WebhookStatus {
+	switch status {
+	case models.WebhookStatusNew:
+		return webhookv1.WebhookStatus_WEBHOOK_STATUS_NEW
+	case models.WebhookStatusSent:
+		return webhookv1.WebhookStatus_WEBHOOK_STATUS_SENT
+	case models.WebhookStatusFailed:
+		return webhookv1.WebhookStatus_WEBHOOK_STATUS_FAILED
+	default:
+		return webhookv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+	}
+}
+
+func convertWebhook(webhook *models.Webhook) *webhookv1.Webhook {
+	item := &webhookv1.Webhook{
+		Id:        webhook.ID.String(),
+		Kind:      webhook.Kind.String(),
+		Status:    convertWebhookStatusToPb(webhook.Status),
+		Attempts:  uint32(webhook.Attempts), //nolint:gosec
+		CreatedAt: timestamppb.New(webhook.CreatedAt.Time),
+	}
+
+	if webhook.SentAt.Valid {
+		item.SentAt = timestamppb.New(webhook.SentAt.Time)
+	}
+
+	if webhook.UpdatedAt.Valid {
+		item.UpdatedAt = timestamppb.New(webhook.UpdatedAt.Time)
+	}
+
+	if webhook.NextAttemptAt.Valid {
+		item.NextAttemptAt = timestamppb.New(webhook.NextAttemptAt.Time)
+	}
+
+	return item
+}
+
+func convertWebhookDelivery(delivery *models.WebhookDelivery) *webhookv1.Delivery {
+	item := &webhookv1.Delivery{
+		EndpointId: delivery.EndpointID.String(),
+		Status:     convertWebhookStatusToPb(delivery.Status),
+		Attempts:   uint32(delivery.Attempts), //nolint:gosec
+	}
+
+	if delivery.Response.Valid {
+		item.Response = &delivery.Response.String
+	}
+
+	if delivery.SentAt.Valid {
+		item.SentAt = timestamppb.New(delivery.SentAt.Time)
+	}
+
+	if delivery.NextAttemptAt.Valid {
+		item.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt.Time)
+	}
+
+	return item
+}
+
+func convertWebhookEndpoint(endpoint *models.WebhookEndpoint) *webhookv1.Endpoint {
+	item := &webhookv1.Endpoint{
+		Id:          endpoint.ID.String(),
+		IsDefault:   endpoint.IsDefault,
+		Url:         endpoint.Url.String,
+		Enabled:     endpoint.Enabled,
+		Kinds:       endpoint.Kinds,
+		Blockchains: make([]commonv1.Blockchain, 0, len(endpoint.Blockchains)),
+		OwnerIds:    make([]string, 0, len(endpoint.OwnerIds)),
+		CreatedAt:   timestamppb.New(endpoint.CreatedAt.Time),
+	}
+
+	for _, blockchain := range endpoint.Blockchains {
+		item.Blockchains = append(item.Blockchains, models.ConvertBlockchainTypeToPb(wconstants.BlockchainType(blockchain)))
+	}
+
+	for _, ownerID := range endpoint.OwnerIds {
+		item.OwnerIds = append(item.OwnerIds, ownerID.String())
+	}
+
+	if endpoint.UpdatedAt.Valid {
+		item.UpdatedAt = timestamppb.New(endpoint.UpdatedAt.Time)
+	}
+
+	return item
+}
+
+func convertWebhookEndpointDTO(endpointURL string, enabled bool, kinds []string, blockchains []commonv1.Blockchain, ownerIDs []string) (webhooks.EndpointDTO, error) {
+	dto := webhooks.EndpointDTO{
+		URL:         endpointURL,
+		Enabled:     enabled,
+		Kinds:       make([]models.WebhookKind, 0, len(kinds)),
+		Blockchains: make([]wconstants.BlockchainType, 0, len(blockchains)),
+		OwnerIDs:    make([]uuid.UUID, 0, len(ownerIDs)),
+	}
+
+	for _, kind := range kinds {
+		dto.Kinds = append(dto.Kinds, models.WebhookKind(kind))
+	}
+
+	for _, item := range blockchains {
+		blockchain, err := models.ConvertBlockchainType(item)
+		if err != nil {
+			return dto, err
+		}
+		dto.Blockchains = append(dto.Blockchains, blockchain)
+	}
+
+	for _, item := range ownerIDs {
+		ownerID, err := uuid.Parse(item)
+		if err != nil {
+			return dto, fmt.Errorf("invalid owner id %s: %w", item, err)
+		}
+		dto.OwnerIDs = append(dto.OwnerIDs, ownerID)
+	}
+
+	return dto, nil
+}
@@ -0,0 +1,67 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/dv-net/dv-processing/internal/services/clients"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+)
+
+// AllowlistInterceptor rejects the requests of the clients from the ip addresses out of the client allowlist.
+//
+// It must be placed after the SignInterceptor. The ip address is taken from the connection,
+// so the proxy in front of the processing must keep the client address. The requests
+// without the client and of the clients without the allowlist are passed.
+type AllowlistInterceptor struct {
+	clientService *clients.Service
+}
+
+func NewAllowlistInterceptor(clientService *clients.Service) *AllowlistInterceptor {
+	return &AllowlistInterceptor{clientService: clientService}
+}
+
+// WrapUnary wraps the unary function
+func (i *AllowlistInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		clientID, err := uuid.Parse(req.Header().Get(ClientIDHeaderName))
+		if err != nil {
+			return next(ctx, req)
+		}
+
+		client, err := i.clientService.GetByID(ctx, clientID)
+		if err != nil {
+			if errors.Is(err, storecmn.ErrNotFound) {
+				return next(ctx, req)
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get client: %w", err))
+		}
+
+		if ip := requestSource(req).IP; !clients.AllowsIP(client, ip) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("requests from %s are not allowed for the client", ip))
+		}
+
+		return next(ctx, req)
+	})
+}
+
+// WrapStreamingClient wraps the streaming client function
+func (*AllowlistInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler wraps the streaming handler function
+func (*AllowlistInterceptor) WrapStreamingHandler(_ connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		_ context.Context,
+		_ connect.StreamingHandlerConn,
+	) error {
+		return fmt.Errorf("streaming is not supported")
+	})
+}
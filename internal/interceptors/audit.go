@@ -0,0 +1,145 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"connectrpc.com/connect"
+	"github.com/dv-net/dv-processing/api/processing/client/v1/clientv1connect"
+	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
+	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
+	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/api/processing/webhook/v1/webhookv1connect"
+	"github.com/dv-net/dv-processing/internal/services/audit"
+	"github.com/dv-net/mx/logger"
+	"github.com/google/uuid"
+)
+
+// auditedProcedures are the procedures recorded to the audit log
+var auditedProcedures = map[string]struct{}{
+	ownerv1connect.OwnerServiceGetSeedsProcedure:                 {},
+	ownerv1connect.OwnerServiceGetPrivateKeysProcedure:           {},
+	ownerv1connect.OwnerServiceGetHotWalletKeysProcedure:         {},
+	ownerv1connect.OwnerServiceConfirmTwoFactorAuthProcedure:     {},
+	ownerv1connect.OwnerServiceDisableTwoFactorAuthProcedure:     {},
+	ownerv1connect.OwnerServiceGetTwoFactorAuthDataProcedure:     {},
+	ownerv1connect.OwnerServiceValidateTwoFactorTokenProcedure:   {},
+	ownerv1connect.OwnerServiceRegenerateRecoveryCodesProcedure:  {},
+	walletv1connect.WalletServiceAttachOwnerColdWalletsProcedure: {},
+	walletv1connect.WalletServiceDetachOwnerColdWalletProcedure:  {},
+	transferv1connect.TransferServiceCreateProcedure:             {},
+	clientv1connect.ClientServiceRotateSecretProcedure:           {},
+	clientv1connect.ClientServiceRevokeSecretProcedure:           {},
+	clientv1connect.ClientServiceCreateAPIKeyProcedure:           {},
+	clientv1connect.ClientServiceUpdateAllowedIPsProcedure:       {},
+	webhookv1connect.WebhookServiceRedeliverProcedure:            {},
+	webhookv1connect.WebhookServiceCreateEndpointProcedure:       {},
+	webhookv1connect.WebhookServiceUpdateEndpointProcedure:       {},
+	webhookv1connect.WebhookServiceDeleteEndpointProcedure:       {},
+}
+
+// AuditInterceptor records the calls of the sensitive procedures to the audit log.
+//
+// It must be placed before the SignInterceptor to record the rejected requests too. The client
+// is recorded only if the SignInterceptor authenticated the request, the client id header of the
+// rejected requests is not trusted. The response of the successful call is not returned if the
+// event can not be recorded. The source of all requests is added to the context for the events
+// recorded by the services.
+type AuditInterceptor struct {
+	logger   logger.Logger
+	auditSvc *audit.Service
+}
+
+func NewAuditInterceptor(l logger.Logger, auditSvc *audit.Service) *AuditInterceptor {
+	return &AuditInterceptor{
+		logger:   logger.With(l, "service", "audit-interceptor"),
+		auditSvc: auditSvc,
+	}
+}
+
+// WrapUnary wraps the unary function
+func (i *AuditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		ctx = audit.WithSource(ctx, requestSource(req))
+
+		if _, ok := auditedProcedures[req.Spec().Procedure]; !ok {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+
+		// the client is set by the SignInterceptor after the request is authenticated
+		source := audit.SourceFromContext(ctx)
+
+		event := audit.Event{
+			ClientID:    source.ClientID,
+			Procedure:   req.Spec().Procedure,
+			SourceIP:    source.IP,
+			Result:      audit.ResultSuccess,
+			Fingerprint: requestFingerprint(req),
+		}
+
+		if msg, ok := req.Any().(interface{ GetOwnerId() string }); ok {
+			if ownerID, parseErr := uuid.Parse(msg.GetOwnerId()); parseErr == nil {
+				event.OwnerID = uuid.NullUUID{UUID: ownerID, Valid: true}
+			}
+		}
+
+		if err != nil {
+			event.Result = connect.CodeOf(err).String()
+		}
+
+		if _, auditErr := i.auditSvc.Record(ctx, event); auditErr != nil {
+			if err != nil {
+				i.logger.Errorw("record audit event", "procedure", event.Procedure, "error", auditErr)
+				return nil, err
+			}
+
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("record audit event: %w", auditErr))
+		}
+
+		return resp, err
+	})
+}
+
+// WrapStreamingClient wraps the streaming client function
+func (*AuditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler wraps the streaming handler function
+func (*AuditInterceptor) WrapStreamingHandler(_ connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		_ context.Context,
+		_ connect.StreamingHandlerConn,
+	) error {
+		return fmt.Errorf("streaming is not supported")
+	})
+}
+
+// requestSource returns the ip address of the request, the client is not authenticated yet
+func requestSource(req connect.AnyRequest) audit.Source {
+	var source audit.Source
+
+	source.IP = req.Peer().Addr
+	if host, _, err := net.SplitHostPort(source.IP); err == nil {
+		source.IP = host
+	}
+
+	return source
+}
+
+// requestFingerprint returns the hash of the procedure and the request payload
+func requestFingerprint(req connect.AnyRequest) string {
+	payload, err := json.Marshal(req.Any())
+	if err != nil {
+		return ""
+	}
+
+	return audit.Fingerprint(req.Spec().Procedure, payload)
+}
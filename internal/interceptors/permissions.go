@@ -0,0 +1,154 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/dv-net/dv-processing/api/processing/client/v1/clientv1connect"
+	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
+	"github.com/dv-net/dv-processing/api/processing/system/v1/systemv1connect"
+	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
+	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/api/processing/webhook/v1/webhookv1connect"
+	"github.com/dv-net/dv-processing/internal/constants"
+)
+
+type permissionsCtxKey struct{}
+
+// withPermissions returns the context with the permissions of the authenticated request
+func withPermissions(ctx context.Context, permissions []string) context.Context {
+	return context.WithValue(ctx, permissionsCtxKey{}, permissions)
+}
+
+// PermissionsFromContext returns the permissions of the api key or the certificate which authenticated the request
+func PermissionsFromContext(ctx context.Context) ([]string, bool) {
+	permissions, ok := ctx.Value(permissionsCtxKey{}).([]string)
+	return permissions, ok
+}
+
+// procedurePermissions maps the procedures to the permissions required to call them
+var procedurePermissions = map[string]constants.Permission{
+	// client service
+	clientv1connect.ClientServiceUpdateCallbackURLProcedure:  constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceGetCallbackURLProcedure:     constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceUpdateSignSettingsProcedure: constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceRotateSecretProcedure:       constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceRevokeSecretProcedure:       constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceCreateAPIKeyProcedure:       constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceGetSecretsProcedure:         constants.PermissionSystemAdmin,
+	clientv1connect.ClientServiceUpdateAllowedIPsProcedure:   constants.PermissionSystemAdmin,
+
+	// owner service
+	ownerv1connect.OwnerServiceCreateProcedure:                  constants.PermissionOwnersCreate,
+	ownerv1connect.OwnerServiceGetSeedsProcedure:                constants.PermissionKeysExport,
+	ownerv1connect.OwnerServiceGetPrivateKeysProcedure:          constants.PermissionKeysExport,
+	ownerv1connect.OwnerServiceGetHotWalletKeysProcedure:        constants.PermissionKeysExport,
+	ownerv1connect.OwnerServiceConfirmTwoFactorAuthProcedure:    constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceDisableTwoFactorAuthProcedure:    constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceGetTwoFactorAuthDataProcedure:    constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceValidateTwoFactorTokenProcedure:  constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceRegenerateRecoveryCodesProcedure: constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceStartMnemonicRotationProcedure:   constants.PermissionOwnersManage,
+	ownerv1connect.OwnerServiceGetMnemonicRotationProcedure:     constants.PermissionOwnersManage,
+
+	// system service
+	systemv1connect.SystemServiceInfoProcedure:               constants.PermissionSystemAdmin,
+	systemv1connect.SystemServiceCheckNewVersionProcedure:    constants.PermissionSystemAdmin,
+	systemv1connect.SystemServiceUpdateToNewVersionProcedure: constants.PermissionSystemAdmin,
+	systemv1connect.SystemServiceGetLastLogsProcedure:        constants.PermissionSystemAdmin,
+	systemv1connect.SystemServiceGetAuditEventsProcedure:     constants.PermissionSystemAdmin,
+
+	// transfer service
+	transferv1connect.TransferServiceCreateProcedure:          constants.PermissionTransfersCreate,
+	transferv1connect.TransferServiceGetByRequestIDProcedure:  constants.PermissionTransfersRead,
+	transferv1connect.TransferServiceSetSweepRuleProcedure:    constants.PermissionTransfersCreate,
+	transferv1connect.TransferServiceGetSweepRulesProcedure:   constants.PermissionTransfersRead,
+	transferv1connect.TransferServiceDeleteSweepRuleProcedure: constants.PermissionTransfersCreate,
+
+	// wallet service
+	walletv1connect.WalletServiceGetOwnerHotWalletsProcedure:           constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceGetOwnerColdWalletsProcedure:          constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceGetOwnerProcessingWalletsProcedure:    constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceGetProcessingWalletsStrategyProcedure: constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceGetColdWalletsDistributionProcedure:   constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceVerifyMessageProcedure:                constants.PermissionWalletsRead,
+	walletv1connect.WalletServiceCreateOwnerProcessingWalletsProcedure: constants.PermissionWalletsCreate,
+	walletv1connect.WalletServiceCreateOwnerHotWalletProcedure:         constants.PermissionWalletsCreate,
+	walletv1connect.WalletServiceCreateOwnerHotWalletsProcedure:        constants.PermissionWalletsCreate,
+	walletv1connect.WalletServiceMarkDirtyHotWalletProcedure:           constants.PermissionWalletsCreate,
+	walletv1connect.WalletServiceSetProcessingWalletsStrategyProcedure: constants.PermissionWalletsManage,
+	walletv1connect.WalletServiceAttachOwnerColdWalletsProcedure:       constants.PermissionWalletsManage,
+	walletv1connect.WalletServiceDetachOwnerColdWalletProcedure:        constants.PermissionWalletsManage,
+	walletv1connect.WalletServiceSetOwnerColdWalletLabelProcedure:      constants.PermissionWalletsManage,
+	walletv1connect.WalletServiceSetColdWalletsDistributionProcedure:   constants.PermissionWalletsManage,
+	walletv1connect.WalletServiceSignMessageProcedure:                  constants.PermissionWalletsManage,
+
+	// webhook service
+	webhookv1connect.WebhookServiceListProcedure:           constants.PermissionWebhooksRead,
+	webhookv1connect.WebhookServiceGetProcedure:            constants.PermissionWebhooksRead,
+	webhookv1connect.WebhookServiceRedeliverProcedure:      constants.PermissionWebhooksManage,
+	webhookv1connect.WebhookServiceListEndpointsProcedure:  constants.PermissionWebhooksRead,
+	webhookv1connect.WebhookServiceCreateEndpointProcedure: constants.PermissionWebhooksManage,
+	webhookv1connect.WebhookServiceUpdateEndpointProcedure: constants.PermissionWebhooksManage,
+	webhookv1connect.WebhookServiceDeleteEndpointProcedure: constants.PermissionWebhooksManage,
+}
+
+// PermissionInterceptor checks the permissions of the api key or the certificate which authenticated the request.
+//
+// It must be placed after the SignInterceptor. The procedures without the required permission
+// are denied. The requests without the permissions are passed, it happens when the
+// checking of the sign is disabled or for the client creation.
+type PermissionInterceptor struct{}
+
+func NewPermissionInterceptor() *PermissionInterceptor { return new(PermissionInterceptor) }
+
+// WrapUnary wraps the unary function
+func (i *PermissionInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		permissions, ok := PermissionsFromContext(ctx)
+		if !ok {
+			return next(ctx, req)
+		}
+
+		if err := checkPermission(req.Spec().Procedure, permissions); err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+
+		return next(ctx, req)
+	})
+}
+
+// WrapStreamingClient wraps the streaming client function
+func (*PermissionInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler wraps the streaming handler function
+func (*PermissionInterceptor) WrapStreamingHandler(_ connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		_ context.Context,
+		_ connect.StreamingHandlerConn,
+	) error {
+		return fmt.Errorf("streaming is not supported")
+	})
+}
+
+// checkPermission checks that the permissions allow to call the procedure
+func checkPermission(procedure string, permissions []string) error {
+	required, ok := procedurePermissions[procedure]
+	if !ok {
+		return fmt.Errorf("procedure %s is not allowed", procedure)
+	}
+
+	if slices.Contains(permissions, constants.PermissionAll.String()) ||
+		slices.Contains(permissions, required.String()) {
+		return nil
+	}
+
+	return fmt.Errorf("api key has no %s permission", required)
+}
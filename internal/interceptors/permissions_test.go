@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"testing"
+
+	clientv1 "github.com/dv-net/dv-processing/api/processing/client/v1"
+	"github.com/dv-net/dv-processing/api/processing/client/v1/clientv1connect"
+	ownerv1 "github.com/dv-net/dv-processing/api/processing/owner/v1"
+	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
+	systemv1 "github.com/dv-net/dv-processing/api/processing/system/v1"
+	transferv1 "github.com/dv-net/dv-processing/api/processing/transfer/v1"
+	"github.com/dv-net/dv-processing/api/processing/transfer/v1/transferv1connect"
+	walletv1 "github.com/dv-net/dv-processing/api/processing/wallet/v1"
+	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	webhookv1 "github.com/dv-net/dv-processing/api/processing/webhook/v1"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestProcedurePermissions(t *testing.T) {
+	files := []protoreflect.FileDescriptor{
+		clientv1.File_processing_client_v1_client_proto,
+		ownerv1.File_processing_owner_v1_owner_proto,
+		systemv1.File_processing_system_v1_system_proto,
+		transferv1.File_processing_transfer_v1_transfer_proto,
+		walletv1.File_processing_wallet_v1_wallets_proto,
+		webhookv1.File_processing_webhook_v1_webhook_proto,
+	}
+
+	for _, file := range files {
+		for i := range file.Services().Len() {
+			service := file.Services().Get(i)
+			for j := range service.Methods().Len() {
+				procedure := "/" + string(service.FullName()) + "/" + string(service.Methods().Get(j).Name())
+				if procedure == clientv1connect.ClientServiceCreateProcedure {
+					continue
+				}
+
+				permission, ok := procedurePermissions[procedure]
+				require.True(t, ok, "procedure %s has no permission", procedure)
+				require.True(t, permission.Valid())
+			}
+		}
+	}
+}
+
+func TestCheckPermission(t *testing.T) {
+	storefront := []string{constants.PermissionWalletsCreate.String()}
+
+	require.NoError(t, checkPermission(ownerv1connect.OwnerServiceGetSeedsProcedure, []string{constants.PermissionAll.String()}))
+	require.NoError(t, checkPermission(ownerv1connect.OwnerServiceGetSeedsProcedure, []string{constants.PermissionKeysExport.String()}))
+	require.Error(t, checkPermission(ownerv1connect.OwnerServiceGetSeedsProcedure, storefront))
+	require.Error(t, checkPermission(transferv1connect.TransferServiceCreateProcedure, storefront))
+	require.NoError(t, checkPermission(walletv1connect.WalletServiceCreateOwnerHotWalletProcedure, storefront))
+	require.Error(t, checkPermission("/processing.unknown.v1.UnknownService/Call", []string{constants.PermissionAll.String()}))
+	require.Error(t, checkPermission(clientv1connect.ClientServiceCreateAPIKeyProcedure, nil))
+}
@@ -0,0 +1,159 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/mx/logger"
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// rateLimiterTTL is the time the limiter of the unused client and procedure is kept
+const rateLimiterTTL = 30 * time.Minute
+
+// defaultProcedureRateLimits are the limits of the procedures which can be brute forced
+var defaultProcedureRateLimits = map[string]rateLimit{
+	ownerv1connect.OwnerServiceValidateTwoFactorTokenProcedure:  {rate: 0.1, burst: 5},
+	ownerv1connect.OwnerServiceConfirmTwoFactorAuthProcedure:    {rate: 0.1, burst: 5},
+	ownerv1connect.OwnerServiceDisableTwoFactorAuthProcedure:    {rate: 0.1, burst: 5},
+	ownerv1connect.OwnerServiceRegenerateRecoveryCodesProcedure: {rate: 0.1, burst: 5},
+}
+
+type rateLimit struct {
+	rate  rate.Limit
+	burst int
+}
+
+// RateLimitInterceptor limits the rate of the requests per client and procedure with the token bucket.
+//
+// It must be placed after the SignInterceptor to count the requests of the authenticated clients only.
+// The requests without the client are limited per ip address. The rejected requests get the
+// resource exhausted code with the Retry-After header and the retry info detail.
+type RateLimitInterceptor struct {
+	logger     logger.Logger
+	enabled    bool
+	limit      rateLimit
+	procedures map[string]rateLimit
+	limiters   *ttlcache.Cache[string, *rate.Limiter]
+}
+
+func NewRateLimitInterceptor(l logger.Logger, conf config.RateLimit) *RateLimitInterceptor {
+	procedures := make(map[string]rateLimit, len(defaultProcedureRateLimits)+len(conf.Procedures))
+	for procedure, limit := range defaultProcedureRateLimits {
+		procedures[procedure] = limit
+	}
+
+	for _, item := range conf.Procedures {
+		procedures[item.Procedure] = rateLimit{rate: rate.Limit(item.Rate), burst: item.Burst}
+	}
+
+	return &RateLimitInterceptor{
+		logger:     logger.With(l, "service", "rate-limit-interceptor"),
+		enabled:    conf.Enabled,
+		limit:      rateLimit{rate: rate.Limit(conf.Rate), burst: conf.Burst},
+		procedures: procedures,
+		limiters: ttlcache.New(
+			ttlcache.WithTTL[string, *rate.Limiter](rateLimiterTTL),
+		),
+	}
+}
+
+func (i *RateLimitInterceptor) Name() string { return "rate-limit-interceptor" }
+
+// Start deletes the limiters of the unused clients and procedures
+func (i *RateLimitInterceptor) Start(_ context.Context) error {
+	go i.limiters.Start()
+	return nil
+}
+
+func (i *RateLimitInterceptor) Stop(_ context.Context) error {
+	i.limiters.Stop()
+	return nil
+}
+
+// WrapUnary wraps the unary function
+func (i *RateLimitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		if !i.enabled {
+			return next(ctx, req)
+		}
+
+		key := req.Header().Get(ClientIDHeaderName)
+		if key == "" {
+			key = "ip:" + requestSource(req).IP
+		}
+
+		if delay := i.reserve(key, req.Spec().Procedure); delay > 0 {
+			i.logger.Warnw("rate limit exceeded", "key", key, "procedure", req.Spec().Procedure)
+			return nil, newRateLimitError(req.Spec().Procedure, delay)
+		}
+
+		return next(ctx, req)
+	})
+}
+
+// WrapStreamingClient wraps the streaming client function
+func (*RateLimitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler wraps the streaming handler function
+func (*RateLimitInterceptor) WrapStreamingHandler(_ connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		_ context.Context,
+		_ connect.StreamingHandlerConn,
+	) error {
+		return fmt.Errorf("streaming is not supported")
+	})
+}
+
+// reserve takes the token of the key and the procedure. It returns the time to wait
+// for the next token if there are no tokens left.
+func (i *RateLimitInterceptor) reserve(key, procedure string) time.Duration {
+	limit, ok := i.procedures[procedure]
+	if !ok {
+		limit = i.limit
+	}
+
+	item, _ := i.limiters.GetOrSet(key+"|"+procedure, rate.NewLimiter(limit.rate, limit.burst))
+	limiter := item.Value()
+
+	now := time.Now()
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return time.Duration(float64(time.Second) / float64(limit.rate))
+	}
+
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+
+	return 0
+}
+
+// newRateLimitError returns the resource exhausted error with the retry hints
+func newRateLimitError(procedure string, delay time.Duration) error {
+	seconds := int(math.Ceil(delay.Seconds()))
+
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("rate limit of %s exceeded, retry after %d seconds", procedure, seconds))
+	err.Meta().Set("Retry-After", strconv.Itoa(seconds))
+
+	if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+
+	return err
+}
@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/dv-net/dv-processing/api/processing/owner/v1/ownerv1connect"
+	"github.com/dv-net/dv-processing/api/processing/wallet/v1/walletv1connect"
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/mx/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	i := NewRateLimitInterceptor(logger.New(), config.RateLimit{
+		Enabled: true,
+		Rate:    1,
+		Burst:   2,
+		Procedures: []config.RateLimitProcedure{
+			{Procedure: ownerv1connect.OwnerServiceDisableTwoFactorAuthProcedure, Rate: 10, Burst: 1},
+		},
+	})
+
+	hotWallet := walletv1connect.WalletServiceCreateOwnerHotWalletProcedure
+	require.Zero(t, i.reserve("client", hotWallet))
+	require.Zero(t, i.reserve("client", hotWallet))
+	require.Greater(t, i.reserve("client", hotWallet), time.Duration(0))
+
+	// the buckets are separate per client and procedure
+	require.Zero(t, i.reserve("other", hotWallet))
+	require.Zero(t, i.reserve("client", walletv1connect.WalletServiceCreateOwnerHotWalletsProcedure))
+
+	// the two factor auth procedures are limited by default
+	validate := ownerv1connect.OwnerServiceValidateTwoFactorTokenProcedure
+	for range 5 {
+		require.Zero(t, i.reserve("client", validate))
+	}
+	delay := i.reserve("client", validate)
+	require.Greater(t, delay, 5*time.Second)
+
+	// the configured procedure overrides the default limit
+	disable := ownerv1connect.OwnerServiceDisableTwoFactorAuthProcedure
+	require.Zero(t, i.reserve("client", disable))
+	require.LessOrEqual(t, i.reserve("client", disable), 100*time.Millisecond)
+
+	var connectErr *connect.Error
+	require.True(t, errors.As(newRateLimitError(validate, delay), &connectErr))
+	require.Equal(t, connect.CodeResourceExhausted, connectErr.Code())
+	require.Equal(t, "10", connectErr.Meta().Get("Retry-After"))
+	require.Len(t, connectErr.Details(), 1)
+
+	detail, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+	require.Equal(t, delay, detail.(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+}
@@ -2,12 +2,19 @@ package interceptors
 
 import (
 	"context"
+	"crypto/hmac"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/dv-net/dv-processing/api/processing/client/v1/clientv1connect"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/mtls"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/services/clients"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/dv-net/dv-processing/internal/util"
@@ -15,27 +22,57 @@ import (
 )
 
 const (
-	ClientIDHeaderName = "X-Client-ID"
-	signHeaderName     = "X-Sign"
+	ClientIDHeaderName      = "X-Client-ID"
+	signHeaderName          = "X-Sign"
+	signVersionHeaderName   = "X-Sign-Version"
+	signTimestampHeaderName = "X-Sign-Timestamp"
+	signNonceHeaderName     = "X-Sign-Nonce"
+	signKeyIDHeaderName     = "X-Sign-Key-ID"
+
+	signVersionV2  = "2"
+	minNonceLength = 16
+	maxNonceLength = 128
 )
 
 var (
-	errEmptySignKey  = fmt.Errorf("empty sign key")
-	errEmptyClientID = fmt.Errorf("empty client id")
+	errEmptySignKey     = fmt.Errorf("empty sign key")
+	errEmptyClientID    = fmt.Errorf("empty client id")
+	errSignV2Required   = fmt.Errorf("client requires sign v2")
+	errInvalidTimestamp = fmt.Errorf("invalid sign timestamp")
+	errInvalidNonce     = fmt.Errorf("invalid sign nonce")
+
+	errCertificateClientMismatch = fmt.Errorf("client id does not match the client certificate")
 )
 
+// SignInterceptor checks the request signatures of the clients.
+//
+// The requests are signed by the active api secrets of the client, the X-Sign-Key-ID header
+// selects the secret, without it the request is checked with all of them.
+// v1 is sha256 of the json payload and the client secret key.
+// v2 is HMAC-SHA256 of the http method, the procedure, the timestamp, the nonce and the json payload.
+// The v2 requests are accepted within the clock skew from the timestamp and only once for each nonce.
+//
+// The client id header of the requests with the mtls client certificate must match the certificate
+// identity. If the certificate identity is trusted, such requests are accepted without the sign.
+// The client of the authenticated request is set to the audit source of the context.
 type SignInterceptor struct {
-	clientService       *clients.Service
-	disableCheckingSign bool
+	clientService            *clients.Service
+	disableCheckingSign      bool
+	clockSkew                time.Duration
+	trustCertificateIdentity bool
 }
 
 func NewSignInterceptor(
 	clientService *clients.Service,
 	disableCheckingSign bool,
+	clockSkew time.Duration,
+	trustCertificateIdentity bool,
 ) *SignInterceptor {
 	return &SignInterceptor{
-		clientService:       clientService,
-		disableCheckingSign: disableCheckingSign,
+		clientService:            clientService,
+		disableCheckingSign:      disableCheckingSign,
+		clockSkew:                clockSkew,
+		trustCertificateIdentity: trustCertificateIdentity,
 	}
 }
 
@@ -50,11 +87,30 @@ func (i *SignInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 			return next(ctx, req)
 		}
 
+		// check the certificate identity
+		identity, ok := mtls.IdentityFromContext(ctx)
+		if ok && req.Spec().Procedure != clientv1connect.ClientServiceCreateProcedure {
+			if req.Header().Get(ClientIDHeaderName) != identity.ClientID.String() {
+				return nil, connect.NewError(connect.CodeUnauthenticated, errCertificateClientMismatch)
+			}
+
+			if i.trustCertificateIdentity {
+				audit.SetClientID(ctx, identity.ClientID)
+				return next(withPermissions(ctx, identity.Permissions), req)
+			}
+		}
+
 		// check sign key
-		if err := i.checkSignKey(ctx, req); err != nil {
+		secret, err := i.checkSignKey(ctx, req)
+		if err != nil {
 			return nil, connect.NewError(connect.CodeUnauthenticated, err)
 		}
 
+		if secret != nil {
+			audit.SetClientID(ctx, secret.ClientID)
+			ctx = withPermissions(ctx, secret.Permissions)
+		}
+
 		return next(ctx, req)
 	})
 }
@@ -79,49 +135,134 @@ func (i *SignInterceptor) WrapStreamingHandler(_ connect.StreamingHandlerFunc) c
 	})
 }
 
-// checkSignKey checks the sign key from the metadata
-func (i *SignInterceptor) checkSignKey(ctx context.Context, req connect.AnyRequest) error {
+// checkSignKey checks the sign key from the metadata and returns the secret which signed the request
+func (i *SignInterceptor) checkSignKey(ctx context.Context, req connect.AnyRequest) (*models.ClientSecret, error) {
 	// skip checking sign key for create client
 	if req.Spec().Procedure == clientv1connect.ClientServiceCreateProcedure {
-		return nil
+		return nil, nil //nolint:nilnil
 	}
 
 	// get sign key
 	signKey := req.Header().Get(signHeaderName)
 	if signKey == "" {
-		return errEmptySignKey
+		return nil, errEmptySignKey
 	}
 
 	// get client id
 	clientID := req.Header().Get(ClientIDHeaderName)
 	if clientID == "" {
-		return errEmptyClientID
+		return nil, errEmptyClientID
 	}
 
 	cui, err := uuid.Parse(clientID)
 	if err != nil {
-		return fmt.Errorf("parse client id error: %w", err)
+		return nil, fmt.Errorf("parse client id error: %w", err)
 	}
 
 	// get client
 	client, err := i.clientService.GetByID(ctx, cui)
 	if err != nil {
 		if errors.Is(err, storecmn.ErrNotFound) {
-			return fmt.Errorf("invalid client")
+			return nil, fmt.Errorf("invalid client")
 		}
-		return fmt.Errorf("get client: %w", err)
+		return nil, fmt.Errorf("get client: %w", err)
 	}
 
 	// marshal payload
 	payload, err := json.Marshal(req.Any())
 	if err != nil {
-		return fmt.Errorf("marshal payload error: %w", err)
+		return nil, fmt.Errorf("marshal payload error: %w", err)
+	}
+
+	secrets, err := i.apiSecrets(ctx, req, client.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Header().Get(signVersionHeaderName) == signVersionV2 {
+		return i.checkSignKeyV2(ctx, req, client.ID, secrets, signKey, payload)
+	}
+
+	if client.RequireSignV2 {
+		return nil, errSignV2Required
 	}
 
 	// check sign key
-	if signKey != util.SHA256Signature(payload, client.SecretKey) {
-		return fmt.Errorf("invalid sign key")
+	for _, secret := range secrets {
+		if signKey == util.SHA256Signature(payload, secret.SecretKey) {
+			return secret, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid sign key")
+}
+
+// apiSecrets returns the secret of the key id header or all active api secrets
+// of the client if the header is not set
+func (i *SignInterceptor) apiSecrets(ctx context.Context, req connect.AnyRequest, clientID uuid.UUID) ([]*models.ClientSecret, error) {
+	if keyID := req.Header().Get(signKeyIDHeaderName); keyID != "" {
+		secretID, err := uuid.Parse(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("parse sign key id error: %w", err)
+		}
+
+		secret, err := i.clientService.GetActiveSecret(ctx, clientID, secretID, constants.ClientSecretScopeAPI)
+		if err != nil {
+			if errors.Is(err, storecmn.ErrNotFound) {
+				return nil, fmt.Errorf("invalid sign key id")
+			}
+			return nil, fmt.Errorf("get client secret: %w", err)
+		}
+
+		return []*models.ClientSecret{secret}, nil
+	}
+
+	secrets, err := i.clientService.GetActiveSecrets(ctx, clientID, constants.ClientSecretScopeAPI)
+	if err != nil {
+		return nil, fmt.Errorf("get client secrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// checkSignKeyV2 checks the v2 signature, the timestamp and the nonce of the request
+func (i *SignInterceptor) checkSignKeyV2(ctx context.Context, req connect.AnyRequest, clientID uuid.UUID, secrets []*models.ClientSecret, signKey string, payload []byte) (*models.ClientSecret, error) {
+	timestamp := req.Header().Get(signTimestampHeaderName)
+
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, errInvalidTimestamp
+	}
+
+	if skew := time.Since(time.Unix(unixTime, 0)).Abs(); skew > i.clockSkew {
+		return nil, fmt.Errorf("%w: out of the allowed clock skew", errInvalidTimestamp)
+	}
+
+	nonce := req.Header().Get(signNonceHeaderName)
+	if len(nonce) < minNonceLength || len(nonce) > maxNonceLength {
+		return nil, fmt.Errorf("%w: length must be from %d to %d", errInvalidNonce, minNonceLength, maxNonceLength)
+	}
+
+	var signed *models.ClientSecret
+	for _, secret := range secrets {
+		expected := util.HMACSignatureV2(secret.SecretKey, req.HTTPMethod(), req.Spec().Procedure, timestamp, nonce, payload)
+		if hmac.Equal([]byte(signKey), []byte(expected)) {
+			signed = secret
+			break
+		}
+	}
+
+	if signed == nil {
+		return nil, fmt.Errorf("invalid sign key")
+	}
+
+	// the nonce is stored after the signature check, so it can not be taken by another request
+	if err := i.clientService.UseRequestNonce(ctx, clientID, nonce); err != nil {
+		if errors.Is(err, clients.ErrNonceAlreadyUsed) {
+			return nil, fmt.Errorf("%w: already used", errInvalidNonce)
+		}
+		return nil, err
 	}
 
-	return nil
+	return signed, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/workflow"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -92,12 +93,38 @@ func AllRiverJobStateValues() []RiverJobState {
 	}
 }
 
-type Client struct {
-	ID          uuid.UUID          `db:"id" json:"id"`
-	SecretKey   string             `db:"secret_key" json:"secret_key"`
-	CallbackUrl string             `db:"callback_url" json:"callback_url"`
+type AuditEvent struct {
+	ID          int64              `db:"id" json:"id"`
+	ClientID    uuid.NullUUID      `db:"client_id" json:"client_id"`
+	OwnerID     uuid.NullUUID      `db:"owner_id" json:"owner_id"`
+	Procedure   string             `db:"procedure" json:"procedure"`
+	SourceIp    string             `db:"source_ip" json:"source_ip"`
+	Result      string             `db:"result" json:"result"`
+	Fingerprint string             `db:"fingerprint" json:"fingerprint"`
+	PrevHash    string             `db:"prev_hash" json:"prev_hash"`
+	Hash        string             `db:"hash" json:"hash"`
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type Client struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	SecretKey     string             `db:"secret_key" json:"secret_key"`
+	CallbackUrl   string             `db:"callback_url" json:"callback_url"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	RequireSignV2 bool               `db:"require_sign_v2" json:"require_sign_v2"`
+	AllowedIps    []string           `db:"allowed_ips" json:"allowed_ips"`
+}
+
+type ClientSecret struct {
+	ID          uuid.UUID                   `db:"id" json:"id"`
+	ClientID    uuid.UUID                   `db:"client_id" json:"client_id"`
+	SecretKey   string                      `db:"secret_key" json:"secret_key"`
+	Scope       constants.ClientSecretScope `db:"scope" json:"scope"`
+	CreatedAt   pgtype.Timestamptz          `db:"created_at" json:"created_at"`
+	ExpiresAt   pgtype.Timestamptz          `db:"expires_at" json:"expires_at"`
+	RevokedAt   pgtype.Timestamptz          `db:"revoked_at" json:"revoked_at"`
+	Permissions []string                    `db:"permissions" json:"permissions"`
 }
 
 type ColdWallet struct {
@@ -109,6 +136,27 @@ type ColdWallet struct {
 	IsDirty    bool                      `db:"is_dirty" json:"is_dirty"`
 	CreatedAt  pgtype.Timestamptz        `db:"created_at" json:"created_at"`
 	UpdatedAt  pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+	Label      pgtype.Text               `db:"label" json:"label"`
+	Note       pgtype.Text               `db:"note" json:"note"`
+	DetachedAt pgtype.Timestamptz        `db:"detached_at" json:"detached_at"`
+}
+
+type ColdWalletDistribution struct {
+	OwnerID    uuid.UUID                     `db:"owner_id" json:"owner_id"`
+	Blockchain wconstants.BlockchainType     `db:"blockchain" json:"blockchain"`
+	Strategy   constants.ColdWalletsStrategy `db:"strategy" json:"strategy"`
+	CreatedAt  pgtype.Timestamptz            `db:"created_at" json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz            `db:"updated_at" json:"updated_at"`
+}
+
+type ColdWalletDistributionItem struct {
+	OwnerID    uuid.UUID                 `db:"owner_id" json:"owner_id"`
+	Blockchain wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
+	Address    string                    `db:"address" json:"address"`
+	Position   int32                     `db:"position" json:"position"`
+	Weight     int32                     `db:"weight" json:"weight"`
+	Cap        decimal.NullDecimal       `db:"cap" json:"cap"`
+	CreatedAt  pgtype.Timestamptz        `db:"created_at" json:"created_at"`
 }
 
 type HotWallet struct {
@@ -125,6 +173,44 @@ type HotWallet struct {
 	UpdatedAt        pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
 }
 
+type HotWalletsPool struct {
+	ID          uuid.UUID                 `db:"id" json:"id"`
+	OwnerID     uuid.UUID                 `db:"owner_id" json:"owner_id"`
+	Blockchain  wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
+	AddressType string                    `db:"address_type" json:"address_type"`
+	Address     string                    `db:"address" json:"address"`
+	Sequence    int32                     `db:"sequence" json:"sequence"`
+	CreatedAt   pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+}
+
+type MnemonicRotation struct {
+	ID                 uuid.UUID                        `db:"id" json:"id"`
+	OwnerID            uuid.UUID                        `db:"owner_id" json:"owner_id"`
+	Status             constants.MnemonicRotationStatus `db:"status" json:"status"`
+	Mnemonic           string                           `db:"mnemonic" json:"mnemonic"`
+	PassPhrase         pgtype.Text                      `db:"pass_phrase" json:"pass_phrase"`
+	PreviousMnemonic   string                           `db:"previous_mnemonic" json:"previous_mnemonic"`
+	PreviousPassPhrase pgtype.Text                      `db:"previous_pass_phrase" json:"previous_pass_phrase"`
+	LastError          pgtype.Text                      `db:"last_error" json:"last_error"`
+	CreatedAt          pgtype.Timestamptz               `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz               `db:"updated_at" json:"updated_at"`
+	CompletedAt        pgtype.Timestamptz               `db:"completed_at" json:"completed_at"`
+}
+
+type MnemonicRotationWallet struct {
+	ID               uuid.UUID                              `db:"id" json:"id"`
+	RotationID       uuid.UUID                              `db:"rotation_id" json:"rotation_id"`
+	WalletType       constants.WalletType                   `db:"wallet_type" json:"wallet_type"`
+	Blockchain       wconstants.BlockchainType              `db:"blockchain" json:"blockchain"`
+	ExternalWalletID pgtype.Text                            `db:"external_wallet_id" json:"external_wallet_id"`
+	OldAddress       string                                 `db:"old_address" json:"old_address"`
+	NewAddress       string                                 `db:"new_address" json:"new_address"`
+	Sequence         int32                                  `db:"sequence" json:"sequence"`
+	Status           constants.MnemonicRotationWalletStatus `db:"status" json:"status"`
+	CreatedAt        pgtype.Timestamptz                     `db:"created_at" json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz                     `db:"updated_at" json:"updated_at"`
+}
+
 type Owner struct {
 	ID           uuid.UUID          `db:"id" json:"id"`
 	ExternalID   string             `db:"external_id" json:"external_id" validate:"required"`
@@ -136,6 +222,24 @@ type Owner struct {
 	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	OtpData      pgtype.Text        `db:"otp_data" json:"otp_data"`
+	Derivation   derivation.Config  `db:"derivation" json:"derivation"`
+	DataKey      pgtype.Text        `db:"data_key" json:"data_key"`
+}
+
+type OwnerRecoveryCode struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	OwnerID   uuid.UUID          `db:"owner_id" json:"owner_id"`
+	CodeHash  string             `db:"code_hash" json:"code_hash"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UsedAt    pgtype.Timestamptz `db:"used_at" json:"used_at"`
+}
+
+type OwnerTwoFactorState struct {
+	OwnerID        uuid.UUID          `db:"owner_id" json:"owner_id"`
+	FailedAttempts int32              `db:"failed_attempts" json:"failed_attempts"`
+	LockedUntil    pgtype.Timestamptz `db:"locked_until" json:"locked_until"`
+	LastUsedStep   int64              `db:"last_used_step" json:"last_used_step"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
 type ProcessedBlock struct {
@@ -171,6 +275,20 @@ type ProcessingWallet struct {
 	UpdatedAt  pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
 }
 
+type ProcessingWalletStrategy struct {
+	OwnerID    uuid.UUID                           `db:"owner_id" json:"owner_id"`
+	Blockchain wconstants.BlockchainType           `db:"blockchain" json:"blockchain"`
+	Strategy   constants.ProcessingWalletsStrategy `db:"strategy" json:"strategy"`
+	CreatedAt  pgtype.Timestamptz                  `db:"created_at" json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz                  `db:"updated_at" json:"updated_at"`
+}
+
+type RequestNonce struct {
+	ClientID  uuid.UUID          `db:"client_id" json:"client_id"`
+	Nonce     string             `db:"nonce" json:"nonce"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type RiverClient struct {
 	ID        string             `db:"id" json:"id"`
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
@@ -236,6 +354,22 @@ type Setting struct {
 	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type SweepRule struct {
+	ID              uuid.UUID                 `db:"id" json:"id"`
+	OwnerID         uuid.UUID                 `db:"owner_id" json:"owner_id"`
+	Blockchain      wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
+	AssetIdentifier string                    `db:"asset_identifier" json:"asset_identifier"`
+	ToAddress       string                    `db:"to_address" json:"to_address"`
+	Threshold       decimal.NullDecimal       `db:"threshold" json:"threshold"`
+	IntervalHours   pgtype.Int4               `db:"interval_hours" json:"interval_hours"`
+	FeeMax          decimal.NullDecimal       `db:"fee_max" json:"fee_max"`
+	Kind            pgtype.Text               `db:"kind" json:"kind"`
+	IsEnabled       bool                      `db:"is_enabled" json:"is_enabled"`
+	LastSweptAt     pgtype.Timestamptz        `db:"last_swept_at" json:"last_swept_at"`
+	CreatedAt       pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+}
+
 type Transfer struct {
 	ID               uuid.UUID                 `db:"id" json:"id"`
 	Status           constants.TransferStatus  `db:"status" json:"status"`
@@ -274,30 +408,90 @@ type TransferTransaction struct {
 	UpdatedAt         pgtype.Timestamptz         `db:"updated_at" json:"updated_at"`
 }
 
+type WalletSequence struct {
+	OwnerID      uuid.UUID                 `db:"owner_id" json:"owner_id"`
+	Blockchain   wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
+	LastSequence int32                     `db:"last_sequence" json:"last_sequence"`
+	UpdatedAt    pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+}
+
 type Webhook struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Kind      WebhookKind        `db:"kind" json:"kind"`
-	Status    WebhookStatus      `db:"status" json:"status"`
-	Attempts  int32              `db:"attempts" json:"attempts"`
-	Payload   []byte             `db:"payload" json:"payload"`
-	ClientID  uuid.UUID          `db:"client_id" json:"client_id"`
-	Response  pgtype.Text        `db:"response" json:"response"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	SentAt    pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID            uuid.UUID                 `db:"id" json:"id"`
+	Kind          WebhookKind               `db:"kind" json:"kind"`
+	Status        WebhookStatus             `db:"status" json:"status"`
+	Attempts      int32                     `db:"attempts" json:"attempts"`
+	Payload       []byte                    `db:"payload" json:"payload"`
+	ClientID      uuid.UUID                 `db:"client_id" json:"client_id"`
+	Response      pgtype.Text               `db:"response" json:"response"`
+	CreatedAt     pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+	SentAt        pgtype.Timestamptz        `db:"sent_at" json:"sent_at"`
+	UpdatedAt     pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+	NextAttemptAt pgtype.Timestamptz        `db:"next_attempt_at" json:"next_attempt_at"`
+	OwnerID       uuid.NullUUID             `db:"owner_id" json:"owner_id"`
+	Blockchain    wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
 }
 
-type WebhookView struct {
+type WebhookDelivery struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	WebhookID     uuid.UUID          `db:"webhook_id" json:"webhook_id"`
+	EndpointID    uuid.UUID          `db:"endpoint_id" json:"endpoint_id"`
+	Status        WebhookStatus      `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	Response      pgtype.Text        `db:"response" json:"response"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type WebhookDeliveryView struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	WebhookID     uuid.UUID          `db:"webhook_id" json:"webhook_id"`
+	EndpointID    uuid.UUID          `db:"endpoint_id" json:"endpoint_id"`
+	Status        WebhookStatus      `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	Response      pgtype.Text        `db:"response" json:"response"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Kind          WebhookKind        `db:"kind" json:"kind"`
+	Payload       []byte             `db:"payload" json:"payload"`
+	ClientID      uuid.UUID          `db:"client_id" json:"client_id"`
+	Url           string             `db:"url" json:"url"`
+	SecretKey     string             `db:"secret_key" json:"secret_key"`
+	SecretKeyID   uuid.NullUUID      `db:"secret_key_id" json:"secret_key_id"`
+}
+
+type WebhookEndpoint struct {
 	ID          uuid.UUID          `db:"id" json:"id"`
-	Kind        WebhookKind        `db:"kind" json:"kind"`
-	Status      WebhookStatus      `db:"status" json:"status"`
-	Attempts    int32              `db:"attempts" json:"attempts"`
-	Payload     []byte             `db:"payload" json:"payload"`
 	ClientID    uuid.UUID          `db:"client_id" json:"client_id"`
-	Response    pgtype.Text        `db:"response" json:"response"`
+	IsDefault   bool               `db:"is_default" json:"is_default"`
+	Url         pgtype.Text        `db:"url" json:"url"`
+	SecretKey   pgtype.Text        `db:"secret_key" json:"secret_key"`
+	Enabled     bool               `db:"enabled" json:"enabled"`
+	Kinds       []string           `db:"kinds" json:"kinds"`
+	Blockchains []string           `db:"blockchains" json:"blockchains"`
+	OwnerIds    []uuid.UUID        `db:"owner_ids" json:"owner_ids"`
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	SentAt      pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	CallbackUrl string             `db:"callback_url" json:"callback_url"`
-	SecretKey   string             `db:"secret_key" json:"secret_key"`
+}
+
+type WebhookView struct {
+	ID            uuid.UUID                 `db:"id" json:"id"`
+	Kind          WebhookKind               `db:"kind" json:"kind"`
+	Status        WebhookStatus             `db:"status" json:"status"`
+	Attempts      int32                     `db:"attempts" json:"attempts"`
+	Payload       []byte                    `db:"payload" json:"payload"`
+	ClientID      uuid.UUID                 `db:"client_id" json:"client_id"`
+	Response      pgtype.Text               `db:"response" json:"response"`
+	CreatedAt     pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+	SentAt        pgtype.Timestamptz        `db:"sent_at" json:"sent_at"`
+	UpdatedAt     pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+	NextAttemptAt pgtype.Timestamptz        `db:"next_attempt_at" json:"next_attempt_at"`
+	OwnerID       uuid.NullUUID             `db:"owner_id" json:"owner_id"`
+	Blockchain    wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
+	CallbackUrl   string                    `db:"callback_url" json:"callback_url"`
+	SecretKey     string                    `db:"secret_key" json:"secret_key"`
+	SecretKeyID   uuid.NullUUID             `db:"secret_key_id" json:"secret_key_id"`
 }
@@ -0,0 +1,49 @@
+package models
+
+import (
+	transferv1 "github.com/dv-net/dv-processing/api/processing/transfer/v1"
+	"github.com/dv-net/dv-processing/pkg/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToPb converts a SweepRule model to a SweepRule protobuf message
+func (r *SweepRule) ToPb() *transferv1.SweepRule {
+	res := &transferv1.SweepRule{
+		Id:              r.ID.String(),
+		OwnerId:         r.OwnerID.String(),
+		Blockchain:      ConvertBlockchainTypeToPb(r.Blockchain),
+		AssetIdentifier: r.AssetIdentifier,
+		ToAddress:       r.ToAddress,
+		IsEnabled:       r.IsEnabled,
+	}
+
+	if r.Threshold.Valid {
+		res.Threshold = utils.Pointer(r.Threshold.Decimal.String())
+	}
+
+	if r.IntervalHours.Valid {
+		res.IntervalHours = &r.IntervalHours.Int32
+	}
+
+	if r.FeeMax.Valid {
+		res.FeeMax = utils.Pointer(r.FeeMax.Decimal.String())
+	}
+
+	if r.Kind.Valid && r.Kind.String != "" {
+		res.Kind = &r.Kind.String
+	}
+
+	if r.LastSweptAt.Valid && !r.LastSweptAt.Time.IsZero() {
+		res.LastSweptAt = timestamppb.New(r.LastSweptAt.Time)
+	}
+
+	if r.CreatedAt.Valid && !r.CreatedAt.Time.IsZero() {
+		res.CreatedAt = timestamppb.New(r.CreatedAt.Time)
+	}
+
+	if r.UpdatedAt.Valid && !r.UpdatedAt.Time.IsZero() {
+		res.UpdatedAt = timestamppb.New(r.UpdatedAt.Time)
+	}
+
+	return res
+}
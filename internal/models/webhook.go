@@ -13,6 +13,8 @@ type WebhookStatus string
 const (
 	WebhookStatusNew  WebhookStatus = "new"
 	WebhookStatusSent WebhookStatus = "sent"
+	// WebhookStatusFailed parks the webhook after the max attempts until it is redelivered manually
+	WebhookStatusFailed WebhookStatus = "failed"
 )
 
 // String returns the webhook status as a string
@@ -21,7 +23,7 @@ func (w WebhookStatus) String() string { return string(w) }
 // Valid
 func (w WebhookStatus) Valid() bool {
 	switch w {
-	case WebhookStatusNew, WebhookStatusSent:
+	case WebhookStatusNew, WebhookStatusSent, WebhookStatusFailed:
 		return true
 	}
 	return false
@@ -52,6 +54,8 @@ const (
 	WebhookKindTransfer       WebhookKind = "transfer"
 	WebhookKindDeposit        WebhookKind = "deposit"
 	WebhookKindTransferStatus WebhookKind = "transfer_status"
+	// WebhookKindMnemonicRotation reports the progress of an owner mnemonic rotation
+	WebhookKindMnemonicRotation WebhookKind = "mnemonic_rotation"
 )
 
 // String returns the webhook kind as a string
@@ -62,7 +66,8 @@ func (w WebhookKind) Valid() bool {
 	switch w {
 	case WebhookKindTransfer,
 		WebhookKindDeposit,
-		WebhookKindTransferStatus:
+		WebhookKindTransferStatus,
+		WebhookKindMnemonicRotation:
 		return true
 	}
 	return false
@@ -0,0 +1,121 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/config"
+)
+
+// Certificates holds the server certificate and the client CA bundle and reloads
+// them when the files change, the new connections use the reloaded files.
+type Certificates struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	state atomic.Pointer[certificatesState]
+}
+
+type certificatesState struct {
+	certificate tls.Certificate
+	clientCAs   *x509.CertPool
+	modTime     time.Time
+}
+
+func NewCertificates(conf config.MTLS) (*Certificates, error) {
+	c := &Certificates{
+		certFile:     conf.CertFile,
+		keyFile:      conf.KeyFile,
+		clientCAFile: conf.ClientCAFile,
+	}
+
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reload loads the files if they are changed since the last load and returns true if they are reloaded.
+func (c *Certificates) Reload() (bool, error) {
+	modTime, err := c.lastModTime()
+	if err != nil {
+		return false, err
+	}
+
+	if current := c.state.Load(); current != nil && current.modTime.Equal(modTime) {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("load server certificate: %w", err)
+	}
+
+	clientCAs, err := loadCertPool(c.clientCAFile)
+	if err != nil {
+		return false, err
+	}
+
+	c.state.Store(&certificatesState{
+		certificate: certificate,
+		clientCAs:   clientCAs,
+		modTime:     modTime,
+	})
+
+	return true, nil
+}
+
+// TLSConfig returns the server tls config which requires the client certificates signed by the client CA bundle.
+func (c *Certificates) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			state := c.state.Load()
+			return &tls.Config{
+				Certificates: []tls.Certificate{state.certificate},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    state.clientCAs,
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2", "http/1.1"},
+			}, nil
+		},
+	}
+}
+
+// lastModTime returns the latest modification time of the files
+func (c *Certificates) lastModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{c.certFile, c.keyFile, c.clientCAFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %s: %w", file, err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read client ca file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("client ca file does not contain certificates")
+	}
+
+	return pool, nil
+}
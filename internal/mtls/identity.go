@@ -0,0 +1,100 @@
+package mtls
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/google/uuid"
+)
+
+// Identity is the client authenticated by the certificate.
+type Identity struct {
+	ClientID    uuid.UUID
+	Permissions []string
+}
+
+// Identities maps the client certificates to the clients by the public key or by the subject.
+type Identities struct {
+	bySPKI    map[string]Identity
+	bySubject map[string]Identity
+}
+
+func NewIdentities(items []config.MTLSIdentity) (*Identities, error) {
+	ids := &Identities{
+		bySPKI:    make(map[string]Identity),
+		bySubject: make(map[string]Identity),
+	}
+
+	for _, item := range items {
+		clientID, err := uuid.Parse(item.ClientID)
+		if err != nil {
+			return nil, err
+		}
+
+		identity := Identity{
+			ClientID:    clientID,
+			Permissions: item.Permissions,
+		}
+
+		if len(identity.Permissions) == 0 {
+			identity.Permissions = []string{constants.PermissionAll.String()}
+		}
+
+		if item.SPKI != "" {
+			ids.bySPKI[item.SPKI] = identity
+		}
+
+		if item.Subject != "" {
+			ids.bySubject[item.Subject] = identity
+		}
+	}
+
+	return ids, nil
+}
+
+// Lookup returns the identity of the certificate, the public key takes precedence over the subject.
+func (i *Identities) Lookup(cert *x509.Certificate) (Identity, bool) {
+	if identity, ok := i.bySPKI[SPKIHash(cert)]; ok {
+		return identity, true
+	}
+
+	identity, ok := i.bySubject[cert.Subject.String()]
+	return identity, ok
+}
+
+// Middleware puts the identity of the verified client certificate to the request context.
+func (i *Identities) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			if identity, ok := i.Lookup(r.TLS.VerifiedChains[0][0]); ok {
+				r = r.WithContext(WithIdentity(r.Context(), identity))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SPKIHash returns base64 sha256 hash of the certificate public key info.
+func SPKIHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+type identityCtxKey struct{}
+
+// WithIdentity returns the context with the identity of the client certificate
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the client certificate
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityCtxKey{}).(Identity)
+	return identity, ok
+}
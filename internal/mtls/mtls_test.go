@@ -0,0 +1,198 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"dv"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCertificate{cert: cert, key: key, der: der}
+}
+
+func (c *testCertificate) writeFiles(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+
+	if keyFile == "" {
+		return
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestIdentities(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	storefront := newTestCertificate(t, "storefront", ca)
+	backend := newTestCertificate(t, "backend", ca)
+
+	storefrontID, backendID := uuid.New(), uuid.New()
+
+	ids, err := NewIdentities([]config.MTLSIdentity{
+		{ClientID: storefrontID.String(), SPKI: SPKIHash(storefront.cert), Permissions: []string{"wallets:create"}},
+		{ClientID: backendID.String(), Subject: "CN=backend,O=dv"},
+	})
+	require.NoError(t, err)
+
+	identity, ok := ids.Lookup(storefront.cert)
+	require.True(t, ok)
+	require.Equal(t, storefrontID, identity.ClientID)
+	require.Equal(t, []string{"wallets:create"}, identity.Permissions)
+
+	identity, ok = ids.Lookup(backend.cert)
+	require.True(t, ok)
+	require.Equal(t, backendID, identity.ClientID)
+	require.Equal(t, []string{"*"}, identity.Permissions)
+
+	_, ok = ids.Lookup(ca.cert)
+	require.False(t, ok)
+}
+
+func TestServerCertificates(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.MTLS{
+		Enabled:      true,
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server-key.pem"),
+		ClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+
+	ca := newTestCertificate(t, "ca", nil)
+	ca.writeFiles(t, conf.ClientCAFile, "")
+
+	server := newTestCertificate(t, "server", ca)
+	server.writeFiles(t, conf.CertFile, conf.KeyFile)
+
+	client := newTestCertificate(t, "storefront", ca)
+	clientID := uuid.New()
+
+	certificates, err := NewCertificates(conf)
+	require.NoError(t, err)
+
+	ids, err := NewIdentities([]config.MTLSIdentity{{ClientID: clientID.String(), Subject: "CN=storefront,O=dv"}})
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(ids.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := IdentityFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(identity.ClientID.String()))
+	})))
+	srv.TLS = certificates.TLSConfig()
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	request := func(clientCerts ...tls.Certificate) (*http.Response, *x509.Certificate, error) {
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: clientCerts,
+			ServerName:   "localhost",
+			MinVersion:   tls.VersionTLS12,
+		}}}
+
+		resp, err := httpClient.Get(srv.URL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp, resp.TLS.PeerCertificates[0], nil
+	}
+
+	// the client without the certificate is rejected
+	_, _, err = request()
+	require.Error(t, err)
+
+	resp, peer, err := request(client.tlsCertificate())
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, clientID.String(), string(body))
+	require.Equal(t, server.cert.SerialNumber, peer.SerialNumber)
+
+	// the files are not changed
+	reloaded, err := certificates.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	// the new server certificate is used for the new connections
+	renewed := newTestCertificate(t, "server", ca)
+	renewed.writeFiles(t, conf.CertFile, conf.KeyFile)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(conf.CertFile, future, future))
+
+	reloaded, err = certificates.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+
+	resp, peer, err = request(client.tlsCertificate())
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, renewed.cert.SerialNumber, peer.SerialNumber)
+}
@@ -0,0 +1,97 @@
+package mtls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/mx/logger"
+)
+
+const serverReadHeaderTimeout = 10 * time.Second
+
+// Server serves the grpc api over tls with the client certificate authentication.
+type Server struct {
+	logger         logger.Logger
+	addr           string
+	reloadInterval time.Duration
+	handler        http.Handler
+	certificates   *Certificates
+
+	server *http.Server
+}
+
+func NewServer(l logger.Logger, conf config.MTLS, addr string, handler http.Handler) (*Server, error) {
+	certificates, err := NewCertificates(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := NewIdentities(conf.Identities)
+	if err != nil {
+		return nil, fmt.Errorf("mtls identities: %w", err)
+	}
+
+	return &Server{
+		logger:         logger.With(l, "service", "mtls-server"),
+		addr:           addr,
+		reloadInterval: conf.ReloadInterval,
+		handler:        identities.Middleware(handler),
+		certificates:   certificates,
+	}, nil
+}
+
+func (s *Server) Name() string { return "mtls-server" }
+
+func (s *Server) Start(ctx context.Context) error {
+	s.server = &http.Server{
+		Addr:              s.addr,
+		Handler:           s.handler,
+		TLSConfig:         s.certificates.TLSConfig(),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+
+	go s.watchCertificates(ctx)
+
+	s.logger.Infow("mtls server started", "address", s.addr)
+
+	if err := s.server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+
+	return s.server.Shutdown(ctx)
+}
+
+// watchCertificates reloads the certificates when the files change
+func (s *Server) watchCertificates(ctx context.Context) {
+	ticker := time.NewTicker(s.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := s.certificates.Reload()
+			if err != nil {
+				s.logger.Errorw("reload mtls certificates", "error", err)
+				continue
+			}
+
+			if reloaded {
+				s.logger.Info("mtls certificates reloaded")
+			}
+		}
+	}
+}
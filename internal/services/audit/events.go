@@ -0,0 +1,222 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_audit_events"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// genesisHash is the previous hash of the first event
+	genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	verifyBatchSize = 1000
+)
+
+type Event struct {
+	ClientID  uuid.NullUUID
+	OwnerID   uuid.NullUUID
+	Procedure string
+	SourceIP  string
+	Result    string
+	// Fingerprint is the hash of the request
+	Fingerprint string
+}
+
+// Record queues the event to the writer and waits until it is appended to the chain.
+// The event may still be recorded if the context is done after it is queued.
+func (s *Service) Record(ctx context.Context, event Event) (*models.AuditEvent, error) {
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+
+	req := &recordRequest{event: event, result: make(chan recordResult, 1)}
+
+	select {
+	case s.queue <- req:
+	case <-s.stopped:
+		return nil, errWriterStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.result:
+		return res.event, res.err
+	case <-s.stopped:
+		return nil, errWriterStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RecordTx appends the event to the chain in the transaction, the event is recorded
+// only if the transaction is committed.
+//
+// The chain lock is held until the transaction is finished, so it is used only for the rare
+// operations which must be recorded together with their changes, the others use Record.
+func (s *Service) RecordTx(ctx context.Context, tx pgx.Tx, event Event) (*models.AuditEvent, error) {
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.appendEvents(ctx, tx, []Event{event})
+	if err != nil {
+		return nil, err
+	}
+
+	return res[0], nil
+}
+
+func (e Event) validate() error {
+	if e.Procedure == "" || e.Result == "" {
+		return fmt.Errorf("audit event procedure and result are required")
+	}
+	return nil
+}
+
+// appendEvents links the events one after another to the end of the chain
+func (s *Service) appendEvents(ctx context.Context, tx pgx.Tx, events []Event) ([]*models.AuditEvent, error) {
+	repo := s.store.AuditEvents(repos.WithTx(tx))
+
+	// the writer is single in the process, the lock keeps the chain linear
+	// with the other processing instances and RecordTx
+	if err := repo.LockChain(ctx); err != nil {
+		return nil, fmt.Errorf("lock audit chain: %w", err)
+	}
+
+	prevHash, err := repo.GetLastHash(ctx)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get last audit hash: %w", err)
+		}
+		prevHash = genesisHash
+	}
+
+	res := make([]*models.AuditEvent, 0, len(events))
+	for _, event := range events {
+		params := repo_audit_events.CreateParams{
+			ClientID:    event.ClientID,
+			OwnerID:     event.OwnerID,
+			Procedure:   event.Procedure,
+			SourceIp:    event.SourceIP,
+			Result:      event.Result,
+			Fingerprint: event.Fingerprint,
+			PrevHash:    prevHash,
+			// postgres stores the time with the microsecond precision
+			CreatedAt: pgtype.Timestamptz{Time: time.Now().UTC().Truncate(time.Microsecond), Valid: true},
+		}
+		params.Hash = eventHash(params)
+
+		item, err := repo.Create(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("create audit event: %w", err)
+		}
+
+		res = append(res, item)
+		prevHash = item.Hash
+	}
+
+	return res, nil
+}
+
+type FindParams = repo_audit_events.FindParams
+
+// Find returns the events by the filters, the newest first.
+func (s *Service) Find(ctx context.Context, params FindParams) ([]*models.AuditEvent, error) {
+	res, err := s.store.AuditEvents().Find(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("find audit events: %w", err)
+	}
+
+	return res.Items, nil
+}
+
+type VerifyResult struct {
+	Checked int64
+	// BrokenAtID is the id of the first event which does not match the chain
+	BrokenAtID *int64
+}
+
+// Verify checks the hashes and the links of all events.
+func (s *Service) Verify(ctx context.Context) (*VerifyResult, error) {
+	res := new(VerifyResult)
+	prevHash := genesisHash
+
+	var lastID int64
+	for {
+		items, err := s.store.AuditEvents().GetAfterID(ctx, lastID, verifyBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("get audit events: %w", err)
+		}
+
+		for _, item := range items {
+			if item.PrevHash != prevHash || item.Hash != eventHash(repo_audit_events.CreateParams{
+				ClientID:    item.ClientID,
+				OwnerID:     item.OwnerID,
+				Procedure:   item.Procedure,
+				SourceIp:    item.SourceIp,
+				Result:      item.Result,
+				Fingerprint: item.Fingerprint,
+				PrevHash:    item.PrevHash,
+				CreatedAt:   item.CreatedAt,
+			}) {
+				res.BrokenAtID = &item.ID
+				return res, nil
+			}
+
+			prevHash = item.Hash
+			lastID = item.ID
+			res.Checked++
+		}
+
+		if len(items) < verifyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+// Fingerprint returns the hash of the request payload of the procedure.
+func Fingerprint(procedure string, payload []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(procedure))
+	hash.Write([]byte{'\n'})
+	hash.Write(payload)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// eventHash returns the hash of the event fields and the previous hash
+func eventHash(params repo_audit_events.CreateParams) string {
+	nullUUID := func(v uuid.NullUUID) string {
+		if !v.Valid {
+			return ""
+		}
+		return v.UUID.String()
+	}
+
+	data := strings.Join([]string{
+		params.PrevHash,
+		strconv.FormatInt(params.CreatedAt.Time.UnixMicro(), 10),
+		nullUUID(params.ClientID),
+		nullUUID(params.OwnerID),
+		params.Procedure,
+		params.SourceIp,
+		params.Result,
+		params.Fingerprint,
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_audit_events"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHash(t *testing.T) {
+	params := repo_audit_events.CreateParams{
+		ClientID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Procedure:   "/processing.owner.v1.OwnerService/GetSeeds",
+		SourceIp:    "10.0.0.1",
+		Result:      ResultSuccess,
+		Fingerprint: Fingerprint("/processing.owner.v1.OwnerService/GetSeeds", []byte(`{"owner_id":"1"}`)),
+		PrevHash:    genesisHash,
+		CreatedAt:   pgtype.Timestamptz{Time: time.Now().Truncate(time.Microsecond), Valid: true},
+	}
+
+	hash := eventHash(params)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, eventHash(params))
+
+	// each field is covered by the hash
+	changes := []func(p *repo_audit_events.CreateParams){
+		func(p *repo_audit_events.CreateParams) { p.ClientID = uuid.NullUUID{} },
+		func(p *repo_audit_events.CreateParams) { p.OwnerID = uuid.NullUUID{UUID: uuid.New(), Valid: true} },
+		func(p *repo_audit_events.CreateParams) {
+			p.Procedure = "/processing.owner.v1.OwnerService/GetPrivateKeys"
+		},
+		func(p *repo_audit_events.CreateParams) { p.SourceIp = "10.0.0.2" },
+		func(p *repo_audit_events.CreateParams) { p.Result = "permission_denied" },
+		func(p *repo_audit_events.CreateParams) { p.Fingerprint = "" },
+		func(p *repo_audit_events.CreateParams) { p.PrevHash = hash },
+		func(p *repo_audit_events.CreateParams) { p.CreatedAt.Time = p.CreatedAt.Time.Add(time.Microsecond) },
+	}
+
+	for _, change := range changes {
+		changed := params
+		change(&changed)
+		require.NotEqual(t, hash, eventHash(changed))
+	}
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"github.com/dv-net/dv-processing/internal/store"
+)
+
+const (
+	// ResultSuccess is the result of the successful operation, the failed operations
+	// are recorded with the error code.
+	ResultSuccess = "success"
+
+	// ProcedureSetDvSecretKey is the procedure of storing the dv admin secret key on the client registration
+	ProcedureSetDvSecretKey = "system.SetDvSecretKey"
+
+	// queueSize is the number of the events waiting for the writer
+	queueSize = 1024
+)
+
+// Service records the sensitive operations to the append-only audit log.
+//
+// Each event contains the hash of the previous one, so the modified or removed
+// events break the chain and are detected by Verify.
+//
+// The events of Record are appended by the single writer started by Start. The writer
+// links the queued events in batches, so the chain lock is taken once per batch instead
+// of once per audited call.
+type Service struct {
+	store   store.IStore
+	queue   chan *recordRequest
+	stopped chan struct{}
+}
+
+func New(st store.IStore) *Service {
+	return &Service{
+		store:   st,
+		queue:   make(chan *recordRequest, queueSize),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Name returns the service name
+func (s *Service) Name() string { return "audit" }
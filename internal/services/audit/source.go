@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Source is the client and the address of the request.
+type Source struct {
+	ClientID uuid.NullUUID
+	IP       string
+}
+
+type sourceCtxKey struct{}
+
+// WithSource returns the context with the source of the request. The client is set
+// by SetClientID only after the request is authenticated.
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceCtxKey{}, &source)
+}
+
+// SetClientID sets the authenticated client of the request source
+func SetClientID(ctx context.Context, clientID uuid.UUID) {
+	if source, ok := ctx.Value(sourceCtxKey{}).(*Source); ok {
+		source.ClientID = uuid.NullUUID{UUID: clientID, Valid: true}
+	}
+}
+
+// SourceFromContext returns the source of the request, it is empty for the background jobs
+func SourceFromContext(ctx context.Context) Source {
+	if source, ok := ctx.Value(sourceCtxKey{}).(*Source); ok {
+		return *source
+	}
+	return Source{}
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	require.Equal(t, Source{}, SourceFromContext(context.Background()))
+
+	// the client is not set without the source
+	SetClientID(context.Background(), uuid.New())
+
+	ctx := WithSource(context.Background(), Source{IP: "10.0.0.1"})
+	require.Equal(t, Source{IP: "10.0.0.1"}, SourceFromContext(ctx))
+
+	// the client is visible to the outer interceptor after the request is authenticated
+	clientID := uuid.New()
+	SetClientID(context.WithValue(ctx, struct{}{}, "inner"), clientID)
+	require.Equal(t, Source{
+		ClientID: uuid.NullUUID{UUID: clientID, Valid: true},
+		IP:       "10.0.0.1",
+	}, SourceFromContext(ctx))
+}
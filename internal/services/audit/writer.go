@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// recordBatchSize is the max number of the events appended in one transaction
+const recordBatchSize = 100
+
+var errWriterStopped = errors.New("audit writer is stopped")
+
+type recordResult struct {
+	event *models.AuditEvent
+	err   error
+}
+
+type recordRequest struct {
+	event  Event
+	result chan recordResult
+}
+
+// Start runs the writer of the recorded events until the context is done
+func (s *Service) Start(ctx context.Context) error {
+	defer close(s.stopped)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case req := <-s.queue:
+			s.writeBatch(ctx, collectBatch(req, s.queue, recordBatchSize))
+		}
+	}
+}
+
+// Stop
+func (s *Service) Stop(_ context.Context) error { return nil }
+
+// collectBatch returns the first request with the already queued ones up to the limit
+func collectBatch(first *recordRequest, queue <-chan *recordRequest, limit int) []*recordRequest {
+	batch := []*recordRequest{first}
+	for len(batch) < limit {
+		select {
+		case req := <-queue:
+			batch = append(batch, req)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// writeBatch appends the events of the batch in one transaction and returns the results to the callers
+func (s *Service) writeBatch(ctx context.Context, batch []*recordRequest) {
+	events := make([]Event, 0, len(batch))
+	for _, req := range batch {
+		events = append(events, req.event)
+	}
+
+	var res []*models.AuditEvent
+	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) (err error) {
+		res, err = s.appendEvents(ctx, tx, events)
+		return err
+	})
+
+	for idx, req := range batch {
+		if err != nil {
+			req.result <- recordResult{err: err}
+			continue
+		}
+		req.result <- recordResult{event: res[idx]}
+	}
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectBatch(t *testing.T) {
+	queue := make(chan *recordRequest, 5)
+	for range 4 {
+		queue <- &recordRequest{}
+	}
+
+	first := &recordRequest{}
+
+	batch := collectBatch(first, queue, 3)
+	require.Len(t, batch, 3)
+	require.Same(t, first, batch[0])
+	require.Len(t, queue, 2)
+
+	// the writer does not wait for more events
+	batch = collectBatch(first, queue, 10)
+	require.Len(t, batch, 3)
+	require.Empty(t, queue)
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("invalid event", func(t *testing.T) {
+		_, err := New(nil).Record(context.Background(), Event{Procedure: "procedure"})
+		require.Error(t, err)
+	})
+
+	t.Run("writer is stopped", func(t *testing.T) {
+		svc := New(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, svc.Start(ctx))
+
+		// the queued events are not written
+		_, err := svc.Record(context.Background(), Event{Procedure: "procedure", Result: ResultSuccess})
+		require.ErrorIs(t, err, errWriterStopped)
+	})
+
+	t.Run("context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := New(nil).Record(ctx, Event{Procedure: "procedure", Result: ResultSuccess})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
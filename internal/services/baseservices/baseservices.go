@@ -11,14 +11,19 @@ import (
 	"github.com/dv-net/dv-processing/internal/eproxy"
 	"github.com/dv-net/dv-processing/internal/madmin"
 	"github.com/dv-net/dv-processing/internal/rmanager"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/services/clients"
 	"github.com/dv-net/dv-processing/internal/services/owners"
 	"github.com/dv-net/dv-processing/internal/services/processedblocks"
 	"github.com/dv-net/dv-processing/internal/services/processedincidents"
+	"github.com/dv-net/dv-processing/internal/services/rotations"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/services/sweeps"
 	"github.com/dv-net/dv-processing/internal/services/system"
 	"github.com/dv-net/dv-processing/internal/services/transfers"
 	"github.com/dv-net/dv-processing/internal/services/wallets"
 	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/internal/updater"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
@@ -31,6 +36,7 @@ import (
 )
 
 type IBaseServices interface { //nolint:interfacebloat
+	Audit() *audit.Service
 	Clients() *clients.Service
 	Owners() *owners.Service
 	ProcessedBlocks() *processedblocks.Service
@@ -40,6 +46,10 @@ type IBaseServices interface { //nolint:interfacebloat
 	Webhooks() *webhooks.Service
 	EProxy() *eproxy.Service
 	Transfers() *transfers.Service
+	Sweeps() *sweeps.Service
+	Rotations() *rotations.Service
+	Secrets() *secrets.Service
+	Signer() signer.Signer
 	Blockchains() *blockchains.Blockchains
 	BTC() *btc.BTC
 	LTC() *ltc.LTC
@@ -54,6 +64,7 @@ type IBaseServices interface { //nolint:interfacebloat
 }
 
 type service struct {
+	audit              *audit.Service
 	clients            *clients.Service
 	owners             *owners.Service
 	processedBlocks    *processedblocks.Service
@@ -64,6 +75,10 @@ type service struct {
 	eproxy             *eproxy.Service
 	blockchains        *blockchains.Blockchains
 	transfers          *transfers.Service
+	sweeps             *sweeps.Service
+	rotations          *rotations.Service
+	secrets            *secrets.Service
+	signer             signer.Signer
 	madmin             *madmin.Service
 	rmanager           *rmanager.Service
 	upd                *updater.Service
@@ -85,18 +100,26 @@ func New(
 	if err != nil {
 		return nil, err
 	}
-	clientsSvc := clients.New(st, systemSvc, madmin)
-	walletsSvc := wallets.New(l, conf, st, publisher, walletSDK)
-	ownersSvc := owners.New(conf, st, walletsSvc)
+	secretsSvc, signerSvc, err := newSigner(conf, st, walletSDK, explorerProxySvc)
+	if err != nil {
+		return nil, err
+	}
+	auditSvc := audit.New(st)
+	clientsSvc := clients.New(st, systemSvc, madmin, auditSvc)
+	walletsSvc := wallets.New(l, conf, st, publisher, walletSDK, secretsSvc, signerSvc)
+	ownersSvc := owners.New(conf, st, secretsSvc, walletsSvc)
 	processedblocksSvc := processedblocks.New(st)
 	processedincidentsSvc := processedincidents.New(st)
 	transfersSvc := transfers.New(l, conf, st, walletsSvc, explorerProxySvc, blockchains, rmanager)
+	sweepsSvc := sweeps.New(l, conf, st, walletsSvc, transfersSvc, explorerProxySvc)
 	webhooksSvc := webhooks.New(l, conf, st, transfersSvc, ownersSvc)
+	rotationsSvc := rotations.New(l, conf, st, walletsSvc, transfersSvc, explorerProxySvc, webhooksSvc, secretsSvc)
 	upd, err := updater.NewService(ctx, l, conf)
 	if err != nil {
 		return nil, err
 	}
 	return &service{
+		audit:              auditSvc,
 		clients:            clientsSvc,
 		owners:             ownersSvc,
 		processedBlocks:    processedblocksSvc,
@@ -106,6 +129,10 @@ func New(
 		webhooks:           webhooksSvc,
 		eproxy:             explorerProxySvc,
 		transfers:          transfersSvc,
+		sweeps:             sweepsSvc,
+		rotations:          rotationsSvc,
+		secrets:            secretsSvc,
+		signer:             signerSvc,
 		blockchains:        blockchains,
 		madmin:             madmin,
 		rmanager:           rmanager,
@@ -113,6 +140,32 @@ func New(
 	}, nil
 }
 
+// newSigner creates the secrets service and the signer. With the remote signer the master key
+// is held by the signer process, so the secrets are sealed by it and the seeds are not decrypted here.
+func newSigner(conf *config.Config, st store.IStore, sdk *walletsdk.SDK, utxo signer.UTXOProvider) (*secrets.Service, signer.Signer, error) {
+	if conf.Signer.Mode == config.SignerModeRemote {
+		remote, err := signer.NewRemote(conf.Signer.Remote)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return secrets.NewSealed(conf, st, remote), remote, nil
+	}
+
+	secretsSvc, err := secrets.New(conf, st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signerSvc, err := signer.New(conf, st, secretsSvc, sdk, utxo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secretsSvc, signerSvc, nil
+}
+
+func (s *service) Audit() *audit.Service                           { return s.audit }
 func (s *service) Clients() *clients.Service                       { return s.clients }
 func (s *service) Owners() *owners.Service                         { return s.owners }
 func (s *service) ProcessedBlocks() *processedblocks.Service       { return s.processedBlocks }
@@ -121,6 +174,10 @@ func (s *service) Wallets() *wallets.Service                       { return s.wa
 func (s *service) System() system.IService                         { return s.system }
 func (s *service) Webhooks() *webhooks.Service                     { return s.webhooks }
 func (s *service) Transfers() *transfers.Service                   { return s.transfers }
+func (s *service) Sweeps() *sweeps.Service                         { return s.sweeps }
+func (s *service) Rotations() *rotations.Service                   { return s.rotations }
+func (s *service) Secrets() *secrets.Service                       { return s.secrets }
+func (s *service) Signer() signer.Signer                           { return s.signer }
 func (s *service) EProxy() *eproxy.Service                         { return s.eproxy }
 func (s *service) Blockchains() *blockchains.Blockchains           { return s.blockchains }
 func (s *service) BTC() *btc.BTC                                   { return s.blockchains.Bitcoin }
@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAllowedIP is returned when the allowlist entry is neither an ip address nor a cidr range.
+var ErrInvalidAllowedIP = errors.New("invalid ip address or cidr range")
+
+// SetAllowedIPs sets the ip addresses and the cidr ranges the client requests are accepted from.
+// The empty list allows the requests from any address.
+func (s *Service) SetAllowedIPs(ctx context.Context, clientID uuid.UUID, allowedIPs []string) ([]string, error) {
+	if clientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	prefixes := make([]string, 0, len(allowedIPs))
+	for _, item := range allowedIPs {
+		prefix, err := parseAllowedIP(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(prefixes, prefix.String()) {
+			prefixes = append(prefixes, prefix.String())
+		}
+	}
+
+	exists, err := s.ExistsByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, storecmn.ErrNotFound
+	}
+
+	s.store.Cache().Clients().Delete(clientID.String())
+
+	if err := s.store.Clients().SetAllowedIPs(ctx, prefixes, clientID); err != nil {
+		return nil, fmt.Errorf("set allowed ips: %w", err)
+	}
+
+	return prefixes, nil
+}
+
+// AllowsIP checks that the client accepts the requests from the ip address
+func AllowsIP(client *models.Client, ip string) bool {
+	if len(client.AllowedIps) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap().WithZone("")
+	for _, item := range client.AllowedIps {
+		prefix, err := parseAllowedIP(item)
+		if err != nil {
+			continue
+		}
+
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAllowedIP parses the ip address or the cidr range to the masked prefix
+func parseAllowedIP(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("%w: %s", ErrInvalidAllowedIP, value)
+		}
+
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %s", ErrInvalidAllowedIP, value)
+	}
+
+	addr = addr.Unmap().WithZone("")
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowsIP(t *testing.T) {
+	require.True(t, AllowsIP(&models.Client{}, "198.51.100.7"))
+
+	client := &models.Client{AllowedIps: []string{"203.0.113.10/32", "10.20.0.0/16", "2001:db8::/32"}}
+
+	for ip, allowed := range map[string]bool{
+		"203.0.113.10":     true,
+		"203.0.113.11":     false,
+		"10.20.30.40":      true,
+		"10.21.0.1":        false,
+		"::ffff:10.20.1.1": true,
+		"2001:db8::1":      true,
+		"2001:db9::1":      false,
+		"":                 false,
+	} {
+		require.Equal(t, allowed, AllowsIP(client, ip), ip)
+	}
+}
+
+func TestParseAllowedIP(t *testing.T) {
+	for value, expected := range map[string]string{
+		"203.0.113.10":        "203.0.113.10/32",
+		" 10.20.30.40/16 ":    "10.20.0.0/16",
+		"::ffff:10.0.0.1":     "10.0.0.1/32",
+		"::ffff:10.0.0.0/104": "10.0.0.0/8",
+		"2001:db8::1/32":      "2001:db8::/32",
+	} {
+		prefix, err := parseAllowedIP(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, prefix.String())
+	}
+
+	for _, value := range []string{"", "localhost", "10.0.0.0/33", "10.0.0"} {
+		_, err := parseAllowedIP(value)
+		require.ErrorIs(t, err, ErrInvalidAllowedIP, value)
+	}
+}
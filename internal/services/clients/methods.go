@@ -9,8 +9,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/dv-net/dv-processing/internal/constants"
 	madmin_requests "github.com/dv-net/dv-processing/internal/madmin/requests"
 	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/dv-net/dv-processing/pkg/utils"
@@ -48,7 +50,11 @@ func (s *Service) Create(ctx context.Context, dto CreateClientDTO) (*CreateClien
 		return nil, fmt.Errorf("invalid processing ip: %s", processingIP)
 	}
 
-	var adminSecretKey string
+	var (
+		adminSecretKey string
+		apiSecret      *models.ClientSecret
+		webhookSecret  *models.ClientSecret
+	)
 	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
 		client, err = s.store.Clients(repos.WithTx(tx)).Create(ctx, secretKey, dto.CallbackURL)
 		if err != nil {
@@ -58,6 +64,22 @@ func (s *Service) Create(ctx context.Context, dto CreateClientDTO) (*CreateClien
 			return err
 		}
 
+		// the initial secrets of both scopes have the same key, they are rotated separately
+		apiSecret, err = s.createSecret(ctx, client.ID, constants.ClientSecretScopeAPI, secretKey, repos.WithTx(tx))
+		if err != nil {
+			return err
+		}
+
+		webhookSecret, err = s.createSecret(ctx, client.ID, constants.ClientSecretScopeWebhook, secretKey, repos.WithTx(tx))
+		if err != nil {
+			return err
+		}
+
+		// the default endpoint sends all webhooks to the callback url
+		if err = s.store.WebhookEndpoints(repos.WithTx(tx)).CreateDefault(ctx, client.ID); err != nil {
+			return fmt.Errorf("create default webhook endpoint: %w", err)
+		}
+
 		req := &madmin_requests.RegisterRequest{
 			BackendClientID:   client.ID.String(),
 			BackendVersion:    dto.BackendVersion,
@@ -82,6 +104,16 @@ func (s *Service) Create(ctx context.Context, dto CreateClientDTO) (*CreateClien
 				return fmt.Errorf("set secret key: %w", err)
 			}
 
+			if _, err = s.auditSvc.RecordTx(ctx, tx, audit.Event{
+				ClientID:    uuid.NullUUID{UUID: client.ID, Valid: true},
+				Procedure:   audit.ProcedureSetDvSecretKey,
+				SourceIP:    audit.SourceFromContext(ctx).IP,
+				Result:      audit.ResultSuccess,
+				Fingerprint: audit.Fingerprint(audit.ProcedureSetDvSecretKey, []byte(registerResp.Data.Processing.SecretKey)),
+			}); err != nil {
+				return err
+			}
+
 			adminSecretKey = registerResp.Data.Backend.SecretKey
 		}
 
@@ -92,8 +124,10 @@ func (s *Service) Create(ctx context.Context, dto CreateClientDTO) (*CreateClien
 	}
 
 	return &CreateClientResult{
-		AdminSecret: adminSecretKey,
-		Client:      client,
+		AdminSecret:   adminSecretKey,
+		Client:        client,
+		APISecret:     apiSecret,
+		WebhookSecret: webhookSecret,
 	}, nil
 }
 
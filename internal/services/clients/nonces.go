@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrNonceAlreadyUsed = errors.New("request nonce is already used")
+
+// SetRequireSignV2 sets whether the client requests must be signed with the v2 scheme.
+func (s *Service) SetRequireSignV2(ctx context.Context, clientID uuid.UUID, require bool) error {
+	if clientID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	exists, err := s.ExistsByID(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return storecmn.ErrNotFound
+	}
+
+	s.store.Cache().Clients().Delete(clientID.String())
+
+	return s.store.Clients().SetRequireSignV2(ctx, require, clientID)
+}
+
+// UseRequestNonce stores the nonce of the signed request. It returns ErrNonceAlreadyUsed
+// if the request with the nonce has been already received.
+func (s *Service) UseRequestNonce(ctx context.Context, clientID uuid.UUID, nonce string) error {
+	created, err := s.store.Clients().CreateRequestNonce(ctx, clientID, nonce)
+	if err != nil {
+		return fmt.Errorf("create request nonce: %w", err)
+	}
+
+	if created == 0 {
+		return ErrNonceAlreadyUsed
+	}
+
+	return nil
+}
+
+// DeleteExpiredRequestNonces deletes the nonces received before the time.
+func (s *Service) DeleteExpiredRequestNonces(ctx context.Context, before time.Time) (int64, error) {
+	return s.store.Clients().DeleteExpiredRequestNonces(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
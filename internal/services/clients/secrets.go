@@ -0,0 +1,214 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_clients"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultSecretGracePeriod is the time the previous secrets remain active after the rotation
+const DefaultSecretGracePeriod = 24 * time.Hour
+
+const secretKeySize = 32
+
+type RotateSecretDTO struct {
+	ClientID    uuid.UUID
+	Scope       constants.ClientSecretScope
+	GracePeriod time.Duration
+}
+
+type RotateSecretResult struct {
+	Secret            *models.ClientSecret
+	PreviousExpiresAt time.Time
+}
+
+type CreateAPIKeyDTO struct {
+	ClientID    uuid.UUID
+	Permissions []constants.Permission
+	// ExpiresIn is the lifetime of the key, the key does not expire if it is zero
+	ExpiresIn time.Duration
+}
+
+// createSecret creates the client secret of the scope with all permissions.
+func (s *Service) createSecret(ctx context.Context, clientID uuid.UUID, scope constants.ClientSecretScope, secretKey string, opts ...repos.Option) (*models.ClientSecret, error) {
+	secret, err := s.store.Clients(opts...).CreateSecret(ctx, repo_clients.CreateSecretParams{
+		ClientID:    clientID,
+		SecretKey:   secretKey,
+		Scope:       scope,
+		Permissions: []string{constants.PermissionAll.String()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s secret: %w", scope, err)
+	}
+
+	return secret, nil
+}
+
+// RotateSecret creates a new secret of the scope. The active secrets of the scope with all
+// permissions expire after the grace period, so the client can switch to the new one.
+// The api keys limited to the permissions are not affected.
+func (s *Service) RotateSecret(ctx context.Context, dto RotateSecretDTO) (*RotateSecretResult, error) {
+	if dto.ClientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !dto.Scope.Valid() {
+		return nil, fmt.Errorf("invalid secret scope: %s", dto.Scope)
+	}
+
+	if dto.GracePeriod < 0 {
+		return nil, fmt.Errorf("grace period must not be negative")
+	}
+
+	exists, err := s.ExistsByID(ctx, dto.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, storecmn.ErrNotFound
+	}
+
+	secretKey, err := utils.SpecialKey(secretKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("key random generate: %w", err)
+	}
+
+	res := &RotateSecretResult{
+		PreviousExpiresAt: time.Now().Add(dto.GracePeriod),
+	}
+
+	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if _, err := s.store.Clients(repos.WithTx(tx)).ExpireSecrets(ctx, pgtype.Timestamptz{
+			Time:  res.PreviousExpiresAt,
+			Valid: true,
+		}, dto.ClientID, dto.Scope); err != nil {
+			return fmt.Errorf("expire %s secrets: %w", dto.Scope, err)
+		}
+
+		res.Secret, err = s.createSecret(ctx, dto.ClientID, dto.Scope, secretKey, repos.WithTx(tx))
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateAPIKey creates the api secret limited to the permissions. The key is active
+// together with the other api secrets of the client.
+func (s *Service) CreateAPIKey(ctx context.Context, dto CreateAPIKeyDTO) (*models.ClientSecret, error) {
+	if dto.ClientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if len(dto.Permissions) == 0 {
+		return nil, fmt.Errorf("permissions are required")
+	}
+
+	permissions := make([]string, 0, len(dto.Permissions))
+	for _, permission := range dto.Permissions {
+		if !permission.Valid() {
+			return nil, fmt.Errorf("invalid permission: %s", permission)
+		}
+
+		if !slices.Contains(permissions, permission.String()) {
+			permissions = append(permissions, permission.String())
+		}
+	}
+
+	if dto.ExpiresIn < 0 {
+		return nil, fmt.Errorf("expiration must not be negative")
+	}
+
+	exists, err := s.ExistsByID(ctx, dto.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, storecmn.ErrNotFound
+	}
+
+	secretKey, err := utils.SpecialKey(secretKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("key random generate: %w", err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if dto.ExpiresIn > 0 {
+		expiresAt = pgtype.Timestamptz{Time: time.Now().Add(dto.ExpiresIn), Valid: true}
+	}
+
+	secret, err := s.store.Clients().CreateSecret(ctx, repo_clients.CreateSecretParams{
+		ClientID:    dto.ClientID,
+		SecretKey:   secretKey,
+		Scope:       constants.ClientSecretScopeAPI,
+		ExpiresAt:   expiresAt,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	return secret, nil
+}
+
+// RevokeSecret revokes the client secret immediately.
+func (s *Service) RevokeSecret(ctx context.Context, clientID, secretID uuid.UUID) error {
+	if clientID == uuid.Nil || secretID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	revoked, err := s.store.Clients().RevokeSecret(ctx, secretID, clientID)
+	if err != nil {
+		return fmt.Errorf("revoke secret: %w", err)
+	}
+
+	if revoked == 0 {
+		return storecmn.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetSecrets returns all client secrets including the expired and revoked ones, the newest first.
+func (s *Service) GetSecrets(ctx context.Context, clientID uuid.UUID) ([]*models.ClientSecret, error) {
+	if clientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	return s.store.Clients().GetSecrets(ctx, clientID)
+}
+
+// GetActiveSecrets returns the active client secrets of the scope, the newest first.
+func (s *Service) GetActiveSecrets(ctx context.Context, clientID uuid.UUID, scope constants.ClientSecretScope) ([]*models.ClientSecret, error) {
+	return s.store.Clients().GetActiveSecrets(ctx, clientID, scope)
+}
+
+// GetActiveSecret returns the active client secret of the scope by the ID.
+func (s *Service) GetActiveSecret(ctx context.Context, clientID, secretID uuid.UUID, scope constants.ClientSecretScope) (*models.ClientSecret, error) {
+	secret, err := s.store.Clients().GetActiveSecretByID(ctx, secretID, clientID, scope)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storecmn.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return secret, nil
+}
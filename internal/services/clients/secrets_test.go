@@ -0,0 +1,207 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_clients"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// secretsStore keeps the client secrets in memory, the other repositories are not implemented
+type secretsStore struct {
+	store.IStore
+	clients map[uuid.UUID]bool
+	secrets []*models.ClientSecret
+}
+
+func (s *secretsStore) Clients(...repos.Option) repo_clients.Querier { return secretsQuerier{s: s} }
+
+type secretsQuerier struct {
+	repo_clients.Querier
+	s *secretsStore
+}
+
+func (q secretsQuerier) ExistsByID(_ context.Context, id uuid.UUID) (bool, error) {
+	return q.s.clients[id], nil
+}
+
+func (q secretsQuerier) CreateSecret(_ context.Context, arg repo_clients.CreateSecretParams) (*models.ClientSecret, error) {
+	secret := &models.ClientSecret{
+		ID:          uuid.New(),
+		ClientID:    arg.ClientID,
+		SecretKey:   arg.SecretKey,
+		Scope:       arg.Scope,
+		CreatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		ExpiresAt:   arg.ExpiresAt,
+		Permissions: arg.Permissions,
+	}
+	q.s.secrets = append(q.s.secrets, secret)
+	return secret, nil
+}
+
+func (q secretsQuerier) RevokeSecret(_ context.Context, id, clientID uuid.UUID) (int64, error) {
+	for _, secret := range q.s.secrets {
+		if secret.ID == id && secret.ClientID == clientID && !secret.RevokedAt.Valid {
+			secret.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (q secretsQuerier) GetActiveSecretByID(_ context.Context, id, clientID uuid.UUID, scope constants.ClientSecretScope) (*models.ClientSecret, error) {
+	for _, secret := range q.s.secrets {
+		if secret.ID == id && secret.ClientID == clientID && secret.Scope == scope && !secret.RevokedAt.Valid &&
+			(!secret.ExpiresAt.Valid || secret.ExpiresAt.Time.After(time.Now())) {
+			return secret, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	clientID := uuid.New()
+
+	tests := []struct {
+		name        string
+		dto         CreateAPIKeyDTO
+		err         error
+		permissions []string
+		expires     bool
+	}{
+		{
+			name:        "deduplicated permissions",
+			dto:         CreateAPIKeyDTO{ClientID: clientID, Permissions: []constants.Permission{constants.PermissionWalletsRead, constants.PermissionTransfersRead, constants.PermissionWalletsRead}},
+			permissions: []string{"wallets:read", "transfers:read"},
+		},
+		{
+			name:        "expiring",
+			dto:         CreateAPIKeyDTO{ClientID: clientID, Permissions: []constants.Permission{constants.PermissionWebhooksRead}, ExpiresIn: time.Hour},
+			permissions: []string{"webhooks:read"},
+			expires:     true,
+		},
+		{
+			name: "empty client",
+			dto:  CreateAPIKeyDTO{Permissions: []constants.Permission{constants.PermissionWalletsRead}},
+			err:  storecmn.ErrEmptyID,
+		},
+		{
+			name: "unknown client",
+			dto:  CreateAPIKeyDTO{ClientID: uuid.New(), Permissions: []constants.Permission{constants.PermissionWalletsRead}},
+			err:  storecmn.ErrNotFound,
+		},
+		{
+			name: "no permissions",
+			dto:  CreateAPIKeyDTO{ClientID: clientID},
+		},
+		{
+			name: "invalid permission",
+			dto:  CreateAPIKeyDTO{ClientID: clientID, Permissions: []constants.Permission{"wallets:delete"}},
+		},
+		{
+			name: "negative expiration",
+			dto:  CreateAPIKeyDTO{ClientID: clientID, Permissions: []constants.Permission{constants.PermissionWalletsRead}, ExpiresIn: -time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &secretsStore{clients: map[uuid.UUID]bool{clientID: true}}
+			s := &Service{store: st}
+
+			secret, err := s.CreateAPIKey(context.Background(), tt.dto)
+			switch {
+			case tt.err != nil:
+				require.ErrorIs(t, err, tt.err)
+				require.Empty(t, st.secrets)
+			case tt.permissions == nil:
+				require.Error(t, err)
+				require.Empty(t, st.secrets)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, constants.ClientSecretScopeAPI, secret.Scope)
+				require.Equal(t, tt.permissions, secret.Permissions)
+				require.Equal(t, tt.expires, secret.ExpiresAt.Valid)
+				require.NotEmpty(t, secret.SecretKey)
+			}
+		})
+	}
+}
+
+func TestRotateSecretValidation(t *testing.T) {
+	clientID := uuid.New()
+
+	tests := []struct {
+		name string
+		dto  RotateSecretDTO
+		err  error
+	}{
+		{name: "empty client", dto: RotateSecretDTO{Scope: constants.ClientSecretScopeAPI}, err: storecmn.ErrEmptyID},
+		{name: "invalid scope", dto: RotateSecretDTO{ClientID: clientID, Scope: "admin"}},
+		{name: "negative grace period", dto: RotateSecretDTO{ClientID: clientID, Scope: constants.ClientSecretScopeWebhook, GracePeriod: -time.Minute}},
+		{name: "unknown client", dto: RotateSecretDTO{ClientID: uuid.New(), Scope: constants.ClientSecretScopeAPI}, err: storecmn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{store: &secretsStore{clients: map[uuid.UUID]bool{clientID: true}}}
+
+			_, err := s.RotateSecret(context.Background(), tt.dto)
+			require.Error(t, err)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRevokeSecret(t *testing.T) {
+	clientID, otherClientID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		clientID uuid.UUID
+		secretID func(secret *models.ClientSecret) uuid.UUID
+		err      error
+	}{
+		{name: "revoked", clientID: clientID, secretID: func(secret *models.ClientSecret) uuid.UUID { return secret.ID }},
+		{name: "empty secret", clientID: clientID, secretID: func(*models.ClientSecret) uuid.UUID { return uuid.Nil }, err: storecmn.ErrEmptyID},
+		{name: "unknown secret", clientID: clientID, secretID: func(*models.ClientSecret) uuid.UUID { return uuid.New() }, err: storecmn.ErrNotFound},
+		{name: "secret of other client", clientID: otherClientID, secretID: func(secret *models.ClientSecret) uuid.UUID { return secret.ID }, err: storecmn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &secretsStore{clients: map[uuid.UUID]bool{clientID: true, otherClientID: true}}
+			s := &Service{store: st}
+
+			secret, err := s.CreateAPIKey(context.Background(), CreateAPIKeyDTO{ClientID: clientID, Permissions: []constants.Permission{constants.PermissionWalletsRead}})
+			require.NoError(t, err)
+
+			err = s.RevokeSecret(context.Background(), tt.clientID, tt.secretID(secret))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+
+				_, err = s.GetActiveSecret(context.Background(), clientID, secret.ID, constants.ClientSecretScopeAPI)
+				require.NoError(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// the revoked secret is not active anymore and can not be revoked twice
+			_, err = s.GetActiveSecret(context.Background(), clientID, secret.ID, constants.ClientSecretScopeAPI)
+			require.ErrorIs(t, err, storecmn.ErrNotFound)
+			require.ErrorIs(t, s.RevokeSecret(context.Background(), clientID, secret.ID), storecmn.ErrNotFound)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"github.com/dv-net/dv-processing/internal/madmin"
+	"github.com/dv-net/dv-processing/internal/services/audit"
 	"github.com/dv-net/dv-processing/internal/services/system"
 	"github.com/dv-net/dv-processing/internal/store"
 )
@@ -10,16 +11,19 @@ type Service struct {
 	store     store.IStore
 	systemSvc system.IService
 	madminSvc *madmin.Service
+	auditSvc  *audit.Service
 }
 
 func New(
 	st store.IStore,
 	systemSvc system.IService,
 	madminSvc *madmin.Service,
+	auditSvc *audit.Service,
 ) *Service {
 	return &Service{
 		store:     st,
 		madminSvc: madminSvc,
 		systemSvc: systemSvc,
+		auditSvc:  auditSvc,
 	}
 }
@@ -3,8 +3,10 @@ package clients
 import "github.com/dv-net/dv-processing/internal/models"
 
 type CreateClientResult struct {
-	AdminSecret string
-	Client      *models.Client
+	AdminSecret   string
+	Client        *models.Client
+	APISecret     *models.ClientSecret
+	WebhookSecret *models.ClientSecret
 }
 
 type CreateClientDTO struct {
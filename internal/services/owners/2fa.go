@@ -26,7 +26,7 @@ type OTPData struct {
 	OtpConfirmed bool   `json:"otp_confirmed"`
 }
 
-func (s *Service) getOTPSecret(owner *models.Owner) (string, error) {
+func (s *Service) getOTPSecret(ctx context.Context, owner *models.Owner) (string, error) {
 	// Try new otp_data format first
 	if owner.OtpData.Valid && owner.OtpData.String != "" {
 		otpDataStr := owner.OtpData.String
@@ -34,7 +34,7 @@ func (s *Service) getOTPSecret(owner *models.Owner) (string, error) {
 		// Decrypt if encrypted
 		if encryption.IsEncrypted(owner.OtpData.String) {
 			var err error
-			otpDataStr, err = encryption.Decrypt(owner.OtpData.String, owner.ID.String())
+			otpDataStr, err = s.secrets.DecryptOTPData(ctx, owner.ID, owner.OtpData.String)
 			if err != nil {
 				return "", fmt.Errorf("decrypt otp data: %w", err)
 			}
@@ -49,7 +49,7 @@ func (s *Service) getOTPSecret(owner *models.Owner) (string, error) {
 	}
 
 	if owner.OtpSecret.Valid && owner.OtpSecret.String != "" {
-		otpSecret, err := encryption.Decrypt(owner.OtpSecret.String, owner.ID.String())
+		otpSecret, err := s.secrets.Decrypt(ctx, owner.ID, owner.OtpSecret.String)
 		if err != nil {
 			return "", fmt.Errorf("decrypt legacy otp secret: %w", err)
 		}
@@ -60,7 +60,7 @@ func (s *Service) getOTPSecret(owner *models.Owner) (string, error) {
 }
 
 // getOTPConfirmed extracts the OTP confirmed status from either otp_data (new format) or otp_confirmed (legacy format)
-func (s *Service) getOTPConfirmed(owner *models.Owner) bool {
+func (s *Service) getOTPConfirmed(ctx context.Context, owner *models.Owner) bool {
 	// Try new otp_data format first
 	if owner.OtpData.Valid && owner.OtpData.String != "" {
 		otpDataStr := owner.OtpData.String
@@ -68,7 +68,7 @@ func (s *Service) getOTPConfirmed(owner *models.Owner) bool {
 		// Decrypt if encrypted
 		if encryption.IsEncrypted(owner.OtpData.String) {
 			var err error
-			otpDataStr, err = encryption.Decrypt(owner.OtpData.String, owner.ID.String())
+			otpDataStr, err = s.secrets.DecryptOTPData(ctx, owner.ID, owner.OtpData.String)
 			if err != nil {
 				return owner.OtpConfirmed
 			}
@@ -86,33 +86,32 @@ func (s *Service) getOTPConfirmed(owner *models.Owner) bool {
 }
 
 // ConfirmTwoFactorAuth confirms two-factor authentication for the owner.
-func (s *Service) ConfirmTwoFactorAuth(ctx context.Context, ownerID uuid.UUID, otp string) error {
+// It returns the one-time recovery codes which are accepted instead of the totp.
+func (s *Service) ConfirmTwoFactorAuth(ctx context.Context, ownerID uuid.UUID, otp string) ([]string, error) {
 	if ownerID == uuid.Nil {
-		return storecmn.ErrEmptyID
+		return nil, storecmn.ErrEmptyID
 	}
 
 	if otp == "" {
-		return storecmn.ErrEmptyOTP
+		return nil, storecmn.ErrEmptyOTP
 	}
 
 	owner, err := s.GetByID(ctx, ownerID)
 	if err != nil {
-		return fmt.Errorf("get owner: %w", err)
+		return nil, fmt.Errorf("get owner: %w", err)
 	}
 
-	otpSecret, err := s.getOTPSecret(owner)
+	otpSecret, err := s.getOTPSecret(ctx, owner)
 	if err != nil {
-		return fmt.Errorf("get otp secret: %w", err)
+		return nil, fmt.Errorf("get otp secret: %w", err)
 	}
 
-	// Validate as soon as we have it, since it's time based.
-	// Also check for remaining request's fields to reduce store calls.
-	if ok := totp.Validate(otp, otpSecret); !ok {
-		return fmt.Errorf("failed to validate totp")
+	if s.getOTPConfirmed(ctx, owner) {
+		return nil, fmt.Errorf("owner already enabled two-factor authentication")
 	}
 
-	if s.getOTPConfirmed(owner) {
-		return fmt.Errorf("owner already enabled two-factor authentication")
+	if err := s.verifyTwoFactorCode(ctx, owner.ID, otpSecret, otp, false); err != nil {
+		return nil, fmt.Errorf("validate totp: %w", err)
 	}
 
 	s.store.Cache().Owners().Delete(ownerID.String())
@@ -124,21 +123,35 @@ func (s *Service) ConfirmTwoFactorAuth(ctx context.Context, ownerID uuid.UUID, o
 	}
 	otpDataStr, err := json.Marshal(otpData)
 	if err != nil {
-		return fmt.Errorf("marshal otp data: %w", err)
+		return nil, fmt.Errorf("marshal otp data: %w", err)
 	}
 	// Always encrypt new OTP data
-	encryptedOtpData, err := encryption.Encrypt(string(otpDataStr), owner.ID.String())
+	encryptedOtpData, err := s.secrets.Encrypt(ctx, owner.ID, string(otpDataStr))
 	if err != nil {
-		return fmt.Errorf("encrypt otp data: %w", err)
+		return nil, fmt.Errorf("encrypt otp data: %w", err)
 	}
-	if err := s.store.Owners().SetOTPData(ctx, owner.ID, pgtype.Text{
-		String: encryptedOtpData,
-		Valid:  true,
-	}); err != nil {
-		return fmt.Errorf("set otp data: %w", err)
+
+	var recoveryCodes []string
+	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if err := s.store.Owners(repos.WithTx(tx)).SetOTPData(ctx, owner.ID, pgtype.Text{
+			String: encryptedOtpData,
+			Valid:  true,
+		}); err != nil {
+			return fmt.Errorf("set otp data: %w", err)
+		}
+
+		if err := s.store.Owners(repos.WithTx(tx)).ConfirmTwoFactorAuth(ctx, ownerID); err != nil {
+			return fmt.Errorf("confirm two factor auth: %w", err)
+		}
+
+		recoveryCodes, err = s.createRecoveryCodes(ctx, ownerID, repos.WithTx(tx))
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.store.Owners().ConfirmTwoFactorAuth(ctx, ownerID)
+	return recoveryCodes, nil
 }
 
 // DisableTwoFactorAuth disables two-factor authentication for the owner.
@@ -157,19 +170,17 @@ func (s *Service) DisableTwoFactorAuth(ctx context.Context, ownerID uuid.UUID, o
 	}
 
 	// get 2FA secret from either new or legacy format
-	otpSecret, err := s.getOTPSecret(owner)
+	otpSecret, err := s.getOTPSecret(ctx, owner)
 	if err != nil {
 		return fmt.Errorf("get otp secret: %w", err)
 	}
 
-	// Validate as soon as we have it, since it's time based.
-	// Also check for remaining request's fields to reduce store calls.
-	if ok := totp.Validate(otpKey, otpSecret); !ok {
-		return fmt.Errorf("failed to validate totp")
+	if !s.getOTPConfirmed(ctx, owner) {
+		return fmt.Errorf("owner already disabled two-factor authentication")
 	}
 
-	if !s.getOTPConfirmed(owner) {
-		return fmt.Errorf("owner already disabled two-factor authentication")
+	if err := s.verifyTwoFactorCode(ctx, owner.ID, otpSecret, otpKey, true); err != nil {
+		return fmt.Errorf("validate totp: %w", err)
 	}
 
 	newSecret, err := totp.Generate(
@@ -198,28 +209,40 @@ func (s *Service) DisableTwoFactorAuth(ctx context.Context, ownerID uuid.UUID, o
 	}
 
 	// Always encrypt new OTP data
-	encryptedOtpData, err := encryption.Encrypt(string(otpDataStr), owner.ID.String())
+	encryptedOtpData, err := s.secrets.Encrypt(ctx, owner.ID, string(otpDataStr))
 	if err != nil {
 		return fmt.Errorf("encrypt otp data: %w", err)
 	}
-	if err := s.store.Owners().SetOTPData(ctx, owner.ID, pgtype.Text{
-		String: encryptedOtpData,
-		Valid:  true,
-	}); err != nil {
-		return fmt.Errorf("set otp data: %w", err)
-	}
 
-	return s.store.Owners().DisableTwoFactorAuth(ctx,
-		pgtype.Text{
-			String: finalNewSecret,
+	return pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if err := s.store.Owners(repos.WithTx(tx)).SetOTPData(ctx, owner.ID, pgtype.Text{
+			String: encryptedOtpData,
 			Valid:  true,
-		}, ownerID,
-	)
+		}); err != nil {
+			return fmt.Errorf("set otp data: %w", err)
+		}
+
+		if err := s.store.OwnerTwoFactor(repos.WithTx(tx)).DeleteRecoveryCodes(ctx, ownerID); err != nil {
+			return fmt.Errorf("delete recovery codes: %w", err)
+		}
+
+		if err := s.store.OwnerTwoFactor(repos.WithTx(tx)).DeleteState(ctx, ownerID); err != nil {
+			return fmt.Errorf("delete two factor state: %w", err)
+		}
+
+		return s.store.Owners(repos.WithTx(tx)).DisableTwoFactorAuth(ctx,
+			pgtype.Text{
+				String: finalNewSecret,
+				Valid:  true,
+			}, ownerID,
+		)
+	})
 }
 
 type GetTwoFactorAuthDataResponse struct {
-	Secret      *string
-	IsConfirmed bool
+	Secret            *string
+	IsConfirmed       bool
+	RecoveryCodesLeft int64
 }
 
 // GetTwoFactorAuthData returns the two-factor authentication data for the owner.
@@ -238,8 +261,14 @@ func (s *Service) GetTwoFactorAuthData(ctx context.Context, ownerID uuid.UUID) (
 	}
 
 	if owner.OtpConfirmed {
+		recoveryCodesLeft, err := s.store.OwnerTwoFactor().CountUnusedRecoveryCodes(ctx, owner.ID)
+		if err != nil {
+			return nil, fmt.Errorf("count recovery codes: %w", err)
+		}
+
 		return &GetTwoFactorAuthDataResponse{
-			IsConfirmed: owner.OtpConfirmed,
+			IsConfirmed:       owner.OtpConfirmed,
+			RecoveryCodesLeft: recoveryCodesLeft,
 		}, nil
 	}
 
@@ -269,20 +298,58 @@ func (s *Service) ValidateTwoFactorToken(ctx context.Context, ownerID uuid.UUID,
 		return fmt.Errorf("get owner: %w", err)
 	}
 
-	if !s.getOTPConfirmed(owner) {
+	if !s.getOTPConfirmed(ctx, owner) {
 		return fmt.Errorf("owner has not confirmed two-factor authentication")
 	}
 
 	// get 2FA secret from either new or legacy format
-	otpSecret, err := s.getOTPSecret(owner)
+	otpSecret, err := s.getOTPSecret(ctx, owner)
 	if err != nil {
 		return fmt.Errorf("get otp secret: %w", err)
 	}
-	if ok := totp.Validate(token, otpSecret); !ok {
-		return fmt.Errorf("failed to validate totp")
+
+	return s.verifyTwoFactorCode(ctx, owner.ID, otpSecret, token, true)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the owner and returns the new ones.
+// Only the totp is accepted, so the codes can not be replaced by the leaked recovery code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, ownerID uuid.UUID, otp string) ([]string, error) {
+	if ownerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if otp == "" {
+		return nil, storecmn.ErrEmptyOTP
+	}
+
+	owner, err := s.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("get owner: %w", err)
+	}
+
+	if !s.getOTPConfirmed(ctx, owner) {
+		return nil, fmt.Errorf("owner has not confirmed two-factor authentication")
+	}
+
+	otpSecret, err := s.getOTPSecret(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("get otp secret: %w", err)
+	}
+
+	if err := s.verifyTwoFactorCode(ctx, owner.ID, otpSecret, otp, false); err != nil {
+		return nil, fmt.Errorf("validate totp: %w", err)
+	}
+
+	var recoveryCodes []string
+	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) (err error) {
+		recoveryCodes, err = s.createRecoveryCodes(ctx, owner.ID, repos.WithTx(tx))
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return recoveryCodes, nil
 }
 
 func (s *Service) EncryptOTPDataForAllOwners(ctx context.Context) error {
@@ -297,7 +364,7 @@ func (s *Service) EncryptOTPDataForAllOwners(ctx context.Context) error {
 				continue
 			}
 
-			encryptedData, err := encryption.Encrypt(owner.OtpData.String, owner.ID.String())
+			encryptedData, err := s.secrets.Encrypt(ctx, owner.ID, owner.OtpData.String, repos.WithTx(tx))
 			if err != nil {
 				return err
 			}
@@ -327,7 +394,7 @@ func (s *Service) DecryptOTPDataForAllOwners(ctx context.Context) error {
 				continue
 			}
 
-			decryptedData, err := encryption.Decrypt(owner.OtpData.String, owner.ID.String())
+			decryptedData, err := s.secrets.DecryptOTPData(ctx, owner.ID, owner.OtpData.String, repos.WithTx(tx))
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,201 @@
+package owners
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod = 30
+
+	// twoFactorFreeAttempts is the number of the failed attempts before the lockout
+	twoFactorFreeAttempts = 5
+	// twoFactorLockout is the lockout after the first attempt over the free ones, it doubles with every next attempt
+	twoFactorLockout    = 30 * time.Second
+	twoFactorMaxLockout = time.Hour
+
+	recoveryCodesCount = 10
+	// recoveryCodeSize is the number of the random bytes of the recovery code, 80 bits
+	recoveryCodeSize = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// verifyTwoFactorCode checks the totp or the recovery code of the owner.
+//
+// The owner is locked out after the failed attempts with the exponential back-off. The totp
+// is accepted once, the codes of the already used time steps are rejected. The recovery
+// codes are accepted once too and are not checked if allowRecovery is false.
+//
+// The state of the owner is locked while the code is checked, so the concurrent attempts
+// are counted one by one and can not pass the lockout.
+func (s *Service) verifyTwoFactorCode(ctx context.Context, ownerID uuid.UUID, otpSecret, code string, allowRecovery bool) error {
+	tx, err := s.store.PSQLConn().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	repo := s.store.OwnerTwoFactor(repos.WithTx(tx))
+
+	state, err := repo.LockState(ctx, ownerID)
+	if err != nil {
+		return fmt.Errorf("lock two factor state: %w", err)
+	}
+
+	if state.LockedUntil.Valid && time.Now().Before(state.LockedUntil.Time) {
+		return fmt.Errorf("%w until %s", ErrTwoFactorLocked, state.LockedUntil.Time.UTC().Format(time.RFC3339))
+	}
+
+	verifyErr := ErrInvalidOTP
+	if recoveryCode, ok := normalizeRecoveryCode(code); ok {
+		if allowRecovery {
+			used, err := repo.UseRecoveryCode(ctx, ownerID, hashRecoveryCode(ownerID, recoveryCode))
+			if err != nil {
+				return fmt.Errorf("use recovery code: %w", err)
+			}
+
+			if used > 0 {
+				if err := repo.ResetFailures(ctx, ownerID); err != nil {
+					return fmt.Errorf("reset two factor failures: %w", err)
+				}
+				return tx.Commit(ctx)
+			}
+		}
+	} else if step, ok := matchTOTPStep(code, otpSecret, time.Now()); ok {
+		used, err := repo.UseStep(ctx, ownerID, step)
+		if err != nil {
+			return fmt.Errorf("use totp step: %w", err)
+		}
+
+		if used > 0 {
+			return tx.Commit(ctx)
+		}
+
+		verifyErr = ErrOTPAlreadyUsed
+	}
+
+	state, err = repo.RegisterFailure(ctx, ownerID)
+	if err != nil {
+		return fmt.Errorf("register two factor failure: %w", err)
+	}
+
+	if lockout := twoFactorLockoutDuration(state.FailedAttempts); lockout > 0 {
+		if err := repo.SetLockedUntil(ctx, pgtype.Timestamptz{
+			Time:  time.Now().Add(lockout),
+			Valid: true,
+		}, ownerID); err != nil {
+			return fmt.Errorf("set two factor lockout: %w", err)
+		}
+	}
+
+	// the failed attempt is stored with the rejected code
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return verifyErr
+}
+
+// createRecoveryCodes replaces the recovery codes of the owner and returns the new codes
+func (s *Service) createRecoveryCodes(ctx context.Context, ownerID uuid.UUID, opts ...repos.Option) ([]string, error) {
+	if err := s.store.OwnerTwoFactor(opts...).DeleteRecoveryCodes(ctx, ownerID); err != nil {
+		return nil, fmt.Errorf("delete recovery codes: %w", err)
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	for range recoveryCodesCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		normalized, _ := normalizeRecoveryCode(code)
+		if err := s.store.OwnerTwoFactor(opts...).CreateRecoveryCode(ctx, ownerID, hashRecoveryCode(ownerID, normalized)); err != nil {
+			return nil, fmt.Errorf("create recovery code: %w", err)
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// matchTOTPStep returns the time step the code is valid for, the previous and the next steps are accepted
+func matchTOTPStep(code, otpSecret string, now time.Time) (int64, bool) {
+	for _, offset := range []int64{0, -1, 1} {
+		at := now.Add(time.Duration(offset*totpPeriod) * time.Second)
+
+		valid, err := totp.ValidateCustom(code, otpSecret, at, totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && valid {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+
+	return 0, false
+}
+
+// twoFactorLockoutDuration returns the lockout after the failed attempts
+func twoFactorLockoutDuration(failedAttempts int32) time.Duration {
+	if failedAttempts < twoFactorFreeAttempts {
+		return 0
+	}
+
+	lockout := twoFactorLockout
+	for range failedAttempts - twoFactorFreeAttempts {
+		lockout *= 2
+		if lockout >= twoFactorMaxLockout {
+			return twoFactorMaxLockout
+		}
+	}
+
+	return lockout
+}
+
+// generateRecoveryCode returns the random code in the xxxx-xxxx-xxxx-xxxx format
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate recovery code: %w", err)
+	}
+
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+
+	return strings.Join([]string{code[:4], code[4:8], code[8:12], code[12:]}, "-"), nil
+}
+
+// normalizeRecoveryCode returns the code without the separators if it looks like the recovery code
+func normalizeRecoveryCode(code string) (string, bool) {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != recoveryCodeEncoding.EncodedLen(recoveryCodeSize) {
+		return "", false
+	}
+
+	if _, err := recoveryCodeEncoding.DecodeString(strings.ToUpper(code)); err != nil {
+		return "", false
+	}
+
+	return code, true
+}
+
+// hashRecoveryCode returns the hash of the normalized recovery code bound to the owner
+func hashRecoveryCode(ownerID uuid.UUID, code string) string {
+	hash := sha256.Sum256([]byte(ownerID.String() + ":" + code))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,78 @@
+package owners
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchTOTPStep(t *testing.T) {
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: issuerName, AccountName: "owner"})
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_000, 0)
+	step := now.Unix() / totpPeriod
+
+	for offset := int64(-1); offset <= 1; offset++ {
+		code, err := totp.GenerateCode(key.Secret(), now.Add(time.Duration(offset*totpPeriod)*time.Second))
+		require.NoError(t, err)
+
+		matched, ok := matchTOTPStep(code, key.Secret(), now)
+		require.True(t, ok)
+		require.Equal(t, step+offset, matched)
+	}
+
+	code, err := totp.GenerateCode(key.Secret(), now.Add(-2*totpPeriod*time.Second))
+	require.NoError(t, err)
+
+	_, ok := matchTOTPStep(code, key.Secret(), now)
+	require.False(t, ok)
+}
+
+func TestTwoFactorLockoutDuration(t *testing.T) {
+	require.Zero(t, twoFactorLockoutDuration(twoFactorFreeAttempts-1))
+	require.Equal(t, twoFactorLockout, twoFactorLockoutDuration(twoFactorFreeAttempts))
+	require.Equal(t, 2*twoFactorLockout, twoFactorLockoutDuration(twoFactorFreeAttempts+1))
+	require.Equal(t, 4*twoFactorLockout, twoFactorLockoutDuration(twoFactorFreeAttempts+2))
+	require.Equal(t, twoFactorMaxLockout, twoFactorLockoutDuration(twoFactorFreeAttempts+100))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	code, err := generateRecoveryCode()
+	require.NoError(t, err)
+	require.Len(t, code, 19)
+	require.Equal(t, 3, strings.Count(code, "-"))
+
+	normalized, ok := normalizeRecoveryCode(code)
+	require.True(t, ok)
+
+	// the code is accepted without the separators and in the upper case
+	again, ok := normalizeRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", "")))
+	require.True(t, ok)
+	require.Equal(t, normalized, again)
+
+	for _, value := range []string{"123456", "", "abcd-efgh-ijkl-mno1"} {
+		_, ok := normalizeRecoveryCode(value)
+		require.False(t, ok, value)
+	}
+
+	ownerID := uuid.New()
+	require.Equal(t, hashRecoveryCode(ownerID, normalized), hashRecoveryCode(ownerID, again))
+	require.NotEqual(t, hashRecoveryCode(ownerID, normalized), hashRecoveryCode(uuid.New(), normalized))
+}
+
+func TestRegenerateRecoveryCodesArguments(t *testing.T) {
+	s := new(Service)
+
+	_, err := s.RegenerateRecoveryCodes(context.Background(), uuid.Nil, "123456")
+	require.ErrorIs(t, err, storecmn.ErrEmptyID)
+
+	_, err = s.RegenerateRecoveryCodes(context.Background(), uuid.New(), "")
+	require.ErrorIs(t, err, storecmn.ErrEmptyOTP)
+}
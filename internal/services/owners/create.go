@@ -12,8 +12,8 @@ import (
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/utils"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/dv-net/go-bip39"
 	"github.com/google/uuid"
@@ -27,6 +27,10 @@ type CreateParams struct {
 	ClientID   uuid.UUID `json:"client_id" validate:"required,uuid"`
 	ExternalID string    `json:"external_id" validate:"required"`
 	Mnemonic   string    `json:"mnemonic" validate:"required"`
+	// PassPhrase is the optional BIP39 passphrase of the mnemonic
+	PassPhrase string `json:"pass_phrase" validate:"max=256"`
+	// Derivation is the custom derivation of the owner addresses, it can not be changed later
+	Derivation derivation.Config `json:"derivation"`
 }
 
 // Create creates a new owner.
@@ -58,6 +62,10 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 		return nil, fmt.Errorf("mnemonic is invalid")
 	}
 
+	if err := params.Derivation.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDerivation, err)
+	}
+
 	// entropy, err := bip39.NewEntropy(256) //nolint:mnd
 	// if err != nil {
 	// 	return nil, fmt.Errorf("generate entropy: %w", err)
@@ -77,7 +85,8 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 		ClientID:   params.ClientID,
 		ExternalID: params.ExternalID,
 		Mnemonic:   params.Mnemonic,
-		// PassPhrase: pgtypeutils.EncodeText(&passphrase),
+		PassPhrase: pgtypeutils.EncodeText(&params.PassPhrase),
+		Derivation: params.Derivation,
 	}
 
 	if err := s.validator.Struct(createParams); err != nil {
@@ -95,10 +104,11 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 		}
 
 		var encryptedMnemonic string
+		passPhrase := params.PassPhrase
 
 		// update mnemonic
 		if s.config.IsEnabledSeedEncryption() {
-			encryptedMnemonic, err = encryption.Encrypt(params.Mnemonic, owner.ID.String())
+			encryptedMnemonic, err = s.secrets.Encrypt(ctx, owner.ID, params.Mnemonic, repos.WithTx(dbTx))
 			if err != nil {
 				return fmt.Errorf("encrypt mnemonic: %w", err)
 			}
@@ -106,6 +116,18 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 			if err := s.store.Owners(repos.WithTx(dbTx)).UpdateMnemonic(ctx, owner.ID, encryptedMnemonic); err != nil {
 				return fmt.Errorf("update mnemonic: %w", err)
 			}
+
+			// the passphrase is stored encrypted alongside the mnemonic
+			if passPhrase != "" {
+				passPhrase, err = s.secrets.Encrypt(ctx, owner.ID, passPhrase, repos.WithTx(dbTx))
+				if err != nil {
+					return fmt.Errorf("encrypt pass phrase: %w", err)
+				}
+
+				if err := s.store.Owners(repos.WithTx(dbTx)).UpdatePassPhrase(ctx, owner.ID, pgtypeutils.EncodeText(&passPhrase)); err != nil {
+					return fmt.Errorf("update pass phrase: %w", err)
+				}
+			}
 		}
 
 		totpSecret, err := totp.Generate(
@@ -133,7 +155,7 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 			return fmt.Errorf("marshal totp data: %w", err)
 		}
 
-		encryptedTotpData, err := encryption.Encrypt(string(totpDataStr), owner.ID.String())
+		encryptedTotpData, err := s.secrets.Encrypt(ctx, owner.ID, string(totpDataStr), repos.WithTx(dbTx))
 		if err != nil {
 			return fmt.Errorf("encrypt 2FA secret: %w", err)
 		}
@@ -151,7 +173,8 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*models.Owne
 				OwnerID:    owner.ID,
 				Blockchain: blockchain,
 				Mnemonic:   encryptedMnemonic,
-				// Passphrase: passphrase,
+				Passphrase: passPhrase,
+				Derivation: params.Derivation,
 			}
 
 			if _, err := s.walletsSvc.Processing().Create(ctx, createParams, repos.WithTx(dbTx)); err != nil {
@@ -12,4 +12,9 @@ var (
 	ErrEmptyMnemonic        = errors.New("empty mnemonic")
 	ErrEmptyPassPhrase      = errors.New("empty pass phrase")
 	ErrTwoFactorDisabled    = errors.New("two factor authentication is disabled")
+	ErrTwoFactorLocked      = errors.New("two factor authentication is locked after failed attempts")
+	ErrInvalidOTP           = errors.New("invalid otp")
+	ErrOTPAlreadyUsed       = errors.New("otp has already been used")
+	ErrInvalidDerivation    = errors.New("invalid derivation")
+	ErrSeedsHeldBySigner    = errors.New("seeds and private keys are exported only by the signer process in the remote signer mode")
 )
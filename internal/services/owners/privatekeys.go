@@ -9,7 +9,6 @@ import (
 
 	"github.com/dv-net/dv-processing/internal/models"
 	wallets2 "github.com/dv-net/dv-processing/internal/services/wallets"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 )
 
@@ -37,6 +36,11 @@ func (s *Service) GetHotWalletKeys(ctx context.Context, request *GetHotWalletKey
 		return nil, fmt.Errorf("validate request: %w", err)
 	}
 
+	// checked before the otp, so the code is not spent
+	if s.secrets.IsSealed() {
+		return nil, ErrSeedsHeldBySigner
+	}
+
 	owner, err := s.GetByID(ctx, request.OwnerID)
 	if err != nil {
 		return nil, fmt.Errorf("get owner: %w", err)
@@ -75,6 +79,7 @@ func (s *Service) GetHotWalletKeys(ctx context.Context, request *GetHotWalletKey
 		Addresses:  request.WalletAddresses,
 		Mnemonic:   owner.Mnemonic,
 		Passphrase: owner.PassPhrase.String,
+		Derivation: owner.Derivation,
 	})
 	if err != nil {
 		return nil, err
@@ -119,6 +124,11 @@ func (s *Service) GetAllPrivateKeys(ctx context.Context, request GetAllPrivateKe
 		return nil, fmt.Errorf("validate request: %w", err)
 	}
 
+	// checked before the otp, so the code is not spent
+	if s.secrets.IsSealed() {
+		return nil, ErrSeedsHeldBySigner
+	}
+
 	owner, err := s.GetByID(ctx, request.OwnerID)
 	if err != nil {
 		return nil, fmt.Errorf("get owner: %w", err)
@@ -136,16 +146,13 @@ func (s *Service) GetAllPrivateKeys(ctx context.Context, request GetAllPrivateKe
 
 	response := make(GetAllPrivateKeysResponse)
 
-	mnemonic := owner.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
+	mnemonic, passPhrase, err := s.decryptSeeds(ctx, owner)
+	if err != nil {
+		return nil, err
 	}
 
 	// handle private keys
-	allProcessingPrivateKeys, err := s.walletsSvc.Processing().GetAllPrivateKeys(ctx, request.OwnerID, mnemonic, owner.PassPhrase.String)
+	allProcessingPrivateKeys, err := s.walletsSvc.Processing().GetAllPrivateKeys(ctx, request.OwnerID, mnemonic, passPhrase, owner.Derivation)
 	if err != nil {
 		return nil, fmt.Errorf("get processing private keys: %w", err)
 	}
@@ -164,7 +171,7 @@ func (s *Service) GetAllPrivateKeys(ctx context.Context, request GetAllPrivateKe
 	}
 
 	// handle hot private keys
-	allHotPrivateKeys, err := s.walletsSvc.Hot().GetAllPrivateKeys(ctx, request.OwnerID, mnemonic, owner.PassPhrase.String)
+	allHotPrivateKeys, err := s.walletsSvc.Hot().GetAllPrivateKeys(ctx, request.OwnerID, mnemonic, passPhrase, owner.Derivation)
 	if err != nil {
 		return nil, fmt.Errorf("get hot private keys: %w", err)
 	}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
 	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
@@ -14,6 +17,7 @@ import (
 type GetSeedsResponse struct {
 	Mnemonic   string
 	PassPhrase string
+	Derivation derivation.Config
 }
 
 // GetSeeds returns the mnemonic and passphrase for the owner.
@@ -22,6 +26,11 @@ func (s *Service) GetSeeds(ctx context.Context, ownerID uuid.UUID, otp string) (
 		return nil, storecmn.ErrEmptyOTP
 	}
 
+	// checked before the otp, so the code is not spent
+	if s.secrets.IsSealed() {
+		return nil, ErrSeedsHeldBySigner
+	}
+
 	owner, err := s.GetByID(ctx, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("get owner: %w", err)
@@ -31,7 +40,7 @@ func (s *Service) GetSeeds(ctx context.Context, ownerID uuid.UUID, otp string) (
 		return nil, fmt.Errorf("owner has no mnemonic")
 	}
 
-	if !s.getOTPConfirmed(owner) {
+	if !s.getOTPConfirmed(ctx, owner) {
 		return nil, fmt.Errorf("two-factor authenticator is disabled")
 	}
 
@@ -44,20 +53,38 @@ func (s *Service) GetSeeds(ctx context.Context, ownerID uuid.UUID, otp string) (
 	// 	return nil, fmt.Errorf("owner has no passphrase")
 	// }
 
-	mnemonic := owner.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, owner.ID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
+	mnemonic, passPhrase, err := s.decryptSeeds(ctx, owner)
+	if err != nil {
+		return nil, err
 	}
 
 	return &GetSeedsResponse{
 		Mnemonic:   mnemonic,
-		PassPhrase: owner.PassPhrase.String,
+		PassPhrase: passPhrase,
+		Derivation: owner.Derivation,
 	}, nil
 }
 
+// decryptSeeds returns the plain mnemonic and the optional passphrase of the owner.
+func (s *Service) decryptSeeds(ctx context.Context, owner *models.Owner) (string, string, error) {
+	mnemonic, passPhrase := owner.Mnemonic, owner.PassPhrase.String
+	if !s.config.IsEnabledSeedEncryption() {
+		return mnemonic, passPhrase, nil
+	}
+
+	mnemonic, err := s.secrets.Decrypt(ctx, owner.ID, mnemonic)
+	if err != nil {
+		return "", "", fmt.Errorf("decrypt mnemonic: %w", err)
+	}
+
+	passPhrase, err = s.secrets.DecryptOptional(ctx, owner.ID, passPhrase)
+	if err != nil {
+		return "", "", fmt.Errorf("decrypt pass phrase: %w", err)
+	}
+
+	return mnemonic, passPhrase, nil
+}
+
 func (s *Service) EncryptSeedsForAllOwners(ctx context.Context) error {
 	owners, err := s.store.Owners().GetAll(ctx)
 	if err != nil {
@@ -70,7 +97,7 @@ func (s *Service) EncryptSeedsForAllOwners(ctx context.Context) error {
 				continue
 			}
 
-			mnemonic, err := encryption.Encrypt(owner.Mnemonic, owner.ID.String())
+			mnemonic, err := s.secrets.Encrypt(ctx, owner.ID, owner.Mnemonic, repos.WithTx(tx))
 			if err != nil {
 				return err
 			}
@@ -78,6 +105,19 @@ func (s *Service) EncryptSeedsForAllOwners(ctx context.Context) error {
 			if err := s.store.Owners(repos.WithTx(tx)).UpdateMnemonic(ctx, owner.ID, mnemonic); err != nil {
 				return err
 			}
+
+			if owner.PassPhrase.String == "" || encryption.IsEncrypted(owner.PassPhrase.String) {
+				continue
+			}
+
+			passPhrase, err := s.secrets.Encrypt(ctx, owner.ID, owner.PassPhrase.String, repos.WithTx(tx))
+			if err != nil {
+				return err
+			}
+
+			if err := s.store.Owners(repos.WithTx(tx)).UpdatePassPhrase(ctx, owner.ID, pgtypeutils.EncodeText(&passPhrase)); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -97,7 +137,7 @@ func (s *Service) DecryptSeedsForAllOwners(ctx context.Context) error {
 				continue
 			}
 
-			mnemonic, err := encryption.Decrypt(owner.Mnemonic, owner.ID.String())
+			mnemonic, err := s.secrets.Decrypt(ctx, owner.ID, owner.Mnemonic, repos.WithTx(tx))
 			if err != nil {
 				return err
 			}
@@ -105,6 +145,19 @@ func (s *Service) DecryptSeedsForAllOwners(ctx context.Context) error {
 			if err := s.store.Owners(repos.WithTx(tx)).UpdateMnemonic(ctx, owner.ID, mnemonic); err != nil {
 				return err
 			}
+
+			if !encryption.IsEncrypted(owner.PassPhrase.String) {
+				continue
+			}
+
+			passPhrase, err := s.secrets.Decrypt(ctx, owner.ID, owner.PassPhrase.String, repos.WithTx(tx))
+			if err != nil {
+				return err
+			}
+
+			if err := s.store.Owners(repos.WithTx(tx)).UpdatePassPhrase(ctx, owner.ID, pgtypeutils.EncodeText(&passPhrase)); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
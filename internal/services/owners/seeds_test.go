@@ -0,0 +1,142 @@
+package owners
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/valid"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDecryptSeeds(t *testing.T) {
+	s := &Service{config: new(config.Config)}
+
+	encrypt := func(t *testing.T, ownerID uuid.UUID, data string) string {
+		t.Helper()
+
+		encrypted, err := encryption.Encrypt(data, ownerID.String())
+		require.NoError(t, err)
+		return encrypted
+	}
+
+	tests := []struct {
+		name       string
+		mnemonic   func(t *testing.T, ownerID uuid.UUID) string
+		passPhrase func(t *testing.T, ownerID uuid.UUID) pgtype.Text
+		want       string
+		err        string
+	}{
+		{
+			name: "with pass phrase",
+			mnemonic: func(t *testing.T, ownerID uuid.UUID) string {
+				return encrypt(t, ownerID, testMnemonic)
+			},
+			passPhrase: func(t *testing.T, ownerID uuid.UUID) pgtype.Text {
+				passPhrase := encrypt(t, ownerID, "TREZOR")
+				return pgtypeutils.EncodeText(&passPhrase)
+			},
+			want: "TREZOR",
+		},
+		{
+			name: "without pass phrase",
+			mnemonic: func(t *testing.T, ownerID uuid.UUID) string {
+				return encrypt(t, ownerID, testMnemonic)
+			},
+			passPhrase: func(*testing.T, uuid.UUID) pgtype.Text { return pgtype.Text{} },
+		},
+		{
+			name: "pass phrase of other owner",
+			mnemonic: func(t *testing.T, ownerID uuid.UUID) string {
+				return encrypt(t, ownerID, testMnemonic)
+			},
+			passPhrase: func(t *testing.T, _ uuid.UUID) pgtype.Text {
+				passPhrase := encrypt(t, uuid.New(), "TREZOR")
+				return pgtypeutils.EncodeText(&passPhrase)
+			},
+			err: "decrypt pass phrase",
+		},
+		{
+			name:       "plain mnemonic",
+			mnemonic:   func(*testing.T, uuid.UUID) string { return testMnemonic },
+			passPhrase: func(*testing.T, uuid.UUID) pgtype.Text { return pgtype.Text{} },
+			err:        "decrypt mnemonic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := &models.Owner{ID: uuid.New()}
+			owner.Mnemonic = tt.mnemonic(t, owner.ID)
+			owner.PassPhrase = tt.passPhrase(t, owner.ID)
+
+			mnemonic, passPhrase, err := s.decryptSeeds(context.Background(), owner)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testMnemonic, mnemonic)
+			require.Equal(t, tt.want, passPhrase)
+		})
+	}
+}
+
+func TestCreateParamsPassPhrase(t *testing.T) {
+	tests := []struct {
+		name       string
+		passPhrase string
+		valid      bool
+	}{
+		{name: "empty", valid: true},
+		{name: "short", passPhrase: "TREZOR", valid: true},
+		{name: "max length", passPhrase: strings.Repeat("x", 256), valid: true},
+		{name: "too long", passPhrase: strings.Repeat("x", 257)},
+	}
+
+	vl := valid.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := vl.Struct(CreateParams{
+				ClientID:   uuid.New(),
+				ExternalID: "owner",
+				Mnemonic:   testMnemonic,
+				PassPhrase: tt.passPhrase,
+			})
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+}
+
+// nopSealer is the sealer of the remote signer which is never called
+type nopSealer struct {
+	secrets.Sealer
+}
+
+func TestExportSealedSeeds(t *testing.T) {
+	conf := new(config.Config)
+	s := &Service{config: conf, secrets: secrets.NewSealed(conf, nil, nopSealer{})}
+
+	// the seeds are rejected before the owner and the otp are checked
+	_, err := s.GetSeeds(context.Background(), uuid.New(), "123456")
+	require.ErrorIs(t, err, ErrSeedsHeldBySigner)
+
+	_, err = s.GetAllPrivateKeys(context.Background(), GetAllPrivateKeysRequest{OwnerID: uuid.New(), OTP: "123456"})
+	require.ErrorIs(t, err, ErrSeedsHeldBySigner)
+
+	_, err = s.GetHotWalletKeys(context.Background(), &GetHotWalletKeysRequest{OwnerID: uuid.New(), OTP: "123456"})
+	require.ErrorIs(t, err, ErrSeedsHeldBySigner)
+}
@@ -2,6 +2,7 @@ package owners
 
 import (
 	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
 	"github.com/dv-net/dv-processing/internal/services/wallets"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/pkg/valid"
@@ -12,6 +13,7 @@ type Service struct {
 	config     *config.Config
 	store      store.IStore
 	walletsSvc *wallets.Service
+	secrets    *secrets.Service
 
 	validator *validator.Validate
 }
@@ -19,12 +21,14 @@ type Service struct {
 func New(
 	conf *config.Config,
 	st store.IStore,
+	secretsSvc *secrets.Service,
 	walletsSvc *wallets.Service,
 ) *Service {
 	return &Service{
 		config:     conf,
 		store:      st,
 		walletsSvc: walletsSvc,
+		secrets:    secretsSvc,
 		validator:  valid.New(),
 	}
 }
@@ -0,0 +1,11 @@
+package rotations
+
+import "errors"
+
+var (
+	ErrInvalidMnemonic     = errors.New("mnemonic is invalid")
+	ErrSameMnemonic        = errors.New("new mnemonic must differ from the current one")
+	ErrRotationInProgress  = errors.New("mnemonic rotation is already in progress")
+	ErrRotationNotFound    = errors.New("mnemonic rotation not found")
+	ErrPassPhraseIsTooLong = errors.New("pass phrase is too long")
+)
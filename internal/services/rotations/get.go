@@ -0,0 +1,56 @@
+package rotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type GetResult struct {
+	Rotation *models.MnemonicRotation
+	Wallets  []*models.MnemonicRotationWallet
+}
+
+// GetLast returns the last mnemonic rotation of the owner with its wallets.
+func (s *Service) GetLast(ctx context.Context, ownerID uuid.UUID) (*GetResult, error) {
+	rotation, err := s.store.MnemonicRotations().GetLastByOwnerID(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRotationNotFound
+		}
+		return nil, fmt.Errorf("get last rotation: %w", err)
+	}
+
+	items, err := s.store.MnemonicRotations().GetWallets(ctx, rotation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get rotation wallets: %w", err)
+	}
+
+	return &GetResult{
+		Rotation: rotation,
+		Wallets:  items,
+	}, nil
+}
+
+// sendWebhook sends the current progress of the rotation to the owner client.
+func (s *Service) sendWebhook(ctx context.Context, rotation *models.MnemonicRotation) error {
+	items, err := s.store.MnemonicRotations().GetWallets(ctx, rotation.ID)
+	if err != nil {
+		return fmt.Errorf("get rotation wallets: %w", err)
+	}
+
+	params, err := s.webhooksSvc.EventMnemonicRotationCreateParams(ctx, webhooks.EventMnemonicRotationCreateParamsData{
+		Rotation: rotation,
+		Wallets:  items,
+	})
+	if err != nil {
+		return fmt.Errorf("get webhook params: %w", err)
+	}
+
+	return s.webhooksSvc.BatchCreate(ctx, []webhooks.BatchCreateParams{params})
+}
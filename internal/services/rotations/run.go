@@ -0,0 +1,333 @@
+package rotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/transfers"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_processing"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
+	"github.com/dv-net/dv-processing/pkg/utils"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/dv-net/dv-processing/rpccode"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Run moves the funds of all migrating rotations and completes the ones whose old wallets are empty.
+//
+// Wallets of disabled blockchains and wallets whose balance can not pay the transfer fee
+// are reported with their own statuses and checked again on every run.
+func (s *Service) Run(ctx context.Context) error {
+	rotations, err := s.store.MnemonicRotations().GetAllMigrating(ctx)
+	if err != nil {
+		return fmt.Errorf("get migrating rotations: %w", err)
+	}
+
+	for _, rotation := range rotations {
+		if err := s.processRotation(ctx, rotation); err != nil {
+			s.logger.Errorw("mnemonic rotation processing failed", "rotation_id", rotation.ID, "owner_id", rotation.OwnerID, "error", err)
+
+			if err := s.store.MnemonicRotations().SetLastError(ctx, rotation.ID, pgtypeutils.EncodeText(utils.Pointer(err.Error()))); err != nil {
+				s.logger.Errorw("set mnemonic rotation last error", "rotation_id", rotation.ID, "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) processRotation(ctx context.Context, rotation *models.MnemonicRotation) error {
+	owner, err := s.store.Owners().GetByID(ctx, rotation.OwnerID)
+	if err != nil {
+		return fmt.Errorf("get owner: %w", err)
+	}
+
+	// wallets created during the migration are derived from the old mnemonic and must be moved too
+	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		_, err := s.addWallets(ctx, owner, rotation, repos.WithTx(tx))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("add wallets: %w", err)
+	}
+
+	items, err := s.store.MnemonicRotations().GetWallets(ctx, rotation.ID)
+	if err != nil {
+		return fmt.Errorf("get rotation wallets: %w", err)
+	}
+
+	// processing wallets pay the fees of the hot wallet transfers, so they are moved last
+	hotInProgress := make(map[string]bool)
+	for _, item := range items {
+		if item.WalletType == constants.WalletTypeHot && item.Status != constants.MnemonicRotationWalletStatusEmpty {
+			hotInProgress[item.Blockchain.String()] = true
+		}
+	}
+
+	available := s.config.Blockchain.Available()
+
+	var changed bool
+	var errs []error
+	for _, item := range items {
+		if item.Status == constants.MnemonicRotationWalletStatusEmpty {
+			continue
+		}
+
+		if item.WalletType == constants.WalletTypeProcessing && hotInProgress[item.Blockchain.String()] {
+			continue
+		}
+
+		status, err := s.migrateWallet(ctx, rotation, item, slices.Contains(available, item.Blockchain))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", item.Blockchain, item.OldAddress, err))
+			continue
+		}
+
+		if status == item.Status {
+			continue
+		}
+
+		if err := s.store.MnemonicRotations().SetWalletStatus(ctx, item.ID, status); err != nil {
+			return fmt.Errorf("set wallet status: %w", err)
+		}
+
+		item.Status = status
+		changed = true
+	}
+
+	if len(errs) > 0 {
+		if changed {
+			if err := s.sendWebhook(ctx, rotation); err != nil {
+				s.logger.Errorw("send mnemonic rotation webhook", "rotation_id", rotation.ID, "error", err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+
+	if rotation.LastError.Valid {
+		if err := s.store.MnemonicRotations().SetLastError(ctx, rotation.ID, pgtype.Text{}); err != nil {
+			return fmt.Errorf("reset last error: %w", err)
+		}
+	}
+
+	if allEmpty(items) {
+		return s.complete(ctx, owner, rotation, items)
+	}
+
+	if changed {
+		if err := s.sendWebhook(ctx, rotation); err != nil {
+			s.logger.Errorw("send mnemonic rotation webhook", "rotation_id", rotation.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// allEmpty reports whether all funds are moved and the rotation can be completed.
+// Skipped wallets and wallets with the balance below the fee keep the rotation in progress,
+// so the old wallets are never archived while funds are left there.
+func allEmpty(items []*models.MnemonicRotationWallet) bool {
+	return !slices.ContainsFunc(items, func(item *models.MnemonicRotationWallet) bool {
+		return item.Status != constants.MnemonicRotationWalletStatusEmpty
+	})
+}
+
+// migrateWallet creates a transfer of the next non-zero balance of the old wallet to the new one
+// and returns the new status of the wallet.
+func (s *Service) migrateWallet(ctx context.Context, rotation *models.MnemonicRotation, item *models.MnemonicRotationWallet, isAvailable bool) (constants.MnemonicRotationWalletStatus, error) {
+	// balances of disabled blockchains can not be checked, the wallet is checked again once the blockchain is enabled
+	if !isAvailable {
+		return constants.MnemonicRotationWalletStatusSkipped, nil
+	}
+
+	assets, err := s.eproxySvc.AddressBalances(ctx, item.OldAddress, item.Blockchain)
+	if err != nil {
+		return item.Status, fmt.Errorf("get balances: %w", err)
+	}
+
+	asset := nextAsset(assets, item.Blockchain.GetAssetIdentifier())
+	if asset == nil {
+		return constants.MnemonicRotationWalletStatusEmpty, nil
+	}
+
+	requestID := rotationRequestID(rotation, item, asset)
+
+	// the balance is not changed since the transfer was created by the previous run
+	transfer, err := s.transfersSvc.GetByRequestID(ctx, requestID)
+	if err == nil {
+		return existingTransferStatus(transfer), nil
+	}
+
+	if !errors.Is(err, storecmn.ErrNotFound) {
+		return item.Status, fmt.Errorf("get transfer: %w", err)
+	}
+
+	var kind *string
+	if item.Blockchain == wconstants.BlockchainTypeTron && item.WalletType == constants.WalletTypeHot {
+		kind = utils.Pointer(constants.TronTransferKindBurnTRX.String())
+	}
+
+	_, err = s.transfersSvc.CreateRotation(ctx, transfers.CreateTransferRequest{
+		OwnerID:         rotation.OwnerID,
+		RequestID:       requestID,
+		Blockchain:      item.Blockchain,
+		FromAddresses:   []string{item.OldAddress},
+		ToAddresses:     []string{item.NewAddress},
+		AssetIdentifier: asset.ID,
+		Kind:            kind,
+		WholeAmount:     true,
+	})
+
+	return createTransferStatus(item, err)
+}
+
+// nextAsset returns the next non-zero asset to move.
+// Tokens are moved first because the native asset may be needed to pay their fees.
+func nextAsset(assets []*models.Asset, nativeAssetID string) *models.Asset {
+	var asset *models.Asset
+	for _, a := range assets {
+		if !a.Amount.IsPositive() {
+			continue
+		}
+
+		if asset == nil || asset.ID == nativeAssetID {
+			asset = a
+		}
+	}
+
+	return asset
+}
+
+// existingTransferStatus returns the wallet status by the transfer of the current balance.
+// The balance is moved as a whole, so a failed transfer of the same balance means it can not pay the fee.
+func existingTransferStatus(transfer *models.Transfer) constants.MnemonicRotationWalletStatus {
+	if transfer.Status == constants.TransferStatusFailed {
+		return constants.MnemonicRotationWalletStatusInsufficientFee
+	}
+
+	return constants.MnemonicRotationWalletStatusMigrating
+}
+
+// createTransferStatus returns the wallet status by the result of the transfer creation.
+func createTransferStatus(item *models.MnemonicRotationWallet, err error) (constants.MnemonicRotationWalletStatus, error) {
+	switch {
+	case err == nil:
+		return constants.MnemonicRotationWalletStatusMigrating, nil
+	// the transfer was created concurrently or another transfer is still in progress
+	case errors.Is(err, storecmn.ErrAlreadyExists),
+		errors.Is(err, rpccode.GetErrorByCode(rpccode.RPCCodeAddressIsTaken)):
+		return constants.MnemonicRotationWalletStatusMigrating, nil
+	// the wallet is checked again when the balance is changed
+	case errors.Is(err, rpccode.GetErrorByCode(rpccode.RPCCodeNotEnoughBalance)),
+		errors.Is(err, rpccode.GetErrorByCode(rpccode.RPCCodeMaxFeeExceeded)):
+		return constants.MnemonicRotationWalletStatusInsufficientFee, nil
+	default:
+		return item.Status, fmt.Errorf("create transfer: %w", err)
+	}
+}
+
+// rotationRequestID returns the request id which is the same for all runs while the balance is not changed,
+// so the transfer is never duplicated if the job is restarted.
+func rotationRequestID(rotation *models.MnemonicRotation, item *models.MnemonicRotationWallet, asset *models.Asset) string {
+	return "rotation-" + uuid.NewSHA1(rotation.ID, []byte(item.OldAddress+":"+asset.ID+":"+asset.Amount.String())).String()
+}
+
+// complete replaces the owner seed with the new one, archives the old wallets and activates the new ones.
+func (s *Service) complete(ctx context.Context, owner *models.Owner, rotation *models.MnemonicRotation, items []*models.MnemonicRotationWallet) error {
+	newHotWallets := make([]*models.HotWallet, 0, len(items))
+	newProcessingWallets := make([]*models.ProcessingWallet, 0, len(items))
+
+	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if err := s.store.Owners(repos.WithTx(tx)).UpdateMnemonic(ctx, owner.ID, rotation.Mnemonic); err != nil {
+			return fmt.Errorf("update mnemonic: %w", err)
+		}
+
+		if err := s.store.Owners(repos.WithTx(tx)).UpdatePassPhrase(ctx, owner.ID, rotation.PassPhrase); err != nil {
+			return fmt.Errorf("update pass phrase: %w", err)
+		}
+
+		for _, item := range items {
+			switch item.WalletType {
+			case constants.WalletTypeHot:
+				if err := s.store.Wallets().Hot(repos.WithTx(tx)).ArchiveWallet(ctx, item.Blockchain, item.OldAddress, owner.ID); err != nil {
+					return fmt.Errorf("archive hot wallet: %w", err)
+				}
+
+				newItem, err := s.store.Wallets().Hot(repos.WithTx(tx)).Create(ctx, repo_wallets_hot.CreateParams{
+					Blockchain:       item.Blockchain,
+					Address:          item.NewAddress,
+					OwnerID:          owner.ID,
+					ExternalWalletID: item.ExternalWalletID.String,
+					Sequence:         item.Sequence,
+					IsActive:         true,
+				})
+				if err != nil {
+					return fmt.Errorf("create hot wallet: %w", err)
+				}
+
+				newHotWallets = append(newHotWallets, newItem)
+			case constants.WalletTypeProcessing:
+				if err := s.store.Wallets().Processing(repos.WithTx(tx)).ArchiveWallet(ctx, item.Blockchain, item.OldAddress, owner.ID); err != nil {
+					return fmt.Errorf("archive processing wallet: %w", err)
+				}
+
+				newItem, err := s.store.Wallets().Processing(repos.WithTx(tx)).Create(ctx, repo_wallets_processing.CreateParams{
+					Blockchain: item.Blockchain,
+					Address:    item.NewAddress,
+					OwnerID:    owner.ID,
+					Sequence:   item.Sequence,
+					IsActive:   true,
+				})
+				if err != nil {
+					return fmt.Errorf("create processing wallet: %w", err)
+				}
+
+				newProcessingWallets = append(newProcessingWallets, newItem)
+			default:
+				return fmt.Errorf("unsupported wallet type: %s", item.WalletType)
+			}
+		}
+
+		// the pool addresses are derived from the old mnemonic
+		if err := s.store.Wallets().HotPool(repos.WithTx(tx)).DeleteByOwnerID(ctx, owner.ID); err != nil {
+			return fmt.Errorf("delete hot wallets pool: %w", err)
+		}
+
+		if err := s.store.MnemonicRotations(repos.WithTx(tx)).SetWalletsStatus(ctx, rotation.ID, constants.MnemonicRotationWalletStatusArchived); err != nil {
+			return fmt.Errorf("set wallets status: %w", err)
+		}
+
+		if err := s.store.MnemonicRotations(repos.WithTx(tx)).Complete(ctx, rotation.ID); err != nil {
+			return fmt.Errorf("complete rotation: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.store.Cache().Owners().Delete(owner.ID.String())
+	s.walletsSvc.Hot().Register(newHotWallets)
+	s.walletsSvc.Processing().Register(newProcessingWallets)
+
+	s.logger.Infow("mnemonic rotation completed", "rotation_id", rotation.ID, "owner_id", owner.ID)
+
+	rotation.Status = constants.MnemonicRotationStatusCompleted
+	rotation.LastError = pgtype.Text{}
+	if err := s.sendWebhook(ctx, rotation); err != nil {
+		s.logger.Errorw("send mnemonic rotation webhook", "rotation_id", rotation.ID, "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package rotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/dv-net/dv-processing/rpccode"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextAsset(t *testing.T) {
+	native := wconstants.BlockchainTypeEthereum.GetAssetIdentifier()
+
+	eth := &models.Asset{ID: native, Amount: decimal.NewFromInt(1)}
+	usdt := &models.Asset{ID: "usdt", Amount: decimal.NewFromInt(10)}
+	usdc := &models.Asset{ID: "usdc", Amount: decimal.NewFromInt(5)}
+	emptyUSDT := &models.Asset{ID: "usdt", Amount: decimal.Zero}
+	emptyETH := &models.Asset{ID: native, Amount: decimal.Zero}
+
+	tests := []struct {
+		name   string
+		assets []*models.Asset
+		want   *models.Asset
+	}{
+		{name: "no assets"},
+		{name: "zero balances", assets: []*models.Asset{emptyETH, emptyUSDT}},
+		{name: "native only", assets: []*models.Asset{eth}, want: eth},
+		{name: "token before native", assets: []*models.Asset{eth, usdt}, want: usdt},
+		{name: "token after native", assets: []*models.Asset{usdt, eth}, want: usdt},
+		{name: "first token", assets: []*models.Asset{eth, usdc, usdt}, want: usdc},
+		{name: "empty token", assets: []*models.Asset{emptyUSDT, eth}, want: eth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, nextAsset(tt.assets, native))
+		})
+	}
+}
+
+func TestExistingTransferStatus(t *testing.T) {
+	tests := []struct {
+		status constants.TransferStatus
+		want   constants.MnemonicRotationWalletStatus
+	}{
+		{status: constants.TransferStatusNew, want: constants.MnemonicRotationWalletStatusMigrating},
+		{status: constants.TransferStatusProcessing, want: constants.MnemonicRotationWalletStatusMigrating},
+		{status: constants.TransferStatusCompleted, want: constants.MnemonicRotationWalletStatusMigrating},
+		{status: constants.TransferStatusFailed, want: constants.MnemonicRotationWalletStatusInsufficientFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			require.Equal(t, tt.want, existingTransferStatus(&models.Transfer{Status: tt.status}))
+		})
+	}
+}
+
+func TestCreateTransferStatus(t *testing.T) {
+	item := &models.MnemonicRotationWallet{Status: constants.MnemonicRotationWalletStatusPending}
+
+	tests := []struct {
+		name    string
+		err     error
+		want    constants.MnemonicRotationWalletStatus
+		wantErr bool
+	}{
+		{name: "created", want: constants.MnemonicRotationWalletStatusMigrating},
+		{name: "already exists", err: storecmn.ErrAlreadyExists, want: constants.MnemonicRotationWalletStatusMigrating},
+		{
+			name: "address is taken",
+			err:  fmt.Errorf("process evm: %w", rpccode.GetErrorByCode(rpccode.RPCCodeAddressIsTaken)),
+			want: constants.MnemonicRotationWalletStatusMigrating,
+		},
+		{
+			name: "not enough balance",
+			err:  fmt.Errorf("process evm: %w for transfer", rpccode.GetErrorByCode(rpccode.RPCCodeNotEnoughBalance)),
+			want: constants.MnemonicRotationWalletStatusInsufficientFee,
+		},
+		{
+			name: "max fee exceeded",
+			err:  rpccode.GetErrorByCode(rpccode.RPCCodeMaxFeeExceeded),
+			want: constants.MnemonicRotationWalletStatusInsufficientFee,
+		},
+		{
+			name:    "not enough resources",
+			err:     rpccode.GetErrorByCode(rpccode.RPCCodeNotEnoughResources),
+			want:    constants.MnemonicRotationWalletStatusPending,
+			wantErr: true,
+		},
+		{name: "other error", err: errors.New("node is down"), want: constants.MnemonicRotationWalletStatusPending, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := createTransferStatus(item, tt.err)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestMigrateWalletDisabledBlockchain(t *testing.T) {
+	item := &models.MnemonicRotationWallet{
+		Blockchain: wconstants.BlockchainTypeBitcoin,
+		Status:     constants.MnemonicRotationWalletStatusPending,
+	}
+
+	// the balances are not requested for disabled blockchains
+	status, err := new(Service).migrateWallet(context.Background(), &models.MnemonicRotation{}, item, false)
+	require.NoError(t, err)
+	require.Equal(t, constants.MnemonicRotationWalletStatusSkipped, status)
+}
+
+func TestAllEmpty(t *testing.T) {
+	items := func(statuses ...constants.MnemonicRotationWalletStatus) []*models.MnemonicRotationWallet {
+		res := make([]*models.MnemonicRotationWallet, 0, len(statuses))
+		for _, status := range statuses {
+			res = append(res, &models.MnemonicRotationWallet{Status: status})
+		}
+		return res
+	}
+
+	require.True(t, allEmpty(nil))
+	require.True(t, allEmpty(items(constants.MnemonicRotationWalletStatusEmpty, constants.MnemonicRotationWalletStatusEmpty)))
+
+	for _, status := range []constants.MnemonicRotationWalletStatus{
+		constants.MnemonicRotationWalletStatusPending,
+		constants.MnemonicRotationWalletStatusMigrating,
+		constants.MnemonicRotationWalletStatusInsufficientFee,
+		constants.MnemonicRotationWalletStatusSkipped,
+	} {
+		require.False(t, allEmpty(items(constants.MnemonicRotationWalletStatusEmpty, status)), status)
+	}
+}
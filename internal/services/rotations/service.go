@@ -0,0 +1,47 @@
+package rotations
+
+import (
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/eproxy"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/services/transfers"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/services/webhooks"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/mx/logger"
+)
+
+type Service struct {
+	logger logger.Logger
+	config *config.Config
+	store  store.IStore
+
+	// Services
+	walletsSvc   *wallets.Service
+	transfersSvc *transfers.Service
+	eproxySvc    *eproxy.Service
+	webhooksSvc  *webhooks.Service
+	secrets      *secrets.Service
+}
+
+func New(
+	l logger.Logger,
+	conf *config.Config,
+	st store.IStore,
+	walletsSvc *wallets.Service,
+	transfersSvc *transfers.Service,
+	eproxySvc *eproxy.Service,
+	webhooksSvc *webhooks.Service,
+	secretsSvc *secrets.Service,
+) *Service {
+	return &Service{
+		logger:       l,
+		config:       conf,
+		store:        st,
+		walletsSvc:   walletsSvc,
+		transfersSvc: transfersSvc,
+		eproxySvc:    eproxySvc,
+		webhooksSvc:  webhooksSvc,
+		secrets:      secretsSvc,
+	}
+}
@@ -0,0 +1,353 @@
+package rotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_mnemonic_rotations"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/dv-net/go-bip39"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	mnemonicEntropyBits = 256
+	maxPassPhraseLength = 256
+)
+
+type StartParams struct {
+	OwnerID uuid.UUID
+	// Mnemonic is the new owner mnemonic, it is generated when empty
+	Mnemonic string
+	// PassPhrase is the optional BIP39 passphrase of the new mnemonic
+	PassPhrase string
+}
+
+type StartResult struct {
+	Rotation *models.MnemonicRotation
+	// Mnemonic is set only when it was generated by the service
+	Mnemonic string
+}
+
+// Start starts the rotation of the owner mnemonic.
+//
+// New wallets are derived from the new mnemonic for all active hot and processing wallets of the owner.
+// The owner keeps using the current mnemonic until the periodic job moves all funds to the new wallets.
+func (s *Service) Start(ctx context.Context, params StartParams) (*StartResult, error) {
+	if params.OwnerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if len(params.PassPhrase) > maxPassPhraseLength {
+		return nil, ErrPassPhraseIsTooLong
+	}
+
+	res := new(StartResult)
+
+	mnemonic := strings.TrimSpace(params.Mnemonic)
+	if mnemonic == "" {
+		entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+		if err != nil {
+			return nil, fmt.Errorf("generate entropy: %w", err)
+		}
+
+		mnemonic, err = bip39.NewMnemonic(entropy)
+		if err != nil {
+			return nil, fmt.Errorf("generate mnemonic: %w", err)
+		}
+
+		res.Mnemonic = mnemonic
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	owner, err := s.store.Owners().GetByID(ctx, params.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("get owner: %w", err)
+	}
+
+	lastRotation, err := s.store.MnemonicRotations().GetLastByOwnerID(ctx, owner.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get last rotation: %w", err)
+	}
+
+	if lastRotation != nil && lastRotation.Status == constants.MnemonicRotationStatusMigrating {
+		return nil, ErrRotationInProgress
+	}
+
+	encryptedMnemonic, passPhrase := mnemonic, params.PassPhrase
+	if s.config.IsEnabledSeedEncryption() {
+		encryptedMnemonic, err = s.secrets.Encrypt(ctx, owner.ID, mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt mnemonic: %w", err)
+		}
+
+		if passPhrase != "" {
+			passPhrase, err = s.secrets.Encrypt(ctx, owner.ID, passPhrase)
+			if err != nil {
+				return nil, fmt.Errorf("encrypt pass phrase: %w", err)
+			}
+		}
+	}
+
+	// the seeds are compared by the signer, so the current one is not decrypted here
+	currentFingerprint, err := s.walletsSvc.SeedFingerprint(ctx, owner.ID, owner.Mnemonic, owner.PassPhrase.String)
+	if err != nil {
+		return nil, fmt.Errorf("current seed fingerprint: %w", err)
+	}
+
+	newFingerprint, err := s.walletsSvc.SeedFingerprint(ctx, owner.ID, encryptedMnemonic, passPhrase)
+	if err != nil {
+		return nil, fmt.Errorf("new seed fingerprint: %w", err)
+	}
+
+	if currentFingerprint == newFingerprint {
+		return nil, ErrSameMnemonic
+	}
+
+	err = pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		rotation, err := s.store.MnemonicRotations(repos.WithTx(tx)).Create(ctx, repo_mnemonic_rotations.CreateParams{
+			OwnerID:            owner.ID,
+			Status:             constants.MnemonicRotationStatusMigrating,
+			Mnemonic:           encryptedMnemonic,
+			PassPhrase:         pgtypeutils.EncodeText(&passPhrase),
+			PreviousMnemonic:   owner.Mnemonic,
+			PreviousPassPhrase: owner.PassPhrase,
+		})
+		if err != nil {
+			// only one rotation per owner can be migrating
+			if strings.Contains(err.Error(), "unique") {
+				return ErrRotationInProgress
+			}
+			return fmt.Errorf("create rotation: %w", err)
+		}
+
+		if _, err := s.addWallets(ctx, owner, rotation, repos.WithTx(tx)); err != nil {
+			return fmt.Errorf("add wallets: %w", err)
+		}
+
+		res.Rotation = rotation
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sendWebhook(ctx, res.Rotation); err != nil {
+		s.logger.Errorw("send mnemonic rotation webhook", "rotation_id", res.Rotation.ID, "error", err)
+	}
+
+	return res, nil
+}
+
+type rotationCandidate struct {
+	walletType       constants.WalletType
+	blockchain       wconstants.BlockchainType
+	externalWalletID pgtype.Text
+	oldAddress       string
+	newAddress       string
+	sequence         int32
+}
+
+// addWallets adds the active owner wallets which are not in the rotation yet and derives
+// their new addresses from the rotation mnemonic. It returns the number of added wallets.
+func (s *Service) addWallets(ctx context.Context, owner *models.Owner, rotation *models.MnemonicRotation, opts ...repos.Option) (int, error) {
+	existing, err := s.store.MnemonicRotations(opts...).GetWallets(ctx, rotation.ID)
+	if err != nil {
+		return 0, fmt.Errorf("get rotation wallets: %w", err)
+	}
+
+	known := make(map[string]struct{}, len(existing))
+	// all evm blockchains share the same hot wallet address
+	evmHotAddresses := make(map[string]*rotationCandidate)
+	for _, item := range existing {
+		known[walletKey(item.Blockchain, item.OldAddress)] = struct{}{}
+		if item.WalletType == constants.WalletTypeHot && item.Blockchain.IsEVM() {
+			evmHotAddresses[item.OldAddress] = &rotationCandidate{newAddress: item.NewAddress, sequence: item.Sequence}
+		}
+	}
+
+	hotWallets, err := s.store.Wallets().Hot(opts...).GetAllByOwnerID(ctx, owner.ID)
+	if err != nil {
+		return 0, fmt.Errorf("get hot wallets: %w", err)
+	}
+
+	processingWallets, err := s.store.Wallets().Processing(opts...).GetAllByOwnerID(ctx, owner.ID)
+	if err != nil {
+		return 0, fmt.Errorf("get processing wallets: %w", err)
+	}
+
+	candidates := make([]*rotationCandidate, 0, len(hotWallets)+len(processingWallets))
+	for _, wallet := range hotWallets {
+		if _, ok := known[walletKey(wallet.Blockchain, wallet.Address)]; ok || !wallet.IsActive {
+			continue
+		}
+
+		candidates = append(candidates, &rotationCandidate{
+			walletType:       constants.WalletTypeHot,
+			blockchain:       wallet.Blockchain,
+			externalWalletID: pgtypeutils.EncodeText(&wallet.ExternalWalletID),
+			oldAddress:       wallet.Address,
+		})
+	}
+
+	for _, wallet := range processingWallets {
+		if _, ok := known[walletKey(wallet.Blockchain, wallet.Address)]; ok || !wallet.IsActive {
+			continue
+		}
+
+		candidates = append(candidates, &rotationCandidate{
+			walletType: constants.WalletTypeProcessing,
+			blockchain: wallet.Blockchain,
+			oldAddress: wallet.Address,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	if err := s.deriveCandidates(ctx, owner, rotation, candidates, evmHotAddresses, opts...); err != nil {
+		return 0, err
+	}
+
+	var added int
+	for _, item := range candidates {
+		_, err := s.store.MnemonicRotations(opts...).CreateWallet(ctx, repo_mnemonic_rotations.CreateWalletParams{
+			RotationID:       rotation.ID,
+			WalletType:       item.walletType,
+			Blockchain:       item.blockchain,
+			ExternalWalletID: item.externalWalletID,
+			OldAddress:       item.oldAddress,
+			NewAddress:       item.newAddress,
+			Sequence:         item.sequence,
+			Status:           constants.MnemonicRotationWalletStatusPending,
+		})
+		if err != nil {
+			// the wallet is already in the rotation
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return 0, fmt.Errorf("create rotation wallet: %w", err)
+		}
+
+		added++
+	}
+
+	return added, nil
+}
+
+// deriveCandidates derives the new addresses of the candidates with one sequences reservation
+// per blockchain and address type.
+func (s *Service) deriveCandidates(
+	ctx context.Context,
+	owner *models.Owner,
+	rotation *models.MnemonicRotation,
+	candidates []*rotationCandidate,
+	evmHotAddresses map[string]*rotationCandidate,
+	opts ...repos.Option,
+) error {
+	type groupKey struct {
+		blockchain  wconstants.BlockchainType
+		addressType string
+	}
+
+	keys := make([]groupKey, 0)
+	groups := make(map[groupKey][]*rotationCandidate)
+	for _, item := range candidates {
+		addressType, err := s.walletsSvc.AddressTypeOf(item.blockchain, item.oldAddress)
+		if err != nil {
+			return fmt.Errorf("address type of %s: %w", item.oldAddress, err)
+		}
+
+		key := groupKey{blockchain: item.blockchain, addressType: addressType}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], item)
+	}
+
+	for _, key := range keys {
+		toDerive := make([]*rotationCandidate, 0, len(groups[key]))
+		for _, item := range groups[key] {
+			isEVMHot := item.walletType == constants.WalletTypeHot && item.blockchain.IsEVM()
+			if isEVMHot {
+				if derived, ok := evmHotAddresses[item.oldAddress]; ok {
+					// the address is derived in this group or for another evm blockchain
+					if derived.newAddress == "" {
+						continue
+					}
+
+					item.newAddress, item.sequence = derived.newAddress, derived.sequence
+
+					// the sequence is used on this blockchain now, so the next derived addresses must skip it
+					if err := s.store.Wallets().Common(opts...).ReserveSequence(ctx, repo_wallets.ReserveSequenceParams{
+						OwnerID:    owner.ID,
+						Blockchain: item.blockchain,
+						Sequence:   item.sequence,
+					}); err != nil {
+						return fmt.Errorf("reserve evm sequence: %w", err)
+					}
+					continue
+				}
+
+				evmHotAddresses[item.oldAddress] = item
+			}
+
+			toDerive = append(toDerive, item)
+		}
+
+		if len(toDerive) == 0 {
+			continue
+		}
+
+		addresses, firstSequence, err := s.walletsSvc.DeriveAddresses(ctx, wallets.DeriveAddressesParams{
+			OwnerID:     owner.ID,
+			Blockchain:  key.blockchain,
+			AddressType: key.addressType,
+			Mnemonic:    rotation.Mnemonic,
+			Passphrase:  rotation.PassPhrase.String,
+			Derivation:  owner.Derivation,
+			Count:       int32(len(toDerive)), //nolint:gosec
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("derive %s addresses: %w", key.blockchain, err)
+		}
+
+		for i, item := range toDerive {
+			item.newAddress = addresses[i]
+			item.sequence = firstSequence + int32(i) //nolint:gosec
+		}
+
+		// evm hot wallets with the same old address in this group share the derived one
+		for _, item := range groups[key] {
+			if item.newAddress != "" {
+				continue
+			}
+
+			derived := evmHotAddresses[item.oldAddress]
+			item.newAddress, item.sequence = derived.newAddress, derived.sequence
+		}
+	}
+
+	return nil
+}
+
+func walletKey(blockchain wconstants.BlockchainType, address string) string {
+	return blockchain.String() + "-" + address
+}
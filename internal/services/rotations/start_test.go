@@ -0,0 +1,105 @@
+package rotations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// memStore keeps the wallet sequences in memory, the other repositories are not implemented
+type memStore struct {
+	store.IStore
+	sequences map[wconstants.BlockchainType]int32
+}
+
+func (s *memStore) Owners(...repos.Option) repo_owners.Querier { return memOwners{} }
+func (s *memStore) Wallets() repos.IWallets                    { return memWallets{s: s} }
+
+type memOwners struct {
+	repo_owners.Querier
+}
+
+// GetDataKey returns no key, the test seeds are encrypted in the ENCv1 format
+func (memOwners) GetDataKey(context.Context, uuid.UUID) (pgtype.Text, error) {
+	return pgtype.Text{}, nil
+}
+
+type memWallets struct {
+	repos.IWallets
+	s *memStore
+}
+
+func (w memWallets) Common(...repos.Option) repo_wallets.Querier { return memSequences{s: w.s} }
+
+type memSequences struct {
+	repo_wallets.Querier
+	s *memStore
+}
+
+func (m memSequences) NextSequences(_ context.Context, arg repo_wallets.NextSequencesParams) (int32, error) {
+	last, ok := m.s.sequences[arg.Blockchain]
+	if !ok {
+		last = -1
+	}
+	m.s.sequences[arg.Blockchain] = last + arg.Count
+	return last + arg.Count, nil
+}
+
+func (m memSequences) ReserveSequence(_ context.Context, arg repo_wallets.ReserveSequenceParams) error {
+	if last, ok := m.s.sequences[arg.Blockchain]; !ok || last < arg.Sequence {
+		m.s.sequences[arg.Blockchain] = arg.Sequence
+	}
+	return nil
+}
+
+func TestDeriveCandidatesReservesReusedEVMSequence(t *testing.T) {
+	owner := &models.Owner{ID: uuid.New()}
+
+	mnemonic, err := encryption.Encrypt(testMnemonic, owner.ID.String())
+	require.NoError(t, err)
+
+	st := &memStore{sequences: map[wconstants.BlockchainType]int32{
+		wconstants.BlockchainTypeEthereum: 4,
+	}}
+
+	conf := new(config.Config)
+	sdk := walletsdk.New(walletsdk.Config{})
+
+	s := &Service{
+		store:      st,
+		walletsSvc: wallets.New(nil, conf, st, nil, sdk, nil, signer.NewLocal(conf, st, nil, sdk, nil)),
+	}
+
+	candidates := []*rotationCandidate{
+		{walletType: constants.WalletTypeHot, blockchain: wconstants.BlockchainTypeEthereum, oldAddress: "0xold"},
+		{walletType: constants.WalletTypeHot, blockchain: wconstants.BlockchainTypeBinanceSmartChain, oldAddress: "0xold"},
+	}
+
+	err = s.deriveCandidates(context.Background(), owner, &models.MnemonicRotation{Mnemonic: mnemonic}, candidates, make(map[string]*rotationCandidate))
+	require.NoError(t, err)
+
+	// the address derived on the first blockchain is reused on the other one
+	require.Equal(t, int32(5), candidates[0].sequence)
+	require.Equal(t, candidates[0].newAddress, candidates[1].newAddress)
+	require.Equal(t, candidates[0].sequence, candidates[1].sequence)
+
+	// the next wallet of the other blockchain must not derive the reused sequence again
+	require.Equal(t, int32(5), st.sequences[wconstants.BlockchainTypeBinanceSmartChain])
+}
@@ -0,0 +1,260 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultRotateBatchSize = 100
+
+type RotateKeyParams struct {
+	// BatchSize is the number of owners processed in one transaction
+	BatchSize int32
+	// DryRun rolls back every batch after the owners are processed and verified
+	DryRun bool
+}
+
+type RotateKeyResult struct {
+	Owners    int
+	Batches   int
+	Rewrapped int
+	Upgraded  int
+	// Errors of the owners failed the verification in the dry run
+	Errors []error
+}
+
+type VerifyResult struct {
+	Owners int
+	Errors []error
+}
+
+// RotateMasterKey re-wraps the data keys of all owners with the current master key.
+//
+// The previous master key must still be known to the key provider, e.g. set as the previous key
+// for the file and env providers or kept as an older version of the vault transit key.
+// The ENCv1 secrets are upgraded on the way, so no secret depends on the previous master key afterwards.
+//
+// Owners are processed in batches ordered by id, each batch in its own transaction with the rows locked.
+// Data keys already wrapped by the current master key are skipped, so an interrupted rotation
+// is resumed by running it again.
+func (s *Service) RotateMasterKey(ctx context.Context, params RotateKeyParams) (*RotateKeyResult, error) {
+	if s.provider == nil {
+		return nil, ErrKeyProviderNotConfigured
+	}
+
+	if params.BatchSize <= 0 {
+		params.BatchSize = defaultRotateBatchSize
+	}
+
+	currentKeyID, err := s.provider.CurrentKeyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current master key id: %w", err)
+	}
+
+	res := new(RotateKeyResult)
+	lastID := uuid.Nil
+	for {
+		owners, err := s.rotateBatch(ctx, currentKeyID, lastID, params, res)
+		if err != nil {
+			return res, fmt.Errorf("batch after owner %s: %w", lastID, err)
+		}
+
+		if len(owners) == 0 {
+			return res, nil
+		}
+
+		res.Batches++
+		res.Owners += len(owners)
+		lastID = owners[len(owners)-1].ID
+
+		if params.DryRun {
+			continue
+		}
+
+		for _, owner := range owners {
+			s.store.Cache().Owners().Delete(owner.ID.String())
+		}
+	}
+}
+
+func (s *Service) rotateBatch(ctx context.Context, currentKeyID string, lastID uuid.UUID, params RotateKeyParams, res *RotateKeyResult) ([]*models.Owner, error) {
+	tx, err := s.store.PSQLConn().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	owners, err := s.store.Owners(repos.WithTx(tx)).GetBatchForUpdate(ctx, lastID, params.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("get owners: %w", err)
+	}
+
+	for _, owner := range owners {
+		upgraded, err := s.upgradeOwner(ctx, owner, repos.WithTx(tx))
+		if err != nil {
+			return nil, fmt.Errorf("upgrade owner %s: %w", owner.ID, err)
+		}
+
+		rewrapped, err := s.rewrapDataKey(ctx, owner.ID, currentKeyID, repos.WithTx(tx))
+		if err != nil {
+			return nil, fmt.Errorf("rewrap owner %s data key: %w", owner.ID, err)
+		}
+
+		if upgraded {
+			res.Upgraded++
+		}
+
+		if rewrapped {
+			res.Rewrapped++
+		}
+
+		// changes are rolled back in the dry run, so they are verified before
+		if params.DryRun {
+			updated, err := s.store.Owners(repos.WithTx(tx)).GetByID(ctx, owner.ID)
+			if err != nil {
+				return nil, fmt.Errorf("get owner %s: %w", owner.ID, err)
+			}
+
+			if err := s.verifyOwner(ctx, updated, currentKeyID, repos.WithTx(tx)); err != nil {
+				res.Errors = append(res.Errors, err)
+			}
+		}
+	}
+
+	if params.DryRun {
+		return owners, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return owners, nil
+}
+
+// rewrapDataKey wraps the owner data key with the current master key.
+// The data key itself is not changed, so the secrets encrypted with it stay valid.
+func (s *Service) rewrapDataKey(ctx context.Context, ownerID uuid.UUID, currentKeyID string, opts ...repos.Option) (bool, error) {
+	wrapped, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+	if err != nil {
+		return false, fmt.Errorf("get data key: %w", err)
+	}
+
+	if !wrapped.Valid || s.provider.WrappedKeyID(wrapped.String) == currentKeyID {
+		return false, nil
+	}
+
+	dataKey, err := s.unwrap(ctx, wrapped.String)
+	if err != nil {
+		return false, err
+	}
+
+	rewrapped, err := s.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return false, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	if err := s.store.Owners(opts...).UpdateDataKey(ctx, ownerID, pgtype.Text{String: rewrapped, Valid: true}); err != nil {
+		return false, fmt.Errorf("update data key: %w", err)
+	}
+
+	s.dataKeys.Store(rewrapped, dataKey)
+
+	return true, nil
+}
+
+// VerifyAllOwners checks that the data keys of all owners are wrapped by the current master key
+// and all their secrets can be decrypted. It returns the verification errors of the owners.
+func (s *Service) VerifyAllOwners(ctx context.Context) (*VerifyResult, error) {
+	if s.provider == nil {
+		return nil, ErrKeyProviderNotConfigured
+	}
+
+	currentKeyID, err := s.provider.CurrentKeyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get current master key id: %w", err)
+	}
+
+	owners, err := s.store.Owners().GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get owners: %w", err)
+	}
+
+	res := &VerifyResult{Owners: len(owners)}
+	for _, owner := range owners {
+		if err := s.verifyOwner(ctx, owner, currentKeyID); err != nil {
+			res.Errors = append(res.Errors, err)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Service) verifyOwner(ctx context.Context, owner *models.Owner, currentKeyID string, opts ...repos.Option) error {
+	var errs []error
+
+	// the data key is unwrapped by the provider, not taken from the cache
+	var dataKey []byte
+	if owner.DataKey.Valid {
+		if keyID := s.provider.WrappedKeyID(owner.DataKey.String); keyID != currentKeyID {
+			errs = append(errs, fmt.Errorf("data key is wrapped by the master key %q", keyID))
+		}
+
+		var err error
+		dataKey, err = s.provider.UnwrapKey(ctx, owner.DataKey.String)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unwrap data key: %w", err))
+		}
+	}
+
+	secrets := map[string]string{
+		"mnemonic":    owner.Mnemonic,
+		"pass phrase": owner.PassPhrase.String,
+		"otp data":    owner.OtpData.String,
+	}
+
+	rotations, err := s.store.MnemonicRotations(opts...).GetAllByOwnerID(ctx, owner.ID)
+	if err != nil {
+		return fmt.Errorf("owner %s: get mnemonic rotations: %w", owner.ID, err)
+	}
+
+	for _, rotation := range rotations {
+		secrets["rotation "+rotation.ID.String()+" mnemonic"] = rotation.Mnemonic
+		secrets["rotation "+rotation.ID.String()+" pass phrase"] = rotation.PassPhrase.String
+		secrets["rotation "+rotation.ID.String()+" previous mnemonic"] = rotation.PreviousMnemonic
+		secrets["rotation "+rotation.ID.String()+" previous pass phrase"] = rotation.PreviousPassPhrase.String
+	}
+
+	for name, value := range secrets {
+		if !encryption.IsEncrypted(value) {
+			continue
+		}
+
+		if !encryption.IsEnvelope(value) {
+			errs = append(errs, fmt.Errorf("%s is not envelope encrypted", name))
+			continue
+		}
+
+		if dataKey == nil {
+			errs = append(errs, fmt.Errorf("%s can not be decrypted without the data key", name))
+			continue
+		}
+
+		if _, err := encryption.DecryptWithKey(value, dataKey, owner.ID.String()); err != nil {
+			errs = append(errs, fmt.Errorf("decrypt %s: %w", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("owner %s: %w", owner.ID, errors.Join(errs...))
+	}
+
+	return nil
+}
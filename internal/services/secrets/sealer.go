@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Sealer encrypts the owner secrets with the master key held by the signer process.
+//
+// The wrapped data keys are stored by the processing and passed with every call, so the
+// signer does not read them from the database and the keys created in a not committed
+// transaction are usable.
+type Sealer interface {
+	// Seal encrypts the secret with the wrapped data key of the owner. A new key is created when
+	// the wrapped one is empty. It returns the wrapped key and the encrypted secret.
+	Seal(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, string, error)
+	// UnsealOTPData decrypts the OTP data of the owner. The signer never decrypts the seeds for the processing.
+	UnsealOTPData(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, error)
+}
+
+// NewSealed creates the service of the processing which works with the remote signer.
+// It has no master key provider: the ENCv2 seeds are decrypted only by the signer process.
+func NewSealed(conf *config.Config, st store.IStore, sealer Sealer) *Service {
+	return &Service{
+		config: conf,
+		store:  st,
+		sealer: sealer,
+	}
+}
+
+// DecryptOTPData decrypts the OTP data of the owner. It is the only ENCv2 secret the processing
+// reads when the master key is held by the signer process.
+func (s *Service) DecryptOTPData(ctx context.Context, ownerID uuid.UUID, data string, opts ...repos.Option) (string, error) {
+	if s.sealer == nil || !encryption.IsEnvelope(data) {
+		return s.Decrypt(ctx, ownerID, data, opts...)
+	}
+
+	wrapped, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("get data key: %w", err)
+	}
+
+	if !wrapped.Valid {
+		return "", fmt.Errorf("owner %s has no data key", ownerID)
+	}
+
+	return s.sealer.UnsealOTPData(ctx, ownerID, wrapped.String, data)
+}
+
+// seal encrypts the secret by the signer and stores the data key created for the owner.
+func (s *Service) seal(ctx context.Context, ownerID uuid.UUID, data string, opts ...repos.Option) (string, error) {
+	wrapped, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("get data key: %w", err)
+	}
+
+	newWrapped, encrypted, err := s.sealer.Seal(ctx, ownerID, wrapped.String, data)
+	if err != nil {
+		return "", fmt.Errorf("seal: %w", err)
+	}
+
+	// the signer without a key provider encrypts the secret in the ENCv1 format
+	if wrapped.Valid || newWrapped == "" {
+		return encrypted, nil
+	}
+
+	updated, err := s.store.Owners(opts...).SetDataKey(ctx, ownerID, pgtype.Text{String: newWrapped, Valid: true})
+	if err != nil {
+		return "", fmt.Errorf("set data key: %w", err)
+	}
+
+	if updated > 0 {
+		return encrypted, nil
+	}
+
+	// the key was created by a concurrent request, the secret is sealed with it again
+	current, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("get data key: %w", err)
+	}
+
+	if !current.Valid {
+		return "", fmt.Errorf("owner %s not found", ownerID)
+	}
+
+	_, encrypted, err = s.sealer.Seal(ctx, ownerID, current.String, data)
+	if err != nil {
+		return "", fmt.Errorf("seal: %w", err)
+	}
+
+	return encrypted, nil
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps the owner data keys in memory, the other repositories are not implemented
+type memStore struct {
+	store.IStore
+	dataKeys map[uuid.UUID]pgtype.Text
+}
+
+func (s *memStore) Owners(...repos.Option) repo_owners.Querier { return memOwners{s: s} }
+
+type memOwners struct {
+	repo_owners.Querier
+	s *memStore
+}
+
+func (o memOwners) GetDataKey(_ context.Context, id uuid.UUID) (pgtype.Text, error) {
+	return o.s.dataKeys[id], nil
+}
+
+func (o memOwners) SetDataKey(_ context.Context, id uuid.UUID, dataKey pgtype.Text) (int64, error) {
+	if o.s.dataKeys[id].Valid {
+		return 0, nil
+	}
+	o.s.dataKeys[id] = dataKey
+	return 1, nil
+}
+
+// backendSealer seals the secrets with the service of the signer process
+type backendSealer struct {
+	backend *Service
+	unseals int
+}
+
+func (b *backendSealer) Seal(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, string, error) {
+	return b.backend.EncryptWithDataKey(ctx, ownerID, wrappedKey, data)
+}
+
+func (b *backendSealer) UnsealOTPData(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, error) {
+	b.unseals++
+	return b.backend.DecryptWithDataKey(ctx, ownerID, wrappedKey, data)
+}
+
+func TestSealed(t *testing.T) {
+	masterKey, err := encryption.ParseMasterKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	require.NoError(t, err)
+
+	sealer := &backendSealer{backend: &Service{provider: encryption.NewStaticKeyProvider(masterKey)}}
+	st := &memStore{dataKeys: make(map[uuid.UUID]pgtype.Text)}
+	s := NewSealed(new(config.Config), st, sealer)
+	ownerID := uuid.New()
+
+	require.True(t, s.IsEnvelope())
+	require.True(t, s.IsSealed())
+
+	mnemonic, err := s.Encrypt(context.Background(), ownerID, "mnemonic")
+	require.NoError(t, err)
+	require.True(t, encryption.IsEnvelope(mnemonic))
+
+	// the data key created by the signer is stored and used for the next secrets
+	dataKey := st.dataKeys[ownerID]
+	require.True(t, dataKey.Valid)
+
+	otpData, err := s.Encrypt(context.Background(), ownerID, `{"otp_secret":"secret"}`)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, st.dataKeys[ownerID])
+
+	// the seeds are readable only by the signer
+	_, err = s.Decrypt(context.Background(), ownerID, mnemonic)
+	require.ErrorIs(t, err, ErrSealedBySigner)
+
+	plain, err := sealer.backend.DecryptWithDataKey(context.Background(), ownerID, dataKey.String, mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, "mnemonic", plain)
+
+	plain, err = s.DecryptOTPData(context.Background(), ownerID, otpData)
+	require.NoError(t, err)
+	require.Equal(t, `{"otp_secret":"secret"}`, plain)
+	require.Equal(t, 1, sealer.unseals)
+
+	// the legacy secrets are still readable
+	legacy, err := encryption.Encrypt("legacy", ownerID.String())
+	require.NoError(t, err)
+
+	plain, err = s.Decrypt(context.Background(), ownerID, legacy)
+	require.NoError(t, err)
+	require.Equal(t, "legacy", plain)
+}
@@ -0,0 +1,285 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/hsm"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrKeyProviderNotConfigured = errors.New("envelope encrypted data requires a master key provider")
+	ErrSealedBySigner           = errors.New("envelope encrypted data is readable only by the signer process")
+)
+
+// Service encrypts owner secrets: mnemonics, pass phrases and OTP data.
+//
+// Without a key provider the secrets are encrypted in the ENCv1 format with the owner id as the password.
+// With a key provider every owner gets a random data key wrapped by the master key and stored in
+// the owners table, the secrets are encrypted with the data key in the ENCv2 format.
+// Both formats are always readable, so the rows can be upgraded one by one.
+//
+// With a sealer the master key is held by the signer process: the secrets are encrypted by it and
+// the ENCv2 ones are not readable by this process, see NewSealed.
+type Service struct {
+	config   *config.Config
+	store    store.IStore
+	provider encryption.KeyProvider
+	sealer   Sealer
+
+	// unwrapped data keys by the wrapped ones, so the provider is called once per key
+	dataKeys sync.Map
+}
+
+func New(conf *config.Config, st store.IStore) (*Service, error) {
+	provider, err := NewKeyProvider(conf.SeedEncryption)
+	if err != nil {
+		return nil, fmt.Errorf("init key provider: %w", err)
+	}
+
+	return &Service{
+		config:   conf,
+		store:    st,
+		provider: provider,
+	}, nil
+}
+
+// NewKeyProvider creates the master key provider from the config.
+// It returns nil if envelope encryption is disabled.
+func NewKeyProvider(conf config.SeedEncryption) (encryption.KeyProvider, error) {
+	switch conf.KeyProvider {
+	case "", config.KeyProviderNone:
+		return nil, nil //nolint:nilnil
+	case config.KeyProviderFile:
+		if conf.PreviousKeyFile != "" {
+			return encryption.NewFileKeyProvider(conf.KeyFile, conf.PreviousKeyFile)
+		}
+		return encryption.NewFileKeyProvider(conf.KeyFile)
+	case config.KeyProviderEnv:
+		if conf.PreviousKeyEnv != "" {
+			return encryption.NewEnvKeyProvider(conf.KeyEnv, conf.PreviousKeyEnv)
+		}
+		return encryption.NewEnvKeyProvider(conf.KeyEnv)
+	case config.KeyProviderVault:
+		return encryption.NewVaultTransitKeyProvider(encryption.VaultTransitConfig{
+			Address: conf.Vault.Address,
+			Token:   conf.Vault.Token,
+			Mount:   conf.Vault.Mount,
+			KeyName: conf.Vault.KeyName,
+			Timeout: conf.Vault.Timeout,
+		})
+	case config.KeyProviderPKCS11:
+		token, err := hsm.Open(hsm.Config{
+			ModulePath: conf.PKCS11.ModulePath,
+			TokenLabel: conf.PKCS11.TokenLabel,
+			Pin:        conf.PKCS11.Pin,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("open hsm: %w", err)
+		}
+		return encryption.NewPKCS11KeyProvider(token, conf.PKCS11.KeyLabel)
+	default:
+		return nil, fmt.Errorf("unsupported key provider: %s", conf.KeyProvider)
+	}
+}
+
+// IsEnvelope returns true if new secrets are encrypted with the owner data keys.
+func (s *Service) IsEnvelope() bool { return s.provider != nil || s.sealer != nil }
+
+// IsSealed returns true if the master key is held by the signer process.
+func (s *Service) IsSealed() bool { return s.sealer != nil }
+
+// Encrypt encrypts the owner secret. The owner data key is created on the first use.
+func (s *Service) Encrypt(ctx context.Context, ownerID uuid.UUID, data string, opts ...repos.Option) (string, error) {
+	if s.sealer != nil {
+		return s.seal(ctx, ownerID, data, opts...)
+	}
+
+	if s.provider == nil {
+		return encryption.Encrypt(data, ownerID.String())
+	}
+
+	dataKey, err := s.dataKey(ctx, ownerID, true, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return encryption.EncryptWithKey(data, dataKey, ownerID.String())
+}
+
+// Decrypt decrypts the owner secret encrypted in any supported format.
+func (s *Service) Decrypt(ctx context.Context, ownerID uuid.UUID, data string, opts ...repos.Option) (string, error) {
+	if !encryption.IsEnvelope(data) {
+		return encryption.Decrypt(data, ownerID.String())
+	}
+
+	if s.sealer != nil {
+		return "", ErrSealedBySigner
+	}
+
+	dataKey, err := s.dataKey(ctx, ownerID, false, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return encryption.DecryptWithKey(data, dataKey, ownerID.String())
+}
+
+// DecryptOptional decrypts the data like Decrypt, but returns empty data as is.
+// It is used for optional secrets, e.g. the BIP39 passphrase.
+func (s *Service) DecryptOptional(ctx context.Context, ownerID uuid.UUID, data string, opts ...repos.Option) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	return s.Decrypt(ctx, ownerID, data, opts...)
+}
+
+// EncryptWithDataKey encrypts the owner secret with the given wrapped data key, a new key is
+// generated when it is empty. It returns the wrapped key and the secret, the key is not stored.
+// Without a key provider the secret is encrypted in the ENCv1 format and the returned key is empty.
+// It is used by the signer process which seals the secrets of the processing.
+func (s *Service) EncryptWithDataKey(ctx context.Context, ownerID uuid.UUID, wrapped, data string) (string, string, error) {
+	if s.provider == nil {
+		encrypted, err := encryption.Encrypt(data, ownerID.String())
+		return "", encrypted, err
+	}
+
+	var (
+		dataKey []byte
+		err     error
+	)
+	if wrapped == "" {
+		dataKey, err = encryption.NewDataKey()
+		if err != nil {
+			return "", "", fmt.Errorf("generate data key: %w", err)
+		}
+
+		wrapped, err = s.provider.WrapKey(ctx, dataKey)
+		if err != nil {
+			return "", "", fmt.Errorf("wrap data key: %w", err)
+		}
+
+		s.dataKeys.Store(wrapped, dataKey)
+	} else {
+		dataKey, err = s.unwrap(ctx, wrapped)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	encrypted, err := encryption.EncryptWithKey(data, dataKey, ownerID.String())
+	if err != nil {
+		return "", "", err
+	}
+
+	return wrapped, encrypted, nil
+}
+
+// DecryptWithDataKey decrypts the owner secret like Decrypt, but the ENCv2 secret is decrypted with
+// the given wrapped data key instead of the stored one, so the key may be not committed yet.
+func (s *Service) DecryptWithDataKey(ctx context.Context, ownerID uuid.UUID, wrapped, data string) (string, error) {
+	if !encryption.IsEnvelope(data) {
+		return encryption.Decrypt(data, ownerID.String())
+	}
+
+	if s.provider == nil {
+		return "", ErrKeyProviderNotConfigured
+	}
+
+	if wrapped == "" {
+		return "", fmt.Errorf("owner %s has no data key", ownerID)
+	}
+
+	dataKey, err := s.unwrap(ctx, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	return encryption.DecryptWithKey(data, dataKey, ownerID.String())
+}
+
+// dataKey returns the unwrapped data key of the owner and creates it if create is set.
+//
+// The wrapped key is always read from the database, so a key created in a rolled back
+// transaction is never used to encrypt data.
+func (s *Service) dataKey(ctx context.Context, ownerID uuid.UUID, create bool, opts ...repos.Option) ([]byte, error) {
+	if s.provider == nil {
+		return nil, ErrKeyProviderNotConfigured
+	}
+
+	wrapped, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("get data key: %w", err)
+	}
+
+	if !wrapped.Valid {
+		if !create {
+			return nil, fmt.Errorf("owner %s has no data key", ownerID)
+		}
+
+		wrapped, err = s.createDataKey(ctx, ownerID, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.unwrap(ctx, wrapped.String)
+}
+
+func (s *Service) createDataKey(ctx context.Context, ownerID uuid.UUID, opts ...repos.Option) (pgtype.Text, error) {
+	dataKey, err := encryption.NewDataKey()
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("generate data key: %w", err)
+	}
+
+	wrapped, err := s.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	updated, err := s.store.Owners(opts...).SetDataKey(ctx, ownerID, pgtype.Text{String: wrapped, Valid: true})
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("set data key: %w", err)
+	}
+
+	// the key was created by a concurrent request
+	if updated == 0 {
+		current, err := s.store.Owners(opts...).GetDataKey(ctx, ownerID)
+		if err != nil {
+			return pgtype.Text{}, fmt.Errorf("get data key: %w", err)
+		}
+
+		if !current.Valid {
+			return pgtype.Text{}, fmt.Errorf("owner %s not found", ownerID)
+		}
+
+		return current, nil
+	}
+
+	s.dataKeys.Store(wrapped, dataKey)
+
+	return pgtype.Text{String: wrapped, Valid: true}, nil
+}
+
+func (s *Service) unwrap(ctx context.Context, wrapped string) ([]byte, error) {
+	if dataKey, ok := s.dataKeys.Load(wrapped); ok {
+		return dataKey.([]byte), nil //nolint:forcetypeassert
+	}
+
+	dataKey, err := s.provider.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+
+	s.dataKeys.Store(wrapped, dataKey)
+
+	return dataKey, nil
+}
@@ -0,0 +1,172 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_mnemonic_rotations"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UpgradeAllOwners re-encrypts the ENCv1 secrets of all owners with their data keys.
+// Every owner is upgraded in its own transaction, so the upgrade can be interrupted and started again.
+// It returns the number of upgraded owners.
+func (s *Service) UpgradeAllOwners(ctx context.Context) (int, error) {
+	if !s.IsEnvelope() {
+		return 0, ErrKeyProviderNotConfigured
+	}
+
+	owners, err := s.store.Owners().GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get owners: %w", err)
+	}
+
+	var upgraded int
+	for _, owner := range owners {
+		var changed bool
+		err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+			var err error
+			changed, err = s.upgradeOwner(ctx, owner, repos.WithTx(tx))
+			return err
+		})
+		if err != nil {
+			return upgraded, fmt.Errorf("upgrade owner %s: %w", owner.ID, err)
+		}
+
+		if changed {
+			s.store.Cache().Owners().Delete(owner.ID.String())
+			upgraded++
+		}
+	}
+
+	return upgraded, nil
+}
+
+func (s *Service) upgradeOwner(ctx context.Context, owner *models.Owner, opts ...repos.Option) (bool, error) {
+	var changed bool
+
+	mnemonic, ok, err := s.upgradeValue(ctx, owner, owner.Mnemonic, opts...)
+	if err != nil {
+		return false, fmt.Errorf("mnemonic: %w", err)
+	}
+
+	if ok {
+		if err := s.store.Owners(opts...).UpdateMnemonic(ctx, owner.ID, mnemonic); err != nil {
+			return false, fmt.Errorf("update mnemonic: %w", err)
+		}
+		changed = true
+	}
+
+	passPhrase, ok, err := s.upgradeText(ctx, owner, owner.PassPhrase, opts...)
+	if err != nil {
+		return false, fmt.Errorf("pass phrase: %w", err)
+	}
+
+	if ok {
+		if err := s.store.Owners(opts...).UpdatePassPhrase(ctx, owner.ID, passPhrase); err != nil {
+			return false, fmt.Errorf("update pass phrase: %w", err)
+		}
+		changed = true
+	}
+
+	otpData, ok, err := s.upgradeText(ctx, owner, owner.OtpData, opts...)
+	if err != nil {
+		return false, fmt.Errorf("otp data: %w", err)
+	}
+
+	if ok {
+		if err := s.store.Owners(opts...).SetOTPData(ctx, owner.ID, otpData); err != nil {
+			return false, fmt.Errorf("update otp data: %w", err)
+		}
+		changed = true
+	}
+
+	// rotations keep the new and the previous seeds of the owner
+	rotations, err := s.store.MnemonicRotations(opts...).GetAllByOwnerID(ctx, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("get mnemonic rotations: %w", err)
+	}
+
+	for _, rotation := range rotations {
+		params := repo_mnemonic_rotations.UpdateSecretsParams{ID: rotation.ID}
+
+		var rotationChanged bool
+		for _, item := range []struct {
+			src *string
+			dst *string
+		}{
+			{&rotation.Mnemonic, &params.Mnemonic},
+			{&rotation.PreviousMnemonic, &params.PreviousMnemonic},
+		} {
+			value, ok, err := s.upgradeValue(ctx, owner, *item.src, opts...)
+			if err != nil {
+				return false, fmt.Errorf("rotation %s mnemonic: %w", rotation.ID, err)
+			}
+			*item.dst = value
+			rotationChanged = rotationChanged || ok
+		}
+
+		for _, item := range []struct {
+			src pgtype.Text
+			dst *pgtype.Text
+		}{
+			{rotation.PassPhrase, &params.PassPhrase},
+			{rotation.PreviousPassPhrase, &params.PreviousPassPhrase},
+		} {
+			value, ok, err := s.upgradeText(ctx, owner, item.src, opts...)
+			if err != nil {
+				return false, fmt.Errorf("rotation %s pass phrase: %w", rotation.ID, err)
+			}
+			*item.dst = value
+			rotationChanged = rotationChanged || ok
+		}
+
+		if !rotationChanged {
+			continue
+		}
+
+		if err := s.store.MnemonicRotations(opts...).UpdateSecrets(ctx, params); err != nil {
+			return false, fmt.Errorf("update rotation %s secrets: %w", rotation.ID, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// upgradeValue re-encrypts the ENCv1 value with the owner data key.
+// Plain and already upgraded values are returned as is.
+func (s *Service) upgradeValue(ctx context.Context, owner *models.Owner, value string, opts ...repos.Option) (string, bool, error) {
+	if !encryption.IsEncrypted(value) || encryption.IsEnvelope(value) {
+		return value, false, nil
+	}
+
+	plain, err := encryption.Decrypt(value, owner.ID.String())
+	if err != nil {
+		return "", false, fmt.Errorf("decrypt: %w", err)
+	}
+
+	encrypted, err := s.Encrypt(ctx, owner.ID, plain, opts...)
+	if err != nil {
+		return "", false, fmt.Errorf("encrypt: %w", err)
+	}
+
+	return encrypted, true, nil
+}
+
+func (s *Service) upgradeText(ctx context.Context, owner *models.Owner, value pgtype.Text, opts ...repos.Option) (pgtype.Text, bool, error) {
+	if !value.Valid {
+		return value, false, nil
+	}
+
+	upgraded, ok, err := s.upgradeValue(ctx, owner, value.String, opts...)
+	if err != nil {
+		return pgtype.Text{}, false, err
+	}
+
+	return pgtype.Text{String: upgraded, Valid: true}, ok, nil
+}
@@ -0,0 +1,13 @@
+package sweeps
+
+import "errors"
+
+var (
+	ErrInvalidRule        = errors.New("invalid sweep rule")
+	ErrColdWalletNotFound = errors.New("cold wallet not found")
+	ErrEmptyCondition     = errors.New("threshold or interval is required")
+	ErrFeeMaxRequired     = errors.New("max fee is required to estimate the sweep fee")
+
+	ErrTokenNotSupported      = errors.New("sweeps of tokens are not supported")
+	ErrBlockchainNotSupported = errors.New("sweeps are not supported for the blockchain")
+)
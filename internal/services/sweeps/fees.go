@@ -0,0 +1,110 @@
+package sweeps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// virtual sizes used for the worst case fee estimation of utxo transfers
+	btcLikeTxOverheadVSize = 11
+	btcLikeOutputVSize     = 43
+	btcLikeLegacyInputSize = 148
+
+	// gas limit of the native asset transfer on evm blockchains
+	evmNativeTransferGas = 21000
+)
+
+var btcLikeInputVSizes = map[string]int64{
+	string(btc.AddressTypeP2PKH):  148,
+	string(btc.AddressTypeP2SH):   91,
+	string(btc.AddressTypeP2WPKH): 68,
+	string(btc.AddressTypeP2TR):   58,
+}
+
+// estimateMaxFee returns the fee in the swept asset units paid in the worst case allowed by the rule.
+//
+// The second value is false when the fee can not be expressed in the swept asset,
+// e.g. for tokens, the sweep is not made in this case. SetRule rejects such rules,
+// so only the rules stored before are skipped.
+func (s *Service) estimateMaxFee(ctx context.Context, rule *models.SweepRule, fromAddresses []string) (decimal.Decimal, bool, error) {
+	if rule.AssetIdentifier != rule.Blockchain.GetAssetIdentifier() {
+		return decimal.Zero, false, nil
+	}
+
+	switch {
+	case rule.Blockchain.IsBitcoinLike():
+		if !rule.FeeMax.Valid {
+			return decimal.Zero, false, nil
+		}
+
+		vSize := int64(btcLikeTxOverheadVSize + btcLikeOutputVSize)
+		for _, address := range fromAddresses {
+			utxos, err := s.eproxySvc.GetUTXO(ctx, rule.Blockchain, address)
+			if err != nil {
+				return decimal.Zero, false, fmt.Errorf("get utxo: %w", err)
+			}
+
+			vSize += int64(len(utxos)) * s.btcLikeInputVSize(rule.Blockchain, address)
+		}
+
+		// fee max is set in satoshi per vbyte
+		fee := decimal.NewFromInt(vSize).Mul(rule.FeeMax.Decimal).Div(decimal.NewFromInt(btc.AssetDecimals))
+
+		return fee, true, nil
+
+	case rule.Blockchain.IsEVM():
+		feeMax := rule.FeeMax.Decimal
+		if !rule.FeeMax.Valid {
+			evmConfig, err := s.config.Blockchain.GetEVMByBlockchainType(rule.Blockchain)
+			if err != nil {
+				return decimal.Zero, false, fmt.Errorf("get evm config: %w", err)
+			}
+
+			feeMax = decimal.NewFromFloat(evmConfig.GetMaxGasFee())
+		}
+
+		if !feeMax.IsPositive() {
+			return decimal.Zero, false, nil
+		}
+
+		// fee max is set in gwei per gas
+		fee := feeMax.Mul(decimal.NewFromInt(evmNativeTransferGas)).Shift(-9)
+
+		return fee, true, nil
+
+	default:
+		return decimal.Zero, false, nil
+	}
+}
+
+func (s *Service) btcLikeInputVSize(blockchain wconstants.BlockchainType, address string) int64 {
+	var addressType string
+	switch blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		t, err := s.walletsSvc.SDK().BTC.DecodeAddressType(address)
+		if err != nil {
+			return btcLikeLegacyInputSize
+		}
+		addressType = string(t)
+	case wconstants.BlockchainTypeLitecoin:
+		t, err := s.walletsSvc.SDK().LTC.DecodeAddressType(address)
+		if err != nil {
+			return btcLikeLegacyInputSize
+		}
+		addressType = string(t)
+	default:
+		return btcLikeLegacyInputSize
+	}
+
+	if size, ok := btcLikeInputVSizes[addressType]; ok {
+		return size
+	}
+
+	return btcLikeLegacyInputSize
+}
@@ -0,0 +1,150 @@
+package sweeps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/transfers"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_sweep_rules"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+type SetRuleParams struct {
+	OwnerID         uuid.UUID
+	Blockchain      wconstants.BlockchainType
+	AssetIdentifier string
+	ToAddress       string
+	Threshold       decimal.NullDecimal
+	IntervalHours   *int32
+	FeeMax          decimal.NullDecimal
+	Kind            *string
+	IsEnabled       bool
+}
+
+func (p SetRuleParams) validate() error {
+	if p.OwnerID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	if !p.Blockchain.Valid() {
+		return fmt.Errorf("invalid blockchain: %s", p.Blockchain.String())
+	}
+
+	if p.AssetIdentifier == "" {
+		return fmt.Errorf("asset identifier is required")
+	}
+
+	if p.ToAddress == "" {
+		return storecmn.ErrEmptyAddress
+	}
+
+	if !p.Threshold.Valid && p.IntervalHours == nil {
+		return ErrEmptyCondition
+	}
+
+	if p.Threshold.Valid && !p.Threshold.Decimal.IsPositive() {
+		return fmt.Errorf("threshold must be greater than 0")
+	}
+
+	if p.IntervalHours != nil && *p.IntervalHours <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	if p.FeeMax.Valid && !p.FeeMax.Decimal.IsPositive() {
+		return fmt.Errorf("max fee must be greater than 0")
+	}
+
+	// the sweep is made only if its worst case fee is less than the swept amount,
+	// so the rules without the fee in the swept asset units are never executed
+	if p.AssetIdentifier != p.Blockchain.GetAssetIdentifier() {
+		return ErrTokenNotSupported
+	}
+
+	switch {
+	case p.Blockchain.IsBitcoinLike():
+		if !p.FeeMax.Valid {
+			return ErrFeeMaxRequired
+		}
+	case p.Blockchain.IsEVM():
+	default:
+		return fmt.Errorf("%w: %s", ErrBlockchainNotSupported, p.Blockchain.String())
+	}
+
+	return nil
+}
+
+// SetRule creates or updates the sweep rule for the owner asset.
+func (s *Service) SetRule(ctx context.Context, params SetRuleParams) (*models.SweepRule, error) {
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRule, err)
+	}
+
+	// the evm fee is estimated by the configured max gas price when the rule has no max fee
+	if params.Blockchain.IsEVM() && !params.FeeMax.Valid {
+		evmConfig, err := s.config.Blockchain.GetEVMByBlockchainType(params.Blockchain)
+		if err != nil {
+			return nil, fmt.Errorf("get evm config: %w", err)
+		}
+
+		if evmConfig.GetMaxGasFee() <= 0 {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRule, ErrFeeMaxRequired)
+		}
+	}
+
+	// the target must be one of the owner cold wallets or resolved by the owner distribution strategy
+	if params.ToAddress != transfers.AutoToAddress {
+		if _, err := s.walletsSvc.Cold().Get(ctx, params.OwnerID, params.Blockchain, params.ToAddress); err != nil {
+			if errors.Is(err, storecmn.ErrNotFound) {
+				return nil, ErrColdWalletNotFound
+			}
+			return nil, fmt.Errorf("get cold wallet: %w", err)
+		}
+	}
+
+	var intervalHours pgtype.Int4
+	if params.IntervalHours != nil {
+		intervalHours = pgtype.Int4{Int32: *params.IntervalHours, Valid: true}
+	}
+
+	rule, err := s.store.SweepRules().Upsert(ctx, repo_sweep_rules.UpsertParams{
+		OwnerID:         params.OwnerID,
+		Blockchain:      params.Blockchain,
+		AssetIdentifier: params.AssetIdentifier,
+		ToAddress:       params.ToAddress,
+		Threshold:       params.Threshold,
+		IntervalHours:   intervalHours,
+		FeeMax:          params.FeeMax,
+		Kind:            pgtypeutils.EncodeText(params.Kind),
+		IsEnabled:       params.IsEnabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert sweep rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// GetRules returns all sweep rules of the owner.
+func (s *Service) GetRules(ctx context.Context, ownerID uuid.UUID) ([]*models.SweepRule, error) {
+	if ownerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	return s.store.SweepRules().GetAllByOwnerID(ctx, ownerID)
+}
+
+// DeleteRule deletes the sweep rule of the owner.
+func (s *Service) DeleteRule(ctx context.Context, ownerID, ruleID uuid.UUID) error {
+	if ownerID == uuid.Nil || ruleID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	return s.store.SweepRules().Delete(ctx, ruleID, ownerID)
+}
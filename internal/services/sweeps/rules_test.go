@@ -0,0 +1,45 @@
+package sweeps
+
+import (
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRuleParamsValidate(t *testing.T) {
+	newParams := func(blockchain wconstants.BlockchainType) SetRuleParams {
+		return SetRuleParams{
+			OwnerID:         uuid.New(),
+			Blockchain:      blockchain,
+			AssetIdentifier: blockchain.GetAssetIdentifier(),
+			ToAddress:       "cold",
+			Threshold:       decimal.NewNullDecimal(decimal.NewFromInt(1)),
+		}
+	}
+
+	require.NoError(t, newParams(wconstants.BlockchainTypeEthereum).validate())
+
+	t.Run("token", func(t *testing.T) {
+		params := newParams(wconstants.BlockchainTypeEthereum)
+		params.AssetIdentifier = "0xdac17f958d2ee523a2206206994597c13d831ec7"
+		require.ErrorIs(t, params.validate(), ErrTokenNotSupported)
+	})
+
+	t.Run("tron", func(t *testing.T) {
+		kind := "burntrx"
+		params := newParams(wconstants.BlockchainTypeTron)
+		params.Kind = &kind
+		require.ErrorIs(t, params.validate(), ErrBlockchainNotSupported)
+	})
+
+	t.Run("utxo without max fee", func(t *testing.T) {
+		params := newParams(wconstants.BlockchainTypeBitcoin)
+		require.ErrorIs(t, params.validate(), ErrFeeMaxRequired)
+
+		params.FeeMax = decimal.NewNullDecimal(decimal.NewFromInt(10))
+		require.NoError(t, params.validate())
+	})
+}
@@ -0,0 +1,238 @@
+package sweeps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/transfers"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type sweepCandidate struct {
+	address string
+	balance decimal.Decimal
+}
+
+// Run evaluates all enabled sweep rules and creates transfers for the due ones.
+func (s *Service) Run(ctx context.Context) error {
+	rules, err := s.store.SweepRules().GetAllEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("get sweep rules: %w", err)
+	}
+
+	available := s.config.Blockchain.Available()
+	now := time.Now()
+
+	for _, rule := range rules {
+		if !slices.Contains(available, rule.Blockchain) {
+			continue
+		}
+
+		if err := s.processRule(ctx, rule, now); err != nil {
+			s.logger.Errorw("sweep rule processing failed", "rule_id", rule.ID, "owner_id", rule.OwnerID, "blockchain", rule.Blockchain, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) processRule(ctx context.Context, rule *models.SweepRule, now time.Time) error {
+	hotWallets, err := s.walletsSvc.Hot().Find(ctx, wallets.FindHotWalletsParams{
+		OwnerID:    &rule.OwnerID,
+		Blockchain: &rule.Blockchain,
+	})
+	if err != nil {
+		return fmt.Errorf("find hot wallets: %w", err)
+	}
+
+	candidates := make([]sweepCandidate, 0, len(hotWallets.Items))
+	for _, wallet := range hotWallets.Items {
+		balance, err := s.eproxySvc.AddressBalance(ctx, wallet.Address, rule.AssetIdentifier, rule.Blockchain)
+		if err != nil {
+			return fmt.Errorf("get balance of %s: %w", wallet.Address, err)
+		}
+
+		if balance.IsPositive() {
+			candidates = append(candidates, sweepCandidate{address: wallet.Address, balance: balance})
+		}
+	}
+
+	intervalDue := rule.IntervalHours.Valid &&
+		(!rule.LastSweptAt.Valid || now.Sub(rule.LastSweptAt.Time) >= time.Duration(rule.IntervalHours.Int32)*time.Hour)
+
+	// utxo based blockchains sweep all hot wallets with one transfer
+	var groups [][]sweepCandidate
+	if rule.Blockchain.IsBitcoinLike() {
+		groups = [][]sweepCandidate{candidates}
+	} else {
+		for _, candidate := range candidates {
+			groups = append(groups, []sweepCandidate{candidate})
+		}
+	}
+
+	var swept, pending bool
+	for _, group := range groups {
+		total := decimal.Zero
+		for _, candidate := range group {
+			total = total.Add(candidate.balance)
+		}
+
+		thresholdDue := rule.Threshold.Valid && total.GreaterThanOrEqual(rule.Threshold.Decimal)
+		if !total.IsPositive() || (!intervalDue && !thresholdDue) {
+			continue
+		}
+
+		ok, err := s.sweep(ctx, rule, group, total)
+		if err != nil {
+			s.logger.Warnw("sweep failed", "rule_id", rule.ID, "blockchain", rule.Blockchain, "error", err)
+			pending = true
+			continue
+		}
+
+		swept = swept || ok
+		pending = pending || !ok
+	}
+
+	// failed or skipped interval sweeps are retried on the next run
+	if swept || (intervalDue && !pending) {
+		if err := s.store.SweepRules().SetLastSweptAt(ctx, rule.ID); err != nil {
+			return fmt.Errorf("set last swept at: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// sweep creates a transfer of the whole amount from the addresses to the rule cold wallet.
+func (s *Service) sweep(ctx context.Context, rule *models.SweepRule, group []sweepCandidate, total decimal.Decimal) (bool, error) {
+	fromAddresses := make([]string, 0, len(group))
+	for _, candidate := range group {
+		fromAddresses = append(fromAddresses, candidate.address)
+	}
+
+	requestID, inProgress, err := s.nextSweepRequestID(ctx, rule, fromAddresses)
+	if err != nil {
+		return false, err
+	}
+
+	if inProgress {
+		s.logger.Infow("sweep skipped, the previous sweep transfer is in progress",
+			"rule_id", rule.ID,
+			"blockchain", rule.Blockchain,
+			"addresses", fromAddresses,
+		)
+		return false, nil
+	}
+
+	maxFee, ok, err := s.estimateMaxFee(ctx, rule, fromAddresses)
+	if err != nil {
+		return false, fmt.Errorf("estimate fee: %w", err)
+	}
+
+	if !ok {
+		s.logger.Warnw("sweep skipped, the fee can not be estimated",
+			"rule_id", rule.ID,
+			"blockchain", rule.Blockchain,
+			"asset_identifier", rule.AssetIdentifier,
+		)
+		return false, nil
+	}
+
+	if maxFee.GreaterThanOrEqual(total) {
+		s.logger.Infow("sweep skipped, fee exceeds the amount",
+			"rule_id", rule.ID,
+			"blockchain", rule.Blockchain,
+			"amount", total.String(),
+			"max_fee", maxFee.String(),
+		)
+		return false, nil
+	}
+
+	var kind *string
+	if rule.Kind.Valid {
+		kind = &rule.Kind.String
+	}
+
+	_, err = s.transfersSvc.Create(ctx, transfers.CreateTransferRequest{
+		OwnerID:         rule.OwnerID,
+		RequestID:       requestID,
+		Blockchain:      rule.Blockchain,
+		FromAddresses:   fromAddresses,
+		ToAddresses:     []string{rule.ToAddress},
+		AssetIdentifier: rule.AssetIdentifier,
+		Kind:            kind,
+		WholeAmount:     true,
+		FeeMax:          rule.FeeMax,
+	})
+	if err != nil {
+		// the transfer was created by the previous run
+		if errors.Is(err, storecmn.ErrAlreadyExists) {
+			return true, nil
+		}
+		return false, fmt.Errorf("create transfer: %w", err)
+	}
+
+	return true, nil
+}
+
+// nextSweepRequestID returns the request id of the next sweep transfer from the addresses.
+// It reports whether the previous sweep transfer from the addresses is still in progress.
+func (s *Service) nextSweepRequestID(ctx context.Context, rule *models.SweepRule, fromAddresses []string) (string, bool, error) {
+	prefix := sweepRequestIDPrefix(rule, fromAddresses)
+
+	last, err := s.store.Transfers().GetLastByRequestIDPrefix(ctx, rule.OwnerID, prefix)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		last = nil
+	case err != nil:
+		return "", false, fmt.Errorf("get last sweep transfer: %w", err)
+	}
+
+	requestID, inProgress := nextSweepRequestID(prefix, last)
+	return requestID, inProgress, nil
+}
+
+// sweepRequestIDPrefix depends only on the rule and the address, the request ids of the sweeps
+// from the address are numbered after it, so a transfer is never duplicated if the job is restarted.
+//
+// The utxo blockchains sweep all hot wallets of the rule with one transfer and the set of the
+// addresses changes between the runs, so their prefix depends only on the rule.
+func sweepRequestIDPrefix(rule *models.SweepRule, fromAddresses []string) string {
+	if rule.Blockchain.IsBitcoinLike() {
+		return "sweep-" + rule.ID.String() + "-"
+	}
+
+	return "sweep-" + uuid.NewSHA1(rule.ID, []byte(fromAddresses[0])).String() + "-"
+}
+
+// nextSweepRequestID returns the request id following the last sweep transfer.
+// A sweep is in progress until its transfer is completed or failed.
+func nextSweepRequestID(prefix string, last *models.Transfer) (string, bool) {
+	if last == nil {
+		return prefix + "0", false
+	}
+
+	switch last.Status {
+	case constants.TransferStatusCompleted, constants.TransferStatusFailed:
+	default:
+		return last.RequestID, true
+	}
+
+	number, err := strconv.ParseInt(strings.TrimPrefix(last.RequestID, prefix), 10, 64)
+	if err != nil {
+		return prefix + "0", false
+	}
+
+	return prefix + strconv.FormatInt(number+1, 10), false
+}
@@ -0,0 +1,142 @@
+package sweeps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepRequestIDPrefix(t *testing.T) {
+	rule := &models.SweepRule{ID: uuid.New(), Blockchain: wconstants.BlockchainTypeEthereum}
+	prefix := sweepRequestIDPrefix(rule, []string{"addr"})
+
+	// the last swept at is changed after the transfer is created, the request id must not follow it
+	rule.LastSweptAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	require.Equal(t, prefix, sweepRequestIDPrefix(rule, []string{"addr"}))
+
+	require.NotEqual(t, prefix, sweepRequestIDPrefix(rule, []string{"other"}))
+	require.NotEqual(t, prefix, sweepRequestIDPrefix(&models.SweepRule{ID: uuid.New()}, []string{"addr"}))
+
+	t.Run("utxo", func(t *testing.T) {
+		rule := &models.SweepRule{ID: uuid.New(), Blockchain: wconstants.BlockchainTypeBitcoin}
+
+		// the set of the swept addresses changes between the runs
+		require.Equal(t,
+			sweepRequestIDPrefix(rule, []string{"a", "b"}),
+			sweepRequestIDPrefix(rule, []string{"c", "a"}),
+		)
+	})
+}
+
+func TestNextSweepRequestID(t *testing.T) {
+	const prefix = "sweep-id-"
+
+	tests := []struct {
+		name       string
+		last       *models.Transfer
+		requestID  string
+		inProgress bool
+	}{
+		{
+			name:      "first sweep",
+			requestID: "sweep-id-0",
+		},
+		{
+			name:      "completed",
+			last:      &models.Transfer{RequestID: "sweep-id-0", Status: constants.TransferStatusCompleted},
+			requestID: "sweep-id-1",
+		},
+		{
+			name:      "failed",
+			last:      &models.Transfer{RequestID: "sweep-id-9", Status: constants.TransferStatusFailed},
+			requestID: "sweep-id-10",
+		},
+		{
+			name:       "new",
+			last:       &models.Transfer{RequestID: "sweep-id-2", Status: constants.TransferStatusNew},
+			requestID:  "sweep-id-2",
+			inProgress: true,
+		},
+		{
+			name:       "unconfirmed",
+			last:       &models.Transfer{RequestID: "sweep-id-2", Status: constants.TransferStatusUnconfirmed},
+			requestID:  "sweep-id-2",
+			inProgress: true,
+		},
+		{
+			name:       "frozen",
+			last:       &models.Transfer{RequestID: "sweep-id-2", Status: constants.TransferStatusFrozen},
+			requestID:  "sweep-id-2",
+			inProgress: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestID, inProgress := nextSweepRequestID(prefix, tt.last)
+			require.Equal(t, tt.requestID, requestID)
+			require.Equal(t, tt.inProgress, inProgress)
+		})
+	}
+}
+
+func TestEstimateMaxFee(t *testing.T) {
+	s := &Service{}
+
+	tests := []struct {
+		name string
+		rule *models.SweepRule
+		fee  decimal.Decimal
+		ok   bool
+	}{
+		{
+			name: "evm native",
+			rule: &models.SweepRule{
+				Blockchain:      wconstants.BlockchainTypeEthereum,
+				AssetIdentifier: wconstants.BlockchainTypeEthereum.GetAssetIdentifier(),
+				FeeMax:          decimal.NewNullDecimal(decimal.NewFromInt(100)),
+			},
+			fee: decimal.RequireFromString("0.0021"),
+			ok:  true,
+		},
+		{
+			name: "evm token",
+			rule: &models.SweepRule{
+				Blockchain:      wconstants.BlockchainTypeEthereum,
+				AssetIdentifier: "0xdac17f958d2ee523a2206206994597c13d831ec7",
+				FeeMax:          decimal.NewNullDecimal(decimal.NewFromInt(100)),
+			},
+		},
+		{
+			name: "tron",
+			rule: &models.SweepRule{
+				Blockchain:      wconstants.BlockchainTypeTron,
+				AssetIdentifier: wconstants.BlockchainTypeTron.GetAssetIdentifier(),
+			},
+		},
+		{
+			name: "bitcoin without fee max",
+			rule: &models.SweepRule{
+				Blockchain:      wconstants.BlockchainTypeBitcoin,
+				AssetIdentifier: wconstants.BlockchainTypeBitcoin.GetAssetIdentifier(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, ok, err := s.estimateMaxFee(context.Background(), tt.rule, []string{"addr"})
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, ok)
+			require.True(t, tt.fee.Equal(fee), "fee %s", fee)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package transfers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/shopspring/decimal"
+)
+
+// AutoToAddress is resolved to one of the owner cold wallets at the transfer creation.
+const AutoToAddress = "auto"
+
+// IsAutoToAddress returns true if the to address must be resolved from the owner cold wallets.
+func (r CreateTransferRequest) IsAutoToAddress() bool {
+	return len(r.ToAddresses) == 1 && r.ToAddresses[0] == AutoToAddress
+}
+
+// resolveColdWallet replaces the auto to address with the owner cold wallet.
+func (s *Service) resolveColdWallet(ctx context.Context, req *CreateTransferRequest) error {
+	amount := req.Amount.Decimal
+	if req.WholeAmount {
+		amount = decimal.Zero
+		for _, fromAddress := range req.FromAddresses {
+			balance, err := s.eproxySvc.AddressBalance(ctx, fromAddress, req.AssetIdentifier, req.Blockchain)
+			if err != nil {
+				return fmt.Errorf("get balance of %s: %w", fromAddress, err)
+			}
+			amount = amount.Add(balance)
+		}
+	}
+
+	res, err := s.walletsSvc.Cold().Resolve(ctx, wallets.ResolveColdWalletParams{
+		OwnerID:         req.OwnerID,
+		Blockchain:      req.Blockchain,
+		AssetIdentifier: req.AssetIdentifier,
+		Amount:          amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	req.ToAddresses = []string{res.Address}
+	req.coldWallet = res
+	req.coldWalletAmount = amount
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_transfers"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
@@ -31,11 +32,29 @@ type CreateTransferRequest struct {
 	Fee             decimal.NullDecimal       `json:"fee"`
 	FeeMax          decimal.NullDecimal       `json:"fee_max"`
 
+	// rotation moves funds from an old owner wallet to its equivalent derived from a new mnemonic.
+	// The to address is not registered yet, so it is not checked and gets the same wallet type.
+	rotation bool
+
 	stateData map[string]any
 
 	walletFromType constants.WalletType
 
 	walletToType constants.WalletType
+
+	// coldWallet is set when the to address is resolved from the owner cold wallets
+	coldWallet       *wallets.ResolveColdWalletResult
+	coldWalletAmount decimal.Decimal
+
+	// processingWallet is the owner processing wallet chosen to pay the transfer fees
+	processingWallet *models.ProcessingWallet
+}
+
+// CreateRotation creates a transfer from an old owner wallet to the wallet derived from the new mnemonic.
+// It is used only by the mnemonic rotation, the from addresses are checked as usual.
+func (s *Service) CreateRotation(ctx context.Context, req CreateTransferRequest) (*models.Transfer, error) {
+	req.rotation = true
+	return s.Create(ctx, req)
 }
 
 // Create transfer
@@ -76,6 +95,13 @@ func (s *Service) Create(ctx context.Context, req CreateTransferRequest) (*model
 		return nil, fmt.Errorf("get owner: %w", err)
 	}
 
+	// resolve the cold wallet by the owner distribution strategy
+	if req.IsAutoToAddress() {
+		if err := s.resolveColdWallet(ctx, &req); err != nil {
+			return nil, fmt.Errorf("resolve cold wallet: %w", err)
+		}
+	}
+
 	// check from addresses and get wallet from type
 	for idx, fromAddress := range req.FromAddresses {
 		// get wallet data
@@ -99,8 +125,12 @@ func (s *Service) Create(ctx context.Context, req CreateTransferRequest) (*model
 	}
 
 	// check to addresses and get wallet to type
+	if req.rotation {
+		req.walletToType = req.walletFromType
+	}
+
 	for idx, toAddress := range req.ToAddresses {
-		if req.walletFromType == constants.WalletTypeProcessing {
+		if req.walletFromType == constants.WalletTypeProcessing || req.rotation {
 			continue
 		}
 
@@ -113,6 +143,11 @@ func (s *Service) Create(ctx context.Context, req CreateTransferRequest) (*model
 			return nil, fmt.Errorf("invalid wallet owner %s", req.OwnerID)
 		}
 
+		// transfers to detached cold wallets are finished, but new ones are not allowed
+		if checkResult.IsDetached {
+			return nil, fmt.Errorf("cold wallet %s is detached", toAddress)
+		}
+
 		if idx == 0 {
 			req.walletToType = checkResult.WalletType
 		} else if req.walletToType != checkResult.WalletType {
@@ -141,7 +176,7 @@ func (s *Service) Create(ctx context.Context, req CreateTransferRequest) (*model
 		}
 
 		// available wallet to types for transfer from hot wallet: cold and processing
-		if req.walletFromType == constants.WalletTypeHot &&
+		if req.walletFromType == constants.WalletTypeHot && !req.rotation &&
 			!slices.Contains([]constants.WalletType{constants.WalletTypeCold, constants.WalletTypeProcessing}, req.walletToType) {
 			return nil, fmt.Errorf("invalid wallet to type %s", req.walletToType)
 		}
@@ -178,6 +213,16 @@ func (s *Service) Create(ctx context.Context, req CreateTransferRequest) (*model
 	// set wallet to type
 	req.stateData["wallet_to_type"] = req.walletToType
 
+	if req.coldWallet != nil {
+		req.stateData["cold_wallet_strategy"] = req.coldWallet.Strategy
+		req.stateData["cold_wallet_address"] = req.coldWallet.Address
+		req.stateData["cold_wallet_amount"] = req.coldWalletAmount.String()
+	}
+
+	if req.processingWallet != nil {
+		req.stateData[constants.ProcessingWalletAddressStateKey] = req.processingWallet.Address
+	}
+
 	createParams := repo_transfers.CreateParams{
 		Status:          constants.TransferStatusNew,
 		OwnerID:         owner.ID,
@@ -120,7 +120,7 @@ func (s *Service) processEVM(ctx context.Context, req *CreateTransferRequest) er
 	// check balances
 	if req.walletFromType == constants.WalletTypeHot && req.AssetIdentifier != req.Blockchain.GetAssetIdentifier() { //nolint:nestif
 		// get processing wallet by owner id
-		processingWallet, err := s.walletsSvc.Processing().GetByBlockchain(ctx, req.OwnerID, req.Blockchain)
+		processingWallet, err := s.processingWallet(ctx, req)
 		if err != nil {
 			return fmt.Errorf("get processing wallet: %w", err)
 		}
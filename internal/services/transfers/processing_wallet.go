@@ -0,0 +1,116 @@
+package transfers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+// processingWallet returns the owner processing wallet which pays the fees of the transfer.
+//
+// The wallet is chosen once by the owner strategy and stored in the transfer state data,
+// so the transfer workflow uses the same wallet.
+func (s *Service) processingWallet(ctx context.Context, req *CreateTransferRequest) (*models.ProcessingWallet, error) {
+	if req.processingWallet != nil {
+		return req.processingWallet, nil
+	}
+
+	items, err := s.walletsSvc.Processing().GetAllByBlockchain(ctx, req.OwnerID, req.Blockchain)
+	if err != nil {
+		return nil, fmt.Errorf("get processing wallets: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("processing wallet: %w", storecmn.ErrNotFound)
+	}
+
+	if len(items) == 1 {
+		req.processingWallet = items[0]
+		return req.processingWallet, nil
+	}
+
+	strategy, err := s.walletsSvc.Processing().GetStrategy(ctx, req.OwnerID, req.Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy == constants.ProcessingWalletsStrategyHighestBalance {
+		balances := make(map[string]decimal.Decimal, len(items))
+		for _, item := range items {
+			balance, err := s.eproxySvc.AddressBalance(ctx, item.Address, req.Blockchain.GetAssetIdentifier(), req.Blockchain)
+			if err != nil {
+				return nil, fmt.Errorf("get balance of %s: %w", item.Address, err)
+			}
+			balances[item.Address] = balance
+		}
+
+		req.processingWallet = selectHighestBalance(items, balances)
+		return req.processingWallet, nil
+	}
+
+	usage, err := s.walletsSvc.Processing().GetUsage(ctx, req.OwnerID, req.Blockchain)
+	if err != nil {
+		return nil, fmt.Errorf("get processing wallets usage: %w", err)
+	}
+
+	usageByAddress := lo.SliceToMap(usage, func(item *wallets.ProcessingWalletUsage) (string, *wallets.ProcessingWalletUsage) {
+		return item.Address, item
+	})
+
+	if strategy == constants.ProcessingWalletsStrategyRoundRobin {
+		req.processingWallet = selectRoundRobin(items, usageByAddress)
+	} else {
+		req.processingWallet = selectLeastBusy(items, usageByAddress)
+	}
+
+	return req.processingWallet, nil
+}
+
+// selectLeastBusy returns the wallet with the lowest number of active transfers.
+func selectLeastBusy(items []*models.ProcessingWallet, usage map[string]*wallets.ProcessingWalletUsage) *models.ProcessingWallet {
+	load := func(item *models.ProcessingWallet) int32 {
+		if u, ok := usage[item.Address]; ok {
+			return u.ActiveTransfersCount
+		}
+		return 0
+	}
+
+	// the first wallet wins for equal loads
+	return slices.MinFunc(items, func(a, b *models.ProcessingWallet) int {
+		return cmp.Compare(load(a), load(b))
+	})
+}
+
+// selectRoundRobin returns the wallet next to the last used one.
+func selectRoundRobin(items []*models.ProcessingWallet, usage map[string]*wallets.ProcessingWalletUsage) *models.ProcessingWallet {
+	lastIdx := -1
+	var lastUsed *wallets.ProcessingWalletUsage
+	for idx, item := range items {
+		u, ok := usage[item.Address]
+		if !ok {
+			continue
+		}
+
+		if lastUsed == nil || u.LastTransferAt.Time.After(lastUsed.LastTransferAt.Time) {
+			lastIdx, lastUsed = idx, u
+		}
+	}
+
+	return items[(lastIdx+1)%len(items)]
+}
+
+// selectHighestBalance returns the wallet with the highest native asset balance.
+func selectHighestBalance(items []*models.ProcessingWallet, balances map[string]decimal.Decimal) *models.ProcessingWallet {
+	// the first wallet wins for equal balances
+	return slices.MaxFunc(items, func(a, b *models.ProcessingWallet) int {
+		return balances[a.Address].Cmp(balances[b.Address])
+	})
+}
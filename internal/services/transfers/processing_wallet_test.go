@@ -0,0 +1,48 @@
+package transfers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/wallets"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectProcessingWallet(t *testing.T) {
+	now := time.Now()
+
+	items := []*models.ProcessingWallet{
+		{Address: "a"},
+		{Address: "b"},
+		{Address: "c"},
+	}
+
+	usage := map[string]*wallets.ProcessingWalletUsage{
+		"a": {Address: "a", ActiveTransfersCount: 2, LastTransferAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}},
+		"b": {Address: "b", ActiveTransfersCount: 0, LastTransferAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		"c": {Address: "c", ActiveTransfersCount: 1, LastTransferAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+	}
+
+	t.Run("least busy", func(t *testing.T) {
+		require.Equal(t, "a", selectLeastBusy(items, nil).Address)
+		require.Equal(t, "b", selectLeastBusy(items, usage).Address)
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		require.Equal(t, "a", selectRoundRobin(items, nil).Address)
+		require.Equal(t, "c", selectRoundRobin(items, usage).Address)
+		require.Equal(t, "a", selectRoundRobin(items[:2], usage).Address)
+	})
+
+	t.Run("highest balance", func(t *testing.T) {
+		require.Equal(t, "a", selectHighestBalance(items, nil).Address)
+		require.Equal(t, "c", selectHighestBalance(items, map[string]decimal.Decimal{
+			"a": decimal.NewFromInt(1),
+			"b": decimal.NewFromInt(2),
+			"c": decimal.NewFromInt(3),
+		}).Address)
+	})
+}
@@ -221,7 +221,7 @@ func (s *Service) processTronHotWallets(ctx context.Context, req *CreateTransfer
 
 func (s *Service) processBurnTRX(ctx context.Context, req *CreateTransferRequest, estimate *tron.EstimateTransferResourcesResult) error {
 	// get processing wallet
-	processingWallet, err := s.walletsSvc.Processing().GetByBlockchain(ctx, req.OwnerID, req.Blockchain)
+	processingWallet, err := s.processingWallet(ctx, req)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
@@ -320,7 +320,7 @@ func (s *Service) processBurnTRX(ctx context.Context, req *CreateTransferRequest
 
 func (s *Service) processResources(ctx context.Context, req *CreateTransferRequest, estimate *tron.EstimateTransferResourcesResult) error {
 	// get processing wallet
-	processingWallet, err := s.walletsSvc.Processing().GetByBlockchain(ctx, req.OwnerID, req.Blockchain)
+	processingWallet, err := s.processingWallet(ctx, req)
 	if err != nil {
 		return fmt.Errorf("get processing wallet: %w", err)
 	}
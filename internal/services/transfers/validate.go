@@ -63,9 +63,15 @@ func (r CreateTransferRequest) validate(conf *config.Config) error {
 		return fmt.Errorf("to addresses must be unique, duplicates: %v", dupl)
 	}
 
+	// the auto address is resolved to the cold wallet later
+	toAddresses := r.ToAddresses
+	if r.IsAutoToAddress() {
+		toAddresses = nil
+	}
+
 	// validate addresses
 	if err := avalidator.ValidateAddressesByBlockchain(
-		append(r.FromAddresses, r.ToAddresses...),
+		append(slices.Clone(r.FromAddresses), toAddresses...),
 		r.Blockchain.String(),
 	); err != nil {
 		return err
@@ -117,6 +117,12 @@ func (s *Service) cacherHandler(ctx context.Context) error {
 		updatedColdWallets := make(map[string]struct{})
 		for _, wallet := range coldWallets.Items {
 			key := cacherKey(wallet.Blockchain, wallet.Address)
+
+			// the attached wallet wins over the detached ones with the same address
+			if _, ok := updatedColdWallets[key]; ok && wallet.DetachedAt.Valid {
+				continue
+			}
+
 			s.store.Cache().ColdWallets().Store(key, wallet)
 			updatedColdWallets[key] = struct{}{}
 		}
@@ -18,6 +18,8 @@ type CheckWalletResult struct {
 	OwnerID          uuid.UUID
 	ExternalWalletID *string
 	IsActivated      *bool
+	// IsDetached is true for cold wallets detached by the owner
+	IsDetached bool
 }
 
 func (s *CheckWalletResult) Activated() bool {
@@ -81,6 +83,7 @@ func (s *Service) checkWalletInCache(blockchain wconstants.BlockchainType, addre
 			return &CheckWalletResult{
 				WalletType: constants.WalletTypeCold,
 				OwnerID:    wallet.OwnerID,
+				IsDetached: wallet.DetachedAt.Valid,
 			}, nil
 		}
 	}
@@ -133,6 +136,7 @@ func (s *Service) checkWalletInDatabase(ctx context.Context, blockchain wconstan
 			return &CheckWalletResult{
 				WalletType: constants.WalletTypeCold,
 				OwnerID:    res.OwnerID,
+				IsDetached: res.DetachedAt.Valid,
 			}, nil
 		}
 	}
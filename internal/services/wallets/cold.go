@@ -10,6 +10,7 @@ import (
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_cold"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/bch"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
@@ -77,7 +78,9 @@ func (s *ColdWallets) Create(ctx context.Context, params CreateColdWalletParams,
 	return newItem, nil
 }
 
-// BatchAttachColdWallets creates cold wallets in batch.
+// BatchAttachColdWallets replaces the owner cold wallets for the blockchain.
+//
+// Existing addresses keep their labels, missing ones are detached.
 func (s *ColdWallets) BatchAttachColdWallets(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, params []CreateColdWalletParams) error {
 	if ownerID == uuid.Nil {
 		return storecmn.ErrEmptyID
@@ -88,16 +91,39 @@ func (s *ColdWallets) BatchAttachColdWallets(ctx context.Context, ownerID uuid.U
 	}
 
 	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
-		if err := s.DeleteAllByBlockchainAndOwnerID(ctx, DeleteAllColdWalletsParams{
-			OwnerID:    &ownerID,
-			Blockchain: &blockchain,
-		}, repos.WithTx(tx)); err != nil {
-			return fmt.Errorf("delete cold wallets: %w", err)
+		existing, err := s.store.Wallets().Cold(repos.WithTx(tx)).GetAllByOwnerID(ctx, ownerID)
+		if err != nil {
+			return fmt.Errorf("get cold wallets: %w", err)
+		}
+
+		attached := make(map[string]struct{}, len(existing))
+		for _, wallet := range existing {
+			if wallet.Blockchain == blockchain {
+				attached[wallet.Address] = struct{}{}
+			}
+		}
+
+		requested := make(map[string]struct{}, len(params))
+		for _, param := range params {
+			requested[param.Address] = struct{}{}
+		}
+
+		for address := range attached {
+			if _, ok := requested[address]; ok {
+				continue
+			}
+
+			if _, err := s.Detach(ctx, ownerID, blockchain, address, repos.WithTx(tx)); err != nil {
+				return fmt.Errorf("detach cold wallet %s: %w", address, err)
+			}
 		}
 
 		for _, param := range params {
-			_, err := s.Create(ctx, param, repos.WithTx(tx))
-			if err != nil {
+			if _, ok := attached[param.Address]; ok {
+				continue
+			}
+
+			if _, err := s.Create(ctx, param, repos.WithTx(tx)); err != nil {
 				return err
 			}
 		}
@@ -108,6 +134,83 @@ func (s *ColdWallets) BatchAttachColdWallets(ctx context.Context, ownerID uuid.U
 	return err
 }
 
+// Detach marks the cold wallet as detached. The wallet is kept for audit
+// and transfers which are already heading to it can be finished.
+func (s *ColdWallets) Detach(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, address string, opts ...repos.Option) (*models.ColdWallet, error) {
+	if ownerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !blockchain.Valid() {
+		return nil, fmt.Errorf("invalid blockchain: %s", blockchain)
+	}
+
+	if address == "" {
+		return nil, storecmn.ErrEmptyAddress
+	}
+
+	item, err := s.store.Wallets().Cold(opts...).Detach(ctx, repo_wallets_cold.DetachParams{
+		OwnerID:    ownerID,
+		Blockchain: blockchain,
+		Address:    address,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storecmn.ErrNotFound
+		}
+		return nil, err
+	}
+
+	s.store.Cache().ColdWallets().Store(cacherKey(blockchain, address), item)
+
+	return item, nil
+}
+
+type SetColdWalletLabelParams struct {
+	OwnerID    uuid.UUID
+	Blockchain wconstants.BlockchainType
+	Address    string
+	Label      *string
+	Note       *string
+}
+
+// SetLabel sets the human-readable label and note of the cold wallet.
+func (s *ColdWallets) SetLabel(ctx context.Context, params SetColdWalletLabelParams) (*models.ColdWallet, error) {
+	if params.OwnerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !params.Blockchain.Valid() {
+		return nil, fmt.Errorf("invalid blockchain: %s", params.Blockchain)
+	}
+
+	if params.Address == "" {
+		return nil, storecmn.ErrEmptyAddress
+	}
+
+	if params.Label != nil && len(*params.Label) > 255 {
+		return nil, fmt.Errorf("label must be at most 255 characters")
+	}
+
+	item, err := s.store.Wallets().Cold().SetLabel(ctx, repo_wallets_cold.SetLabelParams{
+		OwnerID:    params.OwnerID,
+		Blockchain: params.Blockchain,
+		Address:    params.Address,
+		Label:      pgtypeutils.EncodeText(params.Label),
+		Note:       pgtypeutils.EncodeText(params.Note),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storecmn.ErrNotFound
+		}
+		return nil, err
+	}
+
+	s.store.Cache().ColdWallets().Store(cacherKey(params.Blockchain, params.Address), item)
+
+	return item, nil
+}
+
 // GetAllByOwnerID returns all hot wallets for the owner.
 func (s *ColdWallets) GetAllByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.ColdWallet, error) {
 	return s.store.Wallets().Cold().GetAllByOwnerID(ctx, ownerID)
@@ -0,0 +1,311 @@
+package wallets
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_cold_wallet_distributions"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoColdWallets          = errors.New("owner has no active cold wallets")
+	ErrColdWalletsCapReached  = errors.New("all cold wallets have reached their cap")
+	ErrColdWalletNotAttached  = errors.New("cold wallet is not attached")
+	ErrInvalidColdWalletsItem = errors.New("invalid cold wallets distribution item")
+)
+
+type ColdWalletsDistributionItem struct {
+	Address string
+	// Weight is used by the weighted strategy, it must be greater than 0
+	Weight int32
+	// Cap is the maximum amount of every asset which can be sent to the address by the cap strategy
+	Cap decimal.NullDecimal
+}
+
+type ColdWalletsDistribution struct {
+	OwnerID    uuid.UUID
+	Blockchain wconstants.BlockchainType
+	Strategy   constants.ColdWalletsStrategy
+	Items      []ColdWalletsDistributionItem
+}
+
+func (d ColdWalletsDistribution) validate() error {
+	if d.OwnerID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	if !d.Blockchain.Valid() {
+		return fmt.Errorf("invalid blockchain: %s", d.Blockchain)
+	}
+
+	if !d.Strategy.Valid() {
+		return fmt.Errorf("invalid strategy: %s", d.Strategy)
+	}
+
+	if dupl := lo.FindDuplicates(lo.Map(d.Items, func(item ColdWalletsDistributionItem, _ int) string {
+		return item.Address
+	})); len(dupl) > 0 {
+		return fmt.Errorf("%w: duplicated addresses %v", ErrInvalidColdWalletsItem, dupl)
+	}
+
+	for _, item := range d.Items {
+		if item.Address == "" {
+			return fmt.Errorf("%w: %w", ErrInvalidColdWalletsItem, storecmn.ErrEmptyAddress)
+		}
+
+		if item.Weight <= 0 {
+			return fmt.Errorf("%w: weight of %s must be greater than 0", ErrInvalidColdWalletsItem, item.Address)
+		}
+
+		if item.Cap.Valid && !item.Cap.Decimal.IsPositive() {
+			return fmt.Errorf("%w: cap of %s must be greater than 0", ErrInvalidColdWalletsItem, item.Address)
+		}
+	}
+
+	return nil
+}
+
+// SetDistribution replaces the strategy and the settings of the owner cold wallets for the blockchain.
+func (s *ColdWallets) SetDistribution(ctx context.Context, params ColdWalletsDistribution) (*ColdWalletsDistribution, error) {
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	for _, item := range params.Items {
+		if _, err := s.Get(ctx, params.OwnerID, params.Blockchain, item.Address); err != nil {
+			if errors.Is(err, storecmn.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrColdWalletNotAttached, item.Address)
+			}
+			return nil, fmt.Errorf("get cold wallet: %w", err)
+		}
+	}
+
+	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if _, err := s.store.Wallets().ColdDistributions(repos.WithTx(tx)).Upsert(ctx, repo_cold_wallet_distributions.UpsertParams{
+			OwnerID:    params.OwnerID,
+			Blockchain: params.Blockchain,
+			Strategy:   params.Strategy,
+		}); err != nil {
+			return fmt.Errorf("upsert distribution: %w", err)
+		}
+
+		if err := s.store.Wallets().ColdDistributions(repos.WithTx(tx)).DeleteItems(ctx, params.OwnerID, params.Blockchain); err != nil {
+			return fmt.Errorf("delete distribution items: %w", err)
+		}
+
+		for idx, item := range params.Items {
+			if _, err := s.store.Wallets().ColdDistributions(repos.WithTx(tx)).CreateItem(ctx, repo_cold_wallet_distributions.CreateItemParams{
+				OwnerID:    params.OwnerID,
+				Blockchain: params.Blockchain,
+				Address:    item.Address,
+				Position:   int32(idx), //nolint:gosec
+				Weight:     item.Weight,
+				Cap:        item.Cap,
+			}); err != nil {
+				return fmt.Errorf("create distribution item: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDistribution(ctx, params.OwnerID, params.Blockchain)
+}
+
+// GetDistribution returns the cold wallets distribution of the owner for the blockchain.
+// Round-robin over all cold wallets is returned when the owner has not set a distribution.
+func (s *ColdWallets) GetDistribution(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (*ColdWalletsDistribution, error) {
+	if ownerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !blockchain.Valid() {
+		return nil, fmt.Errorf("invalid blockchain: %s", blockchain)
+	}
+
+	res := &ColdWalletsDistribution{
+		OwnerID:    ownerID,
+		Blockchain: blockchain,
+		Strategy:   constants.ColdWalletsStrategyRoundRobin,
+		Items:      []ColdWalletsDistributionItem{},
+	}
+
+	distribution, err := s.store.Wallets().ColdDistributions().Get(ctx, ownerID, blockchain)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return res, nil
+		}
+		return nil, fmt.Errorf("get distribution: %w", err)
+	}
+
+	res.Strategy = distribution.Strategy
+
+	items, err := s.store.Wallets().ColdDistributions().GetItems(ctx, ownerID, blockchain)
+	if err != nil {
+		return nil, fmt.Errorf("get distribution items: %w", err)
+	}
+
+	for _, item := range items {
+		res.Items = append(res.Items, ColdWalletsDistributionItem{
+			Address: item.Address,
+			Weight:  item.Weight,
+			Cap:     item.Cap,
+		})
+	}
+
+	return res, nil
+}
+
+type ResolveColdWalletParams struct {
+	OwnerID         uuid.UUID
+	Blockchain      wconstants.BlockchainType
+	AssetIdentifier string
+	// Amount is the amount of the transfer, it is checked against the address cap
+	Amount decimal.Decimal
+}
+
+type ResolveColdWalletResult struct {
+	Address  string
+	Strategy constants.ColdWalletsStrategy
+}
+
+// Resolve chooses the owner cold wallet for the next transfer by the owner distribution strategy.
+//
+// Previous choices are taken from the transfers created with the resolved address,
+// so failed transfers are not counted.
+func (s *ColdWallets) Resolve(ctx context.Context, params ResolveColdWalletParams) (*ResolveColdWalletResult, error) {
+	distribution, err := s.GetDistribution(ctx, params.OwnerID, params.Blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	coldWallets, err := s.GetAllByOwnerID(ctx, params.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("get cold wallets: %w", err)
+	}
+
+	active := make(map[string]struct{}, len(coldWallets))
+	for _, wallet := range coldWallets {
+		if wallet.Blockchain == params.Blockchain && wallet.IsActive {
+			active[wallet.Address] = struct{}{}
+		}
+	}
+
+	// configured addresses go first in the owner order, the others are sorted by address
+	candidates := make([]ColdWalletsDistributionItem, 0, len(active))
+	for _, item := range distribution.Items {
+		if _, ok := active[item.Address]; ok {
+			candidates = append(candidates, item)
+			delete(active, item.Address)
+		}
+	}
+
+	rest := lo.Keys(active)
+	slices.Sort(rest)
+	for _, address := range rest {
+		candidates = append(candidates, ColdWalletsDistributionItem{Address: address, Weight: 1})
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoColdWallets
+	}
+
+	allocations, err := s.store.Wallets().ColdDistributions().GetAllocations(ctx, repo_cold_wallet_distributions.GetAllocationsParams{
+		AssetIdentifier: params.AssetIdentifier,
+		OwnerID:         params.OwnerID,
+		Blockchain:      params.Blockchain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get allocations: %w", err)
+	}
+
+	allocationsByAddress := lo.SliceToMap(allocations, func(item *repo_cold_wallet_distributions.GetAllocationsRow) (string, *repo_cold_wallet_distributions.GetAllocationsRow) {
+		return item.Address, item
+	})
+
+	var address string
+	switch distribution.Strategy {
+	case constants.ColdWalletsStrategyWeighted:
+		address = resolveWeighted(candidates, allocationsByAddress)
+	case constants.ColdWalletsStrategyCap:
+		address, err = resolveCap(candidates, allocationsByAddress, params.Amount)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		address = resolveRoundRobin(candidates, allocationsByAddress)
+	}
+
+	return &ResolveColdWalletResult{
+		Address:  address,
+		Strategy: distribution.Strategy,
+	}, nil
+}
+
+// resolveRoundRobin returns the address next to the last used one.
+func resolveRoundRobin(candidates []ColdWalletsDistributionItem, allocations map[string]*repo_cold_wallet_distributions.GetAllocationsRow) string {
+	lastIdx := -1
+	var lastUsed *repo_cold_wallet_distributions.GetAllocationsRow
+	for idx, candidate := range candidates {
+		allocation, ok := allocations[candidate.Address]
+		if !ok {
+			continue
+		}
+
+		if lastUsed == nil || allocation.LastTransferAt.Time.After(lastUsed.LastTransferAt.Time) {
+			lastIdx, lastUsed = idx, allocation
+		}
+	}
+
+	return candidates[(lastIdx+1)%len(candidates)].Address
+}
+
+// resolveWeighted returns the address with the lowest number of transfers relative to its weight.
+func resolveWeighted(candidates []ColdWalletsDistributionItem, allocations map[string]*repo_cold_wallet_distributions.GetAllocationsRow) string {
+	load := func(item ColdWalletsDistributionItem) float64 {
+		var count int32
+		if allocation, ok := allocations[item.Address]; ok {
+			count = allocation.TransfersCount
+		}
+		return float64(count) / float64(max(item.Weight, 1))
+	}
+
+	// the first address wins for equal loads
+	return slices.MinFunc(candidates, func(a, b ColdWalletsDistributionItem) int {
+		return cmp.Compare(load(a), load(b))
+	}).Address
+}
+
+// resolveCap returns the first address which can receive the amount without exceeding its cap.
+func resolveCap(candidates []ColdWalletsDistributionItem, allocations map[string]*repo_cold_wallet_distributions.GetAllocationsRow, amount decimal.Decimal) (string, error) {
+	for _, candidate := range candidates {
+		if !candidate.Cap.Valid {
+			return candidate.Address, nil
+		}
+
+		allocated := decimal.Zero
+		if allocation, ok := allocations[candidate.Address]; ok {
+			allocated = allocation.Amount
+		}
+
+		if allocated.Add(amount).LessThanOrEqual(candidate.Cap.Decimal) {
+			return candidate.Address, nil
+		}
+	}
+
+	return "", ErrColdWalletsCapReached
+}
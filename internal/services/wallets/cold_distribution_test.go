@@ -0,0 +1,116 @@
+package wallets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_cold_wallet_distributions"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveColdWallet(t *testing.T) {
+	now := time.Now()
+
+	candidates := []ColdWalletsDistributionItem{
+		{Address: "a", Weight: 3, Cap: decimal.NewNullDecimal(decimal.NewFromInt(10))},
+		{Address: "b", Weight: 1, Cap: decimal.NewNullDecimal(decimal.NewFromInt(5))},
+		{Address: "c", Weight: 1},
+	}
+
+	allocations := map[string]*repo_cold_wallet_distributions.GetAllocationsRow{
+		"a": {Address: "a", TransfersCount: 2, Amount: decimal.NewFromInt(8), LastTransferAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}},
+		"b": {Address: "b", TransfersCount: 1, Amount: decimal.NewFromInt(1), LastTransferAt: pgtype.Timestamptz{Time: now, Valid: true}},
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		require.Equal(t, "a", resolveRoundRobin(candidates, nil))
+		require.Equal(t, "c", resolveRoundRobin(candidates, allocations))
+		require.Equal(t, "a", resolveRoundRobin(candidates[:2], allocations))
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		require.Equal(t, "a", resolveWeighted(candidates, nil))
+		require.Equal(t, "c", resolveWeighted(candidates, allocations))
+		require.Equal(t, "a", resolveWeighted(candidates[:2], allocations))
+	})
+
+	t.Run("cap", func(t *testing.T) {
+		address, err := resolveCap(candidates, allocations, decimal.NewFromInt(2))
+		require.NoError(t, err)
+		require.Equal(t, "a", address)
+
+		address, err = resolveCap(candidates, allocations, decimal.NewFromInt(3))
+		require.NoError(t, err)
+		require.Equal(t, "b", address)
+
+		address, err = resolveCap(candidates, allocations, decimal.NewFromInt(5))
+		require.NoError(t, err)
+		require.Equal(t, "c", address)
+
+		_, err = resolveCap(candidates[:2], allocations, decimal.NewFromInt(5))
+		require.ErrorIs(t, err, ErrColdWalletsCapReached)
+	})
+}
+
+func TestColdWalletsDistributionValidate(t *testing.T) {
+	valid := ColdWalletsDistribution{
+		OwnerID:    uuid.New(),
+		Blockchain: wconstants.BlockchainTypeEthereum,
+		Strategy:   constants.ColdWalletsStrategyWeighted,
+	}
+
+	tests := []struct {
+		name  string
+		items []ColdWalletsDistributionItem
+		err   bool
+	}{
+		{
+			name:  "valid",
+			items: []ColdWalletsDistributionItem{{Address: "a", Weight: 1}, {Address: "b", Weight: 2, Cap: decimal.NewNullDecimal(decimal.NewFromInt(1))}},
+		},
+		{
+			name:  "empty address",
+			items: []ColdWalletsDistributionItem{{Weight: 1}},
+			err:   true,
+		},
+		{
+			name:  "duplicated address",
+			items: []ColdWalletsDistributionItem{{Address: "a", Weight: 1}, {Address: "a", Weight: 1}},
+			err:   true,
+		},
+		{
+			name:  "zero weight",
+			items: []ColdWalletsDistributionItem{{Address: "a"}},
+			err:   true,
+		},
+		{
+			name:  "negative weight",
+			items: []ColdWalletsDistributionItem{{Address: "a", Weight: -1}},
+			err:   true,
+		},
+		{
+			name:  "zero cap",
+			items: []ColdWalletsDistributionItem{{Address: "a", Weight: 1, Cap: decimal.NewNullDecimal(decimal.Zero)}},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid
+			d.Items = tt.items
+
+			err := d.validate()
+			if tt.err {
+				require.ErrorIs(t, err, ErrInvalidColdWalletsItem)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package wallets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newColdTestStore(ownerID uuid.UUID) *memStore {
+	st := newMemStore()
+	st.cold = []*models.ColdWallet{
+		{ID: uuid.New(), OwnerID: ownerID, Blockchain: wconstants.BlockchainTypeEthereum, Address: "a", IsActive: true},
+		{ID: uuid.New(), OwnerID: ownerID, Blockchain: wconstants.BlockchainTypeEthereum, Address: "b", IsActive: true},
+	}
+	return st
+}
+
+func TestColdWalletsDetach(t *testing.T) {
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name       string
+		ownerID    uuid.UUID
+		blockchain wconstants.BlockchainType
+		address    string
+		err        error
+	}{
+		{name: "detached", ownerID: ownerID, blockchain: wconstants.BlockchainTypeEthereum, address: "a"},
+		{name: "empty owner", blockchain: wconstants.BlockchainTypeEthereum, address: "a", err: storecmn.ErrEmptyID},
+		{name: "empty address", ownerID: ownerID, blockchain: wconstants.BlockchainTypeEthereum, err: storecmn.ErrEmptyAddress},
+		{name: "other owner", ownerID: uuid.New(), blockchain: wconstants.BlockchainTypeEthereum, address: "a", err: storecmn.ErrNotFound},
+		{name: "other blockchain", ownerID: ownerID, blockchain: wconstants.BlockchainTypePolygon, address: "a", err: storecmn.ErrNotFound},
+		{name: "unknown address", ownerID: ownerID, blockchain: wconstants.BlockchainTypeEthereum, address: "c", err: storecmn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newColdTestStore(ownerID)
+			s := newColdWallets(st, nil, nil)
+
+			item, err := s.Detach(context.Background(), tt.ownerID, tt.blockchain, tt.address)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, item.DetachedAt.Valid)
+			require.False(t, item.IsActive)
+
+			// the cached wallet is detached as well, so new transfers to it are rejected
+			cached, ok := st.Cache().ColdWallets().Load(cacherKey(tt.blockchain, tt.address))
+			require.True(t, ok)
+			require.True(t, cached.DetachedAt.Valid)
+
+			// the wallet is kept for audit, but can not be detached twice
+			require.Len(t, st.cold, 2)
+			_, err = s.Detach(context.Background(), tt.ownerID, tt.blockchain, tt.address)
+			require.ErrorIs(t, err, storecmn.ErrNotFound)
+		})
+	}
+}
+
+func TestColdWalletsSetLabel(t *testing.T) {
+	ownerID := uuid.New()
+	label, note := "exchange", "main deposit address"
+	long := strings.Repeat("x", 256)
+
+	tests := []struct {
+		name    string
+		params  SetColdWalletLabelParams
+		errIs   error
+		errText string
+	}{
+		{
+			name:   "label and note",
+			params: SetColdWalletLabelParams{OwnerID: ownerID, Blockchain: wconstants.BlockchainTypeEthereum, Address: "b", Label: &label, Note: &note},
+		},
+		{
+			name:   "cleared",
+			params: SetColdWalletLabelParams{OwnerID: ownerID, Blockchain: wconstants.BlockchainTypeEthereum, Address: "b"},
+		},
+		{
+			name:   "empty owner",
+			params: SetColdWalletLabelParams{Blockchain: wconstants.BlockchainTypeEthereum, Address: "b", Label: &label},
+			errIs:  storecmn.ErrEmptyID,
+		},
+		{
+			name:    "long label",
+			params:  SetColdWalletLabelParams{OwnerID: ownerID, Blockchain: wconstants.BlockchainTypeEthereum, Address: "b", Label: &long},
+			errText: "at most 255 characters",
+		},
+		{
+			name:   "other owner",
+			params: SetColdWalletLabelParams{OwnerID: uuid.New(), Blockchain: wconstants.BlockchainTypeEthereum, Address: "b", Label: &label},
+			errIs:  storecmn.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newColdWallets(newColdTestStore(ownerID), nil, nil)
+
+			item, err := s.SetLabel(context.Background(), tt.params)
+			switch {
+			case tt.errIs != nil:
+				require.ErrorIs(t, err, tt.errIs)
+			case tt.errText != "":
+				require.ErrorContains(t, err, tt.errText)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.params.Label != nil, item.Label.Valid)
+				require.Equal(t, tt.params.Note != nil, item.Note.Valid)
+				if tt.params.Label != nil {
+					require.Equal(t, *tt.params.Label, item.Label.String)
+				}
+			}
+		})
+	}
+}
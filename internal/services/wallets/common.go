@@ -5,6 +5,11 @@ import (
 	"fmt"
 
 	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/google/uuid"
 )
@@ -58,3 +63,107 @@ func (s *Service) GetSequenceByWalletType(
 
 	return sequence, nil
 }
+
+// reserveSequences atomically reserves count sequences for the owner and blockchain and returns the first of them.
+// Processing and hot wallets share the same counter, so concurrent requests never derive the same address.
+func reserveSequences(ctx context.Context, st store.IStore, ownerID uuid.UUID, blockchain wconstants.BlockchainType, count int32, opts ...repos.Option) (int32, error) {
+	if count < 1 {
+		return 0, fmt.Errorf("invalid sequences count: %d", count)
+	}
+
+	last, err := st.Wallets().Common(opts...).NextSequences(ctx, repo_wallets.NextSequencesParams{
+		OwnerID:    ownerID,
+		Blockchain: blockchain,
+		Count:      count,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("reserve sequences of %s: %w", blockchain.String(), err)
+	}
+
+	return last - count + 1, nil
+}
+
+// deriveBySigner derives the addresses from the encrypted seed by the signer.
+// The data key of the owner is read with the options, so a key created in the transaction is used.
+func deriveBySigner(ctx context.Context, st store.IStore, sgn signer.Signer, req signer.DeriveAddressesRequest, opts ...repos.Option) ([]string, error) {
+	dataKey, err := st.Owners(opts...).GetDataKey(ctx, req.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("get data key: %w", err)
+	}
+	req.DataKey = dataKey.String
+
+	addresses, err := sgn.DeriveAddresses(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("derive addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+type DeriveAddressesParams struct {
+	OwnerID     uuid.UUID
+	Blockchain  wconstants.BlockchainType
+	AddressType string
+	// Mnemonic and Passphrase are encrypted with the owner id as the owner seed.
+	Mnemonic   string
+	Passphrase string
+	Derivation derivation.Config
+	Count      int32
+}
+
+// DeriveAddresses reserves count sequences of the owner and derives addresses for them from the given seed
+// without creating wallets. It returns the addresses and the sequence of the first one.
+func (s *Service) DeriveAddresses(ctx context.Context, params DeriveAddressesParams, opts ...repos.Option) ([]string, int32, error) {
+	return s.hotWallets.deriveAddresses(ctx, CreateHotWalletsParams{
+		OwnerID:     params.OwnerID,
+		Blockchain:  params.Blockchain,
+		AddressType: params.AddressType,
+		Mnemonic:    params.Mnemonic,
+		Passphrase:  params.Passphrase,
+		Derivation:  params.Derivation,
+	}, params.Count, opts...)
+}
+
+// SeedFingerprint returns the ethereum address of the first sequence of the encrypted seed.
+// Different seeds have different fingerprints, so the seeds are compared without decrypting them.
+func (s *Service) SeedFingerprint(ctx context.Context, ownerID uuid.UUID, mnemonic, passPhrase string, opts ...repos.Option) (string, error) {
+	addresses, err := deriveBySigner(ctx, s.store, s.signer, signer.DeriveAddressesRequest{
+		OwnerID:    ownerID,
+		Blockchain: wconstants.BlockchainTypeEthereum,
+		Mnemonic:   mnemonic,
+		PassPhrase: passPhrase,
+		Count:      1,
+	}, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return addresses[0], nil
+}
+
+// AddressTypeOf returns the address type of the existing address.
+// Blockchains without address types return an empty string.
+func (s *Service) AddressTypeOf(blockchain wconstants.BlockchainType, address string) (string, error) {
+	switch blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		t, err := s.sdk.BTC.DecodeAddressType(address)
+		if err != nil {
+			return "", fmt.Errorf("decode address type: %w", err)
+		}
+		return string(t), nil
+	case wconstants.BlockchainTypeLitecoin:
+		t, err := s.sdk.LTC.DecodeAddressType(address)
+		if err != nil {
+			return "", fmt.Errorf("decode address type: %w", err)
+		}
+		return string(t), nil
+	case wconstants.BlockchainTypeDogecoin:
+		t, err := s.sdk.Doge.DecodeAddressType(address)
+		if err != nil {
+			return "", fmt.Errorf("decode address type: %w", err)
+		}
+		return string(t), nil
+	default:
+		return "", nil
+	}
+}
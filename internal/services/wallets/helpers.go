@@ -26,3 +26,15 @@ func AddressTypeByBlockchain(blockchain wconstants.BlockchainType) (string, erro
 		return "", nil
 	}
 }
+
+// HotAddressTypeByBlockchain returns the address type used for hot wallets when it is not specified in the request.
+func HotAddressTypeByBlockchain(blockchain wconstants.BlockchainType) string {
+	switch blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		return string(btc.AddressTypeP2TR)
+	case wconstants.BlockchainTypeLitecoin:
+		return string(ltc.AddressTypeP2TR)
+	default:
+		return ""
+	}
+}
@@ -7,15 +7,19 @@ import (
 
 	"github.com/dv-net/dv-processing/internal/config"
 	"github.com/dv-net/dv-processing/internal/dispatcher"
-	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -28,6 +32,8 @@ type HotWallets struct {
 	store     store.IStore
 	validator *validator.Validate
 	sdk       *walletsdk.SDK
+	secrets   *secrets.Service
+	signer    signer.Signer
 }
 
 func newHotWallets(
@@ -36,6 +42,8 @@ func newHotWallets(
 	validator *validator.Validate,
 	sdk *walletsdk.SDK,
 	publisher dispatcher.IService,
+	secretsSvc *secrets.Service,
+	signerSvc signer.Signer,
 ) *HotWallets {
 	return &HotWallets{
 		config:    conf,
@@ -43,6 +51,8 @@ func newHotWallets(
 		validator: validator,
 		sdk:       sdk,
 		publisher: publisher,
+		secrets:   secretsSvc,
+		signer:    signerSvc,
 	}
 }
 
@@ -52,79 +62,209 @@ type CreateHotWalletParams struct {
 	AddressType      string                    `validate:"required"`
 	Mnemonic         string                    `validate:"required"`
 	Passphrase       string
+	Derivation       derivation.Config
 	ExternalWalletID string `validate:"required"`
 }
 
-// Create creates a hot wallet
+// Create creates a hot wallet.
 func (s *HotWallets) Create(ctx context.Context, params CreateHotWalletParams, opts ...repos.Option) (*models.HotWallet, error) {
-	// get the max sequence of the processing wallets
-	sequence, err := s.store.Wallets().Common().MaxSequence(ctx, params.Blockchain, params.OwnerID)
+	items, err := s.CreateMany(ctx, CreateHotWalletsParams{
+		OwnerID:           params.OwnerID,
+		Blockchain:        params.Blockchain,
+		AddressType:       params.AddressType,
+		Mnemonic:          params.Mnemonic,
+		Passphrase:        params.Passphrase,
+		Derivation:        params.Derivation,
+		ExternalWalletIDs: []string{params.ExternalWalletID},
+	}, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("get sequence of %s: %w", params.Blockchain.String(), err)
+		return nil, err
 	}
 
-	nextSequence := sequence + 1
+	return items[0], nil
+}
 
-	mnemonic := params.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		// decompress mnemonic
-		mnemonic, err = encryption.Decrypt(mnemonic, params.OwnerID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
+type CreateHotWalletsParams struct {
+	OwnerID           uuid.UUID
+	Blockchain        wconstants.BlockchainType
+	AddressType       string
+	Mnemonic          string
+	Passphrase        string
+	Derivation        derivation.Config
+	ExternalWalletIDs []string
+}
+
+// CreateMany creates hot wallets for the given external wallet ids in one transaction.
+//
+// Addresses are taken from the pre-generated pool when possible, the rest are derived
+// from the owner mnemonic with sequences reserved by a single query.
+func (s *HotWallets) CreateMany(ctx context.Context, params CreateHotWalletsParams, opts ...repos.Option) ([]*models.HotWallet, error) {
+	if len(params.ExternalWalletIDs) == 0 {
+		return nil, fmt.Errorf("external wallet ids are empty")
 	}
 
-	// generate wallet address
-	address, err := s.sdk.AddressWallet(params.Blockchain, params.AddressType, mnemonic, params.Passphrase, uint32(nextSequence)) //nolint:gosec
+	var newItems []*models.HotWallet
+	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		var err error
+		// the transaction passed by the caller takes precedence
+		newItems, err = s.createMany(ctx, params, append([]repos.Option{repos.WithTx(tx)}, opts...)...)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("generate adresses: %w", err)
+		return nil, err
 	}
 
-	createParams := repo_wallets_hot.CreateParams{
-		Blockchain:       params.Blockchain,
-		Address:          address,
-		OwnerID:          params.OwnerID,
-		ExternalWalletID: params.ExternalWalletID,
-		Sequence:         nextSequence,
-		IsActive:         true,
+	for _, newItem := range newItems {
+		s.store.Cache().HotWallets().Store(cacherKey(params.Blockchain, newItem.Address), newItem)
 	}
 
-	// validate create params
-	if err := s.validator.Struct(createParams); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	// publish new hot wallet created events
+	go func() {
+		for _, newItem := range newItems {
+			s.publisher.CreatedHotWalletDispatcher().Publish(newItem)
+		}
+	}()
+
+	return newItems, nil
+}
+
+// createMany creates hot wallets with the given repository options, CreateMany runs it in a transaction.
+func (s *HotWallets) createMany(ctx context.Context, params CreateHotWalletsParams, opts ...repos.Option) ([]*models.HotWallet, error) {
+	createParams := make([]repo_wallets_hot.CreateParams, 0, len(params.ExternalWalletIDs))
+	for _, externalWalletID := range params.ExternalWalletIDs {
+		item := repo_wallets_hot.CreateParams{
+			Blockchain:       params.Blockchain,
+			OwnerID:          params.OwnerID,
+			ExternalWalletID: externalWalletID,
+			IsActive:         true,
+		}
+
+		// check evm address and use the same address for all evm blockchains
+		if params.Blockchain.IsEVM() {
+			existsEVMAddresses, err := s.store.Wallets().Hot(opts...).FindEVMByExternalID(ctx,
+				externalWalletID,
+				wconstants.EVMBlockchains().Strings(),
+				params.OwnerID,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("find evm address by external id: %w", err)
+			}
+
+			if len(existsEVMAddresses) > 0 {
+				existsItem := existsEVMAddresses[0]
+				item.Address = existsItem.Address
+				item.Sequence = existsItem.Sequence
+
+				// the sequence is used on this blockchain now, so the next derived addresses must skip it
+				if err := s.store.Wallets().Common(opts...).ReserveSequence(ctx, repo_wallets.ReserveSequenceParams{
+					OwnerID:    params.OwnerID,
+					Blockchain: params.Blockchain,
+					Sequence:   existsItem.Sequence,
+				}); err != nil {
+					return nil, fmt.Errorf("reserve evm sequence: %w", err)
+				}
+
+				// the pool of this blockchain has the same address at the same sequence
+				if err := s.store.Wallets().HotPool(opts...).DeleteByAddress(ctx, repo_wallets_hot_pool.DeleteByAddressParams{
+					OwnerID:    params.OwnerID,
+					Blockchain: params.Blockchain,
+					Address:    existsItem.Address,
+				}); err != nil {
+					return nil, fmt.Errorf("delete reused evm address from pool: %w", err)
+				}
+			}
+		}
+
+		createParams = append(createParams, item)
 	}
 
-	// check evm address and use the same address for all evm blockchains
-	if params.Blockchain.IsEVM() {
-		existsEVMAddresses, err := s.store.Wallets().Hot().FindEVMByExternalID(ctx,
-			params.ExternalWalletID,
-			wconstants.EVMBlockchains().Strings(),
-			params.OwnerID,
-		)
+	newItems := make([]*models.HotWallet, 0, len(createParams))
+	toDerive := make([]int, 0, len(createParams))
+	for idx := range createParams {
+		if createParams[idx].Address != "" {
+			continue
+		}
+
+		poolItem, err := s.takeFromPool(ctx, params.OwnerID, params.Blockchain, params.AddressType, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("find evm address by external id: %w", err)
+			return nil, err
 		}
 
-		if len(existsEVMAddresses) > 0 {
-			existsItem := existsEVMAddresses[0]
-			createParams.Address = existsItem.Address
-			createParams.Sequence = existsItem.Sequence
+		if poolItem == nil {
+			toDerive = append(toDerive, idx)
+			continue
 		}
+
+		createParams[idx].Address = poolItem.Address
+		createParams[idx].Sequence = poolItem.Sequence
 	}
 
-	newItem, err := s.store.Wallets().Hot(opts...).Create(ctx, createParams)
-	if err != nil {
-		return nil, err
+	if len(toDerive) > 0 {
+		addresses, firstSequence, err := s.deriveAddresses(ctx, params, int32(len(toDerive)), opts...) //nolint:gosec
+		if err != nil {
+			return nil, err
+		}
+
+		for i, idx := range toDerive {
+			createParams[idx].Address = addresses[i]
+			createParams[idx].Sequence = firstSequence + int32(i) //nolint:gosec
+		}
 	}
 
-	s.store.Cache().HotWallets().Store(cacherKey(params.Blockchain, address), newItem)
+	for _, item := range createParams {
+		// validate create params
+		if err := s.validator.Struct(item); err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+
+		newItem, err := s.store.Wallets().Hot(opts...).Create(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+
+		newItems = append(newItems, newItem)
+	}
+
+	return newItems, nil
+}
+
+// Register stores hot wallets created outside of the service in the cache and publishes them
+// as new, so they are watched like the wallets created by CreateMany.
+func (s *HotWallets) Register(items []*models.HotWallet) {
+	for _, item := range items {
+		s.store.Cache().HotWallets().Store(cacherKey(item.Blockchain, item.Address), item)
+	}
 
-	// publish new hot wallet created event
 	go func() {
-		s.publisher.CreatedHotWalletDispatcher().Publish(newItem)
+		for _, item := range items {
+			s.publisher.CreatedHotWalletDispatcher().Publish(item)
+		}
 	}()
+}
 
-	return newItem, nil
+// deriveAddresses reserves count sequences and derives addresses for them by the signer.
+// It returns the addresses and the sequence of the first one.
+func (s *HotWallets) deriveAddresses(ctx context.Context, params CreateHotWalletsParams, count int32, opts ...repos.Option) ([]string, int32, error) {
+	firstSequence, err := reserveSequences(ctx, s.store, params.OwnerID, params.Blockchain, count, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	addresses, err := deriveBySigner(ctx, s.store, s.signer, signer.DeriveAddressesRequest{
+		OwnerID:       params.OwnerID,
+		Blockchain:    params.Blockchain,
+		AddressType:   params.AddressType,
+		Mnemonic:      params.Mnemonic,
+		PassPhrase:    params.Passphrase,
+		Derivation:    params.Derivation,
+		FirstSequence: uint32(firstSequence), //nolint:gosec
+		Count:         uint32(count),         //nolint:gosec
+	}, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return addresses, firstSequence, nil
 }
 
 // Get returns the hot wallet by ownerID, blockchain and address.
@@ -150,31 +290,25 @@ func (s *HotWallets) GetAllByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]
 }
 
 // GetAllPrivateKeys returns all private keys for the owner.
-func (s *HotWallets) GetAllPrivateKeys(ctx context.Context, ownerID uuid.UUID, mnemonic, passPhrase string) (*GetAllPrivateKeysResponse, error) {
+// The mnemonic and the pass phrase must be decrypted.
+func (s *HotWallets) GetAllPrivateKeys(ctx context.Context, ownerID uuid.UUID, mnemonic, passPhrase string, derivationConfig derivation.Config) (*GetAllPrivateKeysResponse, error) {
 	wallets, err := s.store.Wallets().Hot().GetAllByOwnerID(ctx, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("get wallets: %w", err)
 	}
 
-	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, ownerID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
-	}
-
 	response := make(GetAllPrivateKeysResponse)
 	for _, wallet := range wallets {
 		if response[wallet.Blockchain] == nil {
 			response[wallet.Blockchain] = make([]PrivateKeysItem, 0)
 		}
 
-		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence)) //nolint:gosec
+		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), derivationConfig.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("get public: %w", err)
 		}
 
-		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence)) //nolint:gosec
+		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), derivationConfig.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("secret generate: %w", err)
 		}
@@ -195,6 +329,7 @@ type GetPrivateKeysByIDParams struct {
 	Addresses  []string
 	Mnemonic   string
 	Passphrase string
+	Derivation derivation.Config
 }
 
 func (s *HotWallets) GetPrivateKeysByIDs(ctx context.Context, params *GetPrivateKeysByIDParams) (*GetAllPrivateKeysResponse, error) {
@@ -203,12 +338,17 @@ func (s *HotWallets) GetPrivateKeysByIDs(ctx context.Context, params *GetPrivate
 		return nil, fmt.Errorf("get wallets: %w", err)
 	}
 
-	mnemonic := params.Mnemonic
+	mnemonic, passPhrase := params.Mnemonic, params.Passphrase
 	if s.config.IsEnabledSeedEncryption() {
-		mnemonic, err = encryption.Decrypt(mnemonic, params.OwnerID.String())
+		mnemonic, err = s.secrets.Decrypt(ctx, params.OwnerID, mnemonic)
 		if err != nil {
 			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
 		}
+
+		passPhrase, err = s.secrets.DecryptOptional(ctx, params.OwnerID, passPhrase)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt pass phrase: %w", err)
+		}
 	}
 
 	response := make(GetAllPrivateKeysResponse)
@@ -217,12 +357,12 @@ func (s *HotWallets) GetPrivateKeysByIDs(ctx context.Context, params *GetPrivate
 			response[wallet.Blockchain] = make([]PrivateKeysItem, 0)
 		}
 
-		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, params.Passphrase, uint32(wallet.Sequence)) //nolint:gosec
+		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), params.Derivation.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("get public: %w", err)
 		}
 
-		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, params.Passphrase, uint32(wallet.Sequence)) //nolint:gosec
+		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), params.Derivation.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("secret generate: %w", err)
 		}
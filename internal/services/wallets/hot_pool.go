@@ -0,0 +1,181 @@
+package wallets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// hotPoolWrapper periodically tops up the pool of pre-generated hot wallet addresses.
+func (s *Service) hotPoolWrapper(ctx context.Context) {
+	if !s.config.HotWalletsPool.Enabled {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.HotWalletsPool.Interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := s.hotWallets.fillPool(ctx)
+		if err != nil {
+			s.logger.Errorf("fill hot wallets pool: %s", err)
+		}
+
+		if created > 0 {
+			s.logger.Infof("hot wallets pool filled, new addresses count: %d", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fillPool derives missing pool addresses for every owner and enabled blockchain.
+// An error of one owner does not stop filling the pools of the others, the errors are joined.
+func (s *HotWallets) fillPool(ctx context.Context) (int, error) {
+	owners, err := s.store.Owners().GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get owners: %w", err)
+	}
+
+	var (
+		created int
+		errs    []error
+	)
+	for _, owner := range owners {
+		count, err := s.fillOwnerPools(ctx, owner)
+		created += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fill pool for owner %s: %w", owner.ID, err))
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// fillOwnerPools tops up the pools of the owner. The addresses are derived by the signer, so the seed
+// is never decrypted by the processing in the remote signer mode.
+func (s *HotWallets) fillOwnerPools(ctx context.Context, owner *models.Owner) (int, error) {
+	missing := make(map[wconstants.BlockchainType]int32)
+	for _, blockchain := range s.config.Blockchain.Available() {
+		// the addresses taken by the evm wallets reused from the other blockchains are refilled
+		if _, err := s.store.Wallets().HotPool().DeleteUsed(ctx, owner.ID, blockchain); err != nil {
+			return 0, fmt.Errorf("delete used pool addresses of %s: %w", blockchain, err)
+		}
+
+		count, err := s.store.Wallets().HotPool().Count(ctx, repo_wallets_hot_pool.CountParams{
+			OwnerID:     owner.ID,
+			Blockchain:  blockchain,
+			AddressType: HotAddressTypeByBlockchain(blockchain),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("count pool addresses of %s: %w", blockchain, err)
+		}
+
+		if count < s.config.HotWalletsPool.Size {
+			missing[blockchain] = s.config.HotWalletsPool.Size - count
+		}
+	}
+
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	var (
+		created int
+		errs    []error
+	)
+	for _, blockchain := range s.config.Blockchain.Available() {
+		if missing[blockchain] == 0 {
+			continue
+		}
+
+		count, err := s.fillOwnerPool(ctx, owner, blockchain, missing[blockchain])
+		created += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("blockchain %s: %w", blockchain, err))
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+func (s *HotWallets) fillOwnerPool(ctx context.Context, owner *models.Owner, blockchain wconstants.BlockchainType, missing int32) (int, error) {
+	addressType := HotAddressTypeByBlockchain(blockchain)
+
+	firstSequence, err := reserveSequences(ctx, s.store, owner.ID, blockchain, missing)
+	if err != nil {
+		return 0, err
+	}
+
+	addresses, err := deriveBySigner(ctx, s.store, s.signer, signer.DeriveAddressesRequest{
+		OwnerID:       owner.ID,
+		Blockchain:    blockchain,
+		AddressType:   addressType,
+		Mnemonic:      owner.Mnemonic,
+		PassPhrase:    owner.PassPhrase.String,
+		Derivation:    owner.Derivation,
+		FirstSequence: uint32(firstSequence), //nolint:gosec
+		Count:         uint32(missing),       //nolint:gosec
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for idx, address := range addresses {
+		nextSequence := firstSequence + int32(idx) //nolint:gosec
+
+		if _, err := s.store.Wallets().HotPool().Create(ctx, repo_wallets_hot_pool.CreateParams{
+			OwnerID:     owner.ID,
+			Blockchain:  blockchain,
+			AddressType: addressType,
+			Address:     address,
+			Sequence:    nextSequence,
+		}); err != nil {
+			// the address is already in the pool
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return created, fmt.Errorf("create pool address: %w", err)
+		}
+
+		created++
+	}
+
+	return created, nil
+}
+
+// takeFromPool returns a pre-generated address and removes it from the pool.
+// The addresses already used by hot wallets are skipped. It returns nil if the pool has no suitable address.
+func (s *HotWallets) takeFromPool(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, addressType string, opts ...repos.Option) (*models.HotWalletsPool, error) {
+	if !s.config.HotWalletsPool.Enabled {
+		return nil, nil
+	}
+
+	item, err := s.store.Wallets().HotPool(opts...).Take(ctx, repo_wallets_hot_pool.TakeParams{
+		OwnerID:     ownerID,
+		Blockchain:  blockchain,
+		AddressType: addressType,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("take address from pool: %w", err)
+	}
+
+	return item, nil
+}
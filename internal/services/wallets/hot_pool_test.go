@@ -0,0 +1,100 @@
+package wallets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func newPoolOwner(t *testing.T, mnemonic string) *models.Owner {
+	t.Helper()
+
+	owner := &models.Owner{ID: uuid.New()}
+
+	encrypted, err := encryption.Encrypt(mnemonic, owner.ID.String())
+	require.NoError(t, err)
+	owner.Mnemonic = encrypted
+
+	return owner
+}
+
+func newPoolHotWallets(st store.IStore) *HotWallets {
+	conf := new(config.Config)
+	conf.HotWalletsPool.Size = 3
+	conf.Blockchain.Ethereum.Enabled = true
+
+	sdk := walletsdk.New(walletsdk.Config{})
+
+	return &HotWallets{
+		config: conf,
+		store:  st,
+		sdk:    sdk,
+		signer: signer.NewLocal(conf, st, nil, sdk, nil),
+	}
+}
+
+// ethereumPool returns count pool items of the ethereum blockchain
+func ethereumPool(count int) []repo_wallets_hot_pool.CreateParams {
+	res := make([]repo_wallets_hot_pool.CreateParams, count)
+	for idx := range res {
+		res[idx].Blockchain = wconstants.BlockchainTypeEthereum
+	}
+	return res
+}
+
+func TestFillPool(t *testing.T) {
+	t.Run("owner error does not stop other owners", func(t *testing.T) {
+		broken := &models.Owner{ID: uuid.New(), Mnemonic: "not encrypted"}
+		valid := newPoolOwner(t, testMnemonic)
+
+		st := newMemStore(broken, valid)
+		created, err := newPoolHotWallets(st).fillPool(context.Background())
+		require.ErrorContains(t, err, broken.ID.String())
+		require.NotContains(t, err.Error(), valid.ID.String())
+		require.Equal(t, 3, created)
+
+		require.Empty(t, st.pool[broken.ID])
+		require.Len(t, st.pool[valid.ID], 3)
+		for idx, item := range st.pool[valid.ID] {
+			require.Equal(t, wconstants.BlockchainTypeEthereum, item.Blockchain)
+			require.Equal(t, int32(idx), item.Sequence) //nolint:gosec
+		}
+	})
+
+	t.Run("full pool is not decrypted", func(t *testing.T) {
+		// the mnemonic can not be decrypted, so the error means the seed was touched
+		owner := &models.Owner{ID: uuid.New(), Mnemonic: "not encrypted"}
+
+		st := newMemStore(owner)
+		st.pool[owner.ID] = ethereumPool(3)
+
+		created, err := newPoolHotWallets(st).fillPool(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, created)
+	})
+
+	t.Run("partially filled pool", func(t *testing.T) {
+		owner := newPoolOwner(t, testMnemonic)
+
+		st := newMemStore(owner)
+		st.pool[owner.ID] = ethereumPool(2)
+		st.sequences[sequenceKey{owner.ID, wconstants.BlockchainTypeEthereum}] = 1
+
+		created, err := newPoolHotWallets(st).fillPool(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, created)
+		require.Equal(t, int32(2), st.pool[owner.ID][2].Sequence)
+	})
+}
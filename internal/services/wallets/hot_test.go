@@ -0,0 +1,139 @@
+package wallets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
+	"github.com/dv-net/dv-processing/pkg/valid"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateManyReusedEVMSequence(t *testing.T) {
+	owner := newPoolOwner(t, testMnemonic)
+	st := newMemStore(owner)
+
+	conf := new(config.Config)
+	sdk := walletsdk.New(walletsdk.Config{})
+
+	s := &HotWallets{
+		config:    conf,
+		store:     st,
+		validator: valid.New(),
+		sdk:       sdk,
+		signer:    signer.NewLocal(conf, st, nil, sdk, nil),
+	}
+
+	create := func(blockchain wconstants.BlockchainType, externalIDs ...string) []int32 {
+		items, err := s.createMany(context.Background(), CreateHotWalletsParams{
+			OwnerID:           owner.ID,
+			Blockchain:        blockchain,
+			Mnemonic:          owner.Mnemonic,
+			ExternalWalletIDs: externalIDs,
+		})
+		require.NoError(t, err)
+
+		sequences := make([]int32, 0, len(items))
+		for _, item := range items {
+			sequences = append(sequences, item.Sequence)
+		}
+		return sequences
+	}
+
+	require.Equal(t, []int32{0, 1, 2}, create(wconstants.BlockchainTypeEthereum, "a", "b", "c"))
+
+	// the address of the external wallet is reused on the other evm blockchain
+	require.Equal(t, []int32{2}, create(wconstants.BlockchainTypeBinanceSmartChain, "c"))
+
+	// the new wallet must not derive the reused address again
+	require.Equal(t, []int32{3}, create(wconstants.BlockchainTypeBinanceSmartChain, "d"))
+
+	addresses := make(map[wconstants.BlockchainType]map[string]string)
+	for _, item := range st.hot {
+		if addresses[item.Blockchain] == nil {
+			addresses[item.Blockchain] = make(map[string]string)
+		}
+		require.NotContains(t, addresses[item.Blockchain], item.Address)
+		addresses[item.Blockchain][item.Address] = item.ExternalWalletID
+	}
+	require.Len(t, addresses[wconstants.BlockchainTypeBinanceSmartChain], 2)
+}
+
+func TestCreateManyReusedEVMAddressInPool(t *testing.T) {
+	owner := newPoolOwner(t, testMnemonic)
+	st := newMemStore(owner)
+
+	conf := new(config.Config)
+	conf.HotWalletsPool.Enabled = true
+	conf.HotWalletsPool.Size = 3
+	conf.Blockchain.Ethereum.Enabled = true
+	conf.Blockchain.BinanceSmartChain.Enabled = true
+
+	sdk := walletsdk.New(walletsdk.Config{})
+
+	s := &HotWallets{
+		config:    conf,
+		store:     st,
+		validator: valid.New(),
+		sdk:       sdk,
+		signer:    signer.NewLocal(conf, st, nil, sdk, nil),
+	}
+
+	// both evm pools have the same addresses at the same sequences
+	created, err := s.fillPool(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 6, created)
+
+	create := func(blockchain wconstants.BlockchainType, externalIDs ...string) []int32 {
+		items, err := s.createMany(context.Background(), CreateHotWalletsParams{
+			OwnerID:           owner.ID,
+			Blockchain:        blockchain,
+			Mnemonic:          owner.Mnemonic,
+			ExternalWalletIDs: externalIDs,
+		})
+		require.NoError(t, err)
+
+		sequences := make([]int32, 0, len(items))
+		for _, item := range items {
+			sequences = append(sequences, item.Sequence)
+		}
+		return sequences
+	}
+
+	require.Equal(t, []int32{0}, create(wconstants.BlockchainTypeEthereum, "a"))
+
+	// the reused address is removed from the pool of the other blockchain
+	require.Equal(t, []int32{0}, create(wconstants.BlockchainTypeBinanceSmartChain, "a"))
+	for _, item := range st.pool[owner.ID] {
+		require.NotEqual(t, st.hot[0].Address, item.Address)
+	}
+
+	require.Equal(t, []int32{1, 2}, create(wconstants.BlockchainTypeBinanceSmartChain, "b", "c"))
+
+	addresses := make(map[wconstants.BlockchainType]map[string]string)
+	for _, item := range st.hot {
+		if addresses[item.Blockchain] == nil {
+			addresses[item.Blockchain] = make(map[string]string)
+		}
+		require.NotContains(t, addresses[item.Blockchain], item.Address)
+		addresses[item.Blockchain][item.Address] = item.ExternalWalletID
+	}
+
+	// the address used on the blockchain is skipped and removed on the next fill
+	st.pool[owner.ID] = append(st.pool[owner.ID], repo_wallets_hot_pool.CreateParams{
+		Blockchain: wconstants.BlockchainTypeEthereum,
+		Address:    st.hot[0].Address,
+		Sequence:   0,
+	})
+	require.Equal(t, []int32{1}, create(wconstants.BlockchainTypeEthereum, "b"))
+
+	_, err = s.fillPool(context.Background())
+	require.NoError(t, err)
+	for _, item := range st.pool[owner.ID] {
+		require.NotEqual(t, st.hot[0].Address, item.Address)
+	}
+}
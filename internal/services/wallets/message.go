@@ -0,0 +1,84 @@
+package wallets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+)
+
+const maxMessageLength = 4096
+
+var (
+	ErrWalletCannotSign     = fmt.Errorf("only hot and processing wallets can sign messages")
+	ErrInvalidMessageLength = fmt.Errorf("message length must be between 1 and %d bytes", maxMessageLength)
+)
+
+type SignMessageParams struct {
+	OwnerID    uuid.UUID
+	Blockchain wconstants.BlockchainType
+	Address    string
+	Message    string
+}
+
+// SignMessage signs the message with the key of the owner hot or processing wallet to prove the address ownership.
+func (s *Service) SignMessage(ctx context.Context, params SignMessageParams) (string, error) {
+	if params.OwnerID == uuid.Nil {
+		return "", storecmn.ErrEmptyID
+	}
+
+	if params.Message == "" || len(params.Message) > maxMessageLength {
+		return "", ErrInvalidMessageLength
+	}
+
+	wallet, err := s.CheckWallet(ctx, params.Blockchain, params.Address)
+	if err != nil {
+		return "", err
+	}
+
+	// wallets of other owners are not disclosed
+	if wallet.OwnerID != params.OwnerID {
+		return "", ErrAddressNotFound
+	}
+
+	if wallet.WalletType != constants.WalletTypeHot && wallet.WalletType != constants.WalletTypeProcessing {
+		return "", ErrWalletCannotSign
+	}
+
+	sequence, err := s.GetSequenceByWalletType(ctx, wallet.WalletType, params.OwnerID, params.Blockchain, params.Address)
+	if err != nil {
+		return "", fmt.Errorf("get sequence: %w", err)
+	}
+
+	// the key is derived by the signer, so the seed is not decrypted by the processing in the remote mode
+	signature, err := s.signer.SignMessage(ctx, signer.SignMessageRequest{
+		OwnerID:         params.OwnerID,
+		Blockchain:      params.Blockchain,
+		Address:         params.Address,
+		AddressSequence: uint32(sequence), //nolint:gosec
+		Message:         params.Message,
+	})
+	if err != nil {
+		return "", fmt.Errorf("sign message: %w", err)
+	}
+
+	return signature, nil
+}
+
+// VerifyMessage checks that the signature of the message is made by the key of the address.
+// The address does not have to belong to the processing.
+func (s *Service) VerifyMessage(blockchain wconstants.BlockchainType, address, message, signature string) (bool, error) {
+	if !blockchain.Valid() {
+		return false, fmt.Errorf("invalid blockchain type: %s", blockchain.String())
+	}
+
+	if !s.sdk.ValidateAddress(blockchain, address) {
+		return false, fmt.Errorf("invalid address: %s", address)
+	}
+
+	return s.sdk.VerifyMessage(blockchain, address, message, signature)
+}
@@ -8,23 +8,28 @@ import (
 	"github.com/dv-net/dv-processing/internal/config"
 	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/internal/store/repos"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_processing"
 	"github.com/dv-net/dv-processing/internal/store/storecmn"
-	"github.com/dv-net/dv-processing/pkg/encryption"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
+// MaxProcessingWalletsPerRequest limits the number of processing wallets created by one request.
+const MaxProcessingWalletsPerRequest = 10
+
 type ProcessingWallets struct {
 	config    *config.Config
 	store     store.IStore
 	validator *validator.Validate
 	sdk       *walletsdk.SDK
+	signer    signer.Signer
 }
 
 func newProcessingWallets(
@@ -32,12 +37,14 @@ func newProcessingWallets(
 	store store.IStore,
 	validator *validator.Validate,
 	sdk *walletsdk.SDK,
+	signerSvc signer.Signer,
 ) *ProcessingWallets {
 	return &ProcessingWallets{
 		config:    conf,
 		store:     store,
 		validator: validator,
 		sdk:       sdk,
+		signer:    signerSvc,
 	}
 }
 
@@ -46,6 +53,7 @@ type CreateProcessingWalletParams struct {
 	Blockchain wconstants.BlockchainType
 	Mnemonic   string
 	Passphrase string
+	Derivation derivation.Config
 }
 
 // Validate validates the CreateProcessingWalletParams fields.
@@ -80,28 +88,26 @@ func (s *ProcessingWallets) Create(ctx context.Context, params CreateProcessingW
 		return nil, fmt.Errorf("get address type: %w", err)
 	}
 
-	// get the max sequence of the processing wallets
-	sequence, err := s.store.Wallets().Common().MaxSequence(ctx, params.Blockchain, params.OwnerID)
+	nextSequence, err := reserveSequences(ctx, s.store, params.OwnerID, params.Blockchain, 1, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("get sequence of %s: %w", params.Blockchain.String(), err)
-	}
-
-	nextSequence := sequence + 1
-
-	mnemonic := params.Mnemonic
-	if s.config.IsEnabledSeedEncryption() {
-		// decompress mnemonic
-		mnemonic, err = encryption.Decrypt(mnemonic, params.OwnerID.String())
-		if err != nil {
-			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
-		}
+		return nil, err
 	}
 
 	// generate wallet address
-	address, err := s.sdk.AddressWallet(params.Blockchain, addressType, mnemonic, params.Passphrase, uint32(nextSequence)) //nolint:gosec
+	addresses, err := deriveBySigner(ctx, s.store, s.signer, signer.DeriveAddressesRequest{
+		OwnerID:       params.OwnerID,
+		Blockchain:    params.Blockchain,
+		AddressType:   addressType,
+		Mnemonic:      params.Mnemonic,
+		PassPhrase:    params.Passphrase,
+		Derivation:    params.Derivation,
+		FirstSequence: uint32(nextSequence), //nolint:gosec
+		Count:         1,
+	}, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("generate adresses: %w", err)
+		return nil, err
 	}
+	address := addresses[0]
 
 	createParams := repo_wallets_processing.CreateParams{
 		Blockchain: params.Blockchain,
@@ -137,6 +143,13 @@ func (s *ProcessingWallets) Create(ctx context.Context, params CreateProcessingW
 	return newItem, nil
 }
 
+// Register stores processing wallets created outside of the service in the cache.
+func (s *ProcessingWallets) Register(items []*models.ProcessingWallet) {
+	for _, item := range items {
+		s.store.Cache().ProcessingWallets().Store(cacherKey(item.Blockchain, item.Address), item)
+	}
+}
+
 type FindProcessingWalletsParams = repo_wallets_processing.FindParams
 
 // Find returns processing wallets filtered by params.
@@ -182,7 +195,7 @@ func (s *ProcessingWallets) GetAllByOwnerID(ctx context.Context, ownerID uuid.UU
 	return s.store.Wallets().Processing().GetAllByOwnerID(ctx, ownerID)
 }
 
-// GetByBlockchain
+// GetByBlockchain returns the primary processing wallet of the owner for the blockchain.
 func (s *ProcessingWallets) GetByBlockchain(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (*models.ProcessingWallet, error) {
 	if ownerID == uuid.Nil {
 		return nil, storecmn.ErrEmptyID
@@ -203,8 +216,111 @@ func (s *ProcessingWallets) GetByBlockchain(ctx context.Context, ownerID uuid.UU
 	return data, nil
 }
 
+// GetByTransfer returns the processing wallet chosen for the transfer.
+// The primary wallet is returned for transfers created before the owner had several processing wallets.
+func (s *ProcessingWallets) GetByTransfer(ctx context.Context, transfer *models.Transfer) (*models.ProcessingWallet, error) {
+	if address, ok := transfer.StateData[constants.ProcessingWalletAddressStateKey].(string); ok && address != "" {
+		return s.GetByOwnerID(ctx, transfer.OwnerID, transfer.Blockchain, address)
+	}
+
+	return s.GetByBlockchain(ctx, transfer.OwnerID, transfer.Blockchain)
+}
+
+// GetAllByBlockchain returns all active processing wallets of the owner for the blockchain, the first one is the primary.
+func (s *ProcessingWallets) GetAllByBlockchain(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) ([]*models.ProcessingWallet, error) {
+	if ownerID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !blockchain.Valid() {
+		return nil, fmt.Errorf("invalid blockchain: %s", blockchain.String())
+	}
+
+	return s.store.Wallets().Processing().GetAllByBlockchain(ctx, ownerID, blockchain)
+}
+
+// CreateMany creates count additional processing wallets of the owner for the blockchain.
+func (s *ProcessingWallets) CreateMany(ctx context.Context, params CreateProcessingWalletParams, count int) ([]*models.ProcessingWallet, error) {
+	if count <= 0 || count > MaxProcessingWalletsPerRequest {
+		return nil, fmt.Errorf("count must be between 1 and %d", MaxProcessingWalletsPerRequest)
+	}
+
+	items := make([]*models.ProcessingWallet, 0, count)
+	err := pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		for range count {
+			item, err := s.Create(ctx, params, repos.WithTx(tx))
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetStrategy returns the strategy which chooses the owner processing wallet for outgoing transfers.
+// Least busy is returned when the owner has not set a strategy.
+func (s *ProcessingWallets) GetStrategy(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (constants.ProcessingWalletsStrategy, error) {
+	if ownerID == uuid.Nil {
+		return "", storecmn.ErrEmptyID
+	}
+
+	if !blockchain.Valid() {
+		return "", fmt.Errorf("invalid blockchain: %s", blockchain.String())
+	}
+
+	data, err := s.store.Wallets().Processing().GetStrategy(ctx, ownerID, blockchain)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constants.ProcessingWalletsStrategyLeastBusy, nil
+		}
+		return "", fmt.Errorf("get strategy: %w", err)
+	}
+
+	return data.Strategy, nil
+}
+
+// SetStrategy sets the strategy which chooses the owner processing wallet for outgoing transfers.
+func (s *ProcessingWallets) SetStrategy(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, strategy constants.ProcessingWalletsStrategy) error {
+	if ownerID == uuid.Nil {
+		return storecmn.ErrEmptyID
+	}
+
+	if !blockchain.Valid() {
+		return fmt.Errorf("invalid blockchain: %s", blockchain.String())
+	}
+
+	if !strategy.Valid() {
+		return fmt.Errorf("invalid strategy: %s", strategy)
+	}
+
+	if _, err := s.store.Wallets().Processing().UpsertStrategy(ctx, repo_wallets_processing.UpsertStrategyParams{
+		OwnerID:    ownerID,
+		Blockchain: blockchain,
+		Strategy:   strategy,
+	}); err != nil {
+		return fmt.Errorf("upsert strategy: %w", err)
+	}
+
+	return nil
+}
+
+type ProcessingWalletUsage = repo_wallets_processing.GetUsageRow
+
+// GetUsage returns the number of active transfers and the last transfer time of every owner processing wallet
+// which was chosen for a transfer.
+func (s *ProcessingWallets) GetUsage(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) ([]*ProcessingWalletUsage, error) {
+	return s.store.Wallets().Processing().GetUsage(ctx, ownerID, blockchain)
+}
+
 // GetAllPrivateKeys returns all private keys for the owner.
-func (s *ProcessingWallets) GetAllPrivateKeys(ctx context.Context, ownerID uuid.UUID, mnemonic, passPhrase string) (*GetAllPrivateKeysResponse, error) {
+// The mnemonic and the pass phrase must be decrypted.
+func (s *ProcessingWallets) GetAllPrivateKeys(ctx context.Context, ownerID uuid.UUID, mnemonic, passPhrase string, derivationConfig derivation.Config) (*GetAllPrivateKeysResponse, error) {
 	wallets, err := s.store.Wallets().Processing().GetAllByOwnerID(ctx, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("get wallets: %w", err)
@@ -216,12 +332,12 @@ func (s *ProcessingWallets) GetAllPrivateKeys(ctx context.Context, ownerID uuid.
 			response[wallet.Blockchain] = make([]PrivateKeysItem, 0)
 		}
 
-		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence)) //nolint:gosec
+		public, err := s.sdk.AddressPublic(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), derivationConfig.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("get public: %w", err)
 		}
 
-		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence)) //nolint:gosec
+		secret, err := s.sdk.AddressSecret(wallet.Blockchain, wallet.Address, mnemonic, passPhrase, uint32(wallet.Sequence), derivationConfig.Options(wallet.Blockchain)...) //nolint:gosec
 		if err != nil {
 			return nil, fmt.Errorf("secret generate: %w", err)
 		}
@@ -285,6 +401,7 @@ func (s *ProcessingWallets) checkNotCreatedWallets(ctx context.Context) (int, er
 			Blockchain: blockchain,
 			Mnemonic:   owner.Mnemonic,
 			Passphrase: owner.PassPhrase.String,
+			Derivation: owner.Derivation,
 		}); err != nil {
 			return 0, fmt.Errorf("create wallet for owner %s and blockchain %s: %w", wallet.OwnerID, wallet.Blockchain, err)
 		}
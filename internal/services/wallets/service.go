@@ -8,6 +8,8 @@ import (
 	"github.com/dv-net/dv-processing/internal/dispatcher"
 
 	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/signer"
 	"github.com/dv-net/dv-processing/internal/store"
 	"github.com/dv-net/dv-processing/pkg/valid"
 	"github.com/dv-net/dv-processing/pkg/walletsdk"
@@ -29,7 +31,9 @@ type Service struct {
 	cacheReady       chan struct{}
 	cacheReadyOnce   sync.Once
 
-	sdk *walletsdk.SDK
+	sdk     *walletsdk.SDK
+	secrets *secrets.Service
+	signer  signer.Signer
 }
 
 func New(
@@ -38,6 +42,8 @@ func New(
 	st store.IStore,
 	publisher dispatcher.IService,
 	sdk *walletsdk.SDK,
+	secretsSvc *secrets.Service,
+	signerSvc signer.Signer,
 ) *Service {
 	vl := valid.New()
 
@@ -46,9 +52,11 @@ func New(
 		config:            conf,
 		store:             st,
 		sdk:               sdk,
+		secrets:           secretsSvc,
+		signer:            signerSvc,
 		coldWallet:        newColdWallets(st, vl, sdk),
-		hotWallets:        newHotWallets(conf, st, vl, sdk, publisher),
-		processingWallets: newProcessingWallets(conf, st, vl, sdk),
+		hotWallets:        newHotWallets(conf, st, vl, sdk, publisher, secretsSvc, signerSvc),
+		processingWallets: newProcessingWallets(conf, st, vl, sdk, signerSvc),
 		cacheReady:        make(chan struct{}),
 	}
 }
@@ -70,6 +78,8 @@ func (s *Service) Start(ctx context.Context) error {
 		errCh <- s.updateCacheWrapper(ctx, s.config.UseCacheForWallets)
 	}()
 
+	go s.hotPoolWrapper(ctx)
+
 	go func() {
 		s.logger.Info("checking owners not created processing wallets")
 		count, err := s.processingWallets.checkNotCreatedWallets(ctx)
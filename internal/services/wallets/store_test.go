@@ -0,0 +1,221 @@
+package wallets
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/cache"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_cold"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type sequenceKey struct {
+	ownerID    uuid.UUID
+	blockchain wconstants.BlockchainType
+}
+
+// memStore keeps owners, hot and cold wallets, the pool and the sequences in memory,
+// the other repositories are not implemented
+type memStore struct {
+	store.IStore
+	cache     cache.ICache
+	owners    []*models.Owner
+	hot       []*models.HotWallet
+	cold      []*models.ColdWallet
+	pool      map[uuid.UUID][]repo_wallets_hot_pool.CreateParams
+	sequences map[sequenceKey]int32
+}
+
+func newMemStore(owners ...*models.Owner) *memStore {
+	return &memStore{
+		cache:     cache.New(),
+		owners:    owners,
+		pool:      make(map[uuid.UUID][]repo_wallets_hot_pool.CreateParams),
+		sequences: make(map[sequenceKey]int32),
+	}
+}
+
+func (s *memStore) Cache() cache.ICache                        { return s.cache }
+func (s *memStore) Owners(...repos.Option) repo_owners.Querier { return memOwners{owners: s.owners} }
+func (s *memStore) Wallets() repos.IWallets                    { return memWallets{s: s} }
+
+type memOwners struct {
+	repo_owners.Querier
+	owners []*models.Owner
+}
+
+func (o memOwners) GetAll(context.Context) ([]*models.Owner, error) { return o.owners, nil }
+
+// GetDataKey returns no key, the test seeds are encrypted in the ENCv1 format
+func (o memOwners) GetDataKey(context.Context, uuid.UUID) (pgtype.Text, error) {
+	return pgtype.Text{}, nil
+}
+
+type memWallets struct {
+	repos.IWallets
+	s *memStore
+}
+
+func (w memWallets) Cold(...repos.Option) repo_wallets_cold.ICustomQuerier { return memCold{s: w.s} }
+func (w memWallets) Hot(...repos.Option) repo_wallets_hot.ICustomQuerier   { return memHot{s: w.s} }
+func (w memWallets) HotPool(...repos.Option) repo_wallets_hot_pool.Querier { return memHotPool{s: w.s} }
+func (w memWallets) Common(...repos.Option) repo_wallets.Querier           { return memSequences{s: w.s} }
+
+type memCold struct {
+	repo_wallets_cold.ICustomQuerier
+	s *memStore
+}
+
+func (c memCold) find(ownerID uuid.UUID, blockchain wconstants.BlockchainType, address string) (*models.ColdWallet, error) {
+	for _, item := range c.s.cold {
+		if item.OwnerID == ownerID && item.Blockchain == blockchain && item.Address == address && !item.DetachedAt.Valid {
+			return item, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (c memCold) Detach(_ context.Context, arg repo_wallets_cold.DetachParams) (*models.ColdWallet, error) {
+	item, err := c.find(arg.OwnerID, arg.Blockchain, arg.Address)
+	if err != nil {
+		return nil, err
+	}
+	item.IsActive = false
+	item.DetachedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return item, nil
+}
+
+func (c memCold) SetLabel(_ context.Context, arg repo_wallets_cold.SetLabelParams) (*models.ColdWallet, error) {
+	item, err := c.find(arg.OwnerID, arg.Blockchain, arg.Address)
+	if err != nil {
+		return nil, err
+	}
+	item.Label, item.Note = arg.Label, arg.Note
+	return item, nil
+}
+
+type memHot struct {
+	repo_wallets_hot.ICustomQuerier
+	s *memStore
+}
+
+func (h memHot) Create(_ context.Context, arg repo_wallets_hot.CreateParams) (*models.HotWallet, error) {
+	item := &models.HotWallet{
+		ID:               uuid.New(),
+		Blockchain:       arg.Blockchain,
+		Address:          arg.Address,
+		OwnerID:          arg.OwnerID,
+		ExternalWalletID: arg.ExternalWalletID,
+		Sequence:         arg.Sequence,
+		IsActive:         arg.IsActive,
+	}
+	h.s.hot = append(h.s.hot, item)
+	return item, nil
+}
+
+func (h memHot) FindEVMByExternalID(_ context.Context, externalWalletID string, blockchains []string, ownerID uuid.UUID) ([]*models.HotWallet, error) {
+	var res []*models.HotWallet
+	for _, item := range h.s.hot {
+		if item.ExternalWalletID == externalWalletID && item.OwnerID == ownerID && item.IsActive &&
+			slices.Contains(blockchains, item.Blockchain.String()) {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+type memHotPool struct {
+	s *memStore
+}
+
+func (p memHotPool) Count(_ context.Context, arg repo_wallets_hot_pool.CountParams) (int32, error) {
+	var count int32
+	for _, item := range p.s.pool[arg.OwnerID] {
+		if item.Blockchain == arg.Blockchain {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (p memHotPool) Create(_ context.Context, arg repo_wallets_hot_pool.CreateParams) (*models.HotWalletsPool, error) {
+	p.s.pool[arg.OwnerID] = append(p.s.pool[arg.OwnerID], arg)
+	return &models.HotWalletsPool{Address: arg.Address, Sequence: arg.Sequence}, nil
+}
+
+func (p memHotPool) DeleteByAddress(_ context.Context, arg repo_wallets_hot_pool.DeleteByAddressParams) error {
+	p.s.pool[arg.OwnerID] = slices.DeleteFunc(p.s.pool[arg.OwnerID], func(item repo_wallets_hot_pool.CreateParams) bool {
+		return item.Blockchain == arg.Blockchain && item.Address == arg.Address
+	})
+	return nil
+}
+
+func (memHotPool) DeleteByOwnerID(context.Context, uuid.UUID) error { return nil }
+
+// used reports whether the pool address is already used by a hot wallet
+func (p memHotPool) used(ownerID uuid.UUID, item repo_wallets_hot_pool.CreateParams) bool {
+	return slices.ContainsFunc(p.s.hot, func(hot *models.HotWallet) bool {
+		return hot.OwnerID == ownerID && hot.Blockchain == item.Blockchain && hot.Address == item.Address
+	})
+}
+
+func (p memHotPool) DeleteUsed(_ context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (int64, error) {
+	before := len(p.s.pool[ownerID])
+	p.s.pool[ownerID] = slices.DeleteFunc(p.s.pool[ownerID], func(item repo_wallets_hot_pool.CreateParams) bool {
+		return item.Blockchain == blockchain && p.used(ownerID, item)
+	})
+	return int64(before - len(p.s.pool[ownerID])), nil
+}
+
+// Take removes and returns the unused address with the lowest sequence
+func (p memHotPool) Take(_ context.Context, arg repo_wallets_hot_pool.TakeParams) (*models.HotWalletsPool, error) {
+	idx := -1
+	for i, item := range p.s.pool[arg.OwnerID] {
+		if item.Blockchain != arg.Blockchain || item.AddressType != arg.AddressType || p.used(arg.OwnerID, item) {
+			continue
+		}
+		if idx == -1 || item.Sequence < p.s.pool[arg.OwnerID][idx].Sequence {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return nil, pgx.ErrNoRows
+	}
+
+	item := p.s.pool[arg.OwnerID][idx]
+	p.s.pool[arg.OwnerID] = slices.Delete(p.s.pool[arg.OwnerID], idx, idx+1)
+	return &models.HotWalletsPool{Blockchain: item.Blockchain, Address: item.Address, Sequence: item.Sequence}, nil
+}
+
+type memSequences struct {
+	s *memStore
+}
+
+func (m memSequences) NextSequences(_ context.Context, arg repo_wallets.NextSequencesParams) (int32, error) {
+	key := sequenceKey{arg.OwnerID, arg.Blockchain}
+	last, ok := m.s.sequences[key]
+	if !ok {
+		last = -1
+	}
+	m.s.sequences[key] = last + arg.Count
+	return last + arg.Count, nil
+}
+
+func (m memSequences) ReserveSequence(_ context.Context, arg repo_wallets.ReserveSequenceParams) error {
+	key := sequenceKey{arg.OwnerID, arg.Blockchain}
+	if last, ok := m.s.sequences[key]; !ok || last < arg.Sequence {
+		m.s.sequences[key] = arg.Sequence
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhook_endpoints"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
+	"github.com/dv-net/dv-processing/pkg/utils"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const endpointSecretKeySize = 32
+
+var (
+	// ErrInvalidEndpoint is returned when the endpoint settings are invalid
+	ErrInvalidEndpoint = errors.New("invalid webhook endpoint")
+	// ErrDefaultEndpoint is returned when the url of the default endpoint is set or the default endpoint is deleted,
+	// the default endpoint is sent to the client callback url
+	ErrDefaultEndpoint = errors.New("default webhook endpoint uses the client callback url")
+)
+
+// EndpointDTO are the settings of the webhook endpoint. The empty filters match all events.
+type EndpointDTO struct {
+	URL         string
+	Enabled     bool
+	Kinds       []models.WebhookKind
+	Blockchains []wconstants.BlockchainType
+	OwnerIDs    []uuid.UUID
+}
+
+func (dto EndpointDTO) validate() error {
+	for _, kind := range dto.Kinds {
+		if !kind.Valid() {
+			return fmt.Errorf("%w: undefined webhook kind %s", ErrInvalidEndpoint, kind)
+		}
+	}
+
+	for _, blockchain := range dto.Blockchains {
+		if !blockchain.Valid() {
+			return fmt.Errorf("%w: undefined blockchain %s", ErrInvalidEndpoint, blockchain)
+		}
+	}
+
+	return nil
+}
+
+func (dto EndpointDTO) kinds() []string {
+	res := make([]string, 0, len(dto.Kinds))
+	for _, kind := range dto.Kinds {
+		if !slices.Contains(res, kind.String()) {
+			res = append(res, kind.String())
+		}
+	}
+	return res
+}
+
+func (dto EndpointDTO) blockchains() []string {
+	res := make([]string, 0, len(dto.Blockchains))
+	for _, blockchain := range dto.Blockchains {
+		if !slices.Contains(res, blockchain.String()) {
+			res = append(res, blockchain.String())
+		}
+	}
+	return res
+}
+
+func (dto EndpointDTO) ownerIDs() []uuid.UUID {
+	res := make([]uuid.UUID, 0, len(dto.OwnerIDs))
+	for _, ownerID := range dto.OwnerIDs {
+		if !slices.Contains(res, ownerID) {
+			res = append(res, ownerID)
+		}
+	}
+	return res
+}
+
+// CreateEndpoint creates the webhook endpoint of the client with a new secret
+func (s *Service) CreateEndpoint(ctx context.Context, clientID uuid.UUID, dto EndpointDTO) (*models.WebhookEndpoint, error) {
+	if clientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	if !validateEndpointURL(dto.URL) {
+		return nil, fmt.Errorf("%w: invalid url %s", ErrInvalidEndpoint, dto.URL)
+	}
+
+	if err := dto.validate(); err != nil {
+		return nil, err
+	}
+
+	secretKey, err := utils.SpecialKey(endpointSecretKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.store.WebhookEndpoints().Create(ctx, repo_webhook_endpoints.CreateParams{
+		ClientID:    clientID,
+		Url:         pgtypeutils.EncodeText(&dto.URL),
+		SecretKey:   pgtypeutils.EncodeText(&secretKey),
+		Enabled:     dto.Enabled,
+		Kinds:       dto.kinds(),
+		Blockchains: dto.blockchains(),
+		OwnerIds:    dto.ownerIDs(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// UpdateEndpoint replaces the settings of the webhook endpoint of the client.
+// The url of the default endpoint must be empty.
+func (s *Service) UpdateEndpoint(ctx context.Context, clientID, endpointID uuid.UUID, dto EndpointDTO) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, clientID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case endpoint.IsDefault && dto.URL != "":
+		return nil, ErrDefaultEndpoint
+	case !endpoint.IsDefault && !validateEndpointURL(dto.URL):
+		return nil, fmt.Errorf("%w: invalid url %s", ErrInvalidEndpoint, dto.URL)
+	}
+
+	if err := dto.validate(); err != nil {
+		return nil, err
+	}
+
+	params := repo_webhook_endpoints.UpdateParams{
+		ID:          endpoint.ID,
+		ClientID:    clientID,
+		Enabled:     dto.Enabled,
+		Kinds:       dto.kinds(),
+		Blockchains: dto.blockchains(),
+		OwnerIds:    dto.ownerIDs(),
+	}
+
+	if !endpoint.IsDefault {
+		params.Url = pgtypeutils.EncodeText(&dto.URL)
+	}
+
+	endpoint, err = s.store.WebhookEndpoints().Update(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// DeleteEndpoint deletes the webhook endpoint of the client with its deliveries.
+// The state of the webhooks pending for the endpoint is refreshed by the rest of their deliveries.
+func (s *Service) DeleteEndpoint(ctx context.Context, clientID, endpointID uuid.UUID) error {
+	endpoint, err := s.GetEndpoint(ctx, clientID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if endpoint.IsDefault {
+		return ErrDefaultEndpoint
+	}
+
+	return pgx.BeginTxFunc(ctx, s.store.PSQLConn(), pgx.TxOptions{}, func(tx pgx.Tx) error {
+		webhookIDs, err := s.store.WebhookDeliveries(repos.WithTx(tx)).GetPendingWebhookIDsByEndpointID(ctx, endpoint.ID)
+		if err != nil {
+			return fmt.Errorf("get pending webhooks of the endpoint: %w", err)
+		}
+
+		if _, err := s.store.WebhookEndpoints(repos.WithTx(tx)).Delete(ctx, endpoint.ID, clientID); err != nil {
+			return fmt.Errorf("delete webhook endpoint: %w", err)
+		}
+
+		for _, webhookID := range webhookIDs {
+			status, err := s.store.Webhooks(repos.WithTx(tx)).RefreshState(ctx, webhookID)
+			if err != nil {
+				return fmt.Errorf("refresh webhook %s state: %w", webhookID, err)
+			}
+
+			// the webhook is sent to all other endpoints
+			if s.config.Webhooks.RemoveAfterSent && status == models.WebhookStatusSent {
+				if err := s.store.Webhooks(repos.WithTx(tx)).DeleteByID(ctx, webhookID); err != nil {
+					return fmt.Errorf("delete webhook %s: %w", webhookID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetEndpoint returns the webhook endpoint of the client
+func (s *Service) GetEndpoint(ctx context.Context, clientID, endpointID uuid.UUID) (*models.WebhookEndpoint, error) {
+	if clientID == uuid.Nil || endpointID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	endpoint, err := s.store.WebhookEndpoints().GetByID(ctx, endpointID, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storecmn.ErrNotFound
+		}
+		return nil, fmt.Errorf("get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// GetEndpoints returns the webhook endpoints of the client, the default one first
+func (s *Service) GetEndpoints(ctx context.Context, clientID uuid.UUID) ([]*models.WebhookEndpoint, error) {
+	if clientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	endpoints, err := s.store.WebhookEndpoints().GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+func validateEndpointURL(endpointURL string) bool {
+	u, err := url.ParseRequestURI(endpointURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
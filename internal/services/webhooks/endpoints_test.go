@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEndpointURL(t *testing.T) {
+	for endpointURL, expected := range map[string]bool{
+		"https://example.com/webhooks": true,
+		"http://127.0.0.1:8080/hook":   true,
+		"":                             false,
+		"example.com/webhooks":         false,
+		"ftp://example.com":            false,
+		"https://":                     false,
+	} {
+		require.Equal(t, expected, validateEndpointURL(endpointURL), endpointURL)
+	}
+}
+
+func TestEndpointDTO(t *testing.T) {
+	ownerID := uuid.New()
+
+	dto := EndpointDTO{
+		Kinds:       []models.WebhookKind{models.WebhookKindDeposit, models.WebhookKindDeposit, models.WebhookKindTransfer},
+		Blockchains: []wconstants.BlockchainType{wconstants.BlockchainTypeTron, wconstants.BlockchainTypeTron},
+		OwnerIDs:    []uuid.UUID{ownerID, ownerID},
+	}
+
+	require.NoError(t, dto.validate())
+	require.Equal(t, []string{"deposit", "transfer"}, dto.kinds())
+	require.Equal(t, []string{wconstants.BlockchainTypeTron.String()}, dto.blockchains())
+	require.Equal(t, []uuid.UUID{ownerID}, dto.ownerIDs())
+
+	dto.Kinds = append(dto.Kinds, "unknown")
+	require.ErrorIs(t, dto.validate(), ErrInvalidEndpoint)
+
+	dto.Kinds = nil
+	dto.Blockchains = append(dto.Blockchains, "unknown")
+	require.ErrorIs(t, dto.validate(), ErrInvalidEndpoint)
+}
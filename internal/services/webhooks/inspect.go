@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhooks"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// ErrEmptyRedeliverFilter is returned when the redelivery would reset all webhooks of the client
+var ErrEmptyRedeliverFilter = errors.New("webhook id or at least one filter is required")
+
+// List returns the webhooks of the client, newest first
+func (s *Service) List(ctx context.Context, clientID uuid.UUID, params repo_webhooks.FindParams) (*storecmn.FindResponseWithPagingFlag[*models.Webhook], error) {
+	if clientID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	params.ClientID = &clientID
+
+	res, err := s.store.Webhooks().Find(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("find webhooks: %w", err)
+	}
+
+	return res, nil
+}
+
+// Get returns the webhook of the client
+func (s *Service) Get(ctx context.Context, clientID, webhookID uuid.UUID) (*models.Webhook, error) {
+	if clientID == uuid.Nil || webhookID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	res, err := s.store.Webhooks().Find(ctx, repo_webhooks.FindParams{
+		ClientID:   &clientID,
+		ID:         &webhookID,
+		PageParams: storecmn.PageParams{PageSize: lo.ToPtr(uint64(1))},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find webhook: %w", err)
+	}
+
+	if len(res.Items) == 0 {
+		return nil, storecmn.ErrNotFound
+	}
+
+	return res.Items[0], nil
+}
+
+// Redeliver resets the webhook or the filtered webhooks of the client to be sent again.
+// Only the deliveries which are not sent are reset unless the endpoint is set, then all
+// deliveries to the endpoint are reset. It returns the number of the reset webhooks.
+func (s *Service) Redeliver(ctx context.Context, clientID uuid.UUID, params repo_webhooks.RedeliverParams) (int64, error) {
+	if clientID == uuid.Nil {
+		return 0, storecmn.ErrEmptyID
+	}
+
+	if params.ID == nil && params.Kind == nil && params.Status == nil && params.From == nil &&
+		params.To == nil && params.TxHash == nil && params.RequestID == nil {
+		return 0, ErrEmptyRedeliverFilter
+	}
+
+	params.ClientID = &clientID
+
+	count, err := s.store.Webhooks().Redeliver(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("redeliver webhooks: %w", err)
+	}
+
+	// the webhook without the deliveries to reset is found, but nothing is redelivered
+	if params.ID != nil && count == 0 {
+		if _, err := s.Get(ctx, clientID, *params.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// GetDeliveries returns the delivery state of the webhook per endpoint
+func (s *Service) GetDeliveries(ctx context.Context, webhookID uuid.UUID) ([]*models.WebhookDelivery, error) {
+	if webhookID == uuid.Nil {
+		return nil, storecmn.ErrEmptyID
+	}
+
+	deliveries, err := s.store.WebhookDeliveries().GetByWebhookID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
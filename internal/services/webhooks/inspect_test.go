@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhooks"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps the webhooks in memory, the other repositories are not implemented
+type memStore struct {
+	store.IStore
+	webhooks []*models.Webhook
+	// redelivered are the params of the Redeliver calls
+	redelivered []repo_webhooks.RedeliverParams
+}
+
+func (s *memStore) Webhooks(...repos.Option) repo_webhooks.ICustomQuerier { return memWebhooks{s: s} }
+
+type memWebhooks struct {
+	repo_webhooks.ICustomQuerier
+	s *memStore
+}
+
+// filter returns the webhooks matching the client, the id and the status of the params
+func (w memWebhooks) filter(params repo_webhooks.FindParams) []*models.Webhook {
+	var res []*models.Webhook
+	for _, item := range w.s.webhooks {
+		if params.ClientID != nil && item.ClientID != *params.ClientID {
+			continue
+		}
+		if params.ID != nil && item.ID != *params.ID {
+			continue
+		}
+		if params.Status != nil && item.Status != *params.Status {
+			continue
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func (w memWebhooks) Find(_ context.Context, params repo_webhooks.FindParams) (*storecmn.FindResponseWithPagingFlag[*models.Webhook], error) {
+	return &storecmn.FindResponseWithPagingFlag[*models.Webhook]{Items: w.filter(params)}, nil
+}
+
+// Redeliver counts the found webhooks, the sent ones are reset only for the endpoint
+func (w memWebhooks) Redeliver(_ context.Context, params repo_webhooks.RedeliverParams) (int64, error) {
+	w.s.redelivered = append(w.s.redelivered, params)
+
+	var count int64
+	for _, item := range w.filter(params.FindParams) {
+		if item.Status != models.WebhookStatusSent || params.EndpointID != nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newInspectStore(clientID, otherClientID uuid.UUID) *memStore {
+	return &memStore{
+		webhooks: []*models.Webhook{
+			{ID: uuid.New(), ClientID: clientID, Status: models.WebhookStatusFailed},
+			{ID: uuid.New(), ClientID: clientID, Status: models.WebhookStatusSent},
+			{ID: uuid.New(), ClientID: otherClientID, Status: models.WebhookStatusFailed},
+		},
+	}
+}
+
+func TestList(t *testing.T) {
+	clientID, otherClientID := uuid.New(), uuid.New()
+	st := newInspectStore(clientID, otherClientID)
+	s := &Service{store: st}
+
+	_, err := s.List(context.Background(), uuid.Nil, repo_webhooks.FindParams{})
+	require.ErrorIs(t, err, storecmn.ErrEmptyID)
+
+	res, err := s.List(context.Background(), clientID, repo_webhooks.FindParams{})
+	require.NoError(t, err)
+	require.Equal(t, st.webhooks[:2], res.Items)
+
+	// the client filter of the caller is replaced
+	res, err = s.List(context.Background(), clientID, repo_webhooks.FindParams{ClientID: &otherClientID})
+	require.NoError(t, err)
+	require.Equal(t, st.webhooks[:2], res.Items)
+}
+
+func TestGet(t *testing.T) {
+	clientID, otherClientID := uuid.New(), uuid.New()
+	st := newInspectStore(clientID, otherClientID)
+	s := &Service{store: st}
+
+	_, err := s.Get(context.Background(), uuid.Nil, st.webhooks[0].ID)
+	require.ErrorIs(t, err, storecmn.ErrEmptyID)
+
+	_, err = s.Get(context.Background(), clientID, uuid.Nil)
+	require.ErrorIs(t, err, storecmn.ErrEmptyID)
+
+	res, err := s.Get(context.Background(), clientID, st.webhooks[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, st.webhooks[0], res)
+
+	// the webhook of another client is not found
+	_, err = s.Get(context.Background(), clientID, st.webhooks[2].ID)
+	require.ErrorIs(t, err, storecmn.ErrNotFound)
+
+	_, err = s.Get(context.Background(), clientID, uuid.New())
+	require.ErrorIs(t, err, storecmn.ErrNotFound)
+}
+
+func TestRedeliver(t *testing.T) {
+	clientID, otherClientID := uuid.New(), uuid.New()
+
+	t.Run("empty filter", func(t *testing.T) {
+		st := newInspectStore(clientID, otherClientID)
+		s := &Service{store: st}
+
+		_, err := s.Redeliver(context.Background(), uuid.Nil, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{ID: &st.webhooks[0].ID},
+		})
+		require.ErrorIs(t, err, storecmn.ErrEmptyID)
+
+		// the client filter and the paging do not limit the redelivery
+		endpointID := uuid.New()
+		_, err = s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{
+				ClientID:   &clientID,
+				PageParams: storecmn.PageParams{Page: new(uint64)},
+			},
+			EndpointID: &endpointID,
+		})
+		require.ErrorIs(t, err, ErrEmptyRedeliverFilter)
+		require.Empty(t, st.redelivered)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		now := time.Now()
+		status := models.WebhookStatusFailed
+		kind := models.WebhookKindTransferStatus
+		txHash, requestID := "hash", "request"
+
+		for name, params := range map[string]repo_webhooks.FindParams{
+			"status":     {Status: &status},
+			"kind":       {Kind: &kind},
+			"from":       {From: &now},
+			"to":         {To: &now},
+			"tx hash":    {TxHash: &txHash},
+			"request id": {RequestID: &requestID},
+		} {
+			st := newInspectStore(clientID, otherClientID)
+			s := &Service{store: st}
+
+			_, err := s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{FindParams: params})
+			require.NoError(t, err, name)
+			require.Len(t, st.redelivered, 1, name)
+			require.Equal(t, &clientID, st.redelivered[0].ClientID, name)
+		}
+	})
+
+	t.Run("client scope", func(t *testing.T) {
+		st := newInspectStore(clientID, otherClientID)
+		s := &Service{store: st}
+
+		status := models.WebhookStatusFailed
+		count, err := s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{
+				ClientID: &otherClientID,
+				Status:   &status,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+		require.Equal(t, &clientID, st.redelivered[0].ClientID)
+
+		// the webhook of another client is not found
+		_, err = s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{ID: &st.webhooks[2].ID},
+		})
+		require.ErrorIs(t, err, storecmn.ErrNotFound)
+
+		count, err = s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{ID: &st.webhooks[0].ID},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+
+	t.Run("sent webhook", func(t *testing.T) {
+		st := newInspectStore(clientID, otherClientID)
+		s := &Service{store: st}
+
+		// the sent deliveries are not reset without the endpoint
+		count, err := s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{ID: &st.webhooks[1].ID},
+		})
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		endpointID := uuid.New()
+		count, err = s.Redeliver(context.Background(), clientID, repo_webhooks.RedeliverParams{
+			FindParams: repo_webhooks.FindParams{ID: &st.webhooks[1].ID},
+			EndpointID: &endpointID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+		require.Equal(t, &endpointID, st.redelivered[1].EndpointID)
+	})
+}
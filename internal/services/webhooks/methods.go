@@ -119,10 +119,12 @@ func (s *Service) EventTransactionCreateParams(ctx context.Context, params Event
 	}
 
 	return BatchCreateParams{
-		Kind:     params.WebhookKind,
-		Status:   models.WebhookStatusNew,
-		Payload:  payload.Bytes(),
-		ClientID: owner.ClientID,
+		Kind:       params.WebhookKind,
+		Status:     models.WebhookStatusNew,
+		Payload:    payload.Bytes(),
+		ClientID:   owner.ClientID,
+		OwnerID:    uuid.NullUUID{UUID: owner.ID, Valid: true},
+		Blockchain: params.Blockchain,
 	}, nil
 }
 
@@ -182,9 +184,58 @@ func (s *Service) EventTransferStatusCreateParams(ctx context.Context, params Ev
 	}
 
 	return BatchCreateParams{
-		Kind:     models.WebhookKindTransferStatus,
+		Kind:       models.WebhookKindTransferStatus,
+		Status:     models.WebhookStatusNew,
+		Payload:    payload.Bytes(),
+		ClientID:   owner.ClientID,
+		OwnerID:    uuid.NullUUID{UUID: owner.ID, Valid: true},
+		Blockchain: transfer.Blockchain,
+	}, nil
+}
+
+type EventMnemonicRotationCreateParamsData struct {
+	Rotation *models.MnemonicRotation
+	Wallets  []*models.MnemonicRotationWallet
+}
+
+// EventMnemonicRotationCreateParams returns create params for a mnemonic rotation progress event.
+func (s *Service) EventMnemonicRotationCreateParams(ctx context.Context, params EventMnemonicRotationCreateParamsData) (BatchCreateParams, error) {
+	if params.Rotation == nil {
+		return BatchCreateParams{}, fmt.Errorf("rotation is empty")
+	}
+
+	payloadParams := whevents.EventMnemonicRotationPayload{
+		Kind:         models.WebhookKindMnemonicRotation,
+		OwnerID:      params.Rotation.OwnerID,
+		RotationID:   params.Rotation.ID,
+		Status:       params.Rotation.Status,
+		WalletsTotal: len(params.Wallets),
+		LastError:    params.Rotation.LastError.String,
+	}
+
+	for _, item := range params.Wallets {
+		if item.Status == constants.MnemonicRotationWalletStatusEmpty ||
+			item.Status == constants.MnemonicRotationWalletStatusArchived {
+			payloadParams.WalletsMigrated++
+		}
+	}
+
+	payload, err := payloadParams.RawMessage()
+	if err != nil {
+		return BatchCreateParams{}, fmt.Errorf("get raw message for payload: %w", err)
+	}
+
+	// get owner
+	owner, err := s.store.Owners().GetByID(ctx, params.Rotation.OwnerID)
+	if err != nil {
+		return BatchCreateParams{}, fmt.Errorf("get owner: %w", err)
+	}
+
+	return BatchCreateParams{
+		Kind:     models.WebhookKindMnemonicRotation,
 		Status:   models.WebhookStatusNew,
 		Payload:  payload.Bytes(),
 		ClientID: owner.ClientID,
+		OwnerID:  uuid.NullUUID{UUID: owner.ID, Valid: true},
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"slices"
@@ -16,10 +17,13 @@ import (
 	"github.com/dv-net/dv-processing/internal/config"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhook_deliveries"
 	"github.com/dv-net/dv-processing/internal/util"
 	"github.com/dv-net/dv-processing/pkg/dbutils/pgtypeutils"
 	"github.com/dv-net/dv-processing/pkg/utils"
 	"github.com/dv-net/mx/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const (
@@ -102,8 +106,9 @@ func (s *sender) processWebhooks(ctx context.Context) error {
 }
 
 func (s *sender) processAllUnsentWebhooks(ctx context.Context) error {
-	// get all unsent webhooks
-	items, err := s.store.Webhooks().GetUnsent(ctx, models.WebhookStatusNew, s.config.Webhooks.Sender.Quantity)
+	// get all unsent webhook deliveries, the later transfer statuses of the request
+	// are not returned while the earlier one is pending
+	items, err := s.store.WebhookDeliveries().GetUnsent(ctx, models.WebhookStatusNew, s.config.Webhooks.Sender.Quantity)
 	if err != nil {
 		return fmt.Errorf("get unsent error: %w", err)
 	}
@@ -115,15 +120,15 @@ func (s *sender) processAllUnsentWebhooks(ctx context.Context) error {
 
 	sentWebhooks := new(atomic.Int32)
 
-	groupedWebhooks, err := groupByRequestID(items)
+	groupedWebhooks, err := groupByEndpointAndRequestID(items)
 	if err != nil {
-		return fmt.Errorf("group by request_id error: %w", err)
+		return fmt.Errorf("group by endpoint_id and request_id error: %w", err)
 	}
 
 	now := time.Now()
-	for groupName, webhooks := range groupedWebhooks {
+	for group, webhooks := range groupedWebhooks {
 		for chunk := range slices.Chunk(webhooks, 100) {
-			if err := s.handleChunk(ctx, sentWebhooks, chunk, groupName == "none"); err != nil {
+			if err := s.handleChunk(ctx, sentWebhooks, chunk, group.requestID == ""); err != nil {
 				s.logger.Error(err.Error())
 			}
 		}
@@ -141,46 +146,79 @@ func (s *sender) processAllUnsentWebhooks(ctx context.Context) error {
 }
 
 // handleChunk
-func (s *sender) handleChunk(ctx context.Context, sentWebhooks *atomic.Int32, chunk []*models.WebhookView, parallel bool) error {
+func (s *sender) handleChunk(ctx context.Context, sentWebhooks *atomic.Int32, chunk []*models.WebhookDeliveryView, parallel bool) error {
 	wg := new(sync.WaitGroup)
 	wg.Add(len(chunk))
 
 	for _, item := range chunk {
 		fields := []any{
 			"id", item.ID.String(),
+			"webhook_id", item.WebhookID.String(),
+			"endpoint_id", item.EndpointID.String(),
 			"client_id", item.ClientID.String(),
-			"callback_url", item.CallbackUrl,
+			"url", item.Url,
 		}
 
 		fn := func() error {
 			defer wg.Done()
 
 			// send webhook
-			resp, err := s.doRequest(ctx, item.Payload, item.CallbackUrl, item.SecretKey)
+			resp, err := s.doRequest(ctx, item.Payload, item.Url, item.SecretKey, item.SecretKeyID)
 			if err != nil {
 				whResponseData := resp
 				if whResponseData == nil || *whResponseData == "" {
 					whResponseData = utils.Pointer(err.Error())
 				}
 
-				// increment attempt
-				if err := s.store.Webhooks().IncrementAttempt(ctx, item.ID, pgtypeutils.EncodeText(whResponseData)); err != nil {
-					return fmt.Errorf("increment attempt for webhook id %s error: %w", item.ID, err)
+				// park the delivery after the last attempt
+				attempts := item.Attempts + 1
+				if attempts >= s.config.Webhooks.Sender.MaxAttempts {
+					if err := s.store.WebhookDeliveries().SetFailed(ctx, item.ID, pgtypeutils.EncodeText(whResponseData)); err != nil {
+						return fmt.Errorf("set failed for webhook delivery id %s error: %w", item.ID, err)
+					}
+
+					if _, err := s.store.Webhooks().RefreshState(ctx, item.WebhookID); err != nil {
+						return fmt.Errorf("refresh state of webhook id %s error: %w", item.WebhookID, err)
+					}
+
+					return fmt.Errorf("send webhook error after %d attempts, delivery is failed: %w", attempts, err)
+				}
+
+				// increment attempt and schedule the next one
+				if err := s.store.WebhookDeliveries().IncrementAttempt(ctx, repo_webhook_deliveries.IncrementAttemptParams{
+					ID:       item.ID,
+					Response: pgtypeutils.EncodeText(whResponseData),
+					NextAttemptAt: pgtype.Timestamptz{
+						Time:  time.Now().Add(s.retryDelay(attempts)),
+						Valid: true,
+					},
+				}); err != nil {
+					return fmt.Errorf("increment attempt for webhook delivery id %s error: %w", item.ID, err)
+				}
+
+				if _, err := s.store.Webhooks().RefreshState(ctx, item.WebhookID); err != nil {
+					return fmt.Errorf("refresh state of webhook id %s error: %w", item.WebhookID, err)
 				}
 
 				return fmt.Errorf("send webhook error: %w", err)
 			}
 
 			sentWebhooks.Add(1)
-			if s.config.Webhooks.RemoveAfterSent {
-				// delete webhook from database
-				if err := s.store.Webhooks().DeleteByID(ctx, item.ID); err != nil {
-					return fmt.Errorf("delete webhook id %s error: %w", item.ID, err)
-				}
-			} else {
-				// set sent_at to now
-				if err := s.store.Webhooks().SetSentAtNow(ctx, item.ID, pgtypeutils.EncodeText(resp)); err != nil {
-					return fmt.Errorf("set sent_at for webhook id %s error: %w", item.ID, err)
+
+			// set sent_at to now
+			if err := s.store.WebhookDeliveries().SetSentAtNow(ctx, item.ID, pgtypeutils.EncodeText(resp)); err != nil {
+				return fmt.Errorf("set sent_at for webhook delivery id %s error: %w", item.ID, err)
+			}
+
+			status, err := s.store.Webhooks().RefreshState(ctx, item.WebhookID)
+			if err != nil {
+				return fmt.Errorf("refresh state of webhook id %s error: %w", item.WebhookID, err)
+			}
+
+			// delete webhook from database after it is sent to all endpoints
+			if s.config.Webhooks.RemoveAfterSent && status == models.WebhookStatusSent {
+				if err := s.store.Webhooks().DeleteByID(ctx, item.WebhookID); err != nil {
+					return fmt.Errorf("delete webhook id %s error: %w", item.WebhookID, err)
 				}
 			}
 
@@ -207,7 +245,7 @@ func (s *sender) handleChunk(ctx context.Context, sentWebhooks *atomic.Int32, ch
 	return nil
 }
 
-func (s *sender) doRequest(ctx context.Context, payload []byte, callbackURL, webhookSecretKey string) (*string, error) {
+func (s *sender) doRequest(ctx context.Context, payload []byte, callbackURL, webhookSecretKey string, webhookSecretKeyID uuid.NullUUID) (*string, error) {
 	// validate url
 	if _, err := url.ParseRequestURI(callbackURL); err != nil {
 		return nil, fmt.Errorf("invalid callback url %s : %w", callbackURL, err)
@@ -239,6 +277,11 @@ func (s *sender) doRequest(ctx context.Context, payload []byte, callbackURL, web
 		"X-Sign":       {util.SHA256Signature(payloadBuf.Bytes(), webhookSecretKey)},
 	}
 
+	// allows the client to select the secret during the rotation
+	if webhookSecretKeyID.Valid {
+		req.Header.Set("X-Sign-Key-ID", webhookSecretKeyID.UUID.String())
+	}
+
 	// make request
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -261,9 +304,30 @@ func (s *sender) doRequest(ctx context.Context, payload []byte, callbackURL, web
 	return utils.Pointer(string(answer)), nil
 }
 
-// groupByRequestID groups webhooks by request_id
-func groupByRequestID(webhooks []*models.WebhookView) (map[string][]*models.WebhookView, error) {
-	res := make(map[string][]*models.WebhookView)
+// retryDelay returns the exponential back-off with the jitter after the failed attempts.
+// The half of the delay is random to spread the retries of the webhooks failed at once.
+func (s *sender) retryDelay(attempts int32) time.Duration {
+	delay := s.config.Webhooks.Sender.MinBackoff
+	for i := int32(1); i < attempts && delay < s.config.Webhooks.Sender.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, s.config.Webhooks.Sender.MaxBackoff)
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// deliveryGroup is the endpoint and the request of the ordered deliveries,
+// the deliveries without the request are not ordered
+type deliveryGroup struct {
+	endpointID uuid.UUID
+	requestID  string
+}
+
+// groupByEndpointAndRequestID groups webhook deliveries by endpoint_id and request_id,
+// so the failed endpoint does not stop the deliveries to the other endpoints
+func groupByEndpointAndRequestID(webhooks []*models.WebhookDeliveryView) (map[deliveryGroup][]*models.WebhookDeliveryView, error) {
+	res := make(map[deliveryGroup][]*models.WebhookDeliveryView)
 
 	for _, item := range webhooks {
 		var payload struct {
@@ -274,15 +338,12 @@ func groupByRequestID(webhooks []*models.WebhookView) (map[string][]*models.Webh
 			return res, fmt.Errorf("unmarshal webhook payload error: %w", err)
 		}
 
-		if payload.RequestID == "" || item.Kind != models.WebhookKindTransferStatus {
-			payload.RequestID = "none"
-		}
-
-		if _, ok := res[payload.RequestID]; !ok {
-			res[payload.RequestID] = make([]*models.WebhookView, 0)
+		group := deliveryGroup{endpointID: item.EndpointID}
+		if item.Kind == models.WebhookKindTransferStatus {
+			group.requestID = payload.RequestID
 		}
 
-		res[payload.RequestID] = append(res[payload.RequestID], item)
+		res[group] = append(res[group], item)
 	}
 
 	return res, nil
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDelay(t *testing.T) {
+	conf := new(config.Config)
+	conf.Webhooks.Sender.MinBackoff = 10 * time.Second
+	conf.Webhooks.Sender.MaxBackoff = time.Hour
+
+	s := &sender{config: conf}
+
+	for attempts, expected := range map[int32]time.Duration{
+		1:   10 * time.Second,
+		2:   20 * time.Second,
+		3:   40 * time.Second,
+		8:   1280 * time.Second,
+		9:   2560 * time.Second,
+		10:  time.Hour,
+		100: time.Hour,
+	} {
+		for range 100 {
+			delay := s.retryDelay(attempts)
+			require.GreaterOrEqual(t, delay, expected/2, attempts)
+			require.LessOrEqual(t, delay, expected, attempts)
+		}
+	}
+}
+
+func TestGroupByEndpointAndRequestID(t *testing.T) {
+	endpointID, otherEndpointID := uuid.New(), uuid.New()
+	payload := []byte(`{"request_id":"request"}`)
+
+	items := []*models.WebhookDeliveryView{
+		{EndpointID: endpointID, Kind: models.WebhookKindTransferStatus, Payload: payload},
+		{EndpointID: endpointID, Kind: models.WebhookKindTransferStatus, Payload: payload},
+		{EndpointID: otherEndpointID, Kind: models.WebhookKindTransferStatus, Payload: payload},
+		{EndpointID: endpointID, Kind: models.WebhookKindDeposit, Payload: payload},
+	}
+
+	res, err := groupByEndpointAndRequestID(items)
+	require.NoError(t, err)
+	require.Equal(t, map[deliveryGroup][]*models.WebhookDeliveryView{
+		{endpointID: endpointID, requestID: "request"}:      items[:2],
+		{endpointID: otherEndpointID, requestID: "request"}: items[2:3],
+		{endpointID: endpointID}:                            items[3:],
+	}, res)
+
+	_, err = groupByEndpointAndRequestID([]*models.WebhookDeliveryView{{Payload: []byte("{")}})
+	require.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package whevents
+
+import (
+	"bytes"
+
+	"github.com/dv-net/dv-processing/internal/constants"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/google/uuid"
+)
+
+// EventMnemonicRotationPayload
+type EventMnemonicRotationPayload struct {
+	Kind            models.WebhookKind               `json:"kind"`
+	OwnerID         uuid.UUID                        `json:"owner_id"`
+	RotationID      uuid.UUID                        `json:"rotation_id"`
+	Status          constants.MnemonicRotationStatus `json:"status"`
+	WalletsTotal    int                              `json:"wallets_total"`
+	WalletsMigrated int                              `json:"wallets_migrated"`
+	LastError       string                           `json:"last_error,omitempty"`
+}
+
+func (p EventMnemonicRotationPayload) RawMessage() (*bytes.Buffer, error) { return rawMessage(p) }
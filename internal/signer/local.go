@@ -0,0 +1,307 @@
+package signer
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/tron"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Local signs the transactions with the keys derived from the owner seeds stored in the database.
+// It is used by the processing in the local mode and by the signer process.
+type Local struct {
+	config  *config.Config
+	store   store.IStore
+	secrets *secrets.Service
+	sdk     *walletsdk.SDK
+	utxo    UTXOProvider
+	policy  *Policy
+}
+
+var _ Signer = (*Local)(nil)
+
+func NewLocal(conf *config.Config, st store.IStore, secretsSvc *secrets.Service, sdk *walletsdk.SDK, utxo UTXOProvider) *Local {
+	return &Local{
+		config:  conf,
+		store:   st,
+		secrets: secretsSvc,
+		sdk:     sdk,
+		utxo:    utxo,
+		policy:  NewPolicy(conf.Signer.Policy),
+	}
+}
+
+type ownerSeed struct {
+	mnemonic   string
+	passPhrase string
+	derivation derivation.Config
+}
+
+func (s *Local) ownerSeed(ctx context.Context, ownerID uuid.UUID) (*ownerSeed, error) {
+	owner, err := s.store.Owners().GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("get owner: %w", err)
+	}
+
+	seed := &ownerSeed{
+		mnemonic:   owner.Mnemonic,
+		passPhrase: owner.PassPhrase.String,
+		derivation: owner.Derivation,
+	}
+
+	if s.config.IsEnabledSeedEncryption() {
+		seed.mnemonic, err = s.secrets.Decrypt(ctx, owner.ID, seed.mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
+		}
+
+		seed.passPhrase, err = s.secrets.DecryptOptional(ctx, owner.ID, seed.passPhrase)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt pass phrase: %w", err)
+		}
+	}
+
+	return seed, nil
+}
+
+// DeriveAddresses decrypts the seed of the request and derives the addresses from it.
+func (s *Local) DeriveAddresses(ctx context.Context, req DeriveAddressesRequest) ([]string, error) {
+	mnemonic, passPhrase := req.Mnemonic, req.PassPhrase
+	if s.config.IsEnabledSeedEncryption() {
+		var err error
+		mnemonic, err = s.secrets.DecryptWithDataKey(ctx, req.OwnerID, req.DataKey, mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt mnemonic: %w", err)
+		}
+
+		if passPhrase != "" {
+			passPhrase, err = s.secrets.DecryptWithDataKey(ctx, req.OwnerID, req.DataKey, passPhrase)
+			if err != nil {
+				return nil, fmt.Errorf("decrypt pass phrase: %w", err)
+			}
+		}
+	}
+
+	addresses := make([]string, 0, req.Count)
+	for sequence := req.FirstSequence; sequence < req.FirstSequence+req.Count; sequence++ {
+		address, err := s.sdk.AddressWallet(req.Blockchain, req.AddressType, mnemonic, passPhrase, sequence, req.Derivation.Options(req.Blockchain)...)
+		if errors.Is(err, walletsdk.ErrBlockchainUndefined) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, req.Blockchain)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("derive address: %w", err)
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// checkDestinations checks that the funds are sent only to the owner wallets or to the allowed addresses.
+func (s *Local) checkDestinations(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, dests []Destination) error {
+	if !s.policy.OwnerDestinationsOnly() {
+		return nil
+	}
+
+	for _, dest := range dests {
+		if s.policy.IsAllowedDestination(dest.Address) {
+			continue
+		}
+
+		isOwnerWallet, err := s.isOwnerWallet(ctx, ownerID, blockchain, dest.Address)
+		if err != nil {
+			return err
+		}
+
+		if !isOwnerWallet {
+			return fmt.Errorf("%w: destination %s is not allowed", ErrPolicyViolation, dest.Address)
+		}
+	}
+
+	return nil
+}
+
+// isOwnerWallet reports whether the address is a hot, processing or cold wallet of the owner.
+//
+// The wallets are read from the database the processing writes to, so the check does not stop
+// a compromised processing which adds its own address as a wallet of the owner. Only the
+// addresses of the policy allowlist do not depend on the processing.
+func (s *Local) isOwnerWallet(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, address string) (bool, error) {
+	hot, err := s.store.Wallets().Hot().GetByBlockchainAndAddress(ctx, blockchain, address)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("get hot wallet: %w", err)
+	}
+	if err == nil {
+		return hot.OwnerID == ownerID, nil
+	}
+
+	processing, err := s.store.Wallets().Processing().GetByBlockchainAndAddress(ctx, blockchain, address)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("get processing wallet: %w", err)
+	}
+	if err == nil {
+		return processing.OwnerID == ownerID, nil
+	}
+
+	// cold wallet addresses are stored as entered, so evm addresses are checked in the checksum form too
+	coldAddresses := []string{address}
+	if blockchain.IsEVM() && common.IsHexAddress(address) {
+		if checksum := common.HexToAddress(address).Hex(); checksum != address {
+			coldAddresses = append(coldAddresses, checksum)
+		}
+	}
+
+	// transfers to the detached cold wallets are finished, so they are allowed too
+	for _, coldAddress := range coldAddresses {
+		cold, err := s.store.Wallets().Cold().GetByBlockchainAndAddress(ctx, blockchain, coldAddress)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("get cold wallet: %w", err)
+		}
+		if err == nil {
+			return cold.OwnerID == ownerID, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SignEVMTx signs the transaction with the key of the wallet address.
+func (s *Local) SignEVMTx(ctx context.Context, req SignEVMTxRequest) (*types.Transaction, error) {
+	if !req.Blockchain.IsEVM() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, req.Blockchain)
+	}
+
+	dest, err := s.policy.CheckEVMTx(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDestinations(ctx, req.OwnerID, req.Blockchain, []Destination{*dest}); err != nil {
+		return nil, err
+	}
+
+	seed, err := s.ownerSeed(ctx, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	address, privateKey, _, err := evm.WalletPubKeyHash(seed.mnemonic, seed.passPhrase, req.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	if !strings.EqualFold(address, req.Address) {
+		return nil, fmt.Errorf("%w: key of the sequence %d does not match the address %s", ErrPolicyViolation, req.AddressSequence, req.Address)
+	}
+
+	signedTx, err := types.SignTx(req.Tx, types.LatestSignerForChainID(req.Tx.ChainId()), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTronTx signs the transaction raw data with the key of the wallet address.
+func (s *Local) SignTronTx(ctx context.Context, req SignTronTxRequest) ([]byte, error) {
+	dests, err := s.policy.CheckTronTx(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDestinations(ctx, req.OwnerID, wconstants.BlockchainTypeTron, dests); err != nil {
+		return nil, err
+	}
+
+	seed, err := s.ownerSeed(ctx, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	address, privateKey, _, err := tron.WalletPubKeyHash(seed.mnemonic, seed.passPhrase, req.AddressSequence, seed.derivation.Options(wconstants.BlockchainTypeTron)...)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	if address != req.Address {
+		return nil, fmt.Errorf("%w: key of the sequence %d does not match the address %s", ErrPolicyViolation, req.AddressSequence, req.Address)
+	}
+
+	rawData, err := proto.Marshal(req.Tx.GetRawData())
+	if err != nil {
+		return nil, fmt.Errorf("marshal raw data: %w", err)
+	}
+
+	hash := sha256.Sum256(rawData)
+
+	signature, err := crypto.Sign(hash[:], privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	return signature, nil
+}
+
+// SignMessage signs the message with the key of the wallet address.
+func (s *Local) SignMessage(ctx context.Context, req SignMessageRequest) (string, error) {
+	if req.Message == "" {
+		return "", fmt.Errorf("%w: empty message", ErrPolicyViolation)
+	}
+
+	seed, err := s.ownerSeed(ctx, req.OwnerID)
+	if err != nil {
+		return "", err
+	}
+
+	var addressType string
+	switch req.Blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		decoded, err := s.sdk.BTC.DecodeAddressType(req.Address)
+		if err != nil {
+			return "", fmt.Errorf("decode address type: %w", err)
+		}
+		addressType = string(decoded)
+	case wconstants.BlockchainTypeLitecoin:
+		decoded, err := s.sdk.LTC.DecodeAddressType(req.Address)
+		if err != nil {
+			return "", fmt.Errorf("decode address type: %w", err)
+		}
+		addressType = string(decoded)
+	}
+
+	address, err := s.sdk.AddressWallet(req.Blockchain, addressType, seed.mnemonic, seed.passPhrase, req.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+	if errors.Is(err, walletsdk.ErrBlockchainUndefined) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, req.Blockchain)
+	}
+	if err != nil {
+		return "", fmt.Errorf("derive address: %w", err)
+	}
+
+	if normalizeAddress(address) != normalizeAddress(req.Address) {
+		return "", fmt.Errorf("%w: key of the sequence %d does not match the address %s", ErrPolicyViolation, req.AddressSequence, req.Address)
+	}
+
+	signature, err := s.sdk.SignMessage(req.Blockchain, req.Address, seed.mnemonic, seed.passPhrase, req.AddressSequence, req.Message, seed.derivation.Options(req.Blockchain)...)
+	if err != nil {
+		return "", fmt.Errorf("sign message: %w", err)
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,307 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/internal/store/repos"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_cold"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_processing"
+	"github.com/dv-net/dv-processing/pkg/encryption"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	btclikev2 "github.com/dv-net/dv-proto/gen/go/eproxy/btclike/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// memStore keeps owners and wallets in memory, the other repositories are not implemented
+type memStore struct {
+	store.IStore
+	owners     []*models.Owner
+	hot        []*models.HotWallet
+	processing []*models.ProcessingWallet
+	cold       []*models.ColdWallet
+}
+
+func (s *memStore) Owners(...repos.Option) repo_owners.Querier { return memOwners{s: s} }
+func (s *memStore) Wallets() repos.IWallets                    { return memWallets{s: s} }
+
+type memOwners struct {
+	repo_owners.Querier
+	s *memStore
+}
+
+func (o memOwners) GetByID(_ context.Context, id uuid.UUID) (*models.Owner, error) {
+	for _, item := range o.s.owners {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+type memWallets struct {
+	repos.IWallets
+	s *memStore
+}
+
+func (w memWallets) Hot(...repos.Option) repo_wallets_hot.ICustomQuerier { return memHot{s: w.s} }
+func (w memWallets) Processing(...repos.Option) repo_wallets_processing.ICustomQuerier {
+	return memProcessing{s: w.s}
+}
+func (w memWallets) Cold(...repos.Option) repo_wallets_cold.ICustomQuerier { return memCold{s: w.s} }
+
+type memHot struct {
+	repo_wallets_hot.ICustomQuerier
+	s *memStore
+}
+
+func (h memHot) GetByBlockchainAndAddress(_ context.Context, blockchain wconstants.BlockchainType, address string) (*models.HotWallet, error) {
+	for _, item := range h.s.hot {
+		if item.Blockchain == blockchain && item.Address == address {
+			return item, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+type memProcessing struct {
+	repo_wallets_processing.ICustomQuerier
+	s *memStore
+}
+
+func (p memProcessing) GetByBlockchainAndAddress(_ context.Context, blockchain wconstants.BlockchainType, address string) (*models.ProcessingWallet, error) {
+	for _, item := range p.s.processing {
+		if item.Blockchain == blockchain && item.Address == address {
+			return item, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+type memCold struct {
+	repo_wallets_cold.ICustomQuerier
+	s *memStore
+}
+
+func (c memCold) GetByBlockchainAndAddress(_ context.Context, blockchain wconstants.BlockchainType, address string) (*models.ColdWallet, error) {
+	for _, item := range c.s.cold {
+		if item.Blockchain == blockchain && item.Address == address {
+			return item, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+// memUTXO returns the unspent outputs by the address
+type memUTXO map[string][]*btclikev2.UTXOResponse_Item
+
+func (u memUTXO) GetUTXO(_ context.Context, _ wconstants.BlockchainType, address string) ([]*btclikev2.UTXOResponse_Item, error) {
+	items, ok := u[address]
+	if !ok {
+		return nil, errors.New("address not found")
+	}
+	return items, nil
+}
+
+func newTestLocal(st store.IStore, utxo UTXOProvider, policy config.SignerPolicy) *Local {
+	conf := new(config.Config)
+	conf.Signer.Policy = policy
+
+	return NewLocal(conf, st, nil, walletsdk.New(walletsdk.Config{}), utxo)
+}
+
+func TestVerifyInputs(t *testing.T) {
+	utxo := memUTXO{
+		"from": {
+			{TxHash: "a", Sequence: 0, Amount: "0.00005", PkScript: "0014aa"},
+			{TxHash: "a", Sequence: 1, Amount: "0.0003", PkScript: "0014aa"},
+		},
+	}
+
+	newReq := func(inputs ...UTXOInput) SignUTXOTxRequest {
+		return SignUTXOTxRequest{Blockchain: wconstants.BlockchainTypeBitcoin, Inputs: inputs}
+	}
+
+	local := newTestLocal(&memStore{}, utxo, config.SignerPolicy{})
+
+	require.NoError(t, local.verifyInputs(context.Background(), newReq(
+		UTXOInput{Address: "from", Hash: "a", Vout: 0, Amount: 5000, PkScript: "0014aa"},
+		UTXOInput{Address: "from", Hash: "a", Vout: 1, Amount: 30000, PkScript: "0014aa"},
+	), 100_000_000))
+
+	// amount is greater than the unspent output
+	err := local.verifyInputs(context.Background(), newReq(
+		UTXOInput{Address: "from", Hash: "a", Vout: 0, Amount: 5001, PkScript: "0014aa"},
+	), 100_000_000)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+
+	// output is spent or belongs to another address
+	err = local.verifyInputs(context.Background(), newReq(
+		UTXOInput{Address: "from", Hash: "a", Vout: 2, Amount: 5000, PkScript: "0014aa"},
+	), 100_000_000)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+
+	err = local.verifyInputs(context.Background(), newReq(
+		UTXOInput{Address: "from", Hash: "a", Vout: 0, Amount: 5000, PkScript: "0014bb"},
+	), 100_000_000)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+
+	err = local.verifyInputs(context.Background(), newReq(
+		UTXOInput{Address: "other", Hash: "a", Vout: 0, Amount: 5000, PkScript: "0014aa"},
+	), 100_000_000)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrPolicyViolation)
+
+	// the inputs can not be checked without the provider
+	err = newTestLocal(&memStore{}, nil, config.SignerPolicy{}).verifyInputs(context.Background(), newReq(), 100_000_000)
+	require.Error(t, err)
+}
+
+func TestCheckDestinations(t *testing.T) {
+	ownerID := uuid.New()
+	blockchain := wconstants.BlockchainTypeTron
+
+	st := &memStore{
+		hot:        []*models.HotWallet{{OwnerID: ownerID, Blockchain: blockchain, Address: "hot"}},
+		processing: []*models.ProcessingWallet{{OwnerID: ownerID, Blockchain: blockchain, Address: "processing"}},
+		cold: []*models.ColdWallet{
+			{OwnerID: ownerID, Blockchain: blockchain, Address: "cold"},
+			{OwnerID: uuid.New(), Blockchain: blockchain, Address: "other"},
+		},
+	}
+
+	dests := func(addresses ...string) []Destination {
+		res := make([]Destination, 0, len(addresses))
+		for _, address := range addresses {
+			res = append(res, Destination{Address: address})
+		}
+		return res
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		local := newTestLocal(st, nil, config.SignerPolicy{})
+		require.NoError(t, local.checkDestinations(context.Background(), ownerID, blockchain, dests("unknown")))
+	})
+
+	local := newTestLocal(st, nil, config.SignerPolicy{
+		OwnerDestinationsOnly: true,
+		AllowedDestinations:   []string{"exchange"},
+	})
+
+	t.Run("owner wallets", func(t *testing.T) {
+		require.NoError(t, local.checkDestinations(context.Background(), ownerID, blockchain, dests("hot", "processing", "cold")))
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		require.NoError(t, local.checkDestinations(context.Background(), ownerID, blockchain, dests("exchange")))
+	})
+
+	t.Run("wallet of another owner", func(t *testing.T) {
+		err := local.checkDestinations(context.Background(), ownerID, blockchain, dests("hot", "other"))
+		require.ErrorIs(t, err, ErrPolicyViolation)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		err := local.checkDestinations(context.Background(), ownerID, blockchain, dests("unknown"))
+		require.ErrorIs(t, err, ErrPolicyViolation)
+	})
+
+	t.Run("evm cold wallet in checksum form", func(t *testing.T) {
+		st.cold = append(st.cold, &models.ColdWallet{
+			OwnerID:    ownerID,
+			Blockchain: wconstants.BlockchainTypeEthereum,
+			Address:    "0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		})
+
+		err := local.checkDestinations(context.Background(), ownerID, wconstants.BlockchainTypeEthereum, dests("0x9858effd232b4033e47d90003d41ec34ecaeda94"))
+		require.NoError(t, err)
+	})
+
+	t.Run("another blockchain", func(t *testing.T) {
+		err := local.checkDestinations(context.Background(), ownerID, wconstants.BlockchainTypeEthereum, dests("hot"))
+		require.ErrorIs(t, err, ErrPolicyViolation)
+	})
+}
+
+func TestSignMessage(t *testing.T) {
+	owner := &models.Owner{ID: uuid.New()}
+
+	encrypted, err := encryption.Encrypt(testMnemonic, owner.ID.String())
+	require.NoError(t, err)
+	owner.Mnemonic = encrypted
+
+	local := newTestLocal(&memStore{owners: []*models.Owner{owner}}, nil, config.SignerPolicy{})
+
+	address, err := local.sdk.AddressWallet(wconstants.BlockchainTypeEthereum, "", testMnemonic, "", 1)
+	require.NoError(t, err)
+
+	req := SignMessageRequest{
+		OwnerID:         owner.ID,
+		Blockchain:      wconstants.BlockchainTypeEthereum,
+		Address:         address,
+		AddressSequence: 1,
+		Message:         "hello",
+	}
+
+	signature, err := local.SignMessage(context.Background(), req)
+	require.NoError(t, err)
+
+	ok, err := local.sdk.VerifyMessage(req.Blockchain, req.Address, req.Message, signature)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// key of another sequence
+	req.AddressSequence = 2
+	_, err = local.SignMessage(context.Background(), req)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+
+	req.AddressSequence = 1
+	req.Message = ""
+	_, err = local.SignMessage(context.Background(), req)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+}
+
+func TestDeriveAddresses(t *testing.T) {
+	ownerID := uuid.New()
+
+	encrypted, err := encryption.Encrypt(testMnemonic, ownerID.String())
+	require.NoError(t, err)
+
+	local := newTestLocal(&memStore{}, nil, config.SignerPolicy{})
+
+	addresses, err := local.DeriveAddresses(context.Background(), DeriveAddressesRequest{
+		OwnerID:       ownerID,
+		Blockchain:    wconstants.BlockchainTypeEthereum,
+		Mnemonic:      encrypted,
+		FirstSequence: 2,
+		Count:         2,
+	})
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+
+	for idx, address := range addresses {
+		expected, err := local.sdk.AddressWallet(wconstants.BlockchainTypeEthereum, "", testMnemonic, "", uint32(2+idx)) //nolint:gosec
+		require.NoError(t, err)
+		require.Equal(t, expected, address)
+	}
+
+	// the seed is encrypted for another owner
+	_, err = local.DeriveAddresses(context.Background(), DeriveAddressesRequest{
+		OwnerID:    uuid.New(),
+		Blockchain: wconstants.BlockchainTypeEthereum,
+		Mnemonic:   encrypted,
+		Count:      1,
+	})
+	require.Error(t, err)
+}
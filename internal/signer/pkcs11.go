@@ -0,0 +1,246 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/pkg/hsm"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/tron"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	tronaddress "github.com/fbsobreira/gotron-sdk/pkg/address"
+	"google.golang.org/protobuf/proto"
+)
+
+// PKCS11 signs the evm and tron transactions with the wallet keys stored in the HSM.
+//
+// The keys are imported to the token by ImportKeys and can not be read back. The transactions
+// of the wallets without the keys in the token and the bitcoin like transactions are signed
+// by the local signer.
+type PKCS11 struct {
+	token  *hsm.HSM
+	local  *Local
+	store  store.IStore
+	policy *Policy
+}
+
+var _ Signer = (*PKCS11)(nil)
+
+func NewPKCS11(conf *config.Config, st store.IStore, secretsSvc *secrets.Service, sdk *walletsdk.SDK, utxo UTXOProvider) (*PKCS11, error) {
+	token, err := hsm.Open(hsm.Config{
+		ModulePath: conf.Signer.PKCS11.ModulePath,
+		TokenLabel: conf.Signer.PKCS11.TokenLabel,
+		Pin:        conf.Signer.PKCS11.Pin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open hsm: %w", err)
+	}
+
+	return &PKCS11{
+		token:  token,
+		local:  NewLocal(conf, st, secretsSvc, sdk, utxo),
+		store:  st,
+		policy: NewPolicy(conf.Signer.Policy),
+	}, nil
+}
+
+// Close closes the HSM session.
+func (s *PKCS11) Close() error { return s.token.Close() }
+
+// SignMessage signs the message with the local signer, the messages are signed rarely
+// and the token keys are used only for the transactions.
+func (s *PKCS11) SignMessage(ctx context.Context, req SignMessageRequest) (string, error) {
+	return s.local.SignMessage(ctx, req)
+}
+
+// DeriveAddresses derives the addresses with the local signer, the token keys can not derive them.
+func (s *PKCS11) DeriveAddresses(ctx context.Context, req DeriveAddressesRequest) ([]string, error) {
+	return s.local.DeriveAddresses(ctx, req)
+}
+
+// EVMKeyLabel returns the token label of the evm wallet key, the address is the same in all evm blockchains.
+func EVMKeyLabel(address string) string { return "evm:" + strings.ToLower(address) }
+
+// TronKeyLabel returns the token label of the tron wallet key.
+func TronKeyLabel(address string) string { return "tron:" + address }
+
+// SignUTXOTx signs the transaction with the local signer, the secp256k1 keys of the
+// bitcoin like wallets are not stored in the token.
+func (s *PKCS11) SignUTXOTx(ctx context.Context, req SignUTXOTxRequest) ([]byte, error) {
+	return s.local.SignUTXOTx(ctx, req)
+}
+
+// SignEVMTx signs the transaction with the key of the wallet address stored in the token.
+func (s *PKCS11) SignEVMTx(ctx context.Context, req SignEVMTxRequest) (*types.Transaction, error) {
+	if !req.Blockchain.IsEVM() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, req.Blockchain)
+	}
+
+	dest, err := s.policy.CheckEVMTx(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.local.checkDestinations(ctx, req.OwnerID, req.Blockchain, []Destination{*dest}); err != nil {
+		return nil, err
+	}
+
+	label := EVMKeyLabel(req.Address)
+
+	pub, err := s.token.PublicKey(label)
+	if errors.Is(err, hsm.ErrKeyNotFound) {
+		return s.local.SignEVMTx(ctx, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get public key: %w", err)
+	}
+
+	if !strings.EqualFold(crypto.PubkeyToAddress(*pub).Hex(), req.Address) {
+		return nil, fmt.Errorf("%w: token key %s does not match the address %s", ErrPolicyViolation, label, req.Address)
+	}
+
+	txSigner := types.LatestSignerForChainID(req.Tx.ChainId())
+
+	signature, err := s.token.SignSecp256k1(label, txSigner.Hash(req.Tx).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	signedTx, err := req.Tx.WithSignature(txSigner, signature)
+	if err != nil {
+		return nil, fmt.Errorf("set signature: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTronTx signs the transaction raw data with the key of the wallet address stored in the token.
+func (s *PKCS11) SignTronTx(ctx context.Context, req SignTronTxRequest) ([]byte, error) {
+	dests, err := s.policy.CheckTronTx(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.local.checkDestinations(ctx, req.OwnerID, wconstants.BlockchainTypeTron, dests); err != nil {
+		return nil, err
+	}
+
+	label := TronKeyLabel(req.Address)
+
+	pub, err := s.token.PublicKey(label)
+	if errors.Is(err, hsm.ErrKeyNotFound) {
+		return s.local.SignTronTx(ctx, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get public key: %w", err)
+	}
+
+	if tronaddress.PubkeyToAddress(*pub).String() != req.Address {
+		return nil, fmt.Errorf("%w: token key %s does not match the address %s", ErrPolicyViolation, label, req.Address)
+	}
+
+	rawData, err := proto.Marshal(req.Tx.GetRawData())
+	if err != nil {
+		return nil, fmt.Errorf("marshal raw data: %w", err)
+	}
+
+	hash := sha256.Sum256(rawData)
+
+	signature, err := s.token.SignSecp256k1(label, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	return signature, nil
+}
+
+type ImportKeysResult struct {
+	Imported int
+	Skipped  int
+}
+
+// ImportKeys derives the keys of the evm and tron processing wallets of all owners
+// and imports them to the token. The keys which are already in the token are skipped.
+func (s *PKCS11) ImportKeys(ctx context.Context) (*ImportKeysResult, error) {
+	owners, err := s.store.Owners().GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get owners: %w", err)
+	}
+
+	res := new(ImportKeysResult)
+	for _, owner := range owners {
+		wallets, err := s.store.Wallets().Processing().GetAllByOwnerID(ctx, owner.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get processing wallets of owner %s: %w", owner.ID, err)
+		}
+
+		var seed *ownerSeed
+		for _, wallet := range wallets {
+			if !wallet.Blockchain.IsEVM() && wallet.Blockchain != wconstants.BlockchainTypeTron {
+				continue
+			}
+
+			if seed == nil {
+				seed, err = s.local.ownerSeed(ctx, owner.ID)
+				if err != nil {
+					return nil, fmt.Errorf("owner %s: %w", owner.ID, err)
+				}
+			}
+
+			label, privateKey, err := deriveWalletKey(seed, wallet.Blockchain, wallet.Address, uint32(wallet.Sequence)) //nolint:gosec
+			if err != nil {
+				return nil, fmt.Errorf("owner %s: %w", owner.ID, err)
+			}
+
+			err = s.token.ImportSecp256k1Key(label, privateKey)
+			if errors.Is(err, hsm.ErrKeyExists) {
+				res.Skipped++
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("import key %s: %w", label, err)
+			}
+
+			res.Imported++
+		}
+	}
+
+	return res, nil
+}
+
+// deriveWalletKey returns the token label and the private key of the wallet address.
+func deriveWalletKey(seed *ownerSeed, blockchain wconstants.BlockchainType, address string, sequence uint32) (string, *ecdsa.PrivateKey, error) {
+	if blockchain == wconstants.BlockchainTypeTron {
+		derived, privateKey, _, err := tron.WalletPubKeyHash(seed.mnemonic, seed.passPhrase, sequence, seed.derivation.Options(blockchain)...)
+		if err != nil {
+			return "", nil, fmt.Errorf("derive key: %w", err)
+		}
+
+		if derived != address {
+			return "", nil, fmt.Errorf("key of the sequence %d does not match the address %s", sequence, address)
+		}
+
+		return TronKeyLabel(address), privateKey, nil
+	}
+
+	derived, privateKey, _, err := evm.WalletPubKeyHash(seed.mnemonic, seed.passPhrase, sequence, seed.derivation.Options(blockchain)...)
+	if err != nil {
+		return "", nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	if !strings.EqualFold(derived, address) {
+		return "", nil, fmt.Errorf("key of the sequence %d does not match the address %s", sequence, address)
+	}
+
+	return EVMKeyLabel(address), privateKey, nil
+}
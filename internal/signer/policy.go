@@ -0,0 +1,344 @@
+package signer
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// tronContractTypes are the contracts created by the processing
+var tronContractTypes = map[core.Transaction_Contract_ContractType]struct{}{
+	core.Transaction_Contract_TransferContract:           {},
+	core.Transaction_Contract_TriggerSmartContract:       {},
+	core.Transaction_Contract_AccountCreateContract:      {},
+	core.Transaction_Contract_DelegateResourceContract:   {},
+	core.Transaction_Contract_UnDelegateResourceContract: {},
+}
+
+// tokenTransferSelector is the method id of transfer(address,uint256) of the erc20 and trc20 tokens
+var tokenTransferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// tokenTransferDataLength is the length of the transfer call data: the method id, the address and the amount
+const tokenTransferDataLength = 4 + 32 + 32
+
+// Destination is the address which receives the funds or the resources of the transaction.
+type Destination struct {
+	Address string
+	// Contract is the token contract address, it is empty for the native asset
+	Contract string
+	// Amount in the smallest units of the asset
+	Amount *big.Int
+}
+
+// Policy checks the transactions before they are signed.
+type Policy struct {
+	maxEVMGasFeeCap *big.Int
+	maxUTXOFee      int64
+	maxUTXOAmount   *big.Int
+	maxEVMValue     *big.Int
+	maxTronAmount   *big.Int
+	maxTokenAmounts map[string]*big.Int
+
+	ownerDestinationsOnly bool
+	allowedDestinations   map[string]struct{}
+}
+
+func NewPolicy(conf config.SignerPolicy) *Policy {
+	p := &Policy{
+		maxUTXOFee:            conf.MaxUTXOFee,
+		maxTokenAmounts:       make(map[string]*big.Int, len(conf.MaxTokenAmounts)),
+		ownerDestinationsOnly: conf.OwnerDestinationsOnly,
+		allowedDestinations:   make(map[string]struct{}, len(conf.AllowedDestinations)),
+	}
+
+	if conf.MaxEVMGasFeeCapGwei > 0 {
+		p.maxEVMGasFeeCap = new(big.Int).Mul(new(big.Int).SetUint64(conf.MaxEVMGasFeeCapGwei), big.NewInt(params.GWei))
+	}
+
+	if conf.MaxUTXOAmount > 0 {
+		p.maxUTXOAmount = big.NewInt(conf.MaxUTXOAmount)
+	}
+
+	if conf.MaxEVMValueGwei > 0 {
+		p.maxEVMValue = new(big.Int).Mul(new(big.Int).SetUint64(conf.MaxEVMValueGwei), big.NewInt(params.GWei))
+	}
+
+	if conf.MaxTronAmount > 0 {
+		p.maxTronAmount = big.NewInt(conf.MaxTronAmount)
+	}
+
+	// the amounts are checked by the config validation
+	for contract, amount := range conf.MaxTokenAmounts {
+		if value, ok := new(big.Int).SetString(amount, 10); ok {
+			p.maxTokenAmounts[normalizeAddress(contract)] = value
+		}
+	}
+
+	for _, address := range conf.AllowedDestinations {
+		p.allowedDestinations[normalizeAddress(address)] = struct{}{}
+	}
+
+	return p
+}
+
+// normalizeAddress returns the address in the case used by the processing wallets, the hex addresses are lower case.
+func normalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+
+	return address
+}
+
+// OwnerDestinationsOnly reports whether the destinations must be the owner wallets or the allowed addresses.
+func (p *Policy) OwnerDestinationsOnly() bool { return p.ownerDestinationsOnly }
+
+// IsAllowedDestination reports whether the address may receive funds from any owner wallet.
+func (p *Policy) IsAllowedDestination(address string) bool {
+	_, ok := p.allowedDestinations[normalizeAddress(address)]
+	return ok
+}
+
+// checkAmount checks the amount of the destination with the limit of the token or of the native asset.
+func (p *Policy) checkAmount(dest Destination, nativeLimit *big.Int) error {
+	limit := nativeLimit
+	if dest.Contract != "" {
+		limit = p.maxTokenAmounts[normalizeAddress(dest.Contract)]
+	}
+
+	if limit != nil && dest.Amount != nil && dest.Amount.Cmp(limit) > 0 {
+		return fmt.Errorf("%w: amount %s to %s exceeds the limit %s", ErrPolicyViolation, dest.Amount, dest.Address, limit)
+	}
+
+	return nil
+}
+
+// decodeTokenTransfer returns the receiver and the amount of the token transfer call data.
+func decodeTokenTransfer(data []byte) ([]byte, *big.Int, error) {
+	if len(data) != tokenTransferDataLength || !bytes.Equal(data[:4], tokenTransferSelector) {
+		return nil, nil, fmt.Errorf("%w: unsupported contract call", ErrPolicyViolation)
+	}
+
+	// the address is the last 20 bytes of the first argument
+	return data[16:36], new(big.Int).SetBytes(data[36:]), nil
+}
+
+// UTXODestinations returns the outputs which do not pay the change back to the input addresses.
+func UTXODestinations(req SignUTXOTxRequest, outputs []Destination) []Destination {
+	inputAddresses := make(map[string]struct{}, len(req.Inputs))
+	for _, input := range req.Inputs {
+		inputAddresses[input.Address] = struct{}{}
+	}
+
+	res := make([]Destination, 0, len(outputs))
+	for _, output := range outputs {
+		if _, ok := inputAddresses[output.Address]; !ok {
+			res = append(res, output)
+		}
+	}
+
+	return res
+}
+
+// CheckUTXOTx checks the inputs, the fee and the amount sent to the other addresses.
+// The input amounts must be checked with the unspent outputs before.
+func (p *Policy) CheckUTXOTx(req SignUTXOTxRequest, txInputs int, outputs []Destination) error {
+	if len(req.Inputs) == 0 || len(req.Inputs) != txInputs {
+		return fmt.Errorf("%w: transaction has %d inputs, got %d", ErrPolicyViolation, txInputs, len(req.Inputs))
+	}
+
+	var inputsAmount int64
+	for _, input := range req.Inputs {
+		if input.Amount <= 0 {
+			return fmt.Errorf("%w: input %s:%d has no amount", ErrPolicyViolation, input.Hash, input.Vout)
+		}
+
+		inputsAmount += input.Amount
+	}
+
+	var outputsAmount int64
+	for _, output := range outputs {
+		outputsAmount += output.Amount.Int64()
+	}
+
+	fee := inputsAmount - outputsAmount
+	if fee < 0 {
+		return fmt.Errorf("%w: outputs amount %d exceeds inputs amount %d", ErrPolicyViolation, outputsAmount, inputsAmount)
+	}
+
+	if p.maxUTXOFee > 0 && fee > p.maxUTXOFee {
+		return fmt.Errorf("%w: fee %d exceeds the limit %d", ErrPolicyViolation, fee, p.maxUTXOFee)
+	}
+
+	if p.maxUTXOAmount != nil {
+		amount := new(big.Int)
+		for _, dest := range UTXODestinations(req, outputs) {
+			amount.Add(amount, dest.Amount)
+		}
+
+		if amount.Cmp(p.maxUTXOAmount) > 0 {
+			return fmt.Errorf("%w: amount %s exceeds the limit %s", ErrPolicyViolation, amount, p.maxUTXOAmount)
+		}
+	}
+
+	return nil
+}
+
+// CheckEVMTx checks the transaction type, the chain id, the max fee per gas and the amount.
+// It returns the receiver of the native asset or of the erc20 token transfer.
+func (p *Policy) CheckEVMTx(req SignEVMTxRequest) (*Destination, error) {
+	if req.Tx == nil {
+		return nil, fmt.Errorf("%w: empty transaction", ErrPolicyViolation)
+	}
+
+	if req.Tx.Type() != types.DynamicFeeTxType {
+		return nil, fmt.Errorf("%w: unsupported transaction type %d", ErrPolicyViolation, req.Tx.Type())
+	}
+
+	if req.Tx.ChainId() == nil || req.Tx.ChainId().Sign() <= 0 {
+		return nil, fmt.Errorf("%w: transaction has no chain id", ErrPolicyViolation)
+	}
+
+	if p.maxEVMGasFeeCap != nil && req.Tx.GasFeeCap().Cmp(p.maxEVMGasFeeCap) > 0 {
+		return nil, fmt.Errorf("%w: max fee per gas %s exceeds the limit %s", ErrPolicyViolation, req.Tx.GasFeeCap(), p.maxEVMGasFeeCap)
+	}
+
+	dest, err := evmDestination(req.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkAmount(*dest, p.maxEVMValue); err != nil {
+		return nil, err
+	}
+
+	return dest, nil
+}
+
+// evmDestination returns the receiver of the transaction, the processing sends only the native asset and erc20 tokens.
+func evmDestination(tx *types.Transaction) (*Destination, error) {
+	if tx.To() == nil {
+		return nil, fmt.Errorf("%w: contract creation is not allowed", ErrPolicyViolation)
+	}
+
+	if len(tx.Data()) == 0 {
+		return &Destination{
+			Address: strings.ToLower(tx.To().Hex()),
+			Amount:  tx.Value(),
+		}, nil
+	}
+
+	to, amount, err := decodeTokenTransfer(tx.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Value().Sign() != 0 {
+		return nil, fmt.Errorf("%w: token transfer with the native amount", ErrPolicyViolation)
+	}
+
+	return &Destination{
+		Address:  strings.ToLower(common.BytesToAddress(to).Hex()),
+		Contract: strings.ToLower(tx.To().Hex()),
+		Amount:   amount,
+	}, nil
+}
+
+// CheckTronTx checks that the transaction has one known contract owned by the wallet address and the amount.
+// It returns the receivers of the funds or the resources.
+func (p *Policy) CheckTronTx(req SignTronTxRequest) ([]Destination, error) {
+	if req.Tx.GetRawData() == nil {
+		return nil, fmt.Errorf("%w: empty transaction", ErrPolicyViolation)
+	}
+
+	contracts := req.Tx.GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("%w: transaction must have one contract, got %d", ErrPolicyViolation, len(contracts))
+	}
+
+	contract := contracts[0]
+	if _, ok := tronContractTypes[contract.GetType()]; !ok {
+		return nil, fmt.Errorf("%w: unsupported contract type %s", ErrPolicyViolation, contract.GetType())
+	}
+
+	param, err := contract.GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unmarshal contract parameter: %w", ErrPolicyViolation, err)
+	}
+
+	owned, ok := param.(interface{ GetOwnerAddress() []byte })
+	if !ok {
+		return nil, fmt.Errorf("%w: contract %s has no owner address", ErrPolicyViolation, contract.GetType())
+	}
+
+	ownerAddress, err := address.Base58ToAddress(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid address %s: %w", ErrPolicyViolation, req.Address, err)
+	}
+
+	if !bytes.Equal(owned.GetOwnerAddress(), ownerAddress.Bytes()) {
+		return nil, fmt.Errorf("%w: contract is not owned by the address %s", ErrPolicyViolation, req.Address)
+	}
+
+	dests, err := tronDestinations(param)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dest := range dests {
+		if err := p.checkAmount(dest, p.maxTronAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	return dests, nil
+}
+
+// tronDestinations returns the receivers of the contract created by the processing.
+func tronDestinations(param proto.Message) ([]Destination, error) {
+	switch param := param.(type) {
+	case *core.TransferContract:
+		return []Destination{{
+			Address: address.Address(param.GetToAddress()).String(),
+			Amount:  big.NewInt(param.GetAmount()),
+		}}, nil
+	case *core.TriggerSmartContract:
+		if param.GetCallValue() != 0 || param.GetCallTokenValue() != 0 {
+			return nil, fmt.Errorf("%w: token transfer with the native amount", ErrPolicyViolation)
+		}
+
+		to, amount, err := decodeTokenTransfer(param.GetData())
+		if err != nil {
+			return nil, err
+		}
+
+		// the tron address is the evm address with the network prefix
+		return []Destination{{
+			Address:  address.Address(append([]byte{address.TronBytePrefix}, to...)).String(),
+			Contract: address.Address(param.GetContractAddress()).String(),
+			Amount:   amount,
+		}}, nil
+	case *core.AccountCreateContract:
+		return []Destination{{
+			Address: address.Address(param.GetAccountAddress()).String(),
+			Amount:  new(big.Int),
+		}}, nil
+	case *core.DelegateResourceContract:
+		// the delegated resources are not sent, so the amount is not limited
+		return []Destination{{
+			Address: address.Address(param.GetReceiverAddress()).String(),
+		}}, nil
+	default:
+		// the undelegated resources return to the owner
+		return []Destination{}, nil
+	}
+}
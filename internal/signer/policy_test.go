@@ -0,0 +1,331 @@
+package signer_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestCheckUTXOTx(t *testing.T) {
+	policy := signer.NewPolicy(config.SignerPolicy{MaxUTXOFee: 1000, MaxUTXOAmount: 6000})
+
+	req := signer.SignUTXOTxRequest{
+		Inputs: []signer.UTXOInput{
+			{Address: "from", Hash: "a", Vout: 0, Amount: 5000},
+			{Address: "from", Hash: "b", Vout: 1, Amount: 3000},
+		},
+	}
+
+	outputs := func(amount, change int64) []signer.Destination {
+		return []signer.Destination{
+			{Address: "to", Amount: big.NewInt(amount)},
+			{Address: "from", Amount: big.NewInt(change)},
+		}
+	}
+
+	require.NoError(t, policy.CheckUTXOTx(req, 2, outputs(5000, 2500)))
+	require.NoError(t, policy.CheckUTXOTx(req, 2, outputs(6000, 2000)))
+
+	// fee is greater than the limit
+	require.ErrorIs(t, policy.CheckUTXOTx(req, 2, outputs(5000, 1000)), signer.ErrPolicyViolation)
+
+	// outputs are greater than inputs
+	require.ErrorIs(t, policy.CheckUTXOTx(req, 2, outputs(6000, 2001)), signer.ErrPolicyViolation)
+
+	// amount sent to the other addresses is greater than the limit, the change is not counted
+	require.ErrorIs(t, policy.CheckUTXOTx(req, 2, outputs(6001, 1000)), signer.ErrPolicyViolation)
+
+	// inputs do not match the transaction
+	require.ErrorIs(t, policy.CheckUTXOTx(req, 3, outputs(5000, 2500)), signer.ErrPolicyViolation)
+
+	// input without amount
+	req.Inputs[1].Amount = 0
+	require.ErrorIs(t, policy.CheckUTXOTx(req, 2, outputs(4000, 500)), signer.ErrPolicyViolation)
+
+	// the limits are disabled
+	require.NoError(t, signer.NewPolicy(config.SignerPolicy{}).CheckUTXOTx(signer.SignUTXOTxRequest{
+		Inputs: []signer.UTXOInput{{Hash: "a", Amount: 5000}},
+	}, 1, nil))
+}
+
+func TestUTXODestinations(t *testing.T) {
+	req := signer.SignUTXOTxRequest{
+		Inputs: []signer.UTXOInput{{Address: "a"}, {Address: "b"}, {Address: "a"}},
+	}
+
+	outputs := []signer.Destination{
+		{Address: "c", Amount: big.NewInt(1)},
+		{Address: "a", Amount: big.NewInt(2)},
+		{Address: "d", Amount: big.NewInt(3)},
+	}
+
+	require.Equal(t, []signer.Destination{outputs[0], outputs[2]}, signer.UTXODestinations(req, outputs))
+}
+
+func TestCheckEVMTx(t *testing.T) {
+	policy := signer.NewPolicy(config.SignerPolicy{MaxEVMGasFeeCapGwei: 100})
+
+	newTx := func(chainID int64, gasFeeCapGwei int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(chainID),
+			GasFeeCap: new(big.Int).Mul(big.NewInt(gasFeeCapGwei), big.NewInt(params.GWei)),
+			GasTipCap: big.NewInt(params.GWei),
+			Gas:       21000,
+			To:        &common.Address{},
+			Value:     big.NewInt(1),
+		})
+	}
+
+	checkEVMTx := func(req signer.SignEVMTxRequest) error {
+		_, err := policy.CheckEVMTx(req)
+		return err
+	}
+
+	require.NoError(t, checkEVMTx(signer.SignEVMTxRequest{Tx: newTx(1, 100)}))
+	require.ErrorIs(t, checkEVMTx(signer.SignEVMTxRequest{Tx: newTx(1, 101)}), signer.ErrPolicyViolation)
+	require.ErrorIs(t, checkEVMTx(signer.SignEVMTxRequest{Tx: newTx(0, 10)}), signer.ErrPolicyViolation)
+	require.ErrorIs(t, checkEVMTx(signer.SignEVMTxRequest{}), signer.ErrPolicyViolation)
+
+	legacyTx := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1), Gas: 21000, To: &common.Address{}})
+	require.ErrorIs(t, checkEVMTx(signer.SignEVMTxRequest{Tx: legacyTx}), signer.ErrPolicyViolation)
+}
+
+func TestCheckEVMTxDestination(t *testing.T) {
+	token := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	receiver := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+
+	policy := signer.NewPolicy(config.SignerPolicy{
+		MaxEVMValueGwei: 1,
+		MaxTokenAmounts: map[string]string{token.Hex(): "1000"},
+	})
+
+	newTx := func(to *common.Address, value int64, data []byte) signer.SignEVMTxRequest {
+		return signer.SignEVMTxRequest{Tx: types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			GasFeeCap: big.NewInt(params.GWei),
+			Gas:       60000,
+			To:        to,
+			Value:     big.NewInt(value),
+			Data:      data,
+		})}
+	}
+
+	transferData := func(amount int64) []byte {
+		data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+		data = append(data, common.LeftPadBytes(receiver.Bytes(), 32)...)
+		return append(data, common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)...)
+	}
+
+	t.Run("native", func(t *testing.T) {
+		dest, err := policy.CheckEVMTx(newTx(&receiver, params.GWei, nil))
+		require.NoError(t, err)
+		require.Equal(t, strings.ToLower(receiver.Hex()), dest.Address)
+		require.Empty(t, dest.Contract)
+		require.Equal(t, big.NewInt(params.GWei), dest.Amount)
+
+		_, err = policy.CheckEVMTx(newTx(&receiver, params.GWei+1, nil))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		dest, err := policy.CheckEVMTx(newTx(&token, 0, transferData(1000)))
+		require.NoError(t, err)
+		require.Equal(t, strings.ToLower(receiver.Hex()), dest.Address)
+		require.Equal(t, strings.ToLower(token.Hex()), dest.Contract)
+		require.Equal(t, big.NewInt(1000), dest.Amount)
+
+		_, err = policy.CheckEVMTx(newTx(&token, 0, transferData(1001)))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+
+		// the tokens without the limit
+		_, err = policy.CheckEVMTx(newTx(&receiver, 0, transferData(1001)))
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := policy.CheckEVMTx(newTx(&token, 1, transferData(1)))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+
+		// approve(address,uint256)
+		data := transferData(1)
+		copy(data, []byte{0x09, 0x5e, 0xa7, 0xb3})
+		_, err = policy.CheckEVMTx(newTx(&token, 0, data))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+
+		_, err = policy.CheckEVMTx(newTx(nil, 0, []byte{0x60, 0x80}))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+	})
+}
+
+func TestCheckTronTx(t *testing.T) {
+	policy := signer.NewPolicy(config.SignerPolicy{})
+
+	owner := "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	ownerAddress, err := address.Base58ToAddress(owner)
+	require.NoError(t, err)
+
+	newTx := func(contractType core.Transaction_Contract_ContractType, ownerAddress []byte) *core.Transaction {
+		param, err := anypb.New(&core.TransferContract{
+			OwnerAddress: ownerAddress,
+			ToAddress:    ownerAddress,
+			Amount:       1,
+		})
+		require.NoError(t, err)
+
+		return &core.Transaction{
+			RawData: &core.TransactionRaw{
+				Contract: []*core.Transaction_Contract{{Type: contractType, Parameter: param}},
+			},
+		}
+	}
+
+	checkTronTx := func(req signer.SignTronTxRequest) error {
+		_, err := policy.CheckTronTx(req)
+		return err
+	}
+
+	require.NoError(t, checkTronTx(signer.SignTronTxRequest{
+		Address: owner,
+		Tx:      newTx(core.Transaction_Contract_TransferContract, ownerAddress.Bytes()),
+	}))
+
+	// contract owned by another address
+	require.ErrorIs(t, checkTronTx(signer.SignTronTxRequest{
+		Address: owner,
+		Tx:      newTx(core.Transaction_Contract_TransferContract, make([]byte, 21)),
+	}), signer.ErrPolicyViolation)
+
+	// contract which is not created by the processing
+	require.ErrorIs(t, checkTronTx(signer.SignTronTxRequest{
+		Address: owner,
+		Tx:      newTx(core.Transaction_Contract_AccountPermissionUpdateContract, ownerAddress.Bytes()),
+	}), signer.ErrPolicyViolation)
+
+	require.ErrorIs(t, checkTronTx(signer.SignTronTxRequest{Address: owner, Tx: &core.Transaction{}}), signer.ErrPolicyViolation)
+}
+
+func TestCheckTronTxDestination(t *testing.T) {
+	owner := "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	ownerAddress, err := address.Base58ToAddress(owner)
+	require.NoError(t, err)
+
+	receiver := "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"
+	receiverAddress, err := address.Base58ToAddress(receiver)
+	require.NoError(t, err)
+
+	token := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	tokenAddress, err := address.Base58ToAddress(token)
+	require.NoError(t, err)
+
+	policy := signer.NewPolicy(config.SignerPolicy{
+		MaxTronAmount:   100,
+		MaxTokenAmounts: map[string]string{token: "1000"},
+	})
+
+	newTx := func(t *testing.T, contractType core.Transaction_Contract_ContractType, param proto.Message) signer.SignTronTxRequest {
+		t.Helper()
+
+		value, err := anypb.New(param)
+		require.NoError(t, err)
+
+		return signer.SignTronTxRequest{
+			Address: owner,
+			Tx: &core.Transaction{
+				RawData: &core.TransactionRaw{
+					Contract: []*core.Transaction_Contract{{Type: contractType, Parameter: value}},
+				},
+			},
+		}
+	}
+
+	transferData := func(amount int64) []byte {
+		data := []byte{0xa9, 0x05, 0x9c, 0xbb}
+		data = append(data, make([]byte, 12)...)
+		data = append(data, receiverAddress.Bytes()[1:]...)
+		return append(data, common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)...)
+	}
+
+	t.Run("trx", func(t *testing.T) {
+		dests, err := policy.CheckTronTx(newTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: ownerAddress.Bytes(),
+			ToAddress:    receiverAddress.Bytes(),
+			Amount:       100,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []signer.Destination{{Address: receiver, Amount: big.NewInt(100)}}, dests)
+
+		_, err = policy.CheckTronTx(newTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: ownerAddress.Bytes(),
+			ToAddress:    receiverAddress.Bytes(),
+			Amount:       101,
+		}))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		dests, err := policy.CheckTronTx(newTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+			OwnerAddress:    ownerAddress.Bytes(),
+			ContractAddress: tokenAddress.Bytes(),
+			Data:            transferData(1000),
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []signer.Destination{{Address: receiver, Contract: token, Amount: big.NewInt(1000)}}, dests)
+
+		_, err = policy.CheckTronTx(newTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+			OwnerAddress:    ownerAddress.Bytes(),
+			ContractAddress: tokenAddress.Bytes(),
+			Data:            transferData(1001),
+		}))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+
+		_, err = policy.CheckTronTx(newTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+			OwnerAddress:    ownerAddress.Bytes(),
+			ContractAddress: tokenAddress.Bytes(),
+			Data:            transferData(1),
+			CallValue:       1,
+		}))
+		require.ErrorIs(t, err, signer.ErrPolicyViolation)
+	})
+
+	t.Run("resources", func(t *testing.T) {
+		dests, err := policy.CheckTronTx(newTx(t, core.Transaction_Contract_DelegateResourceContract, &core.DelegateResourceContract{
+			OwnerAddress:    ownerAddress.Bytes(),
+			ReceiverAddress: receiverAddress.Bytes(),
+			Balance:         1_000_000,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []signer.Destination{{Address: receiver}}, dests)
+
+		dests, err = policy.CheckTronTx(newTx(t, core.Transaction_Contract_UnDelegateResourceContract, &core.UnDelegateResourceContract{
+			OwnerAddress:    ownerAddress.Bytes(),
+			ReceiverAddress: receiverAddress.Bytes(),
+			Balance:         1_000_000,
+		}))
+		require.NoError(t, err)
+		require.Empty(t, dests)
+	})
+}
+
+func TestIsAllowedDestination(t *testing.T) {
+	policy := signer.NewPolicy(config.SignerPolicy{
+		AllowedDestinations: []string{"0x9858EfFD232B4033E47d90003D41EC34EcaEda94", "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"},
+	})
+
+	require.True(t, policy.IsAllowedDestination("0x9858effd232b4033e47d90003d41ec34ecaeda94"))
+	require.True(t, policy.IsAllowedDestination("TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"))
+
+	// base58 addresses are case sensitive
+	require.False(t, policy.IsAllowedDestination("tla2f6vpqdgre67v1736s7bj8ray5wyju7"))
+	require.False(t, policy.IsAllowedDestination("TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"))
+}
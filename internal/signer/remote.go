@@ -0,0 +1,267 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"connectrpc.com/connect"
+	signerv1 "github.com/dv-net/dv-processing/api/processing/signer/v1"
+	"github.com/dv-net/dv-processing/api/processing/signer/v1/signerv1connect"
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/proto"
+)
+
+// unixSocketBaseURL is the base url of the requests sent over the unix socket
+const unixSocketBaseURL = "http://signer"
+
+// Remote sends the transactions to the signer process.
+type Remote struct {
+	client signerv1connect.SignerServiceClient
+}
+
+var (
+	_ Signer         = (*Remote)(nil)
+	_ secrets.Sealer = (*Remote)(nil)
+)
+
+func NewRemote(conf config.SignerRemote) (*Remote, error) {
+	transport := &http2.Transport{}
+	baseURL := conf.Address
+
+	if config.IsUnixSocket(conf.Address) {
+		socketPath := config.UnixSocketPath(conf.Address)
+		transport.AllowHTTP = true
+		transport.DialTLSContext = func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		}
+		baseURL = unixSocketBaseURL
+	} else {
+		tlsConfig, err := clientTLSConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   conf.Timeout,
+	}
+
+	return &Remote{
+		client: signerv1connect.NewSignerServiceClient(httpClient, baseURL, connect.WithGRPC()),
+	}, nil
+}
+
+func clientTLSConfig(conf config.SignerRemote) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+
+	caPool, err := loadCertPool(conf.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+		ServerName:   conf.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ca file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// remoteError restores the signer errors from the response codes
+func remoteError(err error) error {
+	switch connect.CodeOf(err) {
+	case connect.CodePermissionDenied:
+		return fmt.Errorf("%w: %s", ErrPolicyViolation, connectErrorMessage(err))
+	case connect.CodeUnimplemented:
+		return fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, connectErrorMessage(err))
+	default:
+		return fmt.Errorf("remote signer: %w", err)
+	}
+}
+
+func connectErrorMessage(err error) string {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr.Message()
+	}
+
+	return err.Error()
+}
+
+// SignUTXOTx sends the transaction to the signer process.
+func (s *Remote) SignUTXOTx(ctx context.Context, req SignUTXOTxRequest) ([]byte, error) {
+	inputs := make([]*signerv1.UTXOInput, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		inputs = append(inputs, &signerv1.UTXOInput{
+			Address:         input.Address,
+			AddressSequence: input.AddressSequence,
+			PkScript:        input.PkScript,
+			Hash:            input.Hash,
+			Vout:            input.Vout,
+			Amount:          input.Amount,
+		})
+	}
+
+	resp, err := s.client.SignUTXOTransaction(ctx, connect.NewRequest(&signerv1.SignUTXOTransactionRequest{
+		OwnerId:    req.OwnerID.String(),
+		Blockchain: models.ConvertBlockchainTypeToPb(req.Blockchain),
+		UnsignedTx: req.UnsignedTx,
+		Inputs:     inputs,
+	}))
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	return resp.Msg.GetSignedTx(), nil
+}
+
+// SignEVMTx sends the transaction to the signer process.
+func (s *Remote) SignEVMTx(ctx context.Context, req SignEVMTxRequest) (*types.Transaction, error) {
+	unsignedTx, err := req.Tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal transaction: %w", err)
+	}
+
+	resp, err := s.client.SignEVMTransaction(ctx, connect.NewRequest(&signerv1.SignEVMTransactionRequest{
+		OwnerId:         req.OwnerID.String(),
+		Blockchain:      models.ConvertBlockchainTypeToPb(req.Blockchain),
+		Address:         req.Address,
+		AddressSequence: req.AddressSequence,
+		UnsignedTx:      unsignedTx,
+	}))
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(resp.Msg.GetSignedTx()); err != nil {
+		return nil, fmt.Errorf("unmarshal signed transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SignTronTx sends the transaction raw data to the signer process.
+func (s *Remote) SignTronTx(ctx context.Context, req SignTronTxRequest) ([]byte, error) {
+	rawData, err := proto.Marshal(req.Tx.GetRawData())
+	if err != nil {
+		return nil, fmt.Errorf("marshal raw data: %w", err)
+	}
+
+	resp, err := s.client.SignTronTransaction(ctx, connect.NewRequest(&signerv1.SignTronTransactionRequest{
+		OwnerId:         req.OwnerID.String(),
+		Address:         req.Address,
+		AddressSequence: req.AddressSequence,
+		RawData:         rawData,
+	}))
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	return resp.Msg.GetSignature(), nil
+}
+
+// SignMessage sends the message to the signer process.
+func (s *Remote) SignMessage(ctx context.Context, req SignMessageRequest) (string, error) {
+	resp, err := s.client.SignMessage(ctx, connect.NewRequest(&signerv1.SignMessageRequest{
+		OwnerId:         req.OwnerID.String(),
+		Blockchain:      models.ConvertBlockchainTypeToPb(req.Blockchain),
+		Address:         req.Address,
+		AddressSequence: req.AddressSequence,
+		Message:         req.Message,
+	}))
+	if err != nil {
+		return "", remoteError(err)
+	}
+
+	return resp.Msg.GetSignature(), nil
+}
+
+// DeriveAddresses sends the encrypted seed to the signer process.
+func (s *Remote) DeriveAddresses(ctx context.Context, req DeriveAddressesRequest) ([]string, error) {
+	derivationConfig, err := json.Marshal(req.Derivation)
+	if err != nil {
+		return nil, fmt.Errorf("marshal derivation: %w", err)
+	}
+
+	resp, err := s.client.DeriveAddresses(ctx, connect.NewRequest(&signerv1.DeriveAddressesRequest{
+		OwnerId:       req.OwnerID.String(),
+		Blockchain:    models.ConvertBlockchainTypeToPb(req.Blockchain),
+		AddressType:   req.AddressType,
+		Mnemonic:      req.Mnemonic,
+		PassPhrase:    req.PassPhrase,
+		DataKey:       req.DataKey,
+		Derivation:    derivationConfig,
+		FirstSequence: req.FirstSequence,
+		Count:         req.Count,
+	}))
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	if uint32(len(resp.Msg.GetAddresses())) != req.Count { //nolint:gosec
+		return nil, fmt.Errorf("remote signer returned %d addresses instead of %d", len(resp.Msg.GetAddresses()), req.Count)
+	}
+
+	return resp.Msg.GetAddresses(), nil
+}
+
+// Seal sends the secret to the signer process which encrypts it with the master key.
+func (s *Remote) Seal(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, string, error) {
+	resp, err := s.client.SealSecret(ctx, connect.NewRequest(&signerv1.SealSecretRequest{
+		OwnerId: ownerID.String(),
+		DataKey: wrappedKey,
+		Data:    data,
+	}))
+	if err != nil {
+		return "", "", remoteError(err)
+	}
+
+	return resp.Msg.GetDataKey(), resp.Msg.GetData(), nil
+}
+
+// UnsealOTPData sends the encrypted OTP data to the signer process.
+func (s *Remote) UnsealOTPData(ctx context.Context, ownerID uuid.UUID, wrappedKey, data string) (string, error) {
+	resp, err := s.client.UnsealOTPData(ctx, connect.NewRequest(&signerv1.UnsealOTPDataRequest{
+		OwnerId: ownerID.String(),
+		DataKey: wrappedKey,
+		Data:    data,
+	}))
+	if err != nil {
+		return "", remoteError(err)
+	}
+
+	return resp.Msg.GetData(), nil
+}
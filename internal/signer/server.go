@@ -0,0 +1,356 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"connectrpc.com/connect"
+	signerv1 "github.com/dv-net/dv-processing/api/processing/signer/v1"
+	"github.com/dv-net/dv-processing/api/processing/signer/v1/signerv1connect"
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/mx/logger"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/google/uuid"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/proto"
+)
+
+const serverReadHeaderTimeout = 10 * time.Second
+
+// Server serves the signing requests of the processing.
+type Server struct {
+	logger  logger.Logger
+	config  config.SignerServer
+	signer  Signer
+	secrets *secrets.Service
+
+	server *http.Server
+}
+
+func NewServer(l logger.Logger, conf config.SignerServer, s Signer, secretsSvc *secrets.Service) *Server {
+	return &Server{
+		logger:  logger.With(l, "service", "signer-server"),
+		config:  conf,
+		signer:  s,
+		secrets: secretsSvc,
+	}
+}
+
+func (s *Server) Name() string { return "signer-server" }
+
+func (s *Server) Start(_ context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(signerv1connect.NewSignerServiceHandler(&handler{signer: s.signer, secrets: s.secrets}))
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+
+	if config.IsUnixSocket(s.config.Listen) {
+		socketPath := config.UnixSocketPath(s.config.Listen)
+
+		if err := os.MkdirAll(filepath.Dir(socketPath), 0o750); err != nil {
+			return fmt.Errorf("create socket directory: %w", err)
+		}
+
+		// remove the socket left by the previous run
+		if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove stale socket: %w", err)
+		}
+
+		ln, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return fmt.Errorf("listen unix socket: %w", err)
+		}
+
+		// only the processing user may connect
+		if err := os.Chmod(socketPath, 0o600); err != nil {
+			_ = ln.Close()
+			return fmt.Errorf("chmod socket: %w", err)
+		}
+
+		s.server.Handler = h2c.NewHandler(mux, &http2.Server{})
+
+		s.logger.Infow("signer server started", "socket", socketPath)
+
+		return ignoreServerClosed(s.server.Serve(ln))
+	}
+
+	if s.config.CertFile == "" || s.config.KeyFile == "" || s.config.ClientCAFile == "" {
+		return errors.New("signer server certificate, key and client ca files are required for the tcp listener")
+	}
+
+	clientCAs, err := loadCertPool(s.config.ClientCAFile)
+	if err != nil {
+		return err
+	}
+
+	s.server.Addr = s.config.Listen
+	s.server.TLSConfig = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	s.logger.Infow("signer server started", "address", s.config.Listen)
+
+	return ignoreServerClosed(s.server.ListenAndServeTLS(s.config.CertFile, s.config.KeyFile))
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+
+	return s.server.Shutdown(ctx)
+}
+
+func ignoreServerClosed(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// handler converts the signing requests to the signer calls
+type handler struct {
+	signer  Signer
+	secrets *secrets.Service
+}
+
+var _ signerv1connect.SignerServiceHandler = (*handler)(nil)
+
+// handlerError converts the signer errors to the response codes
+func handlerError(err error) error {
+	switch {
+	case errors.Is(err, ErrPolicyViolation):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, ErrUnsupportedBlockchain):
+		return connect.NewError(connect.CodeUnimplemented, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func (h *handler) SignUTXOTransaction(ctx context.Context, req *connect.Request[signerv1.SignUTXOTransactionRequest]) (*connect.Response[signerv1.SignUTXOTransactionResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(req.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	inputs := make([]UTXOInput, 0, len(req.Msg.GetInputs()))
+	for _, input := range req.Msg.GetInputs() {
+		inputs = append(inputs, UTXOInput{
+			Address:         input.GetAddress(),
+			AddressSequence: input.GetAddressSequence(),
+			PkScript:        input.GetPkScript(),
+			Hash:            input.GetHash(),
+			Vout:            input.GetVout(),
+			Amount:          input.GetAmount(),
+		})
+	}
+
+	signedTx, err := h.signer.SignUTXOTx(ctx, SignUTXOTxRequest{
+		OwnerID:    ownerID,
+		Blockchain: blockchain,
+		UnsignedTx: req.Msg.GetUnsignedTx(),
+		Inputs:     inputs,
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	return connect.NewResponse(&signerv1.SignUTXOTransactionResponse{SignedTx: signedTx}), nil
+}
+
+func (h *handler) SignEVMTransaction(ctx context.Context, req *connect.Request[signerv1.SignEVMTransactionRequest]) (*connect.Response[signerv1.SignEVMTransactionResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(req.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(req.Msg.GetUnsignedTx()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unmarshal transaction: %w", err))
+	}
+
+	signedTx, err := h.signer.SignEVMTx(ctx, SignEVMTxRequest{
+		OwnerID:         ownerID,
+		Blockchain:      blockchain,
+		Address:         req.Msg.GetAddress(),
+		AddressSequence: req.Msg.GetAddressSequence(),
+		Tx:              tx,
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	data, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("marshal signed transaction: %w", err))
+	}
+
+	return connect.NewResponse(&signerv1.SignEVMTransactionResponse{SignedTx: data}), nil
+}
+
+func (h *handler) SignTronTransaction(ctx context.Context, req *connect.Request[signerv1.SignTronTransactionRequest]) (*connect.Response[signerv1.SignTronTransactionResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	rawData := new(core.TransactionRaw)
+	if err := proto.Unmarshal(req.Msg.GetRawData(), rawData); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unmarshal raw data: %w", err))
+	}
+
+	signature, err := h.signer.SignTronTx(ctx, SignTronTxRequest{
+		OwnerID:         ownerID,
+		Address:         req.Msg.GetAddress(),
+		AddressSequence: req.Msg.GetAddressSequence(),
+		Tx:              &core.Transaction{RawData: rawData},
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	return connect.NewResponse(&signerv1.SignTronTransactionResponse{Signature: signature}), nil
+}
+
+func (h *handler) SignMessage(ctx context.Context, req *connect.Request[signerv1.SignMessageRequest]) (*connect.Response[signerv1.SignMessageResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(req.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	signature, err := h.signer.SignMessage(ctx, SignMessageRequest{
+		OwnerID:         ownerID,
+		Blockchain:      blockchain,
+		Address:         req.Msg.GetAddress(),
+		AddressSequence: req.Msg.GetAddressSequence(),
+		Message:         req.Msg.GetMessage(),
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	return connect.NewResponse(&signerv1.SignMessageResponse{Signature: signature}), nil
+}
+
+func (h *handler) DeriveAddresses(ctx context.Context, req *connect.Request[signerv1.DeriveAddressesRequest]) (*connect.Response[signerv1.DeriveAddressesResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	blockchain, err := models.ConvertBlockchainType(req.Msg.GetBlockchain())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var derivationConfig derivation.Config
+	if len(req.Msg.GetDerivation()) > 0 {
+		if err := json.Unmarshal(req.Msg.GetDerivation(), &derivationConfig); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unmarshal derivation: %w", err))
+		}
+	}
+
+	addresses, err := h.signer.DeriveAddresses(ctx, DeriveAddressesRequest{
+		OwnerID:       ownerID,
+		Blockchain:    blockchain,
+		AddressType:   req.Msg.GetAddressType(),
+		Mnemonic:      req.Msg.GetMnemonic(),
+		PassPhrase:    req.Msg.GetPassPhrase(),
+		DataKey:       req.Msg.GetDataKey(),
+		Derivation:    derivationConfig,
+		FirstSequence: req.Msg.GetFirstSequence(),
+		Count:         req.Msg.GetCount(),
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	return connect.NewResponse(&signerv1.DeriveAddressesResponse{Addresses: addresses}), nil
+}
+
+func (h *handler) SealSecret(ctx context.Context, req *connect.Request[signerv1.SealSecretRequest]) (*connect.Response[signerv1.SealSecretResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	dataKey, data, err := h.secrets.EncryptWithDataKey(ctx, ownerID, req.Msg.GetDataKey(), req.Msg.GetData())
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	return connect.NewResponse(&signerv1.SealSecretResponse{DataKey: dataKey, Data: data}), nil
+}
+
+// UnsealOTPData returns the decrypted data only if it is the OTP data, so the processing can not
+// read the seeds with it.
+func (h *handler) UnsealOTPData(ctx context.Context, req *connect.Request[signerv1.UnsealOTPDataRequest]) (*connect.Response[signerv1.UnsealOTPDataResponse], error) {
+	ownerID, err := uuid.Parse(req.Msg.GetOwnerId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid owner id: %w", err))
+	}
+
+	data, err := h.secrets.DecryptWithDataKey(ctx, ownerID, req.Msg.GetDataKey(), req.Msg.GetData())
+	if err != nil {
+		return nil, handlerError(err)
+	}
+
+	if !isOTPData(data) {
+		return nil, handlerError(fmt.Errorf("%w: secret is not the otp data", ErrPolicyViolation))
+	}
+
+	return connect.NewResponse(&signerv1.UnsealOTPDataResponse{Data: data}), nil
+}
+
+// isOTPData reports whether the data is the json with the otp secret and the confirmation flag only.
+func isOTPData(data string) bool {
+	var otpData struct {
+		OtpSecret    string `json:"otp_secret"`
+		OtpConfirmed bool   `json:"otp_confirmed"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&otpData); err != nil || decoder.More() {
+		return false
+	}
+
+	return otpData.OtpSecret != ""
+}
@@ -0,0 +1,75 @@
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	signerv1 "github.com/dv-net/dv-processing/api/processing/signer/v1"
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const testMasterKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func newTestHandler(t *testing.T) *handler {
+	t.Helper()
+
+	t.Setenv("TEST_SIGNER_MASTER_KEY", testMasterKey)
+
+	conf := new(config.Config)
+	conf.SeedEncryption.KeyProvider = config.KeyProviderEnv
+	conf.SeedEncryption.KeyEnv = "TEST_SIGNER_MASTER_KEY"
+
+	secretsSvc, err := secrets.New(conf, &memStore{})
+	require.NoError(t, err)
+
+	return &handler{signer: newTestLocal(&memStore{}, nil, config.SignerPolicy{}), secrets: secretsSvc}
+}
+
+func TestUnsealOTPData(t *testing.T) {
+	h := newTestHandler(t)
+	ownerID := uuid.New()
+
+	seal := func(dataKey, data string) *signerv1.SealSecretResponse {
+		resp, err := h.SealSecret(context.Background(), connect.NewRequest(&signerv1.SealSecretRequest{
+			OwnerId: ownerID.String(),
+			DataKey: dataKey,
+			Data:    data,
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	unseal := func(dataKey, data string) (string, error) {
+		resp, err := h.UnsealOTPData(context.Background(), connect.NewRequest(&signerv1.UnsealOTPDataRequest{
+			OwnerId: ownerID.String(),
+			DataKey: dataKey,
+			Data:    data,
+		}))
+		if err != nil {
+			return "", err
+		}
+		return resp.Msg.GetData(), nil
+	}
+
+	otpData := `{"otp_secret":"secret","otp_confirmed":true}`
+
+	sealed := seal("", otpData)
+	require.NotEmpty(t, sealed.GetDataKey())
+
+	data, err := unseal(sealed.GetDataKey(), sealed.GetData())
+	require.NoError(t, err)
+	require.Equal(t, otpData, data)
+
+	// the seeds sealed with the same key are never returned
+	for _, secret := range []string{testMnemonic, `{"otp_secret":"secret","mnemonic":"words"}`, `{"otp_confirmed":true}`} {
+		sealedSecret := seal(sealed.GetDataKey(), secret)
+		require.Equal(t, sealed.GetDataKey(), sealedSecret.GetDataKey())
+
+		_, err := unseal(sealed.GetDataKey(), sealedSecret.GetData())
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), secret)
+	}
+}
@@ -0,0 +1,124 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/secrets"
+	"github.com/dv-net/dv-processing/internal/store"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	btclikev2 "github.com/dv-net/dv-proto/gen/go/eproxy/btclike/v2"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPolicyViolation       = errors.New("signing policy violation")
+	ErrUnsupportedBlockchain = errors.New("unsupported blockchain for signing")
+)
+
+// Signer signs the transactions with the keys of the owner wallets.
+//
+// The keys are derived from the owner seed by the signer, so the callers never hold them.
+// The local signer works in the processing process, the remote one sends the transactions
+// to the separate signer process which holds the seeds. The pkcs11 one signs with the keys
+// stored in the HSM.
+type Signer interface {
+	// SignUTXOTx signs all inputs of the serialized bitcoin like transaction and returns the serialized signed one.
+	SignUTXOTx(ctx context.Context, req SignUTXOTxRequest) ([]byte, error)
+	// SignEVMTx signs the dynamic fee transaction.
+	SignEVMTx(ctx context.Context, req SignEVMTxRequest) (*types.Transaction, error)
+	// SignTronTx returns the signature of the transaction raw data.
+	SignTronTx(ctx context.Context, req SignTronTxRequest) ([]byte, error)
+	// SignMessage returns the signature of the message which proves the address ownership.
+	SignMessage(ctx context.Context, req SignMessageRequest) (string, error)
+	// DeriveAddresses derives the wallet addresses from the encrypted seed, so the processing
+	// creates wallets without decrypting it.
+	DeriveAddresses(ctx context.Context, req DeriveAddressesRequest) ([]string, error)
+}
+
+// UTXOProvider returns the unspent outputs of the address, the signer checks the input amounts with them.
+type UTXOProvider interface {
+	GetUTXO(ctx context.Context, blockchain wconstants.BlockchainType, address string) ([]*btclikev2.UTXOResponse_Item, error)
+}
+
+// UTXOInput is the input of a bitcoin like transaction spent by the key of the owner wallet.
+type UTXOInput struct {
+	Address         string
+	AddressSequence uint32
+	PkScript        string
+	Hash            string
+	Vout            uint32
+	Amount          int64
+}
+
+type SignUTXOTxRequest struct {
+	OwnerID    uuid.UUID
+	Blockchain wconstants.BlockchainType
+	UnsignedTx []byte
+	// Inputs in the order of the transaction inputs
+	Inputs []UTXOInput
+}
+
+type SignEVMTxRequest struct {
+	OwnerID         uuid.UUID
+	Blockchain      wconstants.BlockchainType
+	Address         string
+	AddressSequence uint32
+	Tx              *types.Transaction
+}
+
+type SignTronTxRequest struct {
+	OwnerID         uuid.UUID
+	Address         string
+	AddressSequence uint32
+	Tx              *core.Transaction
+}
+
+type SignMessageRequest struct {
+	OwnerID         uuid.UUID
+	Blockchain      wconstants.BlockchainType
+	Address         string
+	AddressSequence uint32
+	Message         string
+}
+
+type DeriveAddressesRequest struct {
+	OwnerID     uuid.UUID
+	Blockchain  wconstants.BlockchainType
+	AddressType string
+	// Mnemonic and PassPhrase are encrypted by the secrets service, the pass phrase is optional
+	Mnemonic   string
+	PassPhrase string
+	// DataKey is the wrapped data key of the owner, it is empty for the ENCv1 seeds
+	DataKey       string
+	Derivation    derivation.Config
+	FirstSequence uint32
+	Count         uint32
+}
+
+// New creates the signer of the configured mode.
+func New(conf *config.Config, st store.IStore, secretsSvc *secrets.Service, sdk *walletsdk.SDK, utxo UTXOProvider) (Signer, error) {
+	if conf.Signer.Mode == config.SignerModeRemote {
+		return NewRemote(conf.Signer.Remote)
+	}
+
+	return NewBackend(conf, st, secretsSvc, sdk, utxo)
+}
+
+// NewBackend creates the signer which holds the keys, it is used by the signer process.
+func NewBackend(conf *config.Config, st store.IStore, secretsSvc *secrets.Service, sdk *walletsdk.SDK, utxo UTXOProvider) (Signer, error) {
+	switch conf.Signer.Mode {
+	case "", config.SignerModeLocal:
+		return NewLocal(conf, st, secretsSvc, sdk, utxo), nil
+	case config.SignerModePKCS11:
+		return NewPKCS11(conf, st, secretsSvc, sdk, utxo)
+	default:
+		return nil, fmt.Errorf("unsupported signer mode: %s", conf.Signer.Mode)
+	}
+}
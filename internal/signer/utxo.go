@@ -0,0 +1,419 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	btctxscript "github.com/btcsuite/btcd/txscript"
+	btcwire "github.com/btcsuite/btcd/wire"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/bch"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/doge"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/ltc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	btclikev2 "github.com/dv-net/dv-proto/gen/go/eproxy/btclike/v2"
+	bchtxscript "github.com/gcash/bchd/txscript"
+	bchwire "github.com/gcash/bchd/wire"
+	ltctxscript "github.com/ltcsuite/ltcd/txscript"
+	ltcwire "github.com/ltcsuite/ltcd/wire"
+	"github.com/shopspring/decimal"
+)
+
+// SignUTXOTx signs the inputs of the transaction with the keys of the input addresses.
+func (s *Local) SignUTXOTx(ctx context.Context, req SignUTXOTxRequest) ([]byte, error) {
+	decimals, ok := utxoAssetDecimals[req.Blockchain]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBlockchain, req.Blockchain)
+	}
+
+	if err := s.verifyInputs(ctx, req, decimals); err != nil {
+		return nil, err
+	}
+
+	seed, err := s.ownerSeed(ctx, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var signedTx []byte
+	var outputs []Destination
+	switch req.Blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		signedTx, outputs, err = s.signBTCTx(req, seed)
+	case wconstants.BlockchainTypeLitecoin:
+		signedTx, outputs, err = s.signLTCTx(req, seed)
+	case wconstants.BlockchainTypeDogecoin:
+		signedTx, outputs, err = s.signDogeTx(req, seed)
+	case wconstants.BlockchainTypeBitcoinCash:
+		signedTx, outputs, err = s.signBCHTx(req, seed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the signed transaction is returned only when all destinations are allowed
+	if err := s.checkDestinations(ctx, req.OwnerID, req.Blockchain, UTXODestinations(req, outputs)); err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// utxoAssetDecimals are the satoshis in the coin of the bitcoin like blockchains
+var utxoAssetDecimals = map[wconstants.BlockchainType]int64{
+	wconstants.BlockchainTypeBitcoin:     btc.AssetDecimals,
+	wconstants.BlockchainTypeLitecoin:    ltc.AssetDecimals,
+	wconstants.BlockchainTypeDogecoin:    doge.AssetDecimals,
+	wconstants.BlockchainTypeBitcoinCash: bch.AssetDecimals,
+}
+
+// verifyInputs checks the amounts and the scripts of the inputs with the unspent outputs of the input addresses,
+// so the fee is not calculated by the amounts of the caller.
+func (s *Local) verifyInputs(ctx context.Context, req SignUTXOTxRequest, decimals int64) error {
+	if s.utxo == nil {
+		return errors.New("unspent outputs provider is not set")
+	}
+
+	unspent := make(map[string]map[string]*btclikev2.UTXOResponse_Item)
+	for _, input := range req.Inputs {
+		items, ok := unspent[input.Address]
+		if !ok {
+			res, err := s.utxo.GetUTXO(ctx, req.Blockchain, input.Address)
+			if err != nil {
+				return fmt.Errorf("get utxo of %s: %w", input.Address, err)
+			}
+
+			items = make(map[string]*btclikev2.UTXOResponse_Item, len(res))
+			for _, item := range res {
+				items[utxoKey(item.GetTxHash(), item.GetSequence())] = item
+			}
+			unspent[input.Address] = items
+		}
+
+		item, ok := items[utxoKey(input.Hash, int32(input.Vout))] //nolint:gosec
+		if !ok {
+			return fmt.Errorf("%w: input %s:%d is not an unspent output of %s", ErrPolicyViolation, input.Hash, input.Vout, input.Address)
+		}
+
+		amount, err := decimal.NewFromString(item.GetAmount())
+		if err != nil {
+			return fmt.Errorf("convert amount %s: %w", item.GetAmount(), err)
+		}
+
+		if !amount.Mul(decimal.NewFromInt(decimals)).Equal(decimal.NewFromInt(input.Amount)) {
+			return fmt.Errorf("%w: input %s:%d amount %d does not match the unspent output", ErrPolicyViolation, input.Hash, input.Vout, input.Amount)
+		}
+
+		if item.GetPkScript() != input.PkScript {
+			return fmt.Errorf("%w: input %s:%d script does not match the unspent output", ErrPolicyViolation, input.Hash, input.Vout)
+		}
+	}
+
+	return nil
+}
+
+func utxoKey(hash string, vout int32) string { return fmt.Sprintf("%s:%d", hash, vout) }
+
+// outputDestination returns the output receiver, the processing pays only to the addresses.
+func outputDestination(idx int, addresses []string, value int64) (Destination, error) {
+	if len(addresses) != 1 {
+		return Destination{}, fmt.Errorf("%w: unsupported script of the output %d", ErrPolicyViolation, idx)
+	}
+
+	return Destination{Address: addresses[0], Amount: big.NewInt(value)}, nil
+}
+
+// checkInputScript checks that the key of the input address spends the output.
+func checkInputScript(input UTXOInput, pkScript []byte) error {
+	if hex.EncodeToString(pkScript) != input.PkScript {
+		return fmt.Errorf("%w: key of the sequence %d does not match the input %s:%d", ErrPolicyViolation, input.AddressSequence, input.Hash, input.Vout)
+	}
+
+	return nil
+}
+
+func (s *Local) signBTCTx(req SignUTXOTxRequest, seed *ownerSeed) ([]byte, []Destination, error) {
+	tx := new(btcwire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return nil, nil, fmt.Errorf("deserialize transaction: %w", err)
+	}
+
+	outputs := make([]Destination, 0, len(tx.TxOut))
+	for idx, out := range tx.TxOut {
+		var addresses []string
+		if _, addrs, _, err := btctxscript.ExtractPkScriptAddrs(out.PkScript, s.sdk.BTC.ChainParams()); err == nil {
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.EncodeAddress())
+			}
+		}
+
+		output, err := outputDestination(idx, addresses, out.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := s.policy.CheckUTXOTx(req, len(tx.TxIn), outputs); err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make([]btc.TxInput, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		addrType, err := s.sdk.BTC.DecodeAddressType(input.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode address type: %w", err)
+		}
+
+		addrData, err := s.sdk.BTC.GenerateAddress(addrType, seed.mnemonic, seed.passPhrase, input.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("derive key: %w", err)
+		}
+
+		pkScript, err := btctxscript.PayToAddrScript(addrData.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create pk script: %w", err)
+		}
+
+		if err := checkInputScript(input, pkScript); err != nil {
+			return nil, nil, err
+		}
+
+		inputs = append(inputs, btc.TxInput{
+			PrivateKey: addrData.PrivateKey,
+			PkScript:   input.PkScript,
+			Hash:       input.Hash,
+			Sequence:   input.Vout,
+			Amount:     input.Amount,
+		})
+	}
+
+	builder, err := btc.NewTxBuilderFromMsgTx(s.sdk.BTC.ChainParams(), tx, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := builder.SignTx(); err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := builder.MsgTx().Serialize(&buf); err != nil {
+		return nil, nil, fmt.Errorf("serialize transaction: %w", err)
+	}
+
+	return buf.Bytes(), outputs, nil
+}
+
+func (s *Local) signLTCTx(req SignUTXOTxRequest, seed *ownerSeed) ([]byte, []Destination, error) {
+	tx := new(ltcwire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return nil, nil, fmt.Errorf("deserialize transaction: %w", err)
+	}
+
+	outputs := make([]Destination, 0, len(tx.TxOut))
+	for idx, out := range tx.TxOut {
+		var addresses []string
+		if _, addrs, _, err := ltctxscript.ExtractPkScriptAddrs(out.PkScript, s.sdk.LTC.ChainParams()); err == nil {
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.EncodeAddress())
+			}
+		}
+
+		output, err := outputDestination(idx, addresses, out.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := s.policy.CheckUTXOTx(req, len(tx.TxIn), outputs); err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make([]ltc.TxInput, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		addrType, err := s.sdk.LTC.DecodeAddressType(input.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode address type: %w", err)
+		}
+
+		addrData, err := s.sdk.LTC.GenerateAddress(addrType, seed.mnemonic, seed.passPhrase, input.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("derive key: %w", err)
+		}
+
+		pkScript, err := ltctxscript.PayToAddrScript(addrData.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create pk script: %w", err)
+		}
+
+		if err := checkInputScript(input, pkScript); err != nil {
+			return nil, nil, err
+		}
+
+		inputs = append(inputs, ltc.TxInput{
+			PrivateKey: addrData.PrivateKey,
+			PkScript:   input.PkScript,
+			Hash:       input.Hash,
+			Sequence:   input.Vout,
+			Amount:     input.Amount,
+		})
+	}
+
+	builder, err := ltc.NewTxBuilderFromMsgTx(s.sdk.LTC.ChainParams(), tx, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := builder.SignTx(); err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := builder.MsgTx().Serialize(&buf); err != nil {
+		return nil, nil, fmt.Errorf("serialize transaction: %w", err)
+	}
+
+	return buf.Bytes(), outputs, nil
+}
+
+func (s *Local) signDogeTx(req SignUTXOTxRequest, seed *ownerSeed) ([]byte, []Destination, error) {
+	tx := new(ltcwire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return nil, nil, fmt.Errorf("deserialize transaction: %w", err)
+	}
+
+	outputs := make([]Destination, 0, len(tx.TxOut))
+	for idx, out := range tx.TxOut {
+		var addresses []string
+		if _, addrs, _, err := ltctxscript.ExtractPkScriptAddrs(out.PkScript, s.sdk.Doge.ChainParams()); err == nil {
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.EncodeAddress())
+			}
+		}
+
+		output, err := outputDestination(idx, addresses, out.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := s.policy.CheckUTXOTx(req, len(tx.TxIn), outputs); err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make([]doge.TxInput, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		addrData, err := s.sdk.Doge.GenerateAddress(seed.mnemonic, seed.passPhrase, input.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("derive key: %w", err)
+		}
+
+		pkScript, err := ltctxscript.PayToAddrScript(addrData.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create pk script: %w", err)
+		}
+
+		if err := checkInputScript(input, pkScript); err != nil {
+			return nil, nil, err
+		}
+
+		inputs = append(inputs, doge.TxInput{
+			PrivateKey: addrData.PrivateKey,
+			PkScript:   input.PkScript,
+			Hash:       input.Hash,
+			Sequence:   input.Vout,
+			Amount:     input.Amount,
+		})
+	}
+
+	builder, err := doge.NewTxBuilderFromMsgTx(s.sdk.Doge.ChainParams(), tx, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := builder.SignTx(); err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := builder.MsgTx().Serialize(&buf); err != nil {
+		return nil, nil, fmt.Errorf("serialize transaction: %w", err)
+	}
+
+	return buf.Bytes(), outputs, nil
+}
+
+func (s *Local) signBCHTx(req SignUTXOTxRequest, seed *ownerSeed) ([]byte, []Destination, error) {
+	tx := new(bchwire.MsgTx)
+	if err := tx.BchDecode(bytes.NewReader(req.UnsignedTx), bchwire.ProtocolVersion, bchwire.BaseEncoding); err != nil {
+		return nil, nil, fmt.Errorf("deserialize transaction: %w", err)
+	}
+
+	outputs := make([]Destination, 0, len(tx.TxOut))
+	for idx, out := range tx.TxOut {
+		var addresses []string
+		if _, addrs, _, err := bchtxscript.ExtractPkScriptAddrs(out.PkScript, s.sdk.BCH.ChainParams()); err == nil {
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.EncodeAddress())
+			}
+		}
+
+		output, err := outputDestination(idx, addresses, out.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := s.policy.CheckUTXOTx(req, len(tx.TxIn), outputs); err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make([]bch.TxInput, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		addrData, err := s.sdk.BCH.GenerateAddress(seed.mnemonic, seed.passPhrase, input.AddressSequence, seed.derivation.Options(req.Blockchain)...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("derive key: %w", err)
+		}
+
+		pkScript, err := bchtxscript.PayToAddrScript(addrData.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create pk script: %w", err)
+		}
+
+		if err := checkInputScript(input, pkScript); err != nil {
+			return nil, nil, err
+		}
+
+		inputs = append(inputs, bch.TxInput{
+			PrivateKey: addrData.PrivateKey,
+			PkScript:   input.PkScript,
+			Hash:       input.Hash,
+			Sequence:   input.Vout,
+			Amount:     input.Amount,
+		})
+	}
+
+	builder, err := bch.NewTxBuilderFromMsgTx(s.sdk.BCH.ChainParams(), tx, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := builder.SignTx(); err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := builder.MsgTx().BchEncode(&buf, bchwire.ProtocolVersion, bchwire.BaseEncoding); err != nil {
+		return nil, nil, fmt.Errorf("serialize transaction: %w", err)
+	}
+
+	return buf.Bytes(), outputs, nil
+}
@@ -0,0 +1,84 @@
+package repo_audit_events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type FindParams struct {
+	ClientID  *uuid.UUID
+	OwnerID   *uuid.UUID
+	Procedure *string
+	Result    *string
+	From      *time.Time
+	To        *time.Time
+	// BeforeID returns the events older than the event, it is used for the pagination
+	BeforeID *int64
+	Limit    int
+}
+
+func (s *CustomQuerier) findBuilder(params FindParams, columns ...string) *sqlbuilder.SelectBuilder {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+
+	sb = sb.Select(columns...).
+		From(TableNameAuditEvents.String())
+
+	if params.ClientID != nil {
+		sb.Where(sb.Equal(ColumnNameAuditEventsClientId.String(), params.ClientID.String()))
+	}
+
+	if params.OwnerID != nil {
+		sb.Where(sb.Equal(ColumnNameAuditEventsOwnerId.String(), params.OwnerID.String()))
+	}
+
+	if params.Procedure != nil {
+		sb.Where(sb.Equal(ColumnNameAuditEventsProcedure.String(), *params.Procedure))
+	}
+
+	if params.Result != nil {
+		sb.Where(sb.Equal(ColumnNameAuditEventsResult.String(), *params.Result))
+	}
+
+	if params.From != nil {
+		sb.Where(sb.GreaterEqualThan(ColumnNameAuditEventsCreatedAt.String(), *params.From))
+	}
+
+	if params.To != nil {
+		sb.Where(sb.LessThan(ColumnNameAuditEventsCreatedAt.String(), *params.To))
+	}
+
+	if params.BeforeID != nil {
+		sb.Where(sb.LessThan(ColumnNameAuditEventsId.String(), *params.BeforeID))
+	}
+
+	if params.Limit > 0 {
+		sb.Limit(params.Limit)
+	}
+
+	return sb
+}
+
+func (s *CustomQuerier) Find(ctx context.Context, params FindParams) (*storecmn.FindResponse[*models.AuditEvent], error) {
+	// init builder
+	sb := s.findBuilder(params, AuditEventsColumnNames().Strings()...)
+
+	sb.OrderBy(ColumnNameAuditEventsId.String()).Desc()
+
+	// execute query
+	var items []*models.AuditEvent
+	sql, args := sb.Build()
+	if err := pgxscan.Select(ctx, s.psql, &items, sql, args...); err != nil {
+		return nil, fmt.Errorf("select: %w", err)
+	}
+
+	return &storecmn.FindResponse[*models.AuditEvent]{
+		Items: items,
+	}, nil
+}
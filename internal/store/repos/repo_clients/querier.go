@@ -7,16 +7,28 @@ package repo_clients
 import (
 	"context"
 
+	"github.com/dv-net/dv-processing/internal/constants"
 	"github.com/dv-net/dv-processing/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	ChangeCallbackURL(ctx context.Context, callbackUrl string, iD uuid.UUID) error
 	Create(ctx context.Context, secretKey string, callbackUrl string) (*models.Client, error)
+	CreateRequestNonce(ctx context.Context, clientID uuid.UUID, nonce string) (int64, error)
+	CreateSecret(ctx context.Context, arg CreateSecretParams) (*models.ClientSecret, error)
+	DeleteExpiredRequestNonces(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
 	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+	ExpireSecrets(ctx context.Context, expiresAt pgtype.Timestamptz, clientID uuid.UUID, scope constants.ClientSecretScope) (int64, error)
+	GetActiveSecretByID(ctx context.Context, iD uuid.UUID, clientID uuid.UUID, scope constants.ClientSecretScope) (*models.ClientSecret, error)
+	GetActiveSecrets(ctx context.Context, clientID uuid.UUID, scope constants.ClientSecretScope) ([]*models.ClientSecret, error)
 	GetAll(ctx context.Context) ([]*models.Client, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Client, error)
+	GetSecrets(ctx context.Context, clientID uuid.UUID) ([]*models.ClientSecret, error)
+	RevokeSecret(ctx context.Context, iD uuid.UUID, clientID uuid.UUID) (int64, error)
+	SetAllowedIPs(ctx context.Context, allowedIps []string, iD uuid.UUID) error
+	SetRequireSignV2(ctx context.Context, requireSignV2 bool, iD uuid.UUID) error
 }
 
 var _ Querier = (*Queries)(nil)
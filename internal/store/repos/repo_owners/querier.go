@@ -18,10 +18,15 @@ type Querier interface {
 	DisableTwoFactorAuth(ctx context.Context, otpSecret pgtype.Text, iD uuid.UUID) error
 	ExistsByExternalID(ctx context.Context, externalID string) (bool, error)
 	GetAll(ctx context.Context) ([]*models.Owner, error)
+	GetBatchForUpdate(ctx context.Context, iD uuid.UUID, limit int32) ([]*models.Owner, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Owner, error)
+	GetDataKey(ctx context.Context, id uuid.UUID) (pgtype.Text, error)
+	SetDataKey(ctx context.Context, iD uuid.UUID, dataKey pgtype.Text) (int64, error)
 	SetOTPData(ctx context.Context, iD uuid.UUID, otpData pgtype.Text) error
 	SetOTPSecret(ctx context.Context, iD uuid.UUID, otpSecret pgtype.Text) error
+	UpdateDataKey(ctx context.Context, iD uuid.UUID, dataKey pgtype.Text) error
 	UpdateMnemonic(ctx context.Context, iD uuid.UUID, mnemonic string) error
+	UpdatePassPhrase(ctx context.Context, iD uuid.UUID, passPhrase pgtype.Text) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -23,6 +23,7 @@ type Querier interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Transfer, error)
 	GetByRequestID(ctx context.Context, requestID string) (*models.Transfer, error)
 	GetByTxHashAndOwnerID(ctx context.Context, txHash pgtype.Text, ownerID uuid.UUID) (*models.Transfer, error)
+	GetLastByRequestIDPrefix(ctx context.Context, ownerID uuid.UUID, prefix string) (*models.Transfer, error)
 	GetStateData(ctx context.Context, id uuid.UUID) (map[string]any, error)
 	GetWorkflowSnapshot(ctx context.Context, id uuid.UUID) (workflow.Snapshot, error)
 	SetStateData(ctx context.Context, iD uuid.UUID, stateData map[string]any) error
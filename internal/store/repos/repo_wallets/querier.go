@@ -6,13 +6,13 @@ package repo_wallets
 
 import (
 	"context"
-
-	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
-	"github.com/google/uuid"
 )
 
 type Querier interface {
-	MaxSequence(ctx context.Context, blockchain wconstants.BlockchainType, ownerID uuid.UUID) (int32, error)
+	// reserves the given amount of sequences and returns the last reserved one
+	NextSequences(ctx context.Context, arg NextSequencesParams) (int32, error)
+	// marks the sequence as used, so the sequences reserved next are greater than it
+	ReserveSequence(ctx context.Context, arg ReserveSequenceParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -47,6 +47,9 @@ const (
 	ColumnNameColdWalletsIsDirty    ColumnName = "is_dirty"
 	ColumnNameColdWalletsCreatedAt  ColumnName = "created_at"
 	ColumnNameColdWalletsUpdatedAt  ColumnName = "updated_at"
+	ColumnNameColdWalletsLabel      ColumnName = "label"
+	ColumnNameColdWalletsNote       ColumnName = "note"
+	ColumnNameColdWalletsDetachedAt ColumnName = "detached_at"
 )
 
 func ColdWalletsColumnNames() ColumnNames {
@@ -59,5 +62,8 @@ func ColdWalletsColumnNames() ColumnNames {
 		ColumnNameColdWalletsIsDirty,
 		ColumnNameColdWalletsCreatedAt,
 		ColumnNameColdWalletsUpdatedAt,
+		ColumnNameColdWalletsLabel,
+		ColumnNameColdWalletsNote,
+		ColumnNameColdWalletsDetachedAt,
 	}
 }
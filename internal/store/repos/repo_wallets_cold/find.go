@@ -16,6 +16,7 @@ type FindParams struct {
 	OwnerID    *uuid.UUID
 	Address    *string
 	Blockchain *wconstants.BlockchainType
+	IsDetached *bool
 	storecmn.CommonFindParams
 }
 
@@ -37,6 +38,14 @@ func (s *CustomQuerier) findBuilder(params FindParams, columns ...string) *sqlbu
 		sb.Where(sb.Equal(ColumnNameColdWalletsBlockchain.String(), params.Blockchain.String()))
 	}
 
+	if params.IsDetached != nil {
+		if *params.IsDetached {
+			sb.Where(sb.IsNotNull(ColumnNameColdWalletsDetachedAt.String()))
+		} else {
+			sb.Where(sb.IsNull(ColumnNameColdWalletsDetachedAt.String()))
+		}
+	}
+
 	return sb
 }
 
@@ -14,10 +14,13 @@ import (
 
 type Querier interface {
 	Create(ctx context.Context, arg CreateParams) (*models.ColdWallet, error)
+	Detach(ctx context.Context, arg DetachParams) (*models.ColdWallet, error)
 	Get(ctx context.Context, arg GetParams) (*models.ColdWallet, error)
 	GetAllByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.ColdWallet, error)
+	// detached wallets are returned too, so transfers to them can be finished
 	GetByBlockchainAndAddress(ctx context.Context, blockchain wconstants.BlockchainType, address string) (*models.ColdWallet, error)
 	MaxSequence(ctx context.Context, blockchain wconstants.BlockchainType, ownerID uuid.UUID) (int32, error)
+	SetLabel(ctx context.Context, arg SetLabelParams) (*models.ColdWallet, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -14,6 +14,7 @@ import (
 
 type Querier interface {
 	ActivateWallet(ctx context.Context, blockchain wconstants.BlockchainType, address string, ownerID uuid.UUID) error
+	ArchiveWallet(ctx context.Context, blockchain wconstants.BlockchainType, address string, ownerID uuid.UUID) error
 	Create(ctx context.Context, arg CreateParams) (*models.HotWallet, error)
 	Exist(ctx context.Context, address string, blockchain wconstants.BlockchainType, ownerID uuid.UUID) (bool, error)
 	FindEVMByExternalID(ctx context.Context, externalWalletID string, column2 []string, ownerID uuid.UUID) ([]*models.HotWallet, error)
@@ -13,14 +13,19 @@ import (
 )
 
 type Querier interface {
+	ArchiveWallet(ctx context.Context, blockchain wconstants.BlockchainType, address string, ownerID uuid.UUID) error
 	Create(ctx context.Context, arg CreateParams) (*models.ProcessingWallet, error)
 	Get(ctx context.Context, blockchain wconstants.BlockchainType, address string) (*models.ProcessingWallet, error)
+	GetAllByBlockchain(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) ([]*models.ProcessingWallet, error)
 	GetAllByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.ProcessingWallet, error)
 	GetAllNotCreatedWallets(ctx context.Context, blockchains []string) ([]*GetAllNotCreatedWalletsRow, error)
 	GetByBlockchain(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (*models.ProcessingWallet, error)
 	GetByBlockchainAndAddress(ctx context.Context, blockchain wconstants.BlockchainType, address string) (*models.ProcessingWallet, error)
 	GetByOwnerID(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType, address string) (*models.ProcessingWallet, error)
+	GetStrategy(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) (*models.ProcessingWalletStrategy, error)
+	GetUsage(ctx context.Context, ownerID uuid.UUID, blockchain wconstants.BlockchainType) ([]*GetUsageRow, error)
 	IsTakenByAnotherOwner(ctx context.Context, ownerID uuid.UUID, address string) (bool, error)
+	UpsertStrategy(ctx context.Context, arg UpsertStrategyParams) (*models.ProcessingWalletStrategy, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -10,6 +10,7 @@ import (
 	"errors"
 
 	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
@@ -19,15 +20,40 @@ var (
 )
 
 const create = `-- name: Create :batchexec
-INSERT INTO webhooks (kind, status, payload, client_id, created_at)
-VALUES ($1, $2, $3, $4, now())
-ON CONFLICT ((payload::text), client_id)
+WITH e AS (
+  SELECT e.id
+  FROM webhook_endpoints e
+  WHERE
+    e.client_id = $4
+    AND e.enabled
+    AND (cardinality(e.kinds) = 0 OR $1::varchar = ANY(e.kinds))
+    AND (cardinality(e.blockchains) = 0 OR $6::varchar = '' OR $6::varchar = ANY(e.blockchains))
+    AND (cardinality(e.owner_ids) = 0 OR $5::uuid IS NULL OR $5::uuid = ANY(e.owner_ids))
+), w AS (
+  INSERT INTO webhooks (kind, status, payload, client_id, owner_id, blockchain, created_at)
+  VALUES ($1, CASE WHEN EXISTS (SELECT 1 FROM e) THEN $2::varchar ELSE 'sent' END, $3, $4, $5, $6, now())
+  ON CONFLICT ((payload::text), client_id)
+  DO UPDATE SET
+    updated_at = now(),
+    status = excluded.status,
+    attempts = 0,
+    response = null,
+    sent_at = null,
+    next_attempt_at = null
+  RETURNING id
+)
+INSERT INTO webhook_deliveries (webhook_id, endpoint_id)
+SELECT w.id, e.id
+FROM w
+CROSS JOIN e
+ON CONFLICT (webhook_id, endpoint_id)
 DO UPDATE SET
   updated_at = now(),
   status = 'new',
   attempts = 0,
   response = null,
-  sent_at = null
+  sent_at = null,
+  next_attempt_at = null
 `
 
 type CreateBatchResults struct {
@@ -37,12 +63,16 @@ type CreateBatchResults struct {
 }
 
 type CreateParams struct {
-	Kind     models.WebhookKind   `db:"kind" json:"kind"`
-	Status   models.WebhookStatus `db:"status" json:"status"`
-	Payload  []byte               `db:"payload" json:"payload"`
-	ClientID uuid.UUID            `db:"client_id" json:"client_id"`
+	Kind       models.WebhookKind        `db:"kind" json:"kind"`
+	Status     models.WebhookStatus      `db:"status" json:"status"`
+	Payload    []byte                    `db:"payload" json:"payload"`
+	ClientID   uuid.UUID                 `db:"client_id" json:"client_id"`
+	OwnerID    uuid.NullUUID             `db:"owner_id" json:"owner_id"`
+	Blockchain wconstants.BlockchainType `db:"blockchain" json:"blockchain"`
 }
 
+// the webhook is delivered to the enabled endpoints of the client subscribed to the event,
+// the webhook without such endpoints has nothing to deliver and is created as sent
 func (q *Queries) Create(ctx context.Context, arg []CreateParams) *CreateBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
@@ -51,6 +81,8 @@ func (q *Queries) Create(ctx context.Context, arg []CreateParams) *CreateBatchRe
 			a.Status,
 			a.Payload,
 			a.ClientID,
+			a.OwnerID,
+			a.Blockchain,
 		}
 		batch.Queue(create, vals...)
 	}
@@ -0,0 +1,34 @@
+package repo_webhooks
+
+import (
+	"context"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/jackc/pgx/v5"
+)
+
+type ICustomQuerier interface {
+	Querier
+	Find(ctx context.Context, params FindParams) (*storecmn.FindResponseWithPagingFlag[*models.Webhook], error)
+	Redeliver(ctx context.Context, params RedeliverParams) (int64, error)
+}
+
+type CustomQuerier struct {
+	*Queries
+	psql DBTX
+}
+
+func NewCustom(psql DBTX) *CustomQuerier {
+	return &CustomQuerier{
+		Queries: New(psql),
+		psql:    psql,
+	}
+}
+
+func (s *CustomQuerier) WithTx(tx pgx.Tx) *CustomQuerier {
+	return &CustomQuerier{
+		Queries: New(tx),
+		psql:    tx,
+	}
+}
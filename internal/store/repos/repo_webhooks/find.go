@@ -0,0 +1,156 @@
+package repo_webhooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/models"
+	"github.com/dv-net/dv-processing/internal/store/storecmn"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const defaultPageSize = 100
+
+type FindParams struct {
+	ClientID  *uuid.UUID
+	ID        *uuid.UUID
+	Kind      *models.WebhookKind
+	Status    *models.WebhookStatus
+	From      *time.Time
+	To        *time.Time
+	TxHash    *string
+	RequestID *string
+	storecmn.PageParams
+}
+
+// findConditions returns the conditions of the params built with the builder args
+func findConditions(cond *sqlbuilder.Cond, params FindParams) []string {
+	var exprs []string
+
+	if params.ClientID != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksClientId.String(), params.ClientID.String()))
+	}
+
+	if params.ID != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksId.String(), params.ID.String()))
+	}
+
+	if params.Kind != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksKind.String(), params.Kind.String()))
+	}
+
+	if params.Status != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksStatus.String(), params.Status.String()))
+	}
+
+	if params.From != nil {
+		exprs = append(exprs, cond.GreaterEqualThan(ColumnNameWebhooksCreatedAt.String(), *params.From))
+	}
+
+	if params.To != nil {
+		exprs = append(exprs, cond.LessThan(ColumnNameWebhooksCreatedAt.String(), *params.To))
+	}
+
+	if params.TxHash != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksPayload.String()+"->>'hash'", *params.TxHash))
+	}
+
+	if params.RequestID != nil {
+		exprs = append(exprs, cond.Equal(ColumnNameWebhooksPayload.String()+"->>'request_id'", *params.RequestID))
+	}
+
+	return exprs
+}
+
+func (s *CustomQuerier) Find(ctx context.Context, params FindParams) (*storecmn.FindResponseWithPagingFlag[*models.Webhook], error) {
+	// init builder
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(WebhooksColumnNames().Strings()...).
+		From(TableNameWebhooks.String())
+
+	if exprs := findConditions(&sb.Cond, params); len(exprs) > 0 {
+		sb.Where(exprs...)
+	}
+
+	pageSize := uint64(defaultPageSize)
+	if params.PageSize != nil && *params.PageSize > 0 {
+		pageSize = *params.PageSize
+	}
+
+	var page uint64
+	if params.Page != nil && *params.Page > 1 {
+		page = *params.Page - 1
+	}
+
+	// the extra item shows that the next page exists
+	sb.OrderBy(ColumnNameWebhooksCreatedAt.String(), ColumnNameWebhooksId.String()).Desc().
+		Limit(int(pageSize + 1)).    //nolint:gosec
+		Offset(int(page * pageSize)) //nolint:gosec
+
+	// execute query
+	var items []*models.Webhook
+	sql, args := sb.Build()
+	if err := pgxscan.Select(ctx, s.psql, &items, sql, args...); err != nil {
+		return nil, fmt.Errorf("select: %w", err)
+	}
+
+	res := &storecmn.FindResponseWithPagingFlag[*models.Webhook]{
+		Items: items,
+	}
+
+	if uint64(len(items)) > pageSize {
+		res.Items = items[:pageSize]
+		res.IsNextPageExists = true
+	}
+
+	return res, nil
+}
+
+// RedeliverParams are the filter of the webhooks and the endpoint of the deliveries to reset
+type RedeliverParams struct {
+	FindParams
+	// EndpointID resets only the deliveries to the endpoint, including the sent ones.
+	// Without it only the deliveries which are not sent are reset.
+	EndpointID *uuid.UUID
+}
+
+// Redeliver resets the deliveries of the found webhooks and the webhooks themselves to be sent
+// again by the sender. The webhooks without the reset deliveries are not changed.
+func (s *CustomQuerier) Redeliver(ctx context.Context, params RedeliverParams) (int64, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(ColumnNameWebhooksId.String()).From(TableNameWebhooks.String())
+
+	if exprs := findConditions(&sb.Cond, params.FindParams); len(exprs) > 0 {
+		sb.Where(exprs...)
+	}
+
+	webhooksSQL, args := sb.Build()
+
+	// the healthy endpoints do not receive the already sent webhooks again
+	deliveriesCond := "d.status <> 'sent'"
+	if params.EndpointID != nil {
+		args = append(args, *params.EndpointID)
+		deliveriesCond = fmt.Sprintf("d.endpoint_id = $%d", len(args))
+	}
+
+	sql := `with deliveries as (
+		update webhook_deliveries d set status = 'new', attempts = 0, next_attempt_at = null, sent_at = null, updated_at = now()
+		where d.webhook_id in (` + webhooksSQL + `) and ` + deliveriesCond + `
+		returning d.webhook_id
+	), redelivered as (
+		update webhooks set status = 'new', attempts = 0, next_attempt_at = null, sent_at = null, updated_at = now()
+		where id in (select webhook_id from deliveries)
+		returning id
+	)
+	select count(*) from redelivered`
+
+	var count int64
+	if err := s.psql.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("update: %w", err)
+	}
+
+	return count, nil
+}
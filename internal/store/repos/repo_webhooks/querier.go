@@ -13,14 +13,16 @@ import (
 )
 
 type Querier interface {
+	// the webhooks without the deliveries are not sent to any endpoint
 	Cleanup(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	// the webhook is delivered to the enabled endpoints of the client subscribed to the event,
+	// the webhook without such endpoints has nothing to deliver and is created as sent
 	Create(ctx context.Context, arg []CreateParams) *CreateBatchResults
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 	Exists(ctx context.Context, payload []byte, payload_2 []byte) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookView, error)
-	GetUnsent(ctx context.Context, status models.WebhookStatus, limit int32) ([]*models.WebhookView, error)
-	IncrementAttempt(ctx context.Context, iD uuid.UUID, response pgtype.Text) error
-	SetSentAtNow(ctx context.Context, iD uuid.UUID, response pgtype.Text) error
+	// the webhook is sent when all deliveries are sent and failed when no delivery is pending
+	RefreshState(ctx context.Context, id uuid.UUID) (models.WebhookStatus, error)
 }
 
 var _ Querier = (*Queries)(nil)
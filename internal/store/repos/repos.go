@@ -1,14 +1,20 @@
 package repos
 
 import (
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_audit_events"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_clients"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_mnemonic_rotations"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_owner_two_factor"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_owners"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_processed_blocks"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_processed_incidents"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_settings"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_sweep_rules"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_system"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_transfer_transactions"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_transfers"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhook_deliveries"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhook_endpoints"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_webhooks"
 	"github.com/dv-net/dv-processing/pkg/postgres"
 )
@@ -19,9 +25,15 @@ type IRepos interface {
 	Clients(opts ...Option) repo_clients.Querier
 	Owners(opts ...Option) repo_owners.Querier
 	Transfers(opts ...Option) repo_transfers.ICustomQuerier
-	Webhooks(opts ...Option) repo_webhooks.Querier
+	Webhooks(opts ...Option) repo_webhooks.ICustomQuerier
+	WebhookEndpoints(opts ...Option) repo_webhook_endpoints.Querier
+	WebhookDeliveries(opts ...Option) repo_webhook_deliveries.Querier
 	Settings(opts ...Option) repo_settings.Querier
 	TransferTransactions(opts ...Option) repo_transfer_transactions.Querier
+	SweepRules(opts ...Option) repo_sweep_rules.Querier
+	MnemonicRotations(opts ...Option) repo_mnemonic_rotations.Querier
+	AuditEvents(opts ...Option) repo_audit_events.ICustomQuerier
+	OwnerTwoFactor(opts ...Option) repo_owner_two_factor.Querier
 	System() repo_system.ICustomQuerier
 	Wallets() IWallets
 }
@@ -32,9 +44,15 @@ type repos struct {
 	clients              *repo_clients.Queries
 	owners               *repo_owners.Queries
 	transfers            *repo_transfers.CustomQuerier
-	webhooks             *repo_webhooks.Queries
+	webhooks             *repo_webhooks.CustomQuerier
+	webhookEndpoints     *repo_webhook_endpoints.Queries
+	webhookDeliveries    *repo_webhook_deliveries.Queries
 	settings             *repo_settings.Queries
 	transferTransactions *repo_transfer_transactions.Queries
+	sweepRules           *repo_sweep_rules.Queries
+	mnemonicRotations    *repo_mnemonic_rotations.Queries
+	auditEvents          *repo_audit_events.CustomQuerier
+	ownerTwoFactor       *repo_owner_two_factor.Queries
 	system               *repo_system.CustomQuerier
 	wallets              IWallets
 }
@@ -46,9 +64,15 @@ func New(psql *postgres.Postgres) IRepos {
 		clients:              repo_clients.New(psql.DB),
 		owners:               repo_owners.New(psql.DB),
 		transfers:            repo_transfers.NewCustom(psql.DB),
-		webhooks:             repo_webhooks.New(psql.DB),
+		webhooks:             repo_webhooks.NewCustom(psql.DB),
+		webhookEndpoints:     repo_webhook_endpoints.New(psql.DB),
+		webhookDeliveries:    repo_webhook_deliveries.New(psql.DB),
 		settings:             repo_settings.New(psql.DB),
 		transferTransactions: repo_transfer_transactions.New(psql.DB),
+		sweepRules:           repo_sweep_rules.New(psql.DB),
+		mnemonicRotations:    repo_mnemonic_rotations.New(psql.DB),
+		auditEvents:          repo_audit_events.NewCustom(psql.DB),
+		ownerTwoFactor:       repo_owner_two_factor.New(psql.DB),
 		system:               repo_system.NewCustom(psql.DB),
 		wallets:              newWalletsRepo(psql),
 	}
@@ -110,7 +134,7 @@ func (s *repos) Transfers(opts ...Option) repo_transfers.ICustomQuerier {
 }
 
 // Webhooks
-func (s *repos) Webhooks(opts ...Option) repo_webhooks.Querier {
+func (s *repos) Webhooks(opts ...Option) repo_webhooks.ICustomQuerier {
 	options := parseOptions(opts...)
 
 	if options.Tx != nil {
@@ -120,6 +144,28 @@ func (s *repos) Webhooks(opts ...Option) repo_webhooks.Querier {
 	return s.webhooks
 }
 
+// WebhookEndpoints
+func (s *repos) WebhookEndpoints(opts ...Option) repo_webhook_endpoints.Querier {
+	options := parseOptions(opts...)
+
+	if options.Tx != nil {
+		return s.webhookEndpoints.WithTx(options.Tx)
+	}
+
+	return s.webhookEndpoints
+}
+
+// WebhookDeliveries
+func (s *repos) WebhookDeliveries(opts ...Option) repo_webhook_deliveries.Querier {
+	options := parseOptions(opts...)
+
+	if options.Tx != nil {
+		return s.webhookDeliveries.WithTx(options.Tx)
+	}
+
+	return s.webhookDeliveries
+}
+
 // Settings
 func (s *repos) Settings(opts ...Option) repo_settings.Querier {
 	option := parseOptions(opts...)
@@ -141,6 +187,46 @@ func (s *repos) TransferTransactions(opts ...Option) repo_transfer_transactions.
 	return s.transferTransactions
 }
 
+// SweepRules
+func (s *repos) SweepRules(opts ...Option) repo_sweep_rules.Querier {
+	options := parseOptions(opts...)
+	if options.Tx != nil {
+		return s.sweepRules.WithTx(options.Tx)
+	}
+
+	return s.sweepRules
+}
+
+// MnemonicRotations
+func (s *repos) MnemonicRotations(opts ...Option) repo_mnemonic_rotations.Querier {
+	options := parseOptions(opts...)
+	if options.Tx != nil {
+		return s.mnemonicRotations.WithTx(options.Tx)
+	}
+
+	return s.mnemonicRotations
+}
+
+// AuditEvents
+func (s *repos) AuditEvents(opts ...Option) repo_audit_events.ICustomQuerier {
+	options := parseOptions(opts...)
+	if options.Tx != nil {
+		return s.auditEvents.WithTx(options.Tx)
+	}
+
+	return s.auditEvents
+}
+
+// OwnerTwoFactor
+func (s *repos) OwnerTwoFactor(opts ...Option) repo_owner_two_factor.Querier {
+	options := parseOptions(opts...)
+	if options.Tx != nil {
+		return s.ownerTwoFactor.WithTx(options.Tx)
+	}
+
+	return s.ownerTwoFactor
+}
+
 // System
 func (s *repos) System() repo_system.ICustomQuerier {
 	return s.system
@@ -1,9 +1,11 @@
 package repos
 
 import (
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_cold_wallet_distributions"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_cold"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot"
+	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_hot_pool"
 	"github.com/dv-net/dv-processing/internal/store/repos/repo_wallets_processing"
 	"github.com/dv-net/dv-processing/pkg/postgres"
 )
@@ -11,14 +13,18 @@ import (
 type IWallets interface {
 	Common(opts ...Option) repo_wallets.Querier
 	Hot(opts ...Option) repo_wallets_hot.ICustomQuerier
+	HotPool(opts ...Option) repo_wallets_hot_pool.Querier
 	Cold(opts ...Option) repo_wallets_cold.ICustomQuerier
+	ColdDistributions(opts ...Option) repo_cold_wallet_distributions.Querier
 	Processing(opts ...Option) repo_wallets_processing.ICustomQuerier
 }
 
 type wallets struct {
 	common     *repo_wallets.Queries
 	hot        *repo_wallets_hot.CustomQuerier
+	hotPool    *repo_wallets_hot_pool.Queries
 	cold       *repo_wallets_cold.CustomQuerier
+	coldDistr  *repo_cold_wallet_distributions.Queries
 	processing *repo_wallets_processing.CustomQuerier
 }
 
@@ -26,7 +32,9 @@ func newWalletsRepo(psql *postgres.Postgres) IWallets {
 	return &wallets{
 		common:     repo_wallets.New(psql.DB),
 		hot:        repo_wallets_hot.NewCustom(psql.DB),
+		hotPool:    repo_wallets_hot_pool.New(psql.DB),
 		cold:       repo_wallets_cold.NewCustom(psql.DB),
+		coldDistr:  repo_cold_wallet_distributions.New(psql.DB),
 		processing: repo_wallets_processing.NewCustom(psql.DB),
 	}
 }
@@ -51,6 +59,16 @@ func (s *wallets) Hot(opts ...Option) repo_wallets_hot.ICustomQuerier {
 	return s.hot
 }
 
+func (s *wallets) HotPool(opts ...Option) repo_wallets_hot_pool.Querier {
+	options := parseOptions(opts...)
+
+	if options.Tx != nil {
+		return s.hotPool.WithTx(options.Tx)
+	}
+
+	return s.hotPool
+}
+
 func (s *wallets) Cold(opts ...Option) repo_wallets_cold.ICustomQuerier {
 	options := parseOptions(opts...)
 
@@ -61,6 +79,16 @@ func (s *wallets) Cold(opts ...Option) repo_wallets_cold.ICustomQuerier {
 	return s.cold
 }
 
+func (s *wallets) ColdDistributions(opts ...Option) repo_cold_wallet_distributions.Querier {
+	options := parseOptions(opts...)
+
+	if options.Tx != nil {
+		return s.coldDistr.WithTx(options.Tx)
+	}
+
+	return s.coldDistr
+}
+
 func (s *wallets) Processing(opts ...Option) repo_wallets_processing.ICustomQuerier {
 	options := parseOptions(opts...)
 
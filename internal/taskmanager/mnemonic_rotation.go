@@ -0,0 +1,53 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/services/baseservices"
+
+	"github.com/dv-net/mx/logger"
+	"github.com/riverqueue/river"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	MnemonicRotationPeriodicJob = "mnemonic_rotation"
+)
+
+func getMnemonicRotationJob(cronRule string) (*river.PeriodicJob, error) {
+	s, err := cron.ParseStandard(cronRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return river.NewPeriodicJob(s, func() (river.JobArgs, *river.InsertOpts) {
+		return MnemonicRotationJobArgs{}, nil
+	}, &river.PeriodicJobOpts{
+		RunOnStart: true,
+	}), nil
+}
+
+type MnemonicRotationJobArgs struct{}
+
+func (MnemonicRotationJobArgs) Kind() string { return MnemonicRotationPeriodicJob }
+
+type MnemonicRotationWorker struct {
+	river.WorkerDefaults[MnemonicRotationJobArgs]
+
+	logger logger.Logger
+	bs     baseservices.IBaseServices
+}
+
+func (s *MnemonicRotationWorker) Timeout(*river.Job[MnemonicRotationJobArgs]) time.Duration {
+	return -1
+}
+
+func (s *MnemonicRotationWorker) Work(ctx context.Context, _ *river.Job[MnemonicRotationJobArgs]) error {
+	if err := s.bs.Rotations().Run(ctx); err != nil {
+		return fmt.Errorf("run mnemonic rotations: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/config"
+	"github.com/dv-net/dv-processing/internal/services/baseservices"
+
+	"github.com/dv-net/mx/logger"
+	"github.com/riverqueue/river"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	RequestNonceCleanupPeriodicJob = "request_nonce_cleanup"
+)
+
+func getRequestNonceCleanupJob(cronRule string) (*river.PeriodicJob, error) {
+	s, err := cron.ParseStandard(cronRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return river.NewPeriodicJob(s, func() (river.JobArgs, *river.InsertOpts) {
+		return RequestNonceCleanupJobArgs{}, nil
+	}, &river.PeriodicJobOpts{
+		RunOnStart: true,
+	}), nil
+}
+
+type RequestNonceCleanupJobArgs struct{}
+
+func (RequestNonceCleanupJobArgs) Kind() string { return RequestNonceCleanupPeriodicJob }
+
+type RequestNonceCleanupWorker struct {
+	river.WorkerDefaults[RequestNonceCleanupJobArgs]
+
+	logger logger.Logger
+	config *config.Config
+
+	bs baseservices.IBaseServices
+}
+
+func (s *RequestNonceCleanupWorker) Work(ctx context.Context, _ *river.Job[RequestNonceCleanupJobArgs]) error {
+	// the request is accepted within the clock skew from its timestamp in both directions,
+	// so the nonce must be kept for twice the clock skew
+	affectedRows, err := s.bs.Clients().DeleteExpiredRequestNonces(ctx, time.Now().Add(-2*s.config.Interceptors.SignV2.ClockSkew))
+	if err != nil {
+		return fmt.Errorf("failed to delete expired request nonces: %w", err)
+	}
+
+	if affectedRows > 0 {
+		s.logger.Infof("deleted %d expired request nonces", affectedRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dv-net/dv-processing/internal/services/baseservices"
+
+	"github.com/dv-net/mx/logger"
+	"github.com/riverqueue/river"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	SweepPeriodicJob = "sweep_hot_wallets"
+)
+
+func getSweepJob(cronRule string) (*river.PeriodicJob, error) {
+	s, err := cron.ParseStandard(cronRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return river.NewPeriodicJob(s, func() (river.JobArgs, *river.InsertOpts) {
+		return SweepJobArgs{}, nil
+	}, &river.PeriodicJobOpts{
+		RunOnStart: true,
+	}), nil
+}
+
+type SweepJobArgs struct{}
+
+func (SweepJobArgs) Kind() string { return SweepPeriodicJob }
+
+type SweepWorker struct {
+	river.WorkerDefaults[SweepJobArgs]
+
+	logger logger.Logger
+	bs     baseservices.IBaseServices
+}
+
+func (s *SweepWorker) Timeout(*river.Job[SweepJobArgs]) time.Duration {
+	return -1
+}
+
+func (s *SweepWorker) Work(ctx context.Context, _ *river.Job[SweepJobArgs]) error {
+	if err := s.bs.Sweeps().Run(ctx); err != nil {
+		return fmt.Errorf("run sweeps: %w", err)
+	}
+
+	return nil
+}
@@ -56,6 +56,45 @@ func New(
 		periodicJobs = append(periodicJobs, cr)
 	}
 
+	if conf.Sweeps.Enabled {
+		river.AddWorker(workers, &SweepWorker{
+			logger: l,
+			bs:     bs,
+		})
+
+		cr, err := getSweepJob(conf.Sweeps.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("sweep job: %w", err)
+		}
+
+		periodicJobs = append(periodicJobs, cr)
+	}
+
+	river.AddWorker(workers, &MnemonicRotationWorker{
+		logger: l,
+		bs:     bs,
+	})
+
+	rotationJob, err := getMnemonicRotationJob(conf.MnemonicRotations.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("mnemonic rotation job: %w", err)
+	}
+
+	periodicJobs = append(periodicJobs, rotationJob)
+
+	river.AddWorker(workers, &RequestNonceCleanupWorker{
+		logger: l,
+		config: conf,
+		bs:     bs,
+	})
+
+	nonceCleanupJob, err := getRequestNonceCleanupJob(conf.Interceptors.SignV2.NonceCleanupCron)
+	if err != nil {
+		return nil, fmt.Errorf("request nonce cleanup job: %w", err)
+	}
+
+	periodicJobs = append(periodicJobs, nonceCleanupJob)
+
 	riverClient, err := river.NewClient(riverpgxv5.New(st.PSQLConn()), &river.Config{
 		Queues: map[string]river.QueueConfig{
 			river.QueueDefault: {MaxWorkers: 50},
@@ -165,7 +165,7 @@ func (s *TransferWorkflowWorker) handleEvmFSM(ctx context.Context, transfer *mod
 		return fmt.Errorf("%s blockchain is disabled in config: %w", transfer.Blockchain.String(), river.JobSnooze(time.Minute))
 	}
 
-	fsm, err := fsmevm.NewFSM(s.logger, evmConfig, s.config.IsEnabledSeedEncryption(), s.store, s.bs, evmInstance, transfer)
+	fsm, err := fsmevm.NewFSM(s.logger, evmConfig, s.store, s.bs, evmInstance, transfer)
 	if err != nil {
 		return fmt.Errorf("create %s fsm: %w", transfer.Blockchain.String(), err)
 	}
@@ -1,8 +1,10 @@
 package util //nolint:nolintlint,revive
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"strings"
 )
 
 func SHA256Signature(data []byte, secretKey string) string {
@@ -10,3 +12,12 @@ func SHA256Signature(data []byte, secretKey string) string {
 	sign.Write(append(data, []byte(secretKey)...))
 	return hex.EncodeToString(sign.Sum(nil))
 }
+
+// HMACSignatureV2 returns the hex encoded HMAC-SHA256 of the request. The signed message is
+// the http method, the procedure, the unix timestamp, the nonce and the body separated by new lines.
+func HMACSignatureV2(secretKey, method, procedure, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(strings.Join([]string{method, procedure, timestamp, nonce}, "\n") + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
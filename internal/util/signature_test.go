@@ -0,0 +1,29 @@
+package util_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dv-net/dv-processing/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSignatureV2(t *testing.T) {
+	body := []byte(`{"owner_id":"1"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/processing.transfer.v1.TransferService/Create\n1700000000\nnonce\n"))
+	mac.Write(body)
+
+	sign := util.HMACSignatureV2("secret", "POST", "/processing.transfer.v1.TransferService/Create", "1700000000", "nonce", body)
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), sign)
+
+	// every part of the request is signed
+	require.NotEqual(t, sign, util.HMACSignatureV2("secret", "GET", "/processing.transfer.v1.TransferService/Create", "1700000000", "nonce", body))
+	require.NotEqual(t, sign, util.HMACSignatureV2("secret", "POST", "/processing.owner.v1.OwnerService/Create", "1700000000", "nonce", body))
+	require.NotEqual(t, sign, util.HMACSignatureV2("secret", "POST", "/processing.transfer.v1.TransferService/Create", "1700000001", "nonce", body))
+	require.NotEqual(t, sign, util.HMACSignatureV2("secret", "POST", "/processing.transfer.v1.TransferService/Create", "1700000000", "nonce2", body))
+	require.NotEqual(t, sign, util.HMACSignatureV2("secret2", "POST", "/processing.transfer.v1.TransferService/Create", "1700000000", "nonce", body))
+}
@@ -19,6 +19,7 @@ func TestValidator(t *testing.T) {
 		{"144doNUHK6VjbjJBUeAgMX2WvKqBxLtNow", "mainnet", "bitcoin"},                             // Bitcoin main network (P2PKH)
 		{"3BvMNMiV74tWciG2LXLUmrFunQaEbM4yCK", "mainnet", "bitcoin"},                             // Bitcoin main network (P2SH)
 		{"bc1q5y7mkzgz7r4045ee2x3fjat5pyjlwejjlzszxf", "mainnet", "bitcoin"},                     // Bitcoin main network (bech32)
+		{"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", "mainnet", "bitcoin"}, // Bitcoin main network (taproot)
 		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", "testnet", "bitcoin"},                             // Bitcoin test network (P2PKH)
 		{"2NBFNJTktNa7GZusGbDbGKRZTxdK9VVez3n", "testnet", "bitcoin"},                            // Bitcoin test network (P2SH)
 		{"tb1p0924wz2pcfap83dw7q345uuu0yshgw6jvvmvetpfkstulwdy92nsk3r4af", "testnet", "bitcoin"}, // Bitcoin test network (bech32)
@@ -76,7 +76,11 @@ func Encrypt(data, password string) (string, error) {
 // Decrypt takes a base64-encoded, versioned encrypted string and password,
 // then returns the decrypted plaintext or an error.
 func Decrypt(data, password string) (string, error) {
-	if !IsEncrypted(data) {
+	if IsEnvelope(data) {
+		return "", errors.New("envelope encrypted data requires a data key")
+	}
+
+	if !strings.HasPrefix(data, encryptionPrefix) {
 		return "", errors.New("data is not in recognized encrypted format")
 	}
 	// Remove prefix and decode
@@ -120,7 +124,16 @@ func Decrypt(data, password string) (string, error) {
 	return string(plaintext), nil
 }
 
-// IsEncrypted checks whether the given string has the expected encrypted prefix.
+// IsEncrypted checks whether the given string has one of the encrypted prefixes.
 func IsEncrypted(data string) bool {
-	return strings.HasPrefix(data, encryptionPrefix)
+	return strings.HasPrefix(data, encryptionPrefix) || IsEnvelope(data)
+}
+
+// DecryptOptional decrypts the data like Decrypt, but returns empty data as is.
+// It is used for optional secrets, e.g. the BIP39 passphrase.
+func DecryptOptional(data, password string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	return Decrypt(data, password)
 }
@@ -67,3 +67,25 @@ func BenchmarkDecrypt(b *testing.B) {
 		}
 	}
 }
+
+func TestDecryptOptional(t *testing.T) {
+	decrypted, err := encryption.DecryptOptional("", "testpassword")
+	if err != nil {
+		t.Fatalf("DecryptOptional error: %v", err)
+	}
+	if decrypted != "" {
+		t.Errorf("Expected empty data, got %q", decrypted)
+	}
+
+	cipher, err := encryption.Encrypt("passphrase", "testpassword")
+	if err != nil {
+		t.Fatalf("Encrypt error: %v", err)
+	}
+	decrypted, err = encryption.DecryptOptional(cipher, "testpassword")
+	if err != nil {
+		t.Fatalf("DecryptOptional error: %v", err)
+	}
+	if decrypted != "passphrase" {
+		t.Errorf("Decrypted text %q does not match original %q", decrypted, "passphrase")
+	}
+}
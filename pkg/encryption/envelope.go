@@ -0,0 +1,96 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Prefix of data encrypted with a data key wrapped by a master key
+	envelopePrefix = "ENCv2:"
+
+	// DataKeySize is the size of the AES-256 data keys
+	DataKeySize = 32
+)
+
+var ErrNotEnvelope = errors.New("data is not in envelope encrypted format")
+
+// NewDataKey generates a random data key.
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncryptWithKey encrypts the data with the data key using AES-GCM and returns
+// a base64-encoded string with the ENCv2 prefix. The additional data is authenticated
+// but not stored, e.g. the owner id, so the ciphertext can not be moved to another row.
+func EncryptWithKey(data string, key []byte, additionalData string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	// nonce|ciphertext
+	payload := gcm.Seal(nonce, nonce, []byte(data), []byte(additionalData))
+
+	return envelopePrefix + base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// DecryptWithKey decrypts the data encrypted by EncryptWithKey.
+func DecryptWithKey(data string, key []byte, additionalData string) (string, error) {
+	if !IsEnvelope(data) {
+		return "", ErrNotEnvelope
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(data, envelopePrefix))
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := gcm.Open(nil, raw[:nonceSize], raw[nonceSize:], []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// IsEnvelope checks whether the data is encrypted with a data key.
+func IsEnvelope(data string) bool {
+	return strings.HasPrefix(data, envelopePrefix)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != DataKeySize {
+		return nil, fmt.Errorf("invalid key size: %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,91 @@
+package encryption_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/encryption"
+)
+
+func TestEnvelopeEncryptDecrypt(t *testing.T) {
+	key, err := encryption.NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey error: %v", err)
+	}
+
+	plaintext := "primary unique toss tuition defense alone artefact tube chalk wrist plunge gym"
+	cipher, err := encryption.EncryptWithKey(plaintext, key, "owner-id")
+	if err != nil {
+		t.Fatalf("EncryptWithKey error: %v", err)
+	}
+
+	if !encryption.IsEnvelope(cipher) || !encryption.IsEncrypted(cipher) {
+		t.Errorf("Expected %q to be envelope encrypted", cipher)
+	}
+
+	decrypted, err := encryption.DecryptWithKey(cipher, key, "owner-id")
+	if err != nil {
+		t.Fatalf("DecryptWithKey error: %v", err)
+	}
+	if decrypted != plaintext {
+		t.Errorf("Decrypted text %q does not match original %q", decrypted, plaintext)
+	}
+
+	// the data of one owner can not be moved to another one
+	if _, err := encryption.DecryptWithKey(cipher, key, "another-owner-id"); err == nil {
+		t.Errorf("Expected error for wrong additional data")
+	}
+
+	if _, err := encryption.Decrypt(cipher, "owner-id"); err == nil {
+		t.Errorf("Expected error for password decryption of envelope data")
+	}
+}
+
+func TestStaticKeyProviderRotation(t *testing.T) {
+	oldKey, err := encryption.ParseMasterKey(hex.EncodeToString(make([]byte, 32)))
+	if err != nil {
+		t.Fatalf("ParseMasterKey error: %v", err)
+	}
+
+	newKey, err := encryption.ParseMasterKey("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=")
+	if err != nil {
+		t.Fatalf("ParseMasterKey error: %v", err)
+	}
+
+	dataKey, err := encryption.NewDataKey()
+	if err != nil {
+		t.Fatalf("NewDataKey error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	wrapped, err := encryption.NewStaticKeyProvider(oldKey).WrapKey(ctx, dataKey)
+	if err != nil {
+		t.Fatalf("WrapKey error: %v", err)
+	}
+
+	provider := encryption.NewStaticKeyProvider(newKey, oldKey)
+
+	currentKeyID, err := provider.CurrentKeyID(ctx)
+	if err != nil {
+		t.Fatalf("CurrentKeyID error: %v", err)
+	}
+	if currentKeyID != newKey.ID || provider.WrappedKeyID(wrapped) != oldKey.ID {
+		t.Errorf("Expected the key to be wrapped by the previous master key")
+	}
+
+	// keys wrapped by the previous master key are still readable
+	unwrapped, err := provider.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapKey error: %v", err)
+	}
+	if hex.EncodeToString(unwrapped) != hex.EncodeToString(dataKey) {
+		t.Errorf("Unwrapped key does not match the original one")
+	}
+
+	if _, err := encryption.NewStaticKeyProvider(newKey).UnwrapKey(ctx, wrapped); !errors.Is(err, encryption.ErrUnknownMasterKey) {
+		t.Errorf("Expected ErrUnknownMasterKey, got %v", err)
+	}
+}
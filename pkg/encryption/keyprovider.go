@@ -0,0 +1,195 @@
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrUnknownMasterKey = errors.New("data key is wrapped by an unknown master key")
+
+// KeyProvider wraps and unwraps data keys with a master key which is never stored in the database.
+type KeyProvider interface {
+	// WrapKey encrypts the data key with the current master key.
+	WrapKey(ctx context.Context, dataKey []byte) (string, error)
+	// UnwrapKey decrypts the data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped string) ([]byte, error)
+	// CurrentKeyID returns the id of the master key used by WrapKey.
+	CurrentKeyID(ctx context.Context) (string, error)
+	// WrappedKeyID returns the id of the master key the data key is wrapped by.
+	WrappedKeyID(wrapped string) string
+}
+
+// MasterKey is a 256-bit key held in memory by StaticKeyProvider.
+type MasterKey struct {
+	ID  string
+	Key []byte
+}
+
+// ParseMasterKey parses a hex or base64 encoded 256-bit key.
+// The key id is the fingerprint of the key, so it does not need to be configured.
+func ParseMasterKey(value string) (MasterKey, error) {
+	value = strings.TrimSpace(value)
+
+	key, err := hex.DecodeString(value)
+	if err != nil {
+		key, err = base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return MasterKey{}, errors.New("master key must be hex or base64 encoded")
+		}
+	}
+
+	if len(key) != DataKeySize {
+		return MasterKey{}, fmt.Errorf("master key must be %d bytes, got %d", DataKeySize, len(key))
+	}
+
+	fingerprint := sha256.Sum256(key)
+
+	return MasterKey{
+		ID:  hex.EncodeToString(fingerprint[:4]),
+		Key: key,
+	}, nil
+}
+
+// StaticKeyProvider wraps data keys with AES-GCM using master keys held in memory.
+//
+// The first key is used for wrapping, the others are only used for unwrapping
+// the data keys wrapped before the master key rotation.
+type StaticKeyProvider struct {
+	keys []MasterKey
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+// NewStaticKeyProvider creates a key provider with the current master key and the previous ones.
+func NewStaticKeyProvider(current MasterKey, previous ...MasterKey) *StaticKeyProvider {
+	return &StaticKeyProvider{
+		keys: append([]MasterKey{current}, previous...),
+	}
+}
+
+// NewFileKeyProvider reads the current master key and the optional previous ones from the files.
+func NewFileKeyProvider(path string, previous ...string) (*StaticKeyProvider, error) {
+	keys := make([]MasterKey, 0, len(previous)+1)
+	for _, path := range append([]string{path}, previous...) {
+		key, err := ReadMasterKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return NewStaticKeyProvider(keys[0], keys[1:]...), nil
+}
+
+// NewEnvKeyProvider reads the current master key and the optional previous ones from the environment variables.
+func NewEnvKeyProvider(name string, previous ...string) (*StaticKeyProvider, error) {
+	keys := make([]MasterKey, 0, len(previous)+1)
+	for _, name := range append([]string{name}, previous...) {
+		key, err := ReadMasterKeyEnv(name)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return NewStaticKeyProvider(keys[0], keys[1:]...), nil
+}
+
+// ReadMasterKeyFile reads the master key from the file.
+func ReadMasterKeyFile(path string) (MasterKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MasterKey{}, fmt.Errorf("read master key file: %w", err)
+	}
+
+	key, err := ParseMasterKey(string(data))
+	if err != nil {
+		return MasterKey{}, fmt.Errorf("parse master key file %s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// ReadMasterKeyEnv reads the master key from the environment variable.
+func ReadMasterKeyEnv(name string) (MasterKey, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return MasterKey{}, fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	key, err := ParseMasterKey(value)
+	if err != nil {
+		return MasterKey{}, fmt.Errorf("parse master key from %s: %w", name, err)
+	}
+
+	return key, nil
+}
+
+// CurrentKeyID returns the fingerprint of the current master key.
+func (p *StaticKeyProvider) CurrentKeyID(context.Context) (string, error) {
+	return p.keys[0].ID, nil
+}
+
+// WrappedKeyID returns the fingerprint of the master key the data key is wrapped by.
+func (p *StaticKeyProvider) WrappedKeyID(wrapped string) string {
+	id, _, _ := strings.Cut(wrapped, ":")
+	return id
+}
+
+// WrapKey returns the data key encrypted with the current master key in the `id:base64(nonce|ciphertext)` format.
+func (p *StaticKeyProvider) WrapKey(_ context.Context, dataKey []byte) (string, error) {
+	current := p.keys[0]
+
+	gcm, err := newGCM(current.Key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return current.ID + ":" + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, dataKey, []byte(current.ID))), nil
+}
+
+// UnwrapKey decrypts the data key with the master key it was wrapped by.
+func (p *StaticKeyProvider) UnwrapKey(_ context.Context, wrapped string) ([]byte, error) {
+	id, payload, ok := strings.Cut(wrapped, ":")
+	if !ok {
+		return nil, errors.New("invalid wrapped key format")
+	}
+
+	for _, key := range p.keys {
+		if key.ID != id {
+			continue
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(raw) < nonceSize {
+			return nil, errors.New("wrapped key too short")
+		}
+
+		gcm, err := newGCM(key.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		return gcm.Open(nil, raw[:nonceSize], raw[nonceSize:], []byte(key.ID))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMasterKey, id)
+}
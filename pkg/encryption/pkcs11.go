@@ -0,0 +1,101 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const pkcs11KeyIDPrefix = "pkcs11:"
+
+// KeyWrapper wraps keys with the AES keys stored in a HSM, e.g. hsm.HSM.
+type KeyWrapper interface {
+	WrapKey(label string, key []byte) ([]byte, error)
+	UnwrapKey(label string, wrapped []byte) ([]byte, error)
+}
+
+// PKCS11KeyProvider wraps data keys with the AES key wrap mechanism of a PKCS#11 token.
+// The master key never leaves the token. The wrapped key keeps the label of the master key,
+// so the previous master keys remain usable until the data keys are rotated.
+type PKCS11KeyProvider struct {
+	wrapper  KeyWrapper
+	keyLabel string
+}
+
+var _ KeyProvider = (*PKCS11KeyProvider)(nil)
+
+func NewPKCS11KeyProvider(wrapper KeyWrapper, keyLabel string) (*PKCS11KeyProvider, error) {
+	if keyLabel == "" {
+		return nil, errors.New("pkcs11 key label is required")
+	}
+
+	return &PKCS11KeyProvider{
+		wrapper:  wrapper,
+		keyLabel: keyLabel,
+	}, nil
+}
+
+// WrapKey wraps the data key with the current master key.
+// The result has the format pkcs11:<label>:<base64 wrapped key>.
+func (p *PKCS11KeyProvider) WrapKey(_ context.Context, dataKey []byte) (string, error) {
+	wrapped, err := p.wrapper.WrapKey(p.keyLabel, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("pkcs11 wrap key: %w", err)
+	}
+
+	return pkcs11KeyIDPrefix + p.keyLabel + ":" + base64.StdEncoding.EncodeToString(wrapped), nil
+}
+
+// UnwrapKey unwraps the data key with the master key it was wrapped by.
+func (p *PKCS11KeyProvider) UnwrapKey(_ context.Context, wrapped string) ([]byte, error) {
+	label, data, ok := parsePKCS11Wrapped(wrapped)
+	if !ok {
+		return nil, ErrUnknownMasterKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode wrapped key: %w", err)
+	}
+
+	dataKey, err := p.wrapper.UnwrapKey(label, key)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 unwrap key: %w", err)
+	}
+
+	return dataKey, nil
+}
+
+// CurrentKeyID returns the label of the current master key in the wrapped key prefix format, e.g. pkcs11:master-2.
+func (p *PKCS11KeyProvider) CurrentKeyID(context.Context) (string, error) {
+	return pkcs11KeyIDPrefix + p.keyLabel, nil
+}
+
+// WrappedKeyID returns the label of the master key the data key is wrapped by, e.g. pkcs11:master-1.
+func (p *PKCS11KeyProvider) WrappedKeyID(wrapped string) string {
+	label, _, ok := parsePKCS11Wrapped(wrapped)
+	if !ok {
+		return ""
+	}
+
+	return pkcs11KeyIDPrefix + label
+}
+
+// parsePKCS11Wrapped splits the wrapped key to the master key label and the base64 wrapped key.
+// The label may contain colons, the base64 encoding does not.
+func parsePKCS11Wrapped(wrapped string) (string, string, bool) {
+	if !strings.HasPrefix(wrapped, pkcs11KeyIDPrefix) {
+		return "", "", false
+	}
+
+	rest := strings.TrimPrefix(wrapped, pkcs11KeyIDPrefix)
+
+	idx := strings.LastIndex(rest, ":")
+	if idx <= 0 {
+		return "", "", false
+	}
+
+	return rest[:idx], rest[idx+1:], true
+}
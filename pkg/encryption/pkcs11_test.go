@@ -0,0 +1,76 @@
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/encryption"
+)
+
+// labelWrapper emulates the token, the key is wrapped by prepending the label of the master key.
+type labelWrapper struct {
+	labels map[string]bool
+}
+
+func (w labelWrapper) WrapKey(label string, key []byte) ([]byte, error) {
+	if !w.labels[label] {
+		return nil, errors.New("key not found")
+	}
+
+	return append([]byte(label), key...), nil
+}
+
+func (w labelWrapper) UnwrapKey(label string, wrapped []byte) ([]byte, error) {
+	if !w.labels[label] || !bytes.HasPrefix(wrapped, []byte(label)) {
+		return nil, errors.New("unwrap failed")
+	}
+
+	return wrapped[len(label):], nil
+}
+
+func TestPKCS11KeyProvider(t *testing.T) {
+	wrapper := labelWrapper{labels: map[string]bool{"master:1": true, "master:2": true}}
+
+	previous, err := encryption.NewPKCS11KeyProvider(wrapper, "master:1")
+	if err != nil {
+		t.Fatalf("NewPKCS11KeyProvider error: %v", err)
+	}
+
+	provider, err := encryption.NewPKCS11KeyProvider(wrapper, "master:2")
+	if err != nil {
+		t.Fatalf("NewPKCS11KeyProvider error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	wrapped, err := previous.WrapKey(ctx, []byte("data key"))
+	if err != nil {
+		t.Fatalf("WrapKey error: %v", err)
+	}
+
+	// the data key wrapped by the previous master key is unwrapped by its label
+	unwrapped, err := provider.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapKey error: %v", err)
+	}
+	if string(unwrapped) != "data key" {
+		t.Errorf("Unwrapped key %q does not match the original one", unwrapped)
+	}
+
+	currentKeyID, err := provider.CurrentKeyID(ctx)
+	if err != nil {
+		t.Fatalf("CurrentKeyID error: %v", err)
+	}
+	if currentKeyID != "pkcs11:master:2" {
+		t.Errorf("Expected the current key pkcs11:master:2, got %q", currentKeyID)
+	}
+	if provider.WrappedKeyID(wrapped) != "pkcs11:master:1" {
+		t.Errorf("Expected the key to be wrapped by pkcs11:master:1, got %q", provider.WrappedKeyID(wrapped))
+	}
+
+	if _, err := provider.UnwrapKey(ctx, "vault:v1:ciphertext"); !errors.Is(err, encryption.ErrUnknownMasterKey) {
+		t.Errorf("Expected ErrUnknownMasterKey, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultVaultTimeout = 10 * time.Second
+
+type VaultTransitConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	Token   string
+	// Mount is the path of the transit secrets engine, transit by default
+	Mount   string
+	KeyName string
+	Timeout time.Duration
+}
+
+// VaultTransitKeyProvider wraps data keys with the Vault transit secrets engine
+// or any other service with a compatible HTTP API. The master key never leaves the Vault.
+type VaultTransitKeyProvider struct {
+	conf   VaultTransitConfig
+	client *http.Client
+}
+
+var _ KeyProvider = (*VaultTransitKeyProvider)(nil)
+
+func NewVaultTransitKeyProvider(conf VaultTransitConfig) (*VaultTransitKeyProvider, error) {
+	if conf.Address == "" {
+		return nil, errors.New("vault address is required")
+	}
+
+	if conf.KeyName == "" {
+		return nil, errors.New("vault transit key name is required")
+	}
+
+	if conf.Mount == "" {
+		conf.Mount = "transit"
+	}
+
+	if conf.Timeout == 0 {
+		conf.Timeout = defaultVaultTimeout
+	}
+
+	return &VaultTransitKeyProvider{
+		conf:   conf,
+		client: &http.Client{Timeout: conf.Timeout},
+	}, nil
+}
+
+// WrapKey encrypts the data key with the latest version of the transit key.
+// The result is the vault ciphertext, e.g. vault:v1:...
+func (p *VaultTransitKeyProvider) WrapKey(ctx context.Context, dataKey []byte) (string, error) {
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+
+	if err := p.call(ctx, http.MethodPost, "encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}, &res); err != nil {
+		return "", err
+	}
+
+	if res.Data.Ciphertext == "" {
+		return "", errors.New("vault returned empty ciphertext")
+	}
+
+	return res.Data.Ciphertext, nil
+}
+
+// UnwrapKey decrypts the data key with the transit key version it was wrapped by.
+func (p *VaultTransitKeyProvider) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+
+	if err := p.call(ctx, http.MethodPost, "decrypt", map[string]string{"ciphertext": wrapped}, &res); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(res.Data.Plaintext)
+}
+
+// CurrentKeyID returns the latest version of the transit key in the ciphertext prefix format, e.g. vault:v2.
+func (p *VaultTransitKeyProvider) CurrentKeyID(ctx context.Context) (string, error) {
+	var res struct {
+		Data struct {
+			LatestVersion int `json:"latest_version"`
+		} `json:"data"`
+	}
+
+	if err := p.call(ctx, http.MethodGet, "keys", nil, &res); err != nil {
+		return "", err
+	}
+
+	if res.Data.LatestVersion == 0 {
+		return "", errors.New("vault returned empty key version")
+	}
+
+	return fmt.Sprintf("vault:v%d", res.Data.LatestVersion), nil
+}
+
+// WrappedKeyID returns the transit key version of the ciphertext, e.g. vault:v1.
+func (p *VaultTransitKeyProvider) WrappedKeyID(wrapped string) string {
+	parts := strings.SplitN(wrapped, ":", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+
+	return parts[0] + ":" + parts[1]
+}
+
+func (p *VaultTransitKeyProvider) call(ctx context.Context, method, operation string, body any, res any) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		payload = bytes.NewReader(data)
+	}
+
+	url := strings.TrimRight(p.conf.Address, "/") + "/v1/" + strings.Trim(p.conf.Mount, "/") + "/" + operation + "/" + p.conf.KeyName
+
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.conf.Token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read vault response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault %s: unexpected status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("decode vault response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package encryption_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/encryption"
+)
+
+// newTransitServer emulates the vault transit api, the plaintext is stored as the ciphertext.
+func newTransitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		var req map[string]string
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		var data map[string]any
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/processing":
+			data = map[string]any{"ciphertext": "vault:v2:" + req["plaintext"]}
+		case "/v1/transit/decrypt/processing":
+			data = map[string]any{"plaintext": strings.SplitN(req["ciphertext"], ":", 3)[2]}
+		case "/v1/transit/keys/processing":
+			data = map[string]any{"latest_version": 2}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func TestVaultTransitKeyProvider(t *testing.T) {
+	srv := newTransitServer(t)
+	defer srv.Close()
+
+	provider, err := encryption.NewVaultTransitKeyProvider(encryption.VaultTransitConfig{
+		Address: srv.URL,
+		Token:   "token",
+		KeyName: "processing",
+	})
+	if err != nil {
+		t.Fatalf("NewVaultTransitKeyProvider error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	wrapped, err := provider.WrapKey(ctx, []byte("data key"))
+	if err != nil {
+		t.Fatalf("WrapKey error: %v", err)
+	}
+
+	unwrapped, err := provider.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapKey error: %v", err)
+	}
+	if string(unwrapped) != "data key" {
+		t.Errorf("Unwrapped key %q does not match the original one", unwrapped)
+	}
+
+	currentKeyID, err := provider.CurrentKeyID(ctx)
+	if err != nil {
+		t.Fatalf("CurrentKeyID error: %v", err)
+	}
+	if currentKeyID != "vault:v2" || provider.WrappedKeyID(wrapped) != currentKeyID {
+		t.Errorf("Expected the key to be wrapped by vault:v2, got %q", provider.WrappedKeyID(wrapped))
+	}
+
+	if provider.WrappedKeyID("vault:v1:ciphertext") == currentKeyID {
+		t.Errorf("Expected the previous key version not to be current")
+	}
+}
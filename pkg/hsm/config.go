@@ -0,0 +1,31 @@
+package hsm
+
+import "errors"
+
+var (
+	ErrKeyNotFound = errors.New("key not found in the token")
+	ErrKeyExists   = errors.New("key already exists in the token")
+)
+
+type Config struct {
+	// ModulePath is the path of the PKCS#11 library, e.g. /usr/lib/softhsm/libsofthsm2.so
+	ModulePath string
+	TokenLabel string
+	Pin        string
+}
+
+func (c Config) validate() error {
+	if c.ModulePath == "" {
+		return errors.New("pkcs11 module path is required")
+	}
+
+	if c.TokenLabel == "" {
+		return errors.New("pkcs11 token label is required")
+	}
+
+	if c.Pin == "" {
+		return errors.New("pkcs11 pin is required")
+	}
+
+	return nil
+}
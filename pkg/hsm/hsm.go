@@ -0,0 +1,372 @@
+//go:build cgo
+
+package hsm
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// aesKeySize is the size of the AES wrapping keys in bytes
+const aesKeySize = 32
+
+// secp256k1OID is the DER encoded object identifier of the secp256k1 curve, the CKA_EC_PARAMS value
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// HSM is a PKCS#11 token holding the master keys and the wallet keys.
+//
+// The user session is opened once and kept until Close, the operations
+// open their own sessions, so the HSM can be used concurrently.
+type HSM struct {
+	ctx  *pkcs11.Ctx
+	slot uint
+
+	loginSession pkcs11.SessionHandle
+
+	// public keys by the key labels, the private keys are never read
+	publicKeys sync.Map
+}
+
+// Open loads the PKCS#11 module and logs in to the token.
+func Open(conf Config) (*HSM, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	p11 := pkcs11.New(conf.ModulePath)
+	if p11 == nil {
+		return nil, fmt.Errorf("load pkcs11 module %s", conf.ModulePath)
+	}
+
+	if err := p11.Initialize(); err != nil {
+		p11.Destroy()
+		return nil, fmt.Errorf("initialize pkcs11 module: %w", err)
+	}
+
+	h := &HSM{ctx: p11}
+
+	slot, err := h.findSlot(conf.TokenLabel)
+	if err != nil {
+		h.finalize()
+		return nil, err
+	}
+	h.slot = slot
+
+	h.loginSession, err = p11.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		h.finalize()
+		return nil, fmt.Errorf("open session: %w", err)
+	}
+
+	if err := p11.Login(h.loginSession, pkcs11.CKU_USER, conf.Pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = p11.CloseSession(h.loginSession)
+		h.finalize()
+		return nil, fmt.Errorf("login to token %s: %w", conf.TokenLabel, err)
+	}
+
+	return h, nil
+}
+
+// Close logs out and unloads the module.
+func (h *HSM) Close() error {
+	_ = h.ctx.Logout(h.loginSession)
+	_ = h.ctx.CloseSession(h.loginSession)
+	h.finalize()
+
+	return nil
+}
+
+func (h *HSM) finalize() {
+	_ = h.ctx.Finalize()
+	h.ctx.Destroy()
+}
+
+func (h *HSM) findSlot(tokenLabel string) (uint, error) {
+	slots, err := h.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("get slot list: %w", err)
+	}
+
+	for _, slot := range slots {
+		info, err := h.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("get token info: %w", err)
+		}
+
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("token %s not found", tokenLabel)
+}
+
+// withSession runs fn in a new session of the logged in token.
+func (h *HSM) withSession(fn func(session pkcs11.SessionHandle) error) error {
+	session, err := h.ctx.OpenSession(h.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		return fmt.Errorf("open session: %w", err)
+	}
+	defer func() { _ = h.ctx.CloseSession(session) }()
+
+	return fn(session)
+}
+
+// findObject returns the object of the class with the label.
+func (h *HSM) findObject(session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+
+	if err := h.ctx.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("find objects: %w", err)
+	}
+
+	objects, _, err := h.ctx.FindObjects(session, 1)
+	if finalErr := h.ctx.FindObjectsFinal(session); err == nil && finalErr != nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("find objects: %w", err)
+	}
+
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, label)
+	}
+
+	return objects[0], nil
+}
+
+// GenerateAESKey generates the 256-bit AES key which wraps the data keys.
+func (h *HSM) GenerateAESKey(label string) error {
+	return h.withSession(func(session pkcs11.SessionHandle) error {
+		if _, err := h.findObject(session, pkcs11.CKO_SECRET_KEY, label); err == nil {
+			return fmt.Errorf("%w: %s", ErrKeyExists, label)
+		}
+
+		_, err := h.ctx.GenerateKey(session,
+			[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+			[]*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+				pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+				pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, aesKeySize),
+				pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+				pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+				pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+				pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+				pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+				pkcs11.NewAttribute(pkcs11.CKA_WRAP, true),
+				pkcs11.NewAttribute(pkcs11.CKA_UNWRAP, true),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("generate aes key: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// WrapKey wraps the key with the AES key of the label by the AES key wrap (RFC 3394).
+// The key length must be a multiple of 8 bytes.
+func (h *HSM) WrapKey(label string, key []byte) ([]byte, error) {
+	if len(key) == 0 || len(key)%8 != 0 {
+		return nil, fmt.Errorf("key length must be a multiple of 8 bytes, got %d", len(key))
+	}
+
+	var wrapped []byte
+	err := h.withSession(func(session pkcs11.SessionHandle) error {
+		wrappingKey, err := h.findObject(session, pkcs11.CKO_SECRET_KEY, label)
+		if err != nil {
+			return err
+		}
+
+		// the key is imported as a session object, so it is destroyed with the session
+		keyObject, err := h.ctx.CreateObject(session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE, key),
+		})
+		if err != nil {
+			return fmt.Errorf("create key object: %w", err)
+		}
+
+		wrapped, err = h.ctx.WrapKey(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_WRAP, nil)}, wrappingKey, keyObject)
+		if err != nil {
+			return fmt.Errorf("wrap key: %w", err)
+		}
+
+		return nil
+	})
+
+	return wrapped, err
+}
+
+// UnwrapKey unwraps the key wrapped by WrapKey with the AES key of the label.
+func (h *HSM) UnwrapKey(label string, wrapped []byte) ([]byte, error) {
+	var key []byte
+	err := h.withSession(func(session pkcs11.SessionHandle) error {
+		wrappingKey, err := h.findObject(session, pkcs11.CKO_SECRET_KEY, label)
+		if err != nil {
+			return err
+		}
+
+		keyObject, err := h.ctx.UnwrapKey(session,
+			[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_WRAP, nil)},
+			wrappingKey,
+			wrapped,
+			[]*pkcs11.Attribute{
+				pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+				pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+				pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+				pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, false),
+				pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unwrap key: %w", err)
+		}
+
+		attrs, err := h.ctx.GetAttributeValue(session, keyObject, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil),
+		})
+		if err != nil {
+			return fmt.Errorf("get key value: %w", err)
+		}
+
+		key = attrs[0].Value
+
+		return nil
+	})
+
+	return key, err
+}
+
+// ImportSecp256k1Key stores the private key and its public key with the label.
+// The private key can not be read from the token after the import.
+func (h *HSM) ImportSecp256k1Key(label string, key *ecdsa.PrivateKey) error {
+	ecPoint, err := marshalECPoint(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	err = h.withSession(func(session pkcs11.SessionHandle) error {
+		if _, err := h.findObject(session, pkcs11.CKO_PRIVATE_KEY, label); err == nil {
+			return fmt.Errorf("%w: %s", ErrKeyExists, label)
+		}
+
+		if _, err := h.ctx.CreateObject(session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, ecPoint),
+		}); err != nil {
+			return fmt.Errorf("create public key: %w", err)
+		}
+
+		if _, err := h.ctx.CreateObject(session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE, key.D.FillBytes(make([]byte, 32))),
+		}); err != nil {
+			return fmt.Errorf("create private key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	h.publicKeys.Store(label, &key.PublicKey)
+
+	return nil
+}
+
+// PublicKey returns the secp256k1 public key of the label.
+func (h *HSM) PublicKey(label string) (*ecdsa.PublicKey, error) {
+	if pub, ok := h.publicKeys.Load(label); ok {
+		return pub.(*ecdsa.PublicKey), nil //nolint:forcetypeassert
+	}
+
+	var pub *ecdsa.PublicKey
+	err := h.withSession(func(session pkcs11.SessionHandle) error {
+		object, err := h.findObject(session, pkcs11.CKO_PUBLIC_KEY, label)
+		if err != nil {
+			return err
+		}
+
+		attrs, err := h.ctx.GetAttributeValue(session, object, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			return fmt.Errorf("get public key: %w", err)
+		}
+
+		pub, err = unmarshalECPoint(attrs[0].Value)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.publicKeys.Store(label, pub)
+
+	return pub, nil
+}
+
+// SignSecp256k1 signs the 32 bytes hash with the private key of the label.
+// The result is the 65 bytes [R || S || V] signature with the low S value,
+// the format of the go-ethereum crypto.Sign.
+func (h *HSM) SignSecp256k1(label string, hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash must be 32 bytes, got %d", len(hash))
+	}
+
+	pub, err := h.PublicKey(label)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []byte
+	err = h.withSession(func(session pkcs11.SessionHandle) error {
+		object, err := h.findObject(session, pkcs11.CKO_PRIVATE_KEY, label)
+		if err != nil {
+			return err
+		}
+
+		if err := h.ctx.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, object); err != nil {
+			return fmt.Errorf("sign init: %w", err)
+		}
+
+		rs, err = h.ctx.Sign(session, hash)
+		if err != nil {
+			return fmt.Errorf("sign: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoverableSignature(hash, rs, pub)
+}
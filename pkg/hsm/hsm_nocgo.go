@@ -0,0 +1,37 @@
+//go:build !cgo
+
+package hsm
+
+import (
+	"crypto/ecdsa"
+	"errors"
+)
+
+// errNoCGO is returned by the builds without cgo, the PKCS#11 modules are loaded by cgo
+var errNoCGO = errors.New("pkcs11 requires the build with cgo enabled")
+
+// HSM is a PKCS#11 token holding the master keys and the wallet keys.
+type HSM struct{}
+
+// Open always fails in the builds without cgo.
+func Open(conf Config) (*HSM, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	return nil, errNoCGO
+}
+
+func (h *HSM) Close() error { return nil }
+
+func (h *HSM) GenerateAESKey(string) error { return errNoCGO }
+
+func (h *HSM) WrapKey(string, []byte) ([]byte, error) { return nil, errNoCGO }
+
+func (h *HSM) UnwrapKey(string, []byte) ([]byte, error) { return nil, errNoCGO }
+
+func (h *HSM) ImportSecp256k1Key(string, *ecdsa.PrivateKey) error { return errNoCGO }
+
+func (h *HSM) PublicKey(string) (*ecdsa.PublicKey, error) { return nil, errNoCGO }
+
+func (h *HSM) SignSecp256k1(string, []byte) ([]byte, error) { return nil, errNoCGO }
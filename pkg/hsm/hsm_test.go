@@ -0,0 +1,86 @@
+//go:build cgo
+
+package hsm_test
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/hsm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestHSM opens the token configured by the environment, e.g. with SoftHSM:
+//
+//	softhsm2-util --init-token --free --label dv-processing --pin 1234 --so-pin 1234
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN_LABEL=dv-processing PKCS11_PIN=1234 go test ./pkg/hsm/...
+func openTestHSM(t *testing.T) *hsm.HSM {
+	t.Helper()
+
+	conf := hsm.Config{
+		ModulePath: os.Getenv("PKCS11_MODULE"),
+		TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+		Pin:        os.Getenv("PKCS11_PIN"),
+	}
+
+	if conf.ModulePath == "" {
+		t.Skip("PKCS11_MODULE is not set")
+	}
+
+	h, err := hsm.Open(conf)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = h.Close() })
+
+	return h
+}
+
+func TestWrapKey(t *testing.T) {
+	h := openTestHSM(t)
+
+	label := "test-wrap-" + uuid.NewString()
+	require.NoError(t, h.GenerateAESKey(label))
+	require.ErrorIs(t, h.GenerateAESKey(label), hsm.ErrKeyExists)
+
+	dataKey := make([]byte, 32)
+	_, err := rand.Read(dataKey)
+	require.NoError(t, err)
+
+	wrapped, err := h.WrapKey(label, dataKey)
+	require.NoError(t, err)
+	require.NotEqual(t, dataKey, wrapped)
+
+	unwrapped, err := h.UnwrapKey(label, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	_, err = h.WrapKey("test-wrap-"+uuid.NewString(), dataKey)
+	require.ErrorIs(t, err, hsm.ErrKeyNotFound)
+}
+
+func TestSignSecp256k1(t *testing.T) {
+	h := openTestHSM(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	label := "test-sign-" + uuid.NewString()
+	require.NoError(t, h.ImportSecp256k1Key(label, key))
+	require.ErrorIs(t, h.ImportSecp256k1Key(label, key), hsm.ErrKeyExists)
+
+	pub, err := h.PublicKey(label)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey.Equal(pub))
+
+	hash := crypto.Keccak256([]byte("dv-processing"))
+
+	sig, err := h.SignSecp256k1(label, hash)
+	require.NoError(t, err)
+
+	recovered, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*recovered))
+}
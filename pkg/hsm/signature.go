@@ -0,0 +1,67 @@
+package hsm
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// marshalECPoint returns the CKA_EC_POINT value, the DER encoded octet string of the uncompressed point.
+func marshalECPoint(pub *ecdsa.PublicKey) ([]byte, error) {
+	point, err := asn1.Marshal(crypto.FromECDSAPub(pub))
+	if err != nil {
+		return nil, fmt.Errorf("marshal ec point: %w", err)
+	}
+
+	return point, nil
+}
+
+// unmarshalECPoint parses the CKA_EC_POINT value. Some tokens return the raw point without the octet string.
+func unmarshalECPoint(data []byte) (*ecdsa.PublicKey, error) {
+	var point []byte
+	if rest, err := asn1.Unmarshal(data, &point); err != nil || len(rest) != 0 {
+		point = data
+	}
+
+	pub, err := crypto.UnmarshalPubkey(point)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ec point: %w", err)
+	}
+
+	return pub, nil
+}
+
+// recoverableSignature converts the [R || S] signature of the token to the [R || S || V] one.
+func recoverableSignature(hash, rs []byte, pub *ecdsa.PublicKey) ([]byte, error) {
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("invalid signature length %d", len(rs))
+	}
+
+	// the tokens return any valid S, the chains accept the low one only
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(crypto.S256().Params().N, s)
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[:32], rs[:32])
+	s.FillBytes(sig[32:64])
+
+	expected := crypto.FromECDSAPub(pub)
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+
+		recovered, err := crypto.Ecrecover(hash, sig)
+		if err == nil && string(recovered) == string(expected) {
+			return sig, nil
+		}
+	}
+
+	return nil, errors.New("signature does not match the public key")
+}
@@ -0,0 +1,56 @@
+package hsm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverableSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	hash := crypto.Keccak256([]byte("dv-processing"))
+
+	expected, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+
+	// the token signature is [R || S] without the recovery id
+	sig, err := recoverableSignature(hash, expected[:64], &key.PublicKey)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	// the high S value is normalized
+	highS := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(expected[32:64]))
+	rs := append(append([]byte{}, expected[:32]...), highS.FillBytes(make([]byte, 32))...)
+
+	sig, err = recoverableSignature(hash, rs, &key.PublicKey)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	// the signature of another key
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	_, err = recoverableSignature(hash, expected[:64], &otherKey.PublicKey)
+	require.Error(t, err)
+}
+
+func TestECPoint(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	point, err := marshalECPoint(&key.PublicKey)
+	require.NoError(t, err)
+
+	pub, err := unmarshalECPoint(point)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey.Equal(pub))
+
+	// raw point without the octet string
+	pub, err = unmarshalECPoint(crypto.FromECDSAPub(&key.PublicKey))
+	require.NoError(t, err)
+	require.True(t, key.PublicKey.Equal(pub))
+}
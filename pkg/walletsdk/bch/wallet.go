@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/go-bip39"
 	"github.com/gcash/bchd/bchec"
 	"github.com/gcash/bchd/chaincfg"
@@ -54,7 +55,7 @@ func (s GenerateAddressData) AddressPubKey() (string, error) {
 	return address.String(), nil
 }
 
-func (s WalletSDK) GenerateAddress(mnemonic, passphrase string, sequenceNumber uint32) (*GenerateAddressData, error) {
+func (s WalletSDK) GenerateAddress(mnemonic, passphrase string, sequenceNumber uint32, opts ...derivation.Option) (*GenerateAddressData, error) {
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return nil, fmt.Errorf("invalid mnemonic")
 	}
@@ -66,34 +67,18 @@ func (s WalletSDK) GenerateAddress(mnemonic, passphrase string, sequenceNumber u
 		return nil, fmt.Errorf("failed to create master key: %w", err)
 	}
 
-	// Derive the purpose key (44' for BIP-44)
-	purposeKey, err := masterKey.Child(hdkeychain.HardenedKeyStart + 44)
+	// Default derivation path: m / 44' / coin' / account' / 0 / sequenceNumber.
+	path, err := derivation.NewPath(opts...).Indexes(44, s.chainParams.HDCoinType, sequenceNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive purpose key (44'): %w", err)
+		return nil, err
 	}
 
-	// Derive the coin type key (145' for BCH)
-	coinTypeKey, err := purposeKey.Child(hdkeychain.HardenedKeyStart + s.chainParams.HDCoinType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive coin type key (145'): %w", err)
-	}
-
-	// Derive the account key (0' for the first account)
-	accountKey, err := coinTypeKey.Child(hdkeychain.HardenedKeyStart + 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive account key (0'): %w", err)
-	}
-
-	// Derive the change key (0 for external addresses)
-	changeKey, err := accountKey.Child(0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive change key: %w", err)
-	}
-
-	// Derive the address key using the sequence number
-	addrKey, err := changeKey.Child(sequenceNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive address key: %w", err)
+	addrKey := masterKey
+	for _, idx := range path {
+		addrKey, err = addrKey.Child(idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
 	}
 
 	// Generate the public key from the address key
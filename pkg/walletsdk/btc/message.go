@@ -0,0 +1,243 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// MessageMagic is the prefix of the signed messages.
+const MessageMagic = "Bitcoin Signed Message:\n"
+
+const (
+	compactSignatureLength = 65
+	maxWitnessItemSize     = 520
+
+	// BIP-137 header offsets of the compressed key signatures by address type
+	headerP2PKH       = 27 + 4
+	headerP2SHP2WPKH  = 27 + 8
+	headerP2WPKH      = 27 + 12
+	headerRecoveryIDs = 4
+)
+
+var bip322Tag = []byte("BIP0322-signed-message")
+
+// SignMessage signs the message with the key of the address.
+func (s WalletSDK) SignMessage(data *GenerateAddressData, message string) (string, error) {
+	pkScript, err := txscript.PayToAddrScript(data.Address)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pk script: %w", err)
+	}
+
+	return SignMessage(MessageMagic, data.PrivateKey, pkScript, message)
+}
+
+// VerifyMessage verifies the signature of the message made by the key of the address.
+func (s WalletSDK) VerifyMessage(address, message, signature string) (bool, error) {
+	addr, err := btcutil.DecodeAddress(address, s.chainParams)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode address: %w", err)
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return false, fmt.Errorf("failed to create pk script: %w", err)
+	}
+
+	return VerifyMessage(MessageMagic, pkScript, message, signature)
+}
+
+// SignMessage signs the message for the address with the pkScript.
+//
+// P2PKH and SegWit v0 addresses get a BIP-137 compact signature with the magic prefix,
+// taproot addresses get a BIP-322 simple signature. Both are base64 encoded.
+func SignMessage(magic string, key *btcec.PrivateKey, pkScript []byte, message string) (string, error) {
+	var header byte
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.PubKeyHashTy:
+		header = headerP2PKH
+	case txscript.ScriptHashTy:
+		header = headerP2SHP2WPKH
+	case txscript.WitnessV0PubKeyHashTy:
+		header = headerP2WPKH
+	case txscript.WitnessV1TaprootTy:
+		return signMessageBIP322(key, pkScript, message)
+	default:
+		return "", fmt.Errorf("unsupported address type for message signing")
+	}
+
+	sig := ecdsa.SignCompact(key, messageHash(magic, message), true)
+
+	// SignCompact returns the header of a compressed P2PKH key
+	sig[0] = sig[0] - headerP2PKH + header
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// VerifyMessage verifies a BIP-137 or a BIP-322 simple signature of the message for the address with the pkScript.
+func VerifyMessage(magic string, pkScript []byte, message, signature string) (bool, error) {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) == compactSignatureLength {
+		return verifyMessageBIP137(magic, pkScript, message, sig)
+	}
+
+	return verifyMessageBIP322(pkScript, message, sig)
+}
+
+// messageHash returns the double sha256 of the magic prefix and the message.
+func messageHash(magic, message string) []byte {
+	var buf bytes.Buffer
+	_ = wire.WriteVarString(&buf, 0, magic)
+	_ = wire.WriteVarString(&buf, 0, message)
+
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+func verifyMessageBIP137(magic string, pkScript []byte, message string, sig []byte) (bool, error) {
+	header := sig[0]
+	if header < 27 || header >= headerP2WPKH+headerRecoveryIDs {
+		return false, fmt.Errorf("invalid signature header: %d", header)
+	}
+
+	// segwit headers are not known to the compact signature recovery
+	compactSig := bytes.Clone(sig)
+	if header >= headerP2PKH {
+		compactSig[0] = headerP2PKH + (header-27)%headerRecoveryIDs
+	}
+
+	pubKey, compressed, err := ecdsa.RecoverCompact(compactSig, messageHash(magic, message))
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	var pubKeyHash []byte
+	if compressed {
+		pubKeyHash = btcutil.Hash160(pubKey.SerializeCompressed())
+	} else {
+		pubKeyHash = btcutil.Hash160(pubKey.SerializeUncompressed())
+	}
+
+	// the address type is taken from the pk script, so wallets using any header are supported
+	switch txscript.GetScriptClass(pkScript) {
+	case txscript.PubKeyHashTy:
+		// OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+		return bytes.Equal(pkScript[3:23], pubKeyHash), nil
+	case txscript.WitnessV0PubKeyHashTy:
+		// OP_0 <hash>
+		return compressed && bytes.Equal(pkScript[2:22], pubKeyHash), nil
+	case txscript.ScriptHashTy:
+		// OP_HASH160 <hash> OP_EQUAL of the nested P2WPKH script
+		redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, pubKeyHash...)
+		return compressed && bytes.Equal(pkScript[2:22], btcutil.Hash160(redeemScript)), nil
+	default:
+		return false, fmt.Errorf("unsupported address type for BIP-137 signature")
+	}
+}
+
+// bip322Txs returns the virtual to_spend and to_sign transactions of BIP-322.
+func bip322Txs(pkScript []byte, message string) (*wire.MsgTx, error) {
+	msgHash := chainhash.TaggedHash(bip322Tag, []byte(message))
+
+	sigScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(msgHash[:]).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	toSpend := wire.NewMsgTx(0)
+	toSpend.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: 0xFFFFFFFF},
+		SignatureScript:  sigScript,
+		Sequence:         0,
+	})
+	toSpend.AddTxOut(wire.NewTxOut(0, pkScript))
+
+	toSign := wire.NewMsgTx(0)
+	toSign.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: toSpend.TxHash(), Index: 0},
+		Sequence:         0,
+	})
+	toSign.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+
+	return toSign, nil
+}
+
+func signMessageBIP322(key *btcec.PrivateKey, pkScript []byte, message string) (string, error) {
+	toSign, err := bip322Txs(pkScript, message)
+	if err != nil {
+		return "", fmt.Errorf("failed to build virtual transactions: %w", err)
+	}
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(pkScript, 0)
+	sigHashes := txscript.NewTxSigHashes(toSign, fetcher)
+
+	witness, err := txscript.TaprootWitnessSignature(toSign, sigHashes, 0, 0, pkScript, txscript.SigHashDefault, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := wire.WriteVarInt(&buf, 0, uint64(len(witness))); err != nil {
+		return "", err
+	}
+
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(&buf, 0, item); err != nil {
+			return "", err
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func verifyMessageBIP322(pkScript []byte, message string, sig []byte) (bool, error) {
+	r := bytes.NewReader(sig)
+
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to read witness: %w", err)
+	}
+
+	if count > uint64(len(sig)) {
+		return false, fmt.Errorf("invalid witness items count: %d", count)
+	}
+
+	witness := make(wire.TxWitness, 0, count)
+	for range count {
+		item, err := wire.ReadVarBytes(r, 0, maxWitnessItemSize, "witness item")
+		if err != nil {
+			return false, fmt.Errorf("failed to read witness: %w", err)
+		}
+
+		witness = append(witness, item)
+	}
+
+	if r.Len() != 0 {
+		return false, fmt.Errorf("unexpected data after witness")
+	}
+
+	toSign, err := bip322Txs(pkScript, message)
+	if err != nil {
+		return false, fmt.Errorf("failed to build virtual transactions: %w", err)
+	}
+
+	toSign.TxIn[0].Witness = witness
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(pkScript, 0)
+	vm, err := txscript.NewEngine(pkScript, toSign, 0, txscript.StandardVerifyFlags, nil, txscript.NewTxSigHashes(toSign, fetcher), 0, fetcher)
+	if err != nil {
+		return false, fmt.Errorf("failed to create script engine: %w", err)
+	}
+
+	return vm.Execute() == nil, nil
+}
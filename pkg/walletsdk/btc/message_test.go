@@ -0,0 +1,88 @@
+package btc_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage_AllTypes(t *testing.T) {
+	sdk := btc.NewWalletSDK(&chaincfg.MainNetParams)
+
+	for _, addrType := range []btc.AddressType{btc.AddressTypeP2PKH, btc.AddressTypeP2SH, btc.AddressTypeP2WPKH, btc.AddressTypeP2TR} {
+		t.Run(string(addrType), func(t *testing.T) {
+			data, err := sdk.GenerateAddress(addrType, mnemonic, passphrase, 0)
+			require.NoError(t, err)
+
+			signature, err := sdk.SignMessage(data, "ownership proof")
+			require.NoError(t, err)
+
+			valid, err := sdk.VerifyMessage(data.Address.EncodeAddress(), "ownership proof", signature)
+			require.NoError(t, err)
+			require.True(t, valid)
+
+			valid, err = sdk.VerifyMessage(data.Address.EncodeAddress(), "another message", signature)
+			require.NoError(t, err)
+			require.False(t, valid)
+
+			other, err := sdk.GenerateAddress(addrType, mnemonic, passphrase, 1)
+			require.NoError(t, err)
+
+			valid, err = sdk.VerifyMessage(other.Address.EncodeAddress(), "ownership proof", signature)
+			require.NoError(t, err)
+			require.False(t, valid)
+		})
+	}
+}
+
+func TestVerifyMessage_BIP322Vectors(t *testing.T) {
+	sdk := btc.NewWalletSDK(&chaincfg.MainNetParams)
+
+	const address = "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l"
+
+	valid, err := sdk.VerifyMessage(address, "", "AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=")
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = sdk.VerifyMessage(address, "Hello World", "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=")
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = sdk.VerifyMessage(address, "Hello World!", "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=")
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestSignMessage_BIP137Headers(t *testing.T) {
+	sdk := btc.NewWalletSDK(&chaincfg.MainNetParams)
+
+	testCases := []struct {
+		addrType  btc.AddressType
+		minHeader byte
+	}{
+		{btc.AddressTypeP2PKH, 31},
+		{btc.AddressTypeP2SH, 35},
+		{btc.AddressTypeP2WPKH, 39},
+	}
+
+	for _, tc := range testCases {
+		data, err := sdk.GenerateAddress(tc.addrType, mnemonic, passphrase, 0)
+		require.NoError(t, err)
+
+		pkScript, err := txscript.PayToAddrScript(data.Address)
+		require.NoError(t, err)
+
+		signature, err := btc.SignMessage(btc.MessageMagic, data.PrivateKey, pkScript, "ownership proof")
+		require.NoError(t, err)
+
+		raw, err := base64.StdEncoding.DecodeString(signature)
+		require.NoError(t, err)
+		require.Len(t, raw, 65)
+		require.GreaterOrEqual(t, raw[0], tc.minHeader)
+		require.Less(t, raw[0], tc.minHeader+4)
+	}
+}
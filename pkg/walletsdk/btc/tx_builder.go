@@ -21,6 +21,10 @@ type TxInput struct {
 	Amount     int64
 }
 
+// SignFunc signs the inputs of the transaction instead of their private keys, e.g. with a remote signer.
+// It must not modify the passed transaction and returns the signed copy.
+type SignFunc func(tx *wire.MsgTx, inputs []TxInput) (*wire.MsgTx, error)
+
 type txOutput struct {
 	address string
 	amount  decimal.Decimal
@@ -31,6 +35,7 @@ type TxBuilder struct {
 	chainParams *chaincfg.Params
 	Inputs      []TxInput
 	outputs     []txOutput
+	signFunc    SignFunc
 }
 
 func NewTxBuilder(chainParams *chaincfg.Params) *TxBuilder {
@@ -46,7 +51,38 @@ func NewTransactionFromBuilder(builder *TxBuilder) *TxBuilder {
 		chainParams: builder.chainParams,
 		Inputs:      builder.Inputs,
 		outputs:     builder.outputs,
+		signFunc:    builder.signFunc,
+	}
+}
+
+// NewTxBuilderFromMsgTx creates the builder of the unsigned transaction with the inputs
+// in the order of the transaction inputs, so the transaction can be signed.
+func NewTxBuilderFromMsgTx(chainParams *chaincfg.Params, tx *wire.MsgTx, inputs []TxInput) (*TxBuilder, error) {
+	if len(tx.TxIn) != len(inputs) {
+		return nil, fmt.Errorf("transaction has %d inputs, got %d", len(tx.TxIn), len(inputs))
+	}
+
+	for idx, input := range inputs {
+		h, err := chainhash.NewHashFromStr(input.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot make hash from source tx: %w", err)
+		}
+
+		if tx.TxIn[idx].PreviousOutPoint != *wire.NewOutPoint(h, input.Sequence) {
+			return nil, fmt.Errorf("input %d does not match the transaction outpoint", idx)
+		}
 	}
+
+	return &TxBuilder{
+		tx:          tx.Copy(),
+		chainParams: chainParams,
+		Inputs:      inputs,
+	}, nil
+}
+
+// SetSignFunc sets the function which signs the transaction instead of the private keys of the inputs.
+func (s *TxBuilder) SetSignFunc(fn SignFunc) {
+	s.signFunc = fn
 }
 
 // MsgTx
@@ -107,6 +143,16 @@ func (s *TxBuilder) SignTx() error {
 		return fmt.Errorf("no inputs to sign")
 	}
 
+	if s.signFunc != nil {
+		signed, err := s.signFunc(s.tx, s.Inputs)
+		if err != nil {
+			return fmt.Errorf("sign transaction: %w", err)
+		}
+
+		s.tx = signed
+		return nil
+	}
+
 	multiFetcher := txscript.NewMultiPrevOutFetcher(nil)
 	for i, input := range s.Inputs {
 		scriptBytes, err := hex.DecodeString(input.PkScript)
@@ -220,7 +266,8 @@ func (s *TxBuilder) CalculateTxSize(feePerByte decimal.Decimal) CalculateTxSizeD
 	txStrippedSize := decimal.NewFromInt(int64(s.tx.SerializeSizeStripped()))
 
 	weight := txStrippedSize.Mul(decimal.NewFromInt(3)).Add(txFullSize)
-	vSize := weight.Div(decimal.NewFromInt(4))
+	// virtual size is rounded up, taproot witnesses often produce a weight not divisible by 4
+	vSize := weight.Div(decimal.NewFromInt(4)).Ceil()
 	totalFee := vSize.Mul(feePerByte).Ceil()
 
 	res := CalculateTxSizeData{
@@ -1,11 +1,13 @@
 package btc_test
 
 import (
+	"encoding/hex"
 	"fmt"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -98,6 +100,43 @@ func TestCalculateTxSize(t *testing.T) {
 	assert.True(t, sizeData.TotalFee.GreaterThan(decimal.Zero))
 }
 
+func TestSignTx_P2TR(t *testing.T) {
+	chainParams := &chaincfg.MainNetParams
+	builder := btc.NewTxBuilder(chainParams)
+
+	walletSDK := btc.NewWalletSDK(chainParams)
+
+	addrData, err := walletSDK.GenerateAddress(btc.AddressTypeP2TR, mnemonic, passphrase, 0)
+	require.NoError(t, err)
+
+	pkScript, err := txscript.PayToAddrScript(addrData.Address)
+	require.NoError(t, err)
+
+	require.NoError(t, builder.AddInput(btc.TxInput{
+		PrivateKey: addrData.PrivateKey,
+		PkScript:   hex.EncodeToString(pkScript),
+		Hash:       "0c1a00189296a937e9bbee4114eb4283edb700834624924e48ca44d04281d712",
+		Sequence:   0,
+		Amount:     100000,
+	}))
+
+	require.NoError(t, builder.AddOutput("bc1q6uwkfj82nuhnz30zxk25zqad5xf8qqaayteh55", decimal.NewFromInt(90000)))
+
+	require.NoError(t, builder.SignTx())
+
+	// key path spend contains a single 64 byte schnorr signature
+	witness := builder.MsgTx().TxIn[0].Witness
+	require.Len(t, witness, 1)
+	require.Len(t, witness[0], 64)
+
+	sizeData, err := builder.EmulateTxSize(decimal.NewFromInt(1))
+	require.NoError(t, err)
+
+	// 1 taproot input and 1 p2wpkh output
+	assert.Equal(t, "99", sizeData.VSize.String())
+	assert.Equal(t, "99", sizeData.TotalFee.String())
+}
+
 func TestEmulateTxSize(t *testing.T) {
 	chainParams := &chaincfg.MainNetParams
 	builder := btc.NewTxBuilder(chainParams)
@@ -77,6 +77,28 @@ func TestGenerateAddress_AllTypes(t *testing.T) {
 	}
 }
 
+// TestGenerateAddress_BIP86 checks the taproot derivation against the BIP86 test vectors.
+func TestGenerateAddress_BIP86(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	sdk := btc.NewWalletSDK(&chaincfg.MainNetParams)
+
+	expected := []string{
+		"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+		"bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh",
+	}
+
+	for i, address := range expected {
+		data, err := sdk.GenerateAddress(btc.AddressTypeP2TR, mnemonic, "", uint32(i)) //nolint:gosec
+		if err != nil {
+			t.Fatalf("failed to generate taproot address: %v", err)
+		}
+
+		if data.Address.EncodeAddress() != address {
+			t.Errorf("unexpected taproot address for sequence %d: expected %s, got %s", i, address, data.Address.EncodeAddress())
+		}
+	}
+}
+
 func TestAddressFromPrivateKey_P2PKH(t *testing.T) {
 	// We test the receipt of the address from the private key (P2PKH).
 	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
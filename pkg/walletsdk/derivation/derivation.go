@@ -0,0 +1,173 @@
+// Package derivation builds the BIP32 derivation paths of wallet addresses.
+package derivation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+)
+
+const (
+	// DefaultTemplate is the BIP44 path template used when a custom one is not set.
+	DefaultTemplate = "m/{purpose}'/{coin}'/{account}'/0/{index}"
+
+	hardenedKeyStart uint32 = 0x80000000
+
+	placeholderPurpose = "{purpose}"
+	placeholderCoin    = "{coin}"
+	placeholderAccount = "{account}"
+	placeholderIndex   = "{index}"
+)
+
+var ErrInvalidTemplate = errors.New("invalid derivation path template")
+
+// Config is the derivation configuration of an owner.
+type Config struct {
+	// Account is the BIP44 account index
+	Account uint32 `json:"account"`
+	// Templates are custom path templates by blockchain, e.g. m/44'/60'/{account}'/0/{index}
+	Templates map[wconstants.BlockchainType]string `json:"templates,omitempty"`
+}
+
+// Validate checks the account index and the templates.
+func (c Config) Validate() error {
+	if c.Account >= hardenedKeyStart {
+		return fmt.Errorf("account index must be less than %d", hardenedKeyStart)
+	}
+
+	var evmTemplate *string
+	for blockchain, template := range c.Templates {
+		if !blockchain.Valid() {
+			return fmt.Errorf("invalid blockchain: %s", blockchain)
+		}
+
+		// hot wallets share the address between evm blockchains
+		if blockchain.IsEVM() {
+			if evmTemplate != nil && *evmTemplate != template {
+				return fmt.Errorf("evm blockchains must use the same template")
+			}
+			evmTemplate = &template
+		}
+
+		if _, err := (Path{Account: c.Account, Template: template}).Indexes(0, 0, 0); err != nil {
+			return fmt.Errorf("%s: %w", blockchain, err)
+		}
+	}
+
+	return nil
+}
+
+// IsDefault returns true if the config derives the same addresses as the default paths.
+func (c Config) IsDefault() bool {
+	return c.Account == 0 && len(c.Templates) == 0
+}
+
+// Options returns the derivation options of the blockchain.
+func (c Config) Options(blockchain wconstants.BlockchainType) []Option {
+	return []Option{
+		WithAccount(c.Account),
+		WithTemplate(c.Templates[blockchain]),
+	}
+}
+
+// Path is the derivation path settings of a blockchain.
+type Path struct {
+	Account  uint32
+	Template string
+}
+
+type Option func(*Path)
+
+// WithAccount sets the BIP44 account index.
+func WithAccount(account uint32) Option {
+	return func(p *Path) { p.Account = account }
+}
+
+// WithTemplate sets the custom path template, the default template is used for an empty value.
+func WithTemplate(template string) Option {
+	return func(p *Path) { p.Template = template }
+}
+
+// NewPath returns the path with the options applied.
+func NewPath(opts ...Option) Path {
+	var p Path
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// Indexes returns the child indexes of the address key, hardened indexes include the hardened key offset.
+func (p Path) Indexes(purpose, coinType, index uint32) ([]uint32, error) {
+	template := p.Template
+	if template == "" {
+		template = DefaultTemplate
+	}
+
+	if !strings.HasPrefix(template, "m/") {
+		return nil, fmt.Errorf("%w: must start with m/", ErrInvalidTemplate)
+	}
+
+	if strings.Count(template, placeholderIndex) != 1 {
+		return nil, fmt.Errorf("%w: must contain %s once", ErrInvalidTemplate, placeholderIndex)
+	}
+
+	values := map[string]uint32{
+		placeholderPurpose: purpose,
+		placeholderCoin:    coinType,
+		placeholderAccount: p.Account,
+		placeholderIndex:   index,
+	}
+
+	segments := strings.Split(strings.TrimPrefix(template, "m/"), "/")
+	res := make([]uint32, 0, len(segments))
+	for _, segment := range segments {
+		hardened := strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h")
+		if hardened {
+			segment = segment[:len(segment)-1]
+		}
+
+		value, ok := values[segment]
+		if !ok {
+			v, err := strconv.ParseUint(segment, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid segment %q", ErrInvalidTemplate, segment)
+			}
+			value = uint32(v)
+		}
+
+		if value >= hardenedKeyStart {
+			return nil, fmt.Errorf("%w: segment %q is out of range", ErrInvalidTemplate, segment)
+		}
+
+		if hardened {
+			value += hardenedKeyStart
+		}
+
+		res = append(res, value)
+	}
+
+	return res, nil
+}
+
+// Format returns the path of the address key without the m/ prefix, e.g. 44'/60'/0'/0/1.
+func (p Path) Format(purpose, coinType, index uint32) (string, error) {
+	indexes, err := p.Indexes(purpose, coinType, index)
+	if err != nil {
+		return "", err
+	}
+
+	segments := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx >= hardenedKeyStart {
+			segments = append(segments, strconv.FormatUint(uint64(idx-hardenedKeyStart), 10)+"'")
+			continue
+		}
+		segments = append(segments, strconv.FormatUint(uint64(idx), 10))
+	}
+
+	return strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,181 @@
+package derivation_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	btcchaincfg "github.com/btcsuite/btcd/chaincfg"
+	"github.com/dv-net/dv-processing/pkg/walletsdk"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/ltc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+	"github.com/dv-net/go-bip39"
+	ltcchaincfg "github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	ltchdkeychain "github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestPath(t *testing.T) {
+	path, err := derivation.NewPath().Format(44, 60, 5)
+	require.NoError(t, err)
+	require.Equal(t, "44'/60'/0'/0/5", path)
+
+	path, err = derivation.NewPath(derivation.WithAccount(3)).Format(84, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "84'/0'/3'/0/1", path)
+
+	path, err = derivation.NewPath(derivation.WithAccount(3), derivation.WithTemplate("m/44h/60h/{account}h/7/{index}")).Format(44, 60, 2)
+	require.NoError(t, err)
+	require.Equal(t, "44'/60'/3'/7/2", path)
+
+	for _, template := range []string{"44'/60'/0'/0/{index}", "m/44'/60'/0'/0/0", "m/44'/x'/0'/0/{index}", "m/2147483648/{index}"} {
+		_, err = derivation.NewPath(derivation.WithTemplate(template)).Indexes(44, 60, 0)
+		require.ErrorIs(t, err, derivation.ErrInvalidTemplate, template)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	require.NoError(t, derivation.Config{}.Validate())
+	require.Error(t, derivation.Config{Templates: map[wconstants.BlockchainType]string{"unknown": derivation.DefaultTemplate}}.Validate())
+	require.Error(t, derivation.Config{Templates: map[wconstants.BlockchainType]string{wconstants.BlockchainTypeEthereum: "m/{index}/{index}"}}.Validate())
+
+	// the default config derives the same addresses as before
+	defaultAddress, err := evm.AddressWallet(testMnemonic, "", 0)
+	require.NoError(t, err)
+
+	address, err := evm.AddressWallet(testMnemonic, "", 0, derivation.Config{}.Options(wconstants.BlockchainTypeEthereum)...)
+	require.NoError(t, err)
+	require.Equal(t, defaultAddress, address)
+
+	address, err = evm.AddressWallet(testMnemonic, "", 0, derivation.Config{Account: 1}.Options(wconstants.BlockchainTypeEthereum)...)
+	require.NoError(t, err)
+	require.NotEqual(t, defaultAddress, address)
+
+	// the well-known first address of the test mnemonic
+	require.Equal(t, "0x9858effd232b4033e47d90003d41ec34ecaeda94", defaultAddress)
+}
+
+// btcAddress derives the native segwit address by the path independently of the walletsdk
+func btcAddress(t *testing.T, path []uint32) string {
+	t.Helper()
+
+	key, err := hdkeychain.NewMaster(bip39.NewSeed(testMnemonic, ""), &btcchaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	for _, idx := range path {
+		key, err = key.Derive(idx)
+		require.NoError(t, err)
+	}
+
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+
+	address, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), &btcchaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	return address.EncodeAddress()
+}
+
+// ltcAddress derives the native segwit address by the path independently of the walletsdk
+func ltcAddress(t *testing.T, path []uint32) string {
+	t.Helper()
+
+	key, err := ltchdkeychain.NewMaster(bip39.NewSeed(testMnemonic, ""), &ltcchaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	for _, idx := range path {
+		key, err = key.Derive(idx)
+		require.NoError(t, err)
+	}
+
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+
+	address, err := ltcutil.NewAddressWitnessPubKeyHash(ltcutil.Hash160(pubKey.SerializeCompressed()), &ltcchaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	return address.EncodeAddress()
+}
+
+func hardened(idx uint32) uint32 { return hdkeychain.HardenedKeyStart + idx }
+
+func TestUTXODerivationPaths(t *testing.T) {
+	sdk := walletsdk.New(walletsdk.Config{})
+
+	tests := []struct {
+		name        string
+		blockchain  wconstants.BlockchainType
+		addressType string
+		config      derivation.Config
+		sequence    uint32
+		expected    string
+	}{
+		{
+			name:        "bitcoin default",
+			blockchain:  wconstants.BlockchainTypeBitcoin,
+			addressType: string(btc.AddressTypeP2WPKH),
+			// the BIP84 test vector
+			expected: "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		},
+		{
+			name:        "bitcoin account",
+			blockchain:  wconstants.BlockchainTypeBitcoin,
+			addressType: string(btc.AddressTypeP2WPKH),
+			config:      derivation.Config{Account: 1},
+			sequence:    2,
+			expected:    btcAddress(t, []uint32{hardened(84), hardened(0), hardened(1), 0, 2}),
+		},
+		{
+			name:        "bitcoin template",
+			blockchain:  wconstants.BlockchainTypeBitcoin,
+			addressType: string(btc.AddressTypeP2WPKH),
+			config: derivation.Config{Account: 3, Templates: map[wconstants.BlockchainType]string{
+				wconstants.BlockchainTypeBitcoin: "m/84'/0'/{account}'/1/{index}",
+			}},
+			sequence: 4,
+			expected: btcAddress(t, []uint32{hardened(84), hardened(0), hardened(3), 1, 4}),
+		},
+		{
+			name:        "litecoin default",
+			blockchain:  wconstants.BlockchainTypeLitecoin,
+			addressType: string(ltc.AddressTypeP2WPKH),
+			expected:    ltcAddress(t, []uint32{hardened(84), hardened(2), hardened(0), 0, 0}),
+		},
+		{
+			name:        "litecoin account",
+			blockchain:  wconstants.BlockchainTypeLitecoin,
+			addressType: string(ltc.AddressTypeP2WPKH),
+			config:      derivation.Config{Account: 1},
+			sequence:    2,
+			expected:    ltcAddress(t, []uint32{hardened(84), hardened(2), hardened(1), 0, 2}),
+		},
+		{
+			name:        "litecoin template",
+			blockchain:  wconstants.BlockchainTypeLitecoin,
+			addressType: string(ltc.AddressTypeP2WPKH),
+			config: derivation.Config{Account: 3, Templates: map[wconstants.BlockchainType]string{
+				wconstants.BlockchainTypeLitecoin: "m/84'/2'/{account}'/1/{index}",
+			}},
+			sequence: 4,
+			expected: ltcAddress(t, []uint32{hardened(84), hardened(2), hardened(3), 1, 4}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := sdk.AddressWallet(tt.blockchain, tt.addressType, testMnemonic, "", tt.sequence, tt.config.Options(tt.blockchain)...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, address)
+
+			generated, err := sdk.GenerateAddress(tt.blockchain, tt.addressType, testMnemonic, "", tt.sequence, tt.config.Options(tt.blockchain)...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, generated.Address)
+		})
+	}
+}
@@ -3,3 +3,5 @@ package walletsdk
 import "errors"
 
 var ErrBlockchainUndefined = errors.New("blockchain undefined")
+
+var ErrAddressKeyMismatch = errors.New("derived key does not match the address")
@@ -0,0 +1,52 @@
+package evm
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	signatureLength = 65
+	// recoveryIDOffset is added to the recovery id of the signature by the wallets
+	recoveryIDOffset = 27
+)
+
+// SignMessage signs the message with the EIP-191 personal_sign prefix.
+func SignMessage(privateKey *ecdsa.PrivateKey, message string) (string, error) {
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	sig[crypto.RecoveryIDOffset] += recoveryIDOffset
+
+	return hexutil.Encode(sig), nil
+}
+
+// VerifyMessage verifies the EIP-191 personal_sign signature of the message made by the key of the address.
+func VerifyMessage(address, message, signature string) (bool, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) != signatureLength {
+		return false, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= recoveryIDOffset {
+		sig[crypto.RecoveryIDOffset] -= recoveryIDOffset
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	return strings.EqualFold(crypto.PubkeyToAddress(*pubKey).Hex(), address), nil
+}
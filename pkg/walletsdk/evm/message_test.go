@@ -0,0 +1,43 @@
+package evm_test
+
+import (
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	address, privateKey, _, err := evm.WalletPubKeyHash(mnemonic, passphrase, defaultSequence)
+	require.NoError(t, err)
+
+	signature, err := evm.SignMessage(privateKey, "ownership proof")
+	require.NoError(t, err)
+
+	valid, err := evm.VerifyMessage(address, "ownership proof", signature)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = evm.VerifyMessage(address, "another message", signature)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestVerifyMessage_ChecksumAddress(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+
+	signature, err := evm.SignMessage(privateKey, "hello")
+	require.NoError(t, err)
+
+	// wallets return the recovery id with the 27 offset
+	raw, err := hexutil.Decode(signature)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, raw[64])
+
+	valid, err := evm.VerifyMessage("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", "hello", signature)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
@@ -0,0 +1,40 @@
+package ltc
+
+import (
+	"fmt"
+
+	btcbtcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+)
+
+// MessageMagic is the prefix of the signed messages.
+const MessageMagic = "Litecoin Signed Message:\n"
+
+// SignMessage signs the message with the key of the address.
+func (s WalletSDK) SignMessage(data *GenerateAddressData, message string) (string, error) {
+	pkScript, err := txscript.PayToAddrScript(data.Address)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pk script: %w", err)
+	}
+
+	key, _ := btcbtcec.PrivKeyFromBytes(data.PrivateKey.Serialize())
+
+	return btc.SignMessage(MessageMagic, key, pkScript, message)
+}
+
+// VerifyMessage verifies the signature of the message made by the key of the address.
+func (s WalletSDK) VerifyMessage(address, message, signature string) (bool, error) {
+	addr, err := ltcutil.DecodeAddress(address, s.chainParams)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode address: %w", err)
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return false, fmt.Errorf("failed to create pk script: %w", err)
+	}
+
+	return btc.VerifyMessage(MessageMagic, pkScript, message, signature)
+}
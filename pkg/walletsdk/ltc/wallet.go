@@ -3,6 +3,7 @@ package ltc
 import (
 	"fmt"
 
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/go-bip39"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/btcec/v2/schnorr"
@@ -74,7 +75,7 @@ func (s GenerateAddressData) AddressPubKey() (string, error) {
 	return address.String(), nil
 }
 
-func (s WalletSDK) GenerateAddress(addressType AddressType, mnemonic, passphrase string, sequenceNumber uint32) (*GenerateAddressData, error) {
+func (s WalletSDK) GenerateAddress(addressType AddressType, mnemonic, passphrase string, sequenceNumber uint32, opts ...derivation.Option) (*GenerateAddressData, error) {
 	// Check mnemonic and passphrase
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return nil, fmt.Errorf("invalid mnemonic")
@@ -112,26 +113,18 @@ func (s WalletSDK) GenerateAddress(addressType AddressType, mnemonic, passphrase
 		return nil, fmt.Errorf("unsupported address type")
 	}
 
-	// Derivation path: m / purpose' / 0' / 0' / 0 / sequenceNumber.
-	purposeKey, err := masterKey.Derive(hdkeychain.HardenedKeyStart + purpose)
+	// Default derivation path: m / purpose' / coin' / account' / 0 / sequenceNumber.
+	path, err := derivation.NewPath(opts...).Indexes(purpose, 2, sequenceNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive purpose key: %w", err)
-	}
-	coinKey, err := purposeKey.Derive(hdkeychain.HardenedKeyStart + 2)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive coin key: %w", err)
-	}
-	accountKey, err := coinKey.Derive(hdkeychain.HardenedKeyStart + 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive account key: %w", err)
-	}
-	changeKey, err := accountKey.Derive(0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive change key: %w", err)
+		return nil, err
 	}
-	childKey, err := changeKey.Derive(sequenceNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive address key: %w", err)
+
+	childKey := masterKey
+	for _, idx := range path {
+		childKey, err = childKey.Derive(idx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
 	}
 
 	// Get private key and public keys
@@ -0,0 +1,145 @@
+package walletsdk
+
+import (
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/tron"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/wconstants"
+)
+
+// SignMessage signs the message with the key of the address derived from the mnemonic.
+//
+// Bitcoin like blockchains use BIP-137 signatures (BIP-322 for taproot addresses),
+// EVM blockchains use EIP-191 personal_sign and Tron uses TIP-191.
+func (s *SDK) SignMessage(blockchain wconstants.BlockchainType, address string, mnemonic string, passphrase string, sequence uint32, message string, opts ...derivation.Option) (string, error) {
+	var signature string
+
+	switch blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		addrType, err := s.BTC.DecodeAddressType(address)
+		if err != nil {
+			return "", err
+		}
+
+		addrData, err := s.BTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = s.BTC.SignMessage(addrData, message)
+		if err != nil {
+			return "", err
+		}
+
+	case wconstants.BlockchainTypeLitecoin:
+		addrType, err := s.LTC.DecodeAddressType(address)
+		if err != nil {
+			return "", err
+		}
+
+		addrData, err := s.LTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = s.LTC.SignMessage(addrData, message)
+		if err != nil {
+			return "", err
+		}
+
+	case wconstants.BlockchainTypeBitcoinCash:
+		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = s.BCH.SignMessage(addrData, message)
+		if err != nil {
+			return "", err
+		}
+
+	case wconstants.BlockchainTypeDogecoin:
+		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = s.Doge.SignMessage(addrData, message)
+		if err != nil {
+			return "", err
+		}
+
+	case wconstants.BlockchainTypeEthereum,
+		wconstants.BlockchainTypeBinanceSmartChain,
+		wconstants.BlockchainTypePolygon,
+		wconstants.BlockchainTypeArbitrum,
+		wconstants.BlockchainTypeOptimism,
+		wconstants.BlockchainTypeLinea:
+		_, privateKey, _, err := evm.WalletPubKeyHash(mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = evm.SignMessage(privateKey, message)
+		if err != nil {
+			return "", err
+		}
+
+	case wconstants.BlockchainTypeTron:
+		_, privateKey, _, err := tron.WalletPubKeyHash(mnemonic, passphrase, sequence, opts...)
+		if err != nil {
+			return "", err
+		}
+
+		signature, err = tron.SignMessage(privateKey, message)
+		if err != nil {
+			return "", err
+		}
+
+	default:
+		return "", ErrBlockchainUndefined
+	}
+
+	// the signature is useless if the sequence or the derivation does not match the address
+	valid, err := s.VerifyMessage(blockchain, address, message, signature)
+	if err != nil {
+		return "", err
+	}
+
+	if !valid {
+		return "", ErrAddressKeyMismatch
+	}
+
+	return signature, nil
+}
+
+// VerifyMessage checks that the signature of the message is made by the key of the address.
+func (s *SDK) VerifyMessage(blockchain wconstants.BlockchainType, address string, message string, signature string) (bool, error) {
+	switch blockchain {
+	case wconstants.BlockchainTypeBitcoin:
+		return s.BTC.VerifyMessage(address, message, signature)
+
+	case wconstants.BlockchainTypeLitecoin:
+		return s.LTC.VerifyMessage(address, message, signature)
+
+	case wconstants.BlockchainTypeBitcoinCash:
+		return s.BCH.VerifyMessage(address, message, signature)
+
+	case wconstants.BlockchainTypeDogecoin:
+		return s.Doge.VerifyMessage(address, message, signature)
+
+	case wconstants.BlockchainTypeEthereum,
+		wconstants.BlockchainTypeBinanceSmartChain,
+		wconstants.BlockchainTypePolygon,
+		wconstants.BlockchainTypeArbitrum,
+		wconstants.BlockchainTypeOptimism,
+		wconstants.BlockchainTypeLinea:
+		return evm.VerifyMessage(address, message, signature)
+
+	case wconstants.BlockchainTypeTron:
+		return tron.VerifyMessage(address, message, signature)
+
+	default:
+		return false, ErrBlockchainUndefined
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/dv-net/dv-processing/pkg/chainparams"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/bch"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/btc"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/doge"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/evm"
 	"github.com/dv-net/dv-processing/pkg/walletsdk/ltc"
@@ -56,30 +57,30 @@ func New(conf Config) *SDK {
 	}
 }
 
-func (s *SDK) AddressWallet(blockchain wconstants.BlockchainType, addressType string, mnemonic string, passphrase string, sequence uint32) (string, error) {
+func (s *SDK) AddressWallet(blockchain wconstants.BlockchainType, addressType string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
 	switch blockchain {
 	case wconstants.BlockchainTypeBitcoin:
-		addrData, err := s.BTC.GenerateAddress(btc.AddressType(addressType), mnemonic, passphrase, sequence)
+		addrData, err := s.BTC.GenerateAddress(btc.AddressType(addressType), mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
 		return addrData.Address.String(), nil
 
 	case wconstants.BlockchainTypeLitecoin:
-		addrData, err := s.LTC.GenerateAddress(ltc.AddressType(addressType), mnemonic, passphrase, sequence)
+		addrData, err := s.LTC.GenerateAddress(ltc.AddressType(addressType), mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
 		return addrData.Address.String(), nil
 
 	case wconstants.BlockchainTypeBitcoinCash:
-		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
 		return addrData.Address.String(), nil
 	case wconstants.BlockchainTypeDogecoin:
-		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -91,24 +92,24 @@ func (s *SDK) AddressWallet(blockchain wconstants.BlockchainType, addressType st
 		wconstants.BlockchainTypeArbitrum,
 		wconstants.BlockchainTypeOptimism,
 		wconstants.BlockchainTypeLinea:
-		return evm.AddressWallet(mnemonic, passphrase, sequence)
+		return evm.AddressWallet(mnemonic, passphrase, sequence, opts...)
 
 	case wconstants.BlockchainTypeTron:
-		return tron.AddressWallet(mnemonic, passphrase, sequence)
+		return tron.AddressWallet(mnemonic, passphrase, sequence, opts...)
 
 	default:
 		return "", ErrBlockchainUndefined
 	}
 }
 
-func (s *SDK) AddressSecret(blockchain wconstants.BlockchainType, address string, mnemonic string, passphrase string, sequence uint32) (string, error) {
+func (s *SDK) AddressSecret(blockchain wconstants.BlockchainType, address string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
 	switch blockchain {
 	case wconstants.BlockchainTypeBitcoin:
 		addrType, err := s.BTC.DecodeAddressType(address)
 		if err != nil {
 			return "", err
 		}
-		addrData, err := s.BTC.GenerateAddress(addrType, mnemonic, passphrase, sequence)
+		addrData, err := s.BTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -119,21 +120,21 @@ func (s *SDK) AddressSecret(blockchain wconstants.BlockchainType, address string
 		if err != nil {
 			return "", err
 		}
-		addrData, err := s.LTC.GenerateAddress(addrType, mnemonic, passphrase, sequence)
+		addrData, err := s.LTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
 		return addrData.PrivateKeyWIF.String(), nil
 
 	case wconstants.BlockchainTypeBitcoinCash:
-		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
 		return addrData.PrivateKeyWIF.String(), nil
 
 	case wconstants.BlockchainTypeDogecoin:
-		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -144,17 +145,17 @@ func (s *SDK) AddressSecret(blockchain wconstants.BlockchainType, address string
 		wconstants.BlockchainTypeArbitrum,
 		wconstants.BlockchainTypeOptimism,
 		wconstants.BlockchainTypeLinea:
-		return evm.AddressSecret(address, mnemonic, passphrase, sequence)
+		return evm.AddressSecret(address, mnemonic, passphrase, sequence, opts...)
 
 	case wconstants.BlockchainTypeTron:
-		return tron.AddressSecret(address, mnemonic, passphrase, sequence)
+		return tron.AddressSecret(address, mnemonic, passphrase, sequence, opts...)
 
 	default:
 		return "", ErrBlockchainUndefined
 	}
 }
 
-func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string, mnemonic string, passphrase string, sequence uint32) (string, error) {
+func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
 	switch blockchain {
 	case wconstants.BlockchainTypeBitcoin:
 		addrType, err := s.BTC.DecodeAddressType(address)
@@ -162,7 +163,7 @@ func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string
 			return "", err
 		}
 
-		addrData, err := s.BTC.GenerateAddress(addrType, mnemonic, passphrase, sequence)
+		addrData, err := s.BTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -180,7 +181,7 @@ func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string
 			return "", err
 		}
 
-		addrData, err := s.LTC.GenerateAddress(addrType, mnemonic, passphrase, sequence)
+		addrData, err := s.LTC.GenerateAddress(addrType, mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -193,7 +194,7 @@ func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string
 		return pubKey, nil
 
 	case wconstants.BlockchainTypeBitcoinCash:
-		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -206,7 +207,7 @@ func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string
 		return pubKey, nil
 
 	case wconstants.BlockchainTypeDogecoin:
-		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return "", err
 		}
@@ -223,10 +224,10 @@ func (s *SDK) AddressPublic(blockchain wconstants.BlockchainType, address string
 		wconstants.BlockchainTypeArbitrum,
 		wconstants.BlockchainTypeOptimism,
 		wconstants.BlockchainTypeLinea:
-		return evm.AddressPublic(address, mnemonic, passphrase, sequence)
+		return evm.AddressPublic(address, mnemonic, passphrase, sequence, opts...)
 
 	case wconstants.BlockchainTypeTron:
-		return tron.AddressPublic(address, mnemonic, passphrase, sequence)
+		return tron.AddressPublic(address, mnemonic, passphrase, sequence, opts...)
 
 	default:
 		return "", ErrBlockchainUndefined
@@ -239,10 +240,10 @@ type GenerateAddress struct {
 	PrivateKey string
 }
 
-func (s *SDK) GenerateAddress(blockchain wconstants.BlockchainType, addressType string, mnemonic string, passphrase string, sequence uint32) (*GenerateAddress, error) {
+func (s *SDK) GenerateAddress(blockchain wconstants.BlockchainType, addressType string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (*GenerateAddress, error) {
 	switch blockchain {
 	case wconstants.BlockchainTypeBitcoin:
-		addrData, err := s.BTC.GenerateAddress(btc.AddressType(addressType), mnemonic, passphrase, sequence)
+		addrData, err := s.BTC.GenerateAddress(btc.AddressType(addressType), mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -259,7 +260,7 @@ func (s *SDK) GenerateAddress(blockchain wconstants.BlockchainType, addressType
 		}, nil
 
 	case wconstants.BlockchainTypeLitecoin:
-		addrData, err := s.LTC.GenerateAddress(ltc.AddressType(addressType), mnemonic, passphrase, sequence)
+		addrData, err := s.LTC.GenerateAddress(ltc.AddressType(addressType), mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -276,7 +277,7 @@ func (s *SDK) GenerateAddress(blockchain wconstants.BlockchainType, addressType
 		}, nil
 
 	case wconstants.BlockchainTypeBitcoinCash:
-		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.BCH.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -292,7 +293,7 @@ func (s *SDK) GenerateAddress(blockchain wconstants.BlockchainType, addressType
 			PrivateKey: addrData.PrivateKeyWIF.String(),
 		}, nil
 	case wconstants.BlockchainTypeDogecoin:
-		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence)
+		addrData, err := s.Doge.GenerateAddress(mnemonic, passphrase, sequence, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,59 @@
+package tron
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	addr "github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+const (
+	// MessagePrefix is the TIP-191 prefix of the signed messages
+	MessagePrefix = "\x19TRON Signed Message:\n"
+
+	signatureLength = 65
+	// recoveryIDOffset is added to the recovery id of the signature by the wallets
+	recoveryIDOffset = 27
+)
+
+// SignMessage signs the message with the TIP-191 prefix the same way as TronWeb signMessageV2.
+func SignMessage(privateKey *ecdsa.PrivateKey, message string) (string, error) {
+	sig, err := crypto.Sign(messageHash(message), privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	sig[crypto.RecoveryIDOffset] += recoveryIDOffset
+
+	return hexutil.Encode(sig), nil
+}
+
+// VerifyMessage verifies the TIP-191 signature of the message made by the key of the address.
+func VerifyMessage(address, message, signature string) (bool, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) != signatureLength {
+		return false, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= recoveryIDOffset {
+		sig[crypto.RecoveryIDOffset] -= recoveryIDOffset
+	}
+
+	pubKey, err := crypto.SigToPub(messageHash(message), sig)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+
+	return addr.PubkeyToAddress(*pubKey).String() == address, nil
+}
+
+func messageHash(message string) []byte {
+	return crypto.Keccak256([]byte(MessagePrefix + strconv.Itoa(len(message)) + message))
+}
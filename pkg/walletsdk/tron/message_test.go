@@ -0,0 +1,31 @@
+package tron_test
+
+import (
+	"testing"
+
+	"github.com/dv-net/dv-processing/pkg/walletsdk/tron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	address, privateKey, _, err := tron.WalletPubKeyHash(mnemonic, passphrase, defaultSequence)
+	require.NoError(t, err)
+
+	signature, err := tron.SignMessage(privateKey, "ownership proof")
+	require.NoError(t, err)
+
+	valid, err := tron.VerifyMessage(address, "ownership proof", signature)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = tron.VerifyMessage(address, "another message", signature)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	other, _, _, err := tron.WalletPubKeyHash(mnemonic, passphrase, defaultSequence+1)
+	require.NoError(t, err)
+
+	valid, err = tron.VerifyMessage(other, "ownership proof", signature)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/dv-net/dv-processing/pkg/avalidator"
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 )
@@ -14,6 +15,7 @@ type ReclaimResourceParams struct {
 	FromSequence uint32
 	Mnemonic     string
 	PassPhrase   string
+	Derivation   []derivation.Option
 	ToAddress    string
 	ResourceType core.ResourceCode
 }
@@ -53,7 +55,7 @@ func (t *Tron) ReclaimResource(ctx context.Context, params ReclaimResourceParams
 		return nil, fmt.Errorf("get staked resources: %w", err)
 	}
 
-	address, priv, _, err := WalletPubKeyHash(params.Mnemonic, params.PassPhrase, params.FromSequence)
+	address, priv, _, err := WalletPubKeyHash(params.Mnemonic, params.PassPhrase, params.FromSequence, params.Derivation...)
 	if err != nil {
 		return nil, err
 	}
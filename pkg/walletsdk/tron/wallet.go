@@ -4,8 +4,8 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
-	"strconv"
 
+	"github.com/dv-net/dv-processing/pkg/walletsdk/derivation"
 	"github.com/dv-net/go-bip39"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -20,15 +20,20 @@ func NewWalletSDK() *WalletSDK {
 	return &WalletSDK{}
 }
 
-func WalletPubKeyHash(mnemonic string, passphrase string, sequence uint32) (string, *ecdsa.PrivateKey, *ecdsa.PublicKey, error) {
+func WalletPubKeyHash(mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, *ecdsa.PrivateKey, *ecdsa.PublicKey, error) {
+	path, err := derivation.NewPath(opts...).Format(44, 195, sequence)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
 	seed := bip39.NewSeed(mnemonic, passphrase)
 
 	secret, chainCode := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
-	secret, err := hd.DerivePrivateKeyForPath(
+	secret, err = hd.DerivePrivateKeyForPath(
 		crypto.S256(),
 		secret,
 		chainCode,
-		"44'/195'/0'/0/"+strconv.Itoa(int(sequence)),
+		path,
 	)
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("failed to derive private key: %w", err)
@@ -44,8 +49,8 @@ func WalletPubKeyHash(mnemonic string, passphrase string, sequence uint32) (stri
 	return address.String(), privateKey, &privateKey.PublicKey, nil
 }
 
-func AddressWallet(mnemonic string, passphrase string, sequence uint32) (string, error) {
-	address, _, _, err := WalletPubKeyHash(mnemonic, passphrase, sequence)
+func AddressWallet(mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
+	address, _, _, err := WalletPubKeyHash(mnemonic, passphrase, sequence, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -53,8 +58,8 @@ func AddressWallet(mnemonic string, passphrase string, sequence uint32) (string,
 	return address, nil
 }
 
-func AddressSecret(address string, mnemonic string, passphrase string, sequence uint32) (string, error) {
-	wAddress, private, _, err := WalletPubKeyHash(mnemonic, passphrase, sequence)
+func AddressSecret(address string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
+	wAddress, private, _, err := WalletPubKeyHash(mnemonic, passphrase, sequence, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -64,8 +69,8 @@ func AddressSecret(address string, mnemonic string, passphrase string, sequence
 	return hexutil.Encode(crypto.FromECDSA(private)), nil
 }
 
-func AddressPublic(address string, mnemonic string, passphrase string, sequence uint32) (string, error) {
-	wAddress, _, public, err := WalletPubKeyHash(mnemonic, passphrase, sequence)
+func AddressPublic(address string, mnemonic string, passphrase string, sequence uint32, opts ...derivation.Option) (string, error) {
+	wAddress, _, public, err := WalletPubKeyHash(mnemonic, passphrase, sequence, opts...)
 	if err != nil {
 		return "", err
 	}
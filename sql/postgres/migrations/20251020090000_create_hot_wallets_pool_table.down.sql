@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS hot_wallets_pool_owner_id_blockchain_address_type_idx;
+DROP INDEX IF EXISTS uni_idx_hot_wallets_pool_owner_id_blockchain_address;
+DROP TABLE IF EXISTS hot_wallets_pool;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS hot_wallets_pool
+(
+  id           uuid not null primary key default gen_random_uuid(),
+  owner_id     uuid not null constraint fk_hot_wallets_pool_oid references owners on delete cascade,
+  blockchain   varchar(255) not null check (blockchain != ''),
+  address_type varchar(50) not null default '',
+  address      varchar(255) not null check (address != ''),
+  sequence     int not null check (sequence >= 0),
+  created_at   timestamp with time zone not null default (timezone('utc', now()))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_hot_wallets_pool_owner_id_blockchain_address ON hot_wallets_pool USING btree (owner_id, blockchain, address);
+CREATE INDEX IF NOT EXISTS hot_wallets_pool_owner_id_blockchain_address_type_idx ON hot_wallets_pool USING btree (owner_id, blockchain, address_type, sequence);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS wallet_sequences
+(
+  owner_id      uuid not null constraint fk_wallet_sequences_oid references owners on delete cascade,
+  blockchain    varchar(255) not null check (blockchain != ''),
+  last_sequence int not null check (last_sequence >= 0),
+  updated_at    timestamp with time zone not null default (timezone('utc', now())),
+  PRIMARY KEY (owner_id, blockchain)
+);
+
+INSERT INTO wallet_sequences (owner_id, blockchain, last_sequence)
+SELECT owner_id, blockchain, max(sequence)
+FROM (
+  SELECT owner_id, blockchain, sequence FROM processing_wallets
+  UNION ALL
+  SELECT owner_id, blockchain, sequence FROM hot_wallets
+  UNION ALL
+  SELECT owner_id, blockchain, sequence FROM hot_wallets_pool
+) s
+GROUP BY owner_id, blockchain
+ON CONFLICT (owner_id, blockchain) DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uni_idx_sweep_rules_owner_id_blockchain_asset;
+DROP TABLE IF EXISTS sweep_rules;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS sweep_rules
+(
+  id               uuid not null primary key default gen_random_uuid(),
+  owner_id         uuid not null constraint fk_sweep_rules_oid references owners on delete cascade,
+  blockchain       varchar(255) not null check (blockchain != ''),
+  asset_identifier varchar(255) not null check (asset_identifier != ''),
+  to_address       varchar(255) not null check (to_address != ''),
+  threshold        numeric(150,50),
+  interval_hours   int check (interval_hours > 0),
+  fee_max          numeric(150,50),
+  kind             varchar(255),
+  is_enabled       bool not null default true,
+  last_swept_at    timestamp with time zone,
+  created_at       timestamp with time zone not null default (timezone('utc', now())),
+  updated_at       timestamp with time zone,
+  check (threshold is not null or interval_hours is not null)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_sweep_rules_owner_id_blockchain_asset ON sweep_rules USING btree (owner_id, blockchain, asset_identifier);
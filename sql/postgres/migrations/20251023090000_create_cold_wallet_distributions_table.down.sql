@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cold_wallet_distribution_items;
+DROP TABLE IF EXISTS cold_wallet_distributions;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS cold_wallet_distributions
+(
+  owner_id   uuid not null constraint fk_cold_wallet_distributions_oid references owners on delete cascade,
+  blockchain varchar(255) not null check (blockchain != ''),
+  strategy   varchar(255) not null check (strategy != ''),
+  created_at timestamp with time zone not null default (timezone('utc', now())),
+  updated_at timestamp with time zone,
+  primary key (owner_id, blockchain)
+);
+
+CREATE TABLE IF NOT EXISTS cold_wallet_distribution_items
+(
+  owner_id   uuid not null,
+  blockchain varchar(255) not null,
+  address    varchar(255) not null check (address != ''),
+  position   int not null default 0,
+  weight     int not null default 1 check (weight > 0),
+  cap        numeric(150,50) check (cap > 0),
+  created_at timestamp with time zone not null default (timezone('utc', now())),
+  primary key (owner_id, blockchain, address),
+  constraint fk_cold_wallet_distribution_items_distribution foreign key (owner_id, blockchain)
+    references cold_wallet_distributions (owner_id, blockchain) on delete cascade
+);
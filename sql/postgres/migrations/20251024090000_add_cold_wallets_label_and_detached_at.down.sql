@@ -0,0 +1,8 @@
+DELETE FROM cold_wallets WHERE detached_at IS NOT NULL;
+
+DROP INDEX IF EXISTS uni_idx_cold_wallets_address_blockchain_owner_id;
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_cold_wallets_address_blockchain_owner_id ON cold_wallets USING btree (owner_id, address, blockchain);
+
+ALTER TABLE cold_wallets DROP COLUMN IF EXISTS detached_at;
+ALTER TABLE cold_wallets DROP COLUMN IF EXISTS note;
+ALTER TABLE cold_wallets DROP COLUMN IF EXISTS label;
@@ -0,0 +1,7 @@
+ALTER TABLE cold_wallets ADD COLUMN IF NOT EXISTS label VARCHAR(255) NULL;
+ALTER TABLE cold_wallets ADD COLUMN IF NOT EXISTS note TEXT NULL;
+ALTER TABLE cold_wallets ADD COLUMN IF NOT EXISTS detached_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- detached addresses are kept for audit and can be attached again
+DROP INDEX IF EXISTS uni_idx_cold_wallets_address_blockchain_owner_id;
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_cold_wallets_address_blockchain_owner_id ON cold_wallets USING btree (owner_id, address, blockchain) WHERE detached_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS processing_wallets_owner_id_blockchain_idx;
+DROP TABLE IF EXISTS processing_wallet_strategies;
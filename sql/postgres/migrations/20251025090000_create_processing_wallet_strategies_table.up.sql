@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS processing_wallet_strategies
+(
+  owner_id   uuid not null constraint fk_processing_wallet_strategies_oid references owners on delete cascade,
+  blockchain varchar(255) not null check (blockchain != ''),
+  strategy   varchar(255) not null check (strategy != ''),
+  created_at timestamp with time zone not null default (timezone('utc', now())),
+  updated_at timestamp with time zone,
+  primary key (owner_id, blockchain)
+);
+
+CREATE INDEX IF NOT EXISTS processing_wallets_owner_id_blockchain_idx ON processing_wallets USING btree (owner_id, blockchain);
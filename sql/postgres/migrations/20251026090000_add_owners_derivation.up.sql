@@ -0,0 +1 @@
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS derivation jsonb NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mnemonic_rotation_wallets;
+DROP TABLE IF EXISTS mnemonic_rotations;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS mnemonic_rotations
+(
+  id                   uuid not null primary key default gen_random_uuid(),
+  owner_id             uuid not null constraint fk_mnemonic_rotations_oid references owners on delete cascade,
+  status               varchar(50) not null check (status != ''),
+  mnemonic             text not null check (mnemonic != ''),
+  pass_phrase          text,
+  previous_mnemonic    text not null check (previous_mnemonic != ''),
+  previous_pass_phrase text,
+  last_error           text,
+  created_at           timestamp with time zone not null default (timezone('utc', now())),
+  updated_at           timestamp with time zone,
+  completed_at         timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS mnemonic_rotations_owner_id_idx ON mnemonic_rotations USING btree (owner_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_mnemonic_rotations_owner_id_migrating ON mnemonic_rotations USING btree (owner_id) WHERE status = 'migrating';
+
+CREATE TABLE IF NOT EXISTS mnemonic_rotation_wallets
+(
+  id                 uuid not null primary key default gen_random_uuid(),
+  rotation_id        uuid not null constraint fk_mnemonic_rotation_wallets_rid references mnemonic_rotations on delete cascade,
+  wallet_type        varchar(50) not null check (wallet_type != ''),
+  blockchain         varchar(255) not null check (blockchain != ''),
+  external_wallet_id varchar(255),
+  old_address        varchar(255) not null check (old_address != ''),
+  new_address        varchar(255) not null check (new_address != ''),
+  sequence           int not null check (sequence >= 0),
+  status             varchar(50) not null check (status != ''),
+  created_at         timestamp with time zone not null default (timezone('utc', now())),
+  updated_at         timestamp with time zone
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uni_idx_mnemonic_rotation_wallets_rotation_id_blockchain_address ON mnemonic_rotation_wallets USING btree (rotation_id, blockchain, old_address);
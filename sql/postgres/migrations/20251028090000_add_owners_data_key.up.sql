@@ -0,0 +1 @@
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS data_key text NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS request_nonces;
+ALTER TABLE clients DROP COLUMN IF EXISTS require_sign_v2;
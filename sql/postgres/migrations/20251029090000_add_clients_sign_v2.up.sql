@@ -0,0 +1,10 @@
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS require_sign_v2 boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS request_nonces (
+    client_id uuid NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    nonce VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_nonces_created_at ON request_nonces (created_at);
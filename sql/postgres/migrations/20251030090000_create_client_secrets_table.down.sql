@@ -0,0 +1,9 @@
+DROP VIEW IF EXISTS webhook_view;
+
+CREATE VIEW webhook_view AS (
+  select w.*, c.callback_url, c.secret_key
+  from webhooks w
+  join clients c on c.id = w.client_id
+);
+
+DROP TABLE IF EXISTS client_secrets;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS client_secrets (
+    id uuid NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id uuid NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    secret_key VARCHAR(255) NOT NULL CHECK (secret_key != ''),
+    scope VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_secrets_client_id_scope ON client_secrets (client_id, scope);
+
+-- the existing client secret keeps signing the requests and the webhooks until it is rotated
+INSERT INTO client_secrets (client_id, secret_key, scope, created_at)
+SELECT id, secret_key, 'api', created_at FROM clients;
+
+INSERT INTO client_secrets (client_id, secret_key, scope, created_at)
+SELECT id, secret_key, 'webhook', created_at FROM clients;
+
+DROP VIEW IF EXISTS webhook_view;
+
+CREATE VIEW webhook_view AS (
+  select w.*, c.callback_url, coalesce(s.secret_key, '') as secret_key, s.id as secret_key_id
+  from webhooks w
+  join clients c on c.id = w.client_id
+  left join lateral (
+    select cs.id, cs.secret_key
+    from client_secrets cs
+    where cs.client_id = w.client_id
+      and cs.scope = 'webhook'
+      and cs.revoked_at is null
+      and (cs.expires_at is null or cs.expires_at > now())
+    order by cs.created_at desc
+    limit 1
+  ) s on true
+);
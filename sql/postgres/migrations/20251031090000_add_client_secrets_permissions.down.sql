@@ -0,0 +1 @@
+ALTER TABLE client_secrets DROP COLUMN IF EXISTS permissions;
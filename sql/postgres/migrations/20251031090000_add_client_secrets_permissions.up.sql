@@ -0,0 +1,2 @@
+-- the existing secrets keep the full access
+ALTER TABLE client_secrets ADD COLUMN IF NOT EXISTS permissions VARCHAR(50)[] NOT NULL DEFAULT '{*}';
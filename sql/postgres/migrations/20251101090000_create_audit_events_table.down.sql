@@ -0,0 +1,4 @@
+drop trigger if exists audit_events_append_only_truncate on audit_events;
+drop trigger if exists audit_events_append_only_row on audit_events;
+drop function if exists audit_events_append_only();
+drop table if exists audit_events;
@@ -0,0 +1,30 @@
+create table if not exists audit_events (
+	id bigserial primary key,
+	client_id uuid null,
+	owner_id uuid null,
+	procedure varchar(255) not null,
+	source_ip varchar(64) not null default '',
+	result varchar(50) not null,
+	fingerprint varchar(64) not null,
+	prev_hash varchar(64) not null,
+	hash varchar(64) not null unique,
+	created_at timestamp with time zone not null
+);
+
+create index if not exists audit_events_client_id_idx on audit_events (client_id);
+create index if not exists audit_events_owner_id_idx on audit_events (owner_id);
+create index if not exists audit_events_created_at_idx on audit_events (created_at);
+
+create or replace function audit_events_append_only() returns trigger as $$
+begin
+	raise exception 'audit_events is append-only';
+end;
+$$ language plpgsql;
+
+create trigger audit_events_append_only_row
+	before update or delete on audit_events
+	for each row execute function audit_events_append_only();
+
+create trigger audit_events_append_only_truncate
+	before truncate on audit_events
+	for each statement execute function audit_events_append_only();
@@ -0,0 +1,2 @@
+-- the empty list allows the requests from any address
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS allowed_ips VARCHAR(50)[] NOT NULL DEFAULT '{}';
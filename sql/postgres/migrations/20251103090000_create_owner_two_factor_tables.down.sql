@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS owner_recovery_codes;
+DROP TABLE IF EXISTS owner_two_factor_states;
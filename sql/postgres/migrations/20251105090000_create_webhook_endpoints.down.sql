@@ -0,0 +1,24 @@
+DROP VIEW IF EXISTS webhook_delivery_view;
+DROP VIEW IF EXISTS webhook_view;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
+
+ALTER TABLE webhooks DROP COLUMN IF EXISTS blockchain;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS owner_id;
+
+CREATE VIEW webhook_view AS (
+  select w.*, c.callback_url, coalesce(s.secret_key, '') as secret_key, s.id as secret_key_id
+  from webhooks w
+  join clients c on c.id = w.client_id
+  left join lateral (
+    select cs.id, cs.secret_key
+    from client_secrets cs
+    where cs.client_id = w.client_id
+      and cs.scope = 'webhook'
+      and cs.revoked_at is null
+      and (cs.expires_at is null or cs.expires_at > now())
+    order by cs.created_at desc
+    limit 1
+  ) s on true
+);
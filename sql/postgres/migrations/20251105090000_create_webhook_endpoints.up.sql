@@ -0,0 +1,96 @@
+-- the owner and the blockchain of the event are used by the endpoint filters
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS owner_id uuid;
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS blockchain VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id uuid NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    -- the default endpoint is sent to the client callback url with the client webhook secret
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    url VARCHAR(2048),
+    secret_key VARCHAR(255),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- the empty filters match all events
+    kinds VARCHAR(30)[] NOT NULL DEFAULT '{}',
+    blockchains VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    owner_ids uuid[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    CHECK (is_default OR (url IS NOT NULL AND secret_key IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS webhook_endpoints_client_id_idx ON webhook_endpoints (client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_endpoints_client_id_default_uniq_idx ON webhook_endpoints (client_id) WHERE is_default;
+
+INSERT INTO webhook_endpoints (client_id, is_default)
+SELECT id, TRUE FROM clients
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id uuid NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    endpoint_id uuid NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL DEFAULT 'new',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (webhook_id, endpoint_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_endpoint_id_idx ON webhook_deliveries (endpoint_id);
+
+-- the unsent webhooks are delivered to the default endpoints
+INSERT INTO webhook_deliveries (webhook_id, endpoint_id, status, attempts, response, next_attempt_at, created_at, updated_at)
+SELECT w.id, e.id, w.status, w.attempts, w.response, w.next_attempt_at, w.created_at, w.updated_at
+FROM webhooks w
+JOIN webhook_endpoints e ON e.client_id = w.client_id AND e.is_default
+WHERE w.status != 'sent'
+ON CONFLICT DO NOTHING;
+
+-- the view is recreated to include the new columns of webhooks
+DROP VIEW IF EXISTS webhook_view;
+
+CREATE VIEW webhook_view AS (
+  select w.*, c.callback_url, coalesce(s.secret_key, '') as secret_key, s.id as secret_key_id
+  from webhooks w
+  join clients c on c.id = w.client_id
+  left join lateral (
+    select cs.id, cs.secret_key
+    from client_secrets cs
+    where cs.client_id = w.client_id
+      and cs.scope = 'webhook'
+      and cs.revoked_at is null
+      and (cs.expires_at is null or cs.expires_at > now())
+    order by cs.created_at desc
+    limit 1
+  ) s on true
+);
+
+CREATE VIEW webhook_delivery_view AS (
+  select
+    d.*,
+    w.kind,
+    w.payload,
+    w.client_id,
+    coalesce(e.url, c.callback_url) as url,
+    coalesce(e.secret_key, s.secret_key, '') as secret_key,
+    case when e.secret_key is null then s.id end as secret_key_id
+  from webhook_deliveries d
+  join webhooks w on w.id = d.webhook_id
+  join webhook_endpoints e on e.id = d.endpoint_id
+  join clients c on c.id = w.client_id
+  left join lateral (
+    select cs.id, cs.secret_key
+    from client_secrets cs
+    where cs.client_id = w.client_id
+      and cs.scope = 'webhook'
+      and cs.revoked_at is null
+      and (cs.expires_at is null or cs.expires_at > now())
+    order by cs.created_at desc
+    limit 1
+  ) s on true
+);
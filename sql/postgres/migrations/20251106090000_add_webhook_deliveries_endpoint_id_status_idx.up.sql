@@ -0,0 +1,2 @@
+-- the pending deliveries of the endpoint are checked to keep the order of the transfer statuses
+CREATE INDEX IF NOT EXISTS webhook_deliveries_endpoint_id_status_idx ON webhook_deliveries (endpoint_id, status);
@@ -0,0 +1,13 @@
+-- name: LockChain :exec
+select pg_advisory_xact_lock(hashtext('audit_events'));
+
+-- name: GetLastHash :one
+select hash from audit_events order by id desc limit 1;
+
+-- name: Create :one
+insert into audit_events (client_id, owner_id, procedure, source_ip, result, fingerprint, prev_hash, hash, created_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	returning *;
+
+-- name: GetAfterID :many
+select * from audit_events where id > $1 order by id limit $2;
@@ -1,2 +1,39 @@
 -- name: ChangeCallbackURL :exec
 update clients set callback_url = $1, updated_at = now() where id = $2;
+
+-- name: SetAllowedIPs :exec
+update clients set allowed_ips = $1, updated_at = now() where id = $2;
+
+-- name: SetRequireSignV2 :exec
+update clients set require_sign_v2 = $1, updated_at = now() where id = $2;
+
+-- name: CreateRequestNonce :execrows
+insert into request_nonces (client_id, nonce, created_at) values ($1, $2, now()) on conflict do nothing;
+
+-- name: DeleteExpiredRequestNonces :execrows
+delete from request_nonces where created_at < $1;
+
+-- name: CreateSecret :one
+insert into client_secrets (client_id, secret_key, scope, created_at, expires_at, permissions)
+	values ($1, $2, $3, now(), $4, $5)
+	returning *;
+
+-- name: GetSecrets :many
+select * from client_secrets where client_id = $1 order by created_at desc;
+
+-- name: GetActiveSecrets :many
+select * from client_secrets
+	where client_id = $1 and scope = $2 and revoked_at is null and (expires_at is null or expires_at > now())
+	order by created_at desc;
+
+-- name: GetActiveSecretByID :one
+select * from client_secrets
+	where id = $1 and client_id = $2 and scope = $3 and revoked_at is null and (expires_at is null or expires_at > now())
+	limit 1;
+
+-- name: ExpireSecrets :execrows
+update client_secrets set expires_at = sqlc.arg(expires_at)
+	where client_id = sqlc.arg(client_id) and scope = sqlc.arg(scope) and '*' = any(permissions) and revoked_at is null and (expires_at is null or expires_at > sqlc.arg(expires_at));
+
+-- name: RevokeSecret :execrows
+update client_secrets set revoked_at = now() where id = $1 and client_id = $2 and revoked_at is null;
@@ -0,0 +1,39 @@
+-- name: Upsert :one
+INSERT INTO cold_wallet_distributions (owner_id, blockchain, strategy, created_at)
+	VALUES ($1, $2, $3, now())
+	ON CONFLICT (owner_id, blockchain) DO UPDATE
+	SET strategy = excluded.strategy,
+		updated_at = now()
+	RETURNING *;
+
+-- name: Get :one
+SELECT * FROM cold_wallet_distributions WHERE owner_id = $1 AND blockchain = $2;
+
+-- name: Delete :exec
+DELETE FROM cold_wallet_distributions WHERE owner_id = $1 AND blockchain = $2;
+
+-- name: CreateItem :one
+INSERT INTO cold_wallet_distribution_items (owner_id, blockchain, address, position, weight, cap, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, now())
+	RETURNING *;
+
+-- name: GetItems :many
+SELECT * FROM cold_wallet_distribution_items
+WHERE owner_id = $1 AND blockchain = $2
+ORDER BY position, address;
+
+-- name: DeleteItems :exec
+DELETE FROM cold_wallet_distribution_items WHERE owner_id = $1 AND blockchain = $2;
+
+-- name: GetAllocations :many
+SELECT
+	t.to_addresses[1]::varchar AS address,
+	count(*)::int AS transfers_count,
+	coalesce(sum((t.state_data->>'cold_wallet_amount')::numeric) FILTER (WHERE t.asset_identifier = sqlc.arg(asset_identifier)), 0)::numeric AS amount,
+	max(t.created_at)::timestamptz AS last_transfer_at
+FROM transfers t
+WHERE t.owner_id = sqlc.arg(owner_id)
+	AND t.blockchain = sqlc.arg(blockchain)
+	AND t.status != 'failed'
+	AND t.state_data->>'cold_wallet_strategy' IS NOT NULL
+GROUP BY t.to_addresses[1];
@@ -0,0 +1,37 @@
+-- name: Create :one
+INSERT INTO mnemonic_rotations (owner_id, status, mnemonic, pass_phrase, previous_mnemonic, previous_pass_phrase, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, now())
+	RETURNING *;
+
+-- name: GetLastByOwnerID :one
+SELECT * FROM mnemonic_rotations WHERE owner_id = $1 ORDER BY created_at DESC LIMIT 1;
+
+-- name: GetAllByOwnerID :many
+SELECT * FROM mnemonic_rotations WHERE owner_id = $1 ORDER BY created_at;
+
+-- name: GetAllMigrating :many
+SELECT * FROM mnemonic_rotations WHERE status = 'migrating' ORDER BY created_at;
+
+-- name: SetLastError :exec
+UPDATE mnemonic_rotations SET last_error = $2, updated_at = now() WHERE id = $1;
+
+-- name: Complete :exec
+UPDATE mnemonic_rotations SET status = 'completed', last_error = NULL, completed_at = now(), updated_at = now() WHERE id = $1;
+
+-- name: CreateWallet :one
+INSERT INTO mnemonic_rotation_wallets (rotation_id, wallet_type, blockchain, external_wallet_id, old_address, new_address, sequence, status, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+	ON CONFLICT (rotation_id, blockchain, old_address) DO NOTHING
+	RETURNING *;
+
+-- name: GetWallets :many
+SELECT * FROM mnemonic_rotation_wallets WHERE rotation_id = $1 ORDER BY wallet_type, blockchain, created_at;
+
+-- name: SetWalletStatus :exec
+UPDATE mnemonic_rotation_wallets SET status = $2, updated_at = now() WHERE id = $1;
+
+-- name: SetWalletsStatus :exec
+UPDATE mnemonic_rotation_wallets SET status = $2, updated_at = now() WHERE rotation_id = $1;
+
+-- name: UpdateSecrets :exec
+UPDATE mnemonic_rotations SET mnemonic = $2, pass_phrase = $3, previous_mnemonic = $4, previous_pass_phrase = $5, updated_at = now() WHERE id = $1;
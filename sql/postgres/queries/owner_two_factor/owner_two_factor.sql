@@ -0,0 +1,36 @@
+-- name: LockState :one
+-- the state is created if it does not exist and is locked until the end of the transaction
+insert into owner_two_factor_states (owner_id, updated_at) values ($1, now())
+	on conflict (owner_id) do update set owner_id = excluded.owner_id
+	returning *;
+
+-- name: UseStep :execrows
+insert into owner_two_factor_states (owner_id, last_used_step, updated_at) values ($1, $2, now())
+	on conflict (owner_id) do update set last_used_step = excluded.last_used_step, failed_attempts = 0, locked_until = null, updated_at = now()
+	where owner_two_factor_states.last_used_step < excluded.last_used_step;
+
+-- name: RegisterFailure :one
+insert into owner_two_factor_states (owner_id, failed_attempts, updated_at) values ($1, 1, now())
+	on conflict (owner_id) do update set failed_attempts = owner_two_factor_states.failed_attempts + 1, updated_at = now()
+	returning *;
+
+-- name: SetLockedUntil :exec
+update owner_two_factor_states set locked_until = $1, updated_at = now() where owner_id = $2;
+
+-- name: ResetFailures :exec
+update owner_two_factor_states set failed_attempts = 0, locked_until = null, updated_at = now() where owner_id = $1;
+
+-- name: DeleteState :exec
+delete from owner_two_factor_states where owner_id = $1;
+
+-- name: CreateRecoveryCode :exec
+insert into owner_recovery_codes (owner_id, code_hash, created_at) values ($1, $2, now());
+
+-- name: UseRecoveryCode :execrows
+update owner_recovery_codes set used_at = now() where owner_id = $1 and code_hash = $2 and used_at is null;
+
+-- name: CountUnusedRecoveryCodes :one
+select count(*) from owner_recovery_codes where owner_id = $1 and used_at is null;
+
+-- name: DeleteRecoveryCodes :exec
+delete from owner_recovery_codes where owner_id = $1;
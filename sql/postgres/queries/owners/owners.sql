@@ -11,4 +11,19 @@ update owners set otp_secret=$2 where id=$1;
 update owners set mnemonic = $2 where id = $1;
 
 -- name: SetOTPData :exec
-update owners set otp_data = $2 where id = $1;
\ No newline at end of file
+update owners set otp_data = $2 where id = $1;
+
+-- name: UpdatePassPhrase :exec
+update owners set pass_phrase = $2 where id = $1;
+
+-- name: GetDataKey :one
+select data_key from owners where id = $1;
+
+-- name: SetDataKey :execrows
+update owners set data_key = $2 where id = $1 and data_key is null;
+
+-- name: UpdateDataKey :exec
+update owners set data_key = $2 where id = $1;
+
+-- name: GetBatchForUpdate :many
+select * from owners where id > $1 order by id limit $2 for update;
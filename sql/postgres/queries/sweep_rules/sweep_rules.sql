@@ -0,0 +1,24 @@
+-- name: Upsert :one
+INSERT INTO sweep_rules (owner_id, blockchain, asset_identifier, to_address, threshold, interval_hours, fee_max, kind, is_enabled, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
+	ON CONFLICT (owner_id, blockchain, asset_identifier) DO UPDATE
+	SET to_address = excluded.to_address,
+		threshold = excluded.threshold,
+		interval_hours = excluded.interval_hours,
+		fee_max = excluded.fee_max,
+		kind = excluded.kind,
+		is_enabled = excluded.is_enabled,
+		updated_at = now()
+	RETURNING *;
+
+-- name: GetAllByOwnerID :many
+SELECT * FROM sweep_rules WHERE owner_id = $1 ORDER BY created_at;
+
+-- name: GetAllEnabled :many
+SELECT * FROM sweep_rules WHERE is_enabled = true ORDER BY created_at;
+
+-- name: Delete :exec
+DELETE FROM sweep_rules WHERE id = $1 AND owner_id = $2;
+
+-- name: SetLastSweptAt :exec
+UPDATE sweep_rules SET last_swept_at = now() WHERE id = $1;
@@ -28,6 +28,9 @@ select * from transfers where status = 'new' order by created_at asc limit 100;
 -- name: GetByRequestID :one
 select * from transfers where request_id = $1;
 
+-- name: GetLastByRequestIDPrefix :one
+select * from transfers where owner_id = @owner_id and starts_with(request_id, @prefix::text) order by created_at desc limit 1;
+
 -- name: GetActiveTronTransfersResources :one
 with dataset as (
 	select * from transfers where blockchain = 'tron' and kind = 'resources' and status in ('new', 'processing', 'unconfirmed')
@@ -1,14 +1,14 @@
--- name: MaxSequence :one
-with maxProcessing as (
-	select coalesce(max(sequence),-1)::int as sequence from processing_wallets pw where pw.blockchain = $1 and pw.owner_id = $2
-),
-maxHot as (
-	select coalesce(max(sequence),-1)::int as sequence from hot_wallets hw where hw.blockchain = $1 and hw.owner_id = $2
-)
-select
-  coalesce(
-	case
-		when mp.sequence > mh.sequence then mp.sequence else mh.sequence
-	end, 0
-  )::int
-from maxProcessing mp, maxHot mh;
+-- name: NextSequences :one
+-- reserves the given amount of sequences and returns the last reserved one
+INSERT INTO wallet_sequences (owner_id, blockchain, last_sequence, updated_at)
+	VALUES (@owner_id, @blockchain, @count::int - 1, now())
+	ON CONFLICT (owner_id, blockchain) DO UPDATE
+	SET last_sequence = wallet_sequences.last_sequence + @count::int, updated_at = now()
+	RETURNING last_sequence;
+
+-- name: ReserveSequence :exec
+-- marks the sequence as used, so the sequences reserved next are greater than it
+INSERT INTO wallet_sequences (owner_id, blockchain, last_sequence, updated_at)
+	VALUES (@owner_id, @blockchain, @sequence::int, now())
+	ON CONFLICT (owner_id, blockchain) DO UPDATE
+	SET last_sequence = greatest(wallet_sequences.last_sequence, @sequence::int), updated_at = now();
@@ -5,10 +5,23 @@ from cold_wallets w
 where w.blockchain = $1 and w.owner_id = $2;
 
 -- name: Get :one
-select * from cold_wallets where owner_id = $1 and blockchain = $2 and address = $3 LIMIT 1;
+select * from cold_wallets where owner_id = $1 and blockchain = $2 and address = $3 and detached_at is null LIMIT 1;
 
 -- name: GetAllByOwnerID :many
-select * from cold_wallets where owner_id = $1;
+select * from cold_wallets where owner_id = $1 and detached_at is null;
 
 -- name: GetByBlockchainAndAddress :one
-select * from cold_wallets where blockchain = $1 and address = $2;
+-- detached wallets are returned too, so transfers to them can be finished
+select * from cold_wallets where blockchain = $1 and address = $2
+order by detached_at desc nulls first
+LIMIT 1;
+
+-- name: Detach :one
+update cold_wallets set is_active = false, detached_at = now(), updated_at = now()
+where owner_id = $1 and blockchain = $2 and address = $3 and detached_at is null
+returning *;
+
+-- name: SetLabel :one
+update cold_wallets set label = $4, note = $5, updated_at = now()
+where owner_id = $1 and blockchain = $2 and address = $3 and detached_at is null
+returning *;
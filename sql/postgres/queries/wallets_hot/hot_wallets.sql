@@ -24,3 +24,6 @@ select * from hot_wallets where is_active = true;
 
 -- name: FindEVMByExternalID :many
 select * from hot_wallets where external_wallet_id = $1 and blockchain in (select unnest($2::text[])) and owner_id = $3 and is_active = true order by sequence desc limit 1;
+
+-- name: ArchiveWallet :exec
+update hot_wallets w set is_active = false, updated_at = now() where w.blockchain = $1 and w.address = $2 and w.owner_id = $3;
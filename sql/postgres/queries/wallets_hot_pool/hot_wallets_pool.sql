@@ -0,0 +1,41 @@
+-- name: Create :one
+INSERT INTO hot_wallets_pool (owner_id, blockchain, address_type, address, sequence, created_at)
+	VALUES ($1, $2, $3, $4, $5, now())
+	ON CONFLICT (owner_id, blockchain, address) DO NOTHING
+	RETURNING *;
+
+-- name: Count :one
+SELECT count(*)::int FROM hot_wallets_pool
+WHERE owner_id = $1 AND blockchain = $2 AND address_type = $3;
+
+-- name: DeleteByOwnerID :exec
+DELETE FROM hot_wallets_pool WHERE owner_id = $1;
+
+-- name: DeleteByAddress :exec
+-- DeleteByAddress removes the address used by a hot wallet from the pool of the blockchain
+DELETE FROM hot_wallets_pool WHERE owner_id = $1 AND blockchain = $2 AND address = $3;
+
+-- name: DeleteUsed :execrows
+-- DeleteUsed removes the pool addresses which are already used by hot wallets
+DELETE FROM hot_wallets_pool hwp
+WHERE hwp.owner_id = $1 AND hwp.blockchain = $2
+	AND EXISTS (
+		SELECT 1 FROM hot_wallets hw
+		WHERE hw.owner_id = hwp.owner_id AND hw.blockchain = hwp.blockchain AND hw.address = hwp.address
+	);
+
+-- name: Take :one
+DELETE FROM hot_wallets_pool
+WHERE id = (
+	SELECT hwp.id FROM hot_wallets_pool hwp
+	WHERE hwp.owner_id = $1 AND hwp.blockchain = $2 AND hwp.address_type = $3
+		AND NOT EXISTS (
+			SELECT 1 FROM hot_wallets hw
+			WHERE hw.owner_id = hwp.owner_id AND hw.blockchain = hwp.blockchain AND hw.address = hwp.address
+		)
+	ORDER BY hwp.sequence ASC
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
@@ -0,0 +1,10 @@
+-- name: UpsertStrategy :one
+INSERT INTO processing_wallet_strategies (owner_id, blockchain, strategy, created_at)
+	VALUES ($1, $2, $3, now())
+	ON CONFLICT (owner_id, blockchain) DO UPDATE
+	SET strategy = excluded.strategy,
+		updated_at = now()
+	RETURNING *;
+
+-- name: GetStrategy :one
+SELECT * FROM processing_wallet_strategies WHERE owner_id = $1 AND blockchain = $2;
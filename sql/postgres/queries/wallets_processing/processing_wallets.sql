@@ -8,7 +8,21 @@ select * from processing_wallets where address = $2 and blockchain = $1 limit 1;
 select * from processing_wallets where address = $3 and owner_id = $1 and blockchain = $2 limit 1;
 
 -- name: GetByBlockchain :one
-select * from processing_wallets where owner_id = $1 and blockchain = $2 limit 1;
+select * from processing_wallets where owner_id = $1 and blockchain = $2 and is_active order by created_at, sequence limit 1;
+
+-- name: GetAllByBlockchain :many
+select * from processing_wallets where owner_id = $1 and blockchain = $2 and is_active order by created_at, sequence;
+
+-- name: GetUsage :many
+SELECT
+	(t.state_data->>'processing_wallet_address')::varchar AS address,
+	count(*) FILTER (WHERE t.status IN ('new', 'pending', 'processing', 'in_mempool', 'unconfirmed'))::int AS active_transfers_count,
+	max(t.created_at)::timestamptz AS last_transfer_at
+FROM transfers t
+WHERE t.owner_id = $1
+	AND t.blockchain = $2
+	AND t.state_data->>'processing_wallet_address' IS NOT NULL
+GROUP BY t.state_data->>'processing_wallet_address';
 
 -- name: GetByBlockchainAndAddress :one
 select * from processing_wallets where blockchain = $1 and address = $2;
@@ -33,3 +47,6 @@ SELECT * FROM missing_combinations;
 
 -- name: IsTakenByAnotherOwner :one
 select exists(select 1 from processing_wallets where owner_id != $1 and address = $2);
+
+-- name: ArchiveWallet :exec
+update processing_wallets w set is_active = false, updated_at = now() where w.blockchain = $1 and w.address = $2 and w.owner_id = $3;
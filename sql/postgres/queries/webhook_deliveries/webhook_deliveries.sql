@@ -0,0 +1,40 @@
+-- name: GetUnsent :many
+-- the transfer status waits while the earlier status of the request is pending for the endpoint,
+-- including the one waiting for the next attempt, so the statuses are delivered in order.
+-- The deliveries to the disabled endpoints are parked until the endpoint is enabled again
+select * from webhook_delivery_view d
+where
+  d.status = $1
+  and (d.next_attempt_at is null or d.next_attempt_at <= now())
+  and exists (select 1 from webhook_endpoints e where e.id = d.endpoint_id and e.enabled)
+  and not (
+    d.kind = 'transfer_status'
+    and coalesce(d.payload->>'request_id', '') <> ''
+    and exists (
+      select 1 from webhook_deliveries pd
+      join webhooks pw on pw.id = pd.webhook_id
+      where pd.endpoint_id = d.endpoint_id
+        and pd.status = d.status
+        and pw.kind = d.kind
+        and pw.payload->>'request_id' = d.payload->>'request_id'
+        and (pd.created_at, pd.id) < (d.created_at, d.id)
+    )
+  )
+order by d.created_at asc, d.id asc
+limit sqlc.arg('limit');
+
+-- name: GetByWebhookID :many
+select * from webhook_deliveries where webhook_id = $1 order by created_at asc;
+
+-- name: GetPendingWebhookIDsByEndpointID :many
+-- the state of these webhooks changes when the deliveries to the endpoint are removed
+select distinct webhook_id from webhook_deliveries where endpoint_id = $1 and status <> 'sent';
+
+-- name: IncrementAttempt :exec
+update webhook_deliveries set updated_at = now(), attempts = attempts + 1, response = $2, next_attempt_at = $3 where id = $1;
+
+-- name: SetFailed :exec
+update webhook_deliveries set updated_at = now(), attempts = attempts + 1, response = $2, status = 'failed', next_attempt_at = null where id = $1;
+
+-- name: SetSentAtNow :exec
+update webhook_deliveries set sent_at = now(), response = $2, status = 'sent', attempts = attempts + 1, next_attempt_at = null, updated_at = now() where id = $1;
@@ -0,0 +1,22 @@
+-- name: Create :one
+insert into webhook_endpoints (client_id, url, secret_key, enabled, kinds, blockchains, owner_ids, created_at)
+	values ($1, $2, $3, $4, $5, $6, $7, now())
+	returning *;
+
+-- name: CreateDefault :exec
+insert into webhook_endpoints (client_id, is_default, created_at) values ($1, true, now())
+	on conflict do nothing;
+
+-- name: Update :one
+update webhook_endpoints set url = $3, enabled = $4, kinds = $5, blockchains = $6, owner_ids = $7, updated_at = now()
+	where id = $1 and client_id = $2
+	returning *;
+
+-- name: Delete :execrows
+delete from webhook_endpoints where id = $1 and client_id = $2 and not is_default;
+
+-- name: GetByID :one
+select * from webhook_endpoints where id = $1 and client_id = $2;
+
+-- name: GetByClientID :many
+select * from webhook_endpoints where client_id = $1 order by is_default desc, created_at asc;
@@ -1,41 +1,66 @@
 -- name: Create :batchexec
-INSERT INTO webhooks (kind, status, payload, client_id, created_at)
-VALUES ($1, $2, $3, $4, now())
-ON CONFLICT ((payload::text), client_id)
+-- the webhook is delivered to the enabled endpoints of the client subscribed to the event,
+-- the webhook without such endpoints has nothing to deliver and is created as sent
+WITH e AS (
+  SELECT e.id
+  FROM webhook_endpoints e
+  WHERE
+    e.client_id = $4
+    AND e.enabled
+    AND (cardinality(e.kinds) = 0 OR $1::varchar = ANY(e.kinds))
+    AND (cardinality(e.blockchains) = 0 OR $6::varchar = '' OR $6::varchar = ANY(e.blockchains))
+    AND (cardinality(e.owner_ids) = 0 OR $5::uuid IS NULL OR $5::uuid = ANY(e.owner_ids))
+), w AS (
+  INSERT INTO webhooks (kind, status, payload, client_id, owner_id, blockchain, created_at)
+  VALUES ($1, CASE WHEN EXISTS (SELECT 1 FROM e) THEN $2::varchar ELSE 'sent' END, $3, $4, $5, $6, now())
+  ON CONFLICT ((payload::text), client_id)
+  DO UPDATE SET
+    updated_at = now(),
+    status = excluded.status,
+    attempts = 0,
+    response = null,
+    sent_at = null,
+    next_attempt_at = null
+  RETURNING id
+)
+INSERT INTO webhook_deliveries (webhook_id, endpoint_id)
+SELECT w.id, e.id
+FROM w
+CROSS JOIN e
+ON CONFLICT (webhook_id, endpoint_id)
 DO UPDATE SET
   updated_at = now(),
   status = 'new',
   attempts = 0,
   response = null,
-  sent_at = null;
-  
--- name: IncrementAttempt :exec
-update webhooks set updated_at = now(), attempts = attempts + 1, response = $2 where id = $1;
+  sent_at = null,
+  next_attempt_at = null;
 
--- name: GetUnsent :many
-select * from webhook_view w
-where
-  w.status = $1
-  and (
-  -- new
-    w.updated_at is null
-  -- once a minute the first day
-    or (extract(epoch from (w.updated_at - w.created_at)) < 3600 * 24)
-  -- once an hour the first week
-    or (
-        extract(epoch from (w.updated_at - w.created_at)) between 3600 * 24 and 3600 * 24 * 7
-      and
-        extract(epoch from (now() - date_trunc('hour', w.updated_at))) > 3601
-    )
-  -- once a day
-    or (
-        extract(epoch from (w.updated_at - w.created_at)) > 3600 * 24 * 7
-      and
-        date_trunc('day', now()) > date_trunc('day', w.updated_at)
-    )
-)
-order by w.created_at asc
-limit sqlc.arg('limit');
+-- name: RefreshState :one
+-- the webhook is sent when all deliveries are sent and failed when no delivery is pending
+update webhooks w set
+  status = s.status,
+  attempts = s.attempts,
+  response = s.response,
+  next_attempt_at = s.next_attempt_at,
+  sent_at = case when s.status = 'sent' then s.sent_at end,
+  updated_at = now()
+from (
+  select
+    case
+      when bool_or(d.status = 'new') then 'new'
+      when bool_or(d.status = 'failed') then 'failed'
+      else 'sent'
+    end as status,
+    coalesce(max(d.attempts), 0)::int as attempts,
+    (array_agg(d.response order by d.updated_at desc nulls last))[1] as response,
+    min(d.next_attempt_at) filter (where d.status = 'new') as next_attempt_at,
+    max(d.sent_at) as sent_at
+  from webhook_deliveries d
+  where d.webhook_id = sqlc.arg('id')
+) s
+where w.id = sqlc.arg('id')
+returning w.status;
 
 -- name: GetByID :one
 select * from webhook_view w where w.id = $1 limit 1;
@@ -43,8 +68,9 @@ select * from webhook_view w where w.id = $1 limit 1;
 -- name: Exists :one
 SELECT EXISTS (SELECT 1 FROM webhooks WHERE payload->>'hash'=$1 AND payload->>'type'=$2)::boolean;
 
--- name: SetSentAtNow :exec
-update webhooks set sent_at=now(), response=$2, status='sent', attempts = attempts + 1, updated_at=now() where id=$1;
-
 -- name: Cleanup :execrows
-DELETE FROM webhooks WHERE created_at < $1 AND status = 'sent';
+-- the webhooks without the deliveries are not sent to any endpoint
+DELETE FROM webhooks w
+WHERE
+  w.created_at < $1
+  AND (w.status = 'sent' OR NOT EXISTS (SELECT 1 FROM webhook_deliveries d WHERE d.webhook_id = w.id));